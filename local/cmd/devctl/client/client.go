@@ -0,0 +1,115 @@
+// Package client decodes verifier API responses: the envelope every endpoint
+// wraps its payload in, and the plugin, policy, history and auth payloads
+// devctl reads out of it.
+//
+// Older verifiers return some payloads in other shapes: policy lists and
+// policy history as bare arrays, with or without the envelope, and auth as a
+// single token. The types here accept those shapes as well, so devctl works
+// against both.
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// StrictAPI makes Decode reject fields the types in this package don't know
+// about, so drift between devctl and the verifier API fails loudly.
+var StrictAPI bool
+
+// APIResponse is the envelope every verifier endpoint wraps its payload in.
+type APIResponse[T any] struct {
+	Data      T         `json:"data"`
+	Error     *APIError `json:"error,omitempty"`
+	Status    int       `json:"status,omitempty"`
+	Timestamp string    `json:"timestamp"`
+	Version   string    `json:"version"`
+}
+
+type APIError struct {
+	Message string `json:"message"`
+	Details string `json:"details,omitempty"`
+}
+
+// Decode unmarshals a verifier response envelope into T, returning the
+// envelope's error message if the verifier reported one.
+func Decode[T any](body []byte) (T, error) {
+	return DecodeFrom[T](bytes.NewReader(body))
+}
+
+// DecodeFrom is Decode over a stream, so large bodies are decoded without
+// first being read into memory. A body that is a bare JSON array rather than
+// an envelope is decoded into T as it is. Errors from r itself, such as a
+// body over a size limit, are returned unwrapped.
+func DecodeFrom[T any](r io.Reader) (T, error) {
+	var zero T
+	src := &readErrReader{r: r}
+	br := bufio.NewReader(src)
+
+	dec := json.NewDecoder(br)
+	if StrictAPI {
+		dec.DisallowUnknownFields()
+	}
+
+	var resp APIResponse[T]
+	var err error
+	if firstByte(br) == '[' {
+		err = dec.Decode(&resp.Data)
+	} else {
+		err = dec.Decode(&resp)
+	}
+	if err != nil {
+		if src.err != nil && errors.Is(err, src.err) {
+			return zero, err
+		}
+		return zero, fmt.Errorf("decode response: %w", err)
+	}
+
+	if resp.Error != nil && resp.Error.Message != "" {
+		if resp.Error.Details != "" {
+			return zero, fmt.Errorf("%s: %s", resp.Error.Message, resp.Error.Details)
+		}
+		return zero, fmt.Errorf("%s", resp.Error.Message)
+	}
+
+	return resp.Data, nil
+}
+
+// firstByte returns the first non-whitespace byte of br without consuming
+// it, or 0 when there is none.
+func firstByte(br *bufio.Reader) byte {
+	for n := 1; ; n++ {
+		peeked, err := br.Peek(n)
+		if len(peeked) < n {
+			return 0
+		}
+		b := peeked[n-1]
+		switch b {
+		case ' ', '\t', '\r', '\n':
+			if err != nil {
+				return 0
+			}
+			continue
+		}
+		return b
+	}
+}
+
+// readErrReader remembers the last error r returned other than io.EOF, so
+// DecodeFrom can tell read failures from malformed JSON.
+type readErrReader struct {
+	r   io.Reader
+	err error
+}
+
+func (e *readErrReader) Read(p []byte) (int, error) {
+	n, err := e.r.Read(p)
+	if err != nil && err != io.EOF {
+		e.err = err
+	}
+	return n, err
+}
@@ -0,0 +1,278 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func at(s string) time.Time {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func ptr[T any](v T) *T { return &v }
+
+// decoder adapts Decode[T] to the fixture table.
+func decoder[T any]() func([]byte) (interface{}, error) {
+	return func(body []byte) (interface{}, error) {
+		return Decode[T](body)
+	}
+}
+
+var (
+	policyP1 = Policy{
+		ID: "p1", PublicKey: "02a1b2c3", PluginID: "vultisig-dca-0000", PluginVersion: "1.2.0",
+		PolicyVersion: 1, Signature: "0xsig", Recipe: "CgR0ZXN0", Billing: []BillingEntry{}, Active: true,
+	}
+	policyP2 = Policy{
+		ID: "p2", PublicKey: "02a1b2c3", PluginID: "vultisig-dca-0000", PluginVersion: "1.2.0",
+		PolicyVersion: 3, Signature: "0xsig", Recipe: "CgR0ZXN0", Billing: []BillingEntry{}, Active: false,
+		DeactivationReason: ptr("paused"),
+	}
+	historyT1 = PolicyHistoryEntry{
+		ID: "t1", PluginID: "vultisig-dca-0000", AppName: "DCA Plugin", PolicyID: "p1", PublicKey: "02a1b2c3",
+		Chain: "Ethereum", Amount: ptr("1000000000000000"), TxHash: ptr("0xabc"), Status: "SIGNED",
+		StatusOnChain: ptr("SUCCESS"), CreatedAt: at("2026-01-02T03:04:05Z"), UpdatedAt: at("2026-01-02T03:05:05Z"),
+		BroadcastedAt: ptr(at("2026-01-02T03:04:35Z")),
+	}
+	historyT2 = PolicyHistoryEntry{
+		ID: "t2", PluginID: "vultisig-dca-0000", AppName: "DCA Plugin", PolicyID: "p1", PublicKey: "02a1b2c3",
+		Chain: "Ethereum", Status: "ERROR", ErrorMessage: ptr("insufficient funds"),
+		CreatedAt: at("2026-01-03T03:04:05Z"), UpdatedAt: at("2026-01-03T03:04:06Z"),
+	}
+)
+
+func TestDecodeFixtures(t *testing.T) {
+	tests := []struct {
+		fixture string
+		decode  func([]byte) (interface{}, error)
+		want    interface{}
+	}{
+		{
+			fixture: "plugin.json",
+			decode:  decoder[Plugin](),
+			want: Plugin{
+				ID: "vultisig-dca-0000", Title: "DCA Plugin", Description: "Dollar cost averaging swaps",
+				ServerEndpoint: "http://localhost:8082", Category: "plugin",
+				CreatedAt: at("2026-01-02T03:04:05Z"), UpdatedAt: at("2026-01-03T03:04:05Z"),
+				Pricing: []json.RawMessage{json.RawMessage(`{"type": "once", "amount": 0}`)},
+				LogoURL: "https://example.com/logo.png", Features: []string{"swaps", "recurring"},
+				Audited: true, RatesCount: 3, AvgRating: 4.5, Installations: 12, Version: "1.2.0",
+			},
+		},
+		{
+			fixture: "plugin_list.json",
+			decode:  decoder[PluginList](),
+			want: PluginList{
+				Plugins: []Plugin{
+					{ID: "vultisig-dca-0000", Title: "DCA Plugin", ServerEndpoint: "http://localhost:8082", Category: "plugin",
+						CreatedAt: at("2026-01-02T03:04:05Z"), UpdatedAt: at("2026-01-02T03:04:05Z")},
+					{ID: "vultisig-fees-feee", Title: "Fees", ServerEndpoint: "http://localhost:8085", Category: "fees",
+						CreatedAt: at("2026-01-02T03:04:05Z"), UpdatedAt: at("2026-01-02T03:04:05Z")},
+				},
+				TotalCount: 2,
+			},
+		},
+		{
+			fixture: "policy.json",
+			decode:  decoder[Policy](),
+			want: Policy{
+				ID: "5f0c6a1e-7d3b-4b8e-9a52-0c1d2e3f4a5b", PublicKey: "02a1b2c3", PluginID: "vultisig-dca-0000",
+				PluginVersion: "1.2.0", PolicyVersion: 2, Signature: "0xsig", Recipe: "CgR0ZXN0",
+				Billing: []BillingEntry{
+					{ID: "b1", Type: "recurring", Frequency: ptr("monthly"), StartDate: at("2026-01-01T00:00:00Z"), Amount: 500000, Asset: "usdc"},
+				},
+				Active:    true,
+				CreatedAt: ptr(at("2026-01-02T03:04:05Z")),
+				UpdatedAt: ptr(at("2026-01-03T03:04:05Z")),
+			},
+		},
+		{
+			fixture: "policy_list.json",
+			decode:  decoder[PolicyList](),
+			want:    PolicyList{Policies: []Policy{policyP1, policyP2}, TotalCount: 7},
+		},
+		{
+			// An envelope whose data is the array itself.
+			fixture: "policy_list_data_array.json",
+			decode:  decoder[PolicyList](),
+			want:    PolicyList{Policies: []Policy{policyP1}, TotalCount: 1},
+		},
+		{
+			// A bare array with no envelope, as 'policy list' read it before
+			// the envelope was decoded.
+			fixture: "policy_list_bare.json",
+			decode:  decoder[PolicyList](),
+			want:    PolicyList{Policies: []Policy{policyP1, policyP2}, TotalCount: 2},
+		},
+		{
+			fixture: "policy_history.json",
+			decode:  decoder[PolicyHistory](),
+			want:    PolicyHistory{History: []PolicyHistoryEntry{historyT1}, TotalCount: 40},
+		},
+		{
+			// An envelope whose data is the array of entries, as 'verify
+			// transactions' read it before PolicyHistory.
+			fixture: "policy_history_data_array.json",
+			decode:  decoder[PolicyHistory](),
+			want:    PolicyHistory{History: []PolicyHistoryEntry{historyT1, historyT2}, TotalCount: 2},
+		},
+		{
+			fixture: "auth.json",
+			decode:  decoder[AuthResponse](),
+			want:    AuthResponse{AccessToken: "access", RefreshToken: "refresh", ExpiresIn: 3600},
+		},
+		{
+			fixture: "auth_legacy.json",
+			decode:  decoder[AuthResponse](),
+			want:    AuthResponse{Token: "legacy"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			body, err := os.ReadFile(filepath.Join("testdata", tt.fixture))
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := tt.decode(body)
+			if err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("decoded %s:\n got %+v\nwant %+v", tt.fixture, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuthResponseBearerToken(t *testing.T) {
+	for _, tt := range []struct {
+		resp AuthResponse
+		want string
+	}{
+		{AuthResponse{AccessToken: "access", Token: "legacy"}, "access"},
+		{AuthResponse{Token: "legacy"}, "legacy"},
+		{AuthResponse{}, ""},
+	} {
+		if got := tt.resp.BearerToken(); got != tt.want {
+			t.Errorf("%+v.BearerToken() = %q, want %q", tt.resp, got, tt.want)
+		}
+	}
+}
+
+func TestDecodeErrorEnvelope(t *testing.T) {
+	body, err := os.ReadFile(filepath.Join("testdata", "error.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = Decode[Policy](body)
+	if err == nil || err.Error() != "policy not found: no policy with id p9" {
+		t.Errorf("error envelope: got %v", err)
+	}
+
+	_, err = Decode[Policy]([]byte(`{"data": null, "error": {"message": "unauthorized"}}`))
+	if err == nil || err.Error() != "unauthorized" {
+		t.Errorf("error without details: got %v", err)
+	}
+}
+
+func TestDecodeStrict(t *testing.T) {
+	body := []byte(`{"data": {"token": "legacy", "scope": "all"}, "timestamp": "", "version": ""}`)
+
+	got, err := Decode[AuthResponse](body)
+	if err != nil || got.Token != "legacy" {
+		t.Fatalf("lenient decode: got %+v, %v", got, err)
+	}
+
+	StrictAPI = true
+	defer func() { StrictAPI = false }()
+	_, err = Decode[AuthResponse](body)
+	if err == nil || !strings.Contains(err.Error(), `unknown field "scope"`) {
+		t.Errorf("strict decode: got %v, want an unknown field error", err)
+	}
+
+	// PolicyList and PolicyHistory decode themselves, so strictness has to
+	// reach their UnmarshalJSON too, in every shape they accept.
+	tests := []struct {
+		fixture string
+		decode  func([]byte) (interface{}, error)
+	}{
+		{"policy_list.json", decoder[PolicyList]()},
+		{"policy_list_data_array.json", decoder[PolicyList]()},
+		{"policy_list_bare.json", decoder[PolicyList]()},
+		{"policy_history.json", decoder[PolicyHistory]()},
+		{"policy_history_data_array.json", decoder[PolicyHistory]()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			body, err := os.ReadFile(filepath.Join("testdata", tt.fixture))
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = tt.decode(body)
+			if err != nil {
+				t.Fatalf("strict decode of the fixture: %v", err)
+			}
+
+			// Add an unknown field to the first element of the list.
+			i := bytes.Index(body, []byte(`{"id": `))
+			if i < 0 {
+				t.Fatal("fixture has no list element")
+			}
+			drifted := append(append(append([]byte{}, body[:i+1]...), `"surprise": 1, `...), body[i+1:]...)
+			_, err = tt.decode(drifted)
+			if err == nil || !strings.Contains(err.Error(), `unknown field "surprise"`) {
+				t.Errorf("strict decode with an unknown field: got %v, want an unknown field error", err)
+			}
+		})
+	}
+}
+
+func TestDecodeMalformed(t *testing.T) {
+	for _, body := range []string{``, `{"data": `, `not json`} {
+		_, err := Decode[Policy]([]byte(body))
+		if err == nil || !strings.HasPrefix(err.Error(), "decode response: ") {
+			t.Errorf("Decode(%q): got %v, want a decode response error", body, err)
+		}
+	}
+
+	_, err := Decode[Policy]([]byte(`{"data": `))
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("truncated body: got %v, want io.ErrUnexpectedEOF", err)
+	}
+}
+
+// failingReader returns part of a body and then err.
+type failingReader struct {
+	body string
+	err  error
+}
+
+func (r *failingReader) Read(p []byte) (int, error) {
+	if r.body == "" {
+		return 0, r.err
+	}
+	n := copy(p, r.body)
+	r.body = r.body[n:]
+	return n, nil
+}
+
+func TestDecodeFromReadError(t *testing.T) {
+	readErr := errors.New("response too large")
+	_, err := DecodeFrom[Policy](&failingReader{body: `{"data": {"id": "p1", `, err: readErr})
+	if err != readErr {
+		t.Errorf("read error: got %v, want it returned as is", err)
+	}
+}
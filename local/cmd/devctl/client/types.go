@@ -0,0 +1,161 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+)
+
+type Plugin struct {
+	ID             string            `json:"id"`
+	Title          string            `json:"title"`
+	Description    string            `json:"description"`
+	ServerEndpoint string            `json:"server_endpoint"`
+	Category       string            `json:"category_id"`
+	CreatedAt      time.Time         `json:"created_at"`
+	UpdatedAt      time.Time         `json:"updated_at"`
+	Pricing        []json.RawMessage `json:"pricing,omitempty"`
+	LogoURL        string            `json:"logo_url,omitempty"`
+	ThumbnailURL   string            `json:"thumbnail_url,omitempty"`
+	BannerURL      string            `json:"banner_url,omitempty"`
+	Images         []json.RawMessage `json:"images,omitempty"`
+	FAQs           []json.RawMessage `json:"faqs,omitempty"`
+	Features       []string          `json:"features,omitempty"`
+	Audited        bool              `json:"audited"`
+	RatesCount     int               `json:"rates_count"`
+	AvgRating      float64           `json:"avg_rating"`
+	Installations  int               `json:"installations"`
+	PayoutAddress  string            `json:"payout_address,omitempty"`
+	Version        string            `json:"version,omitempty"`
+}
+
+type PluginList struct {
+	Plugins    []Plugin `json:"plugins"`
+	TotalCount int      `json:"total_count"`
+}
+
+type Policy struct {
+	ID                 string         `json:"id"`
+	PublicKey          string         `json:"public_key"`
+	PluginID           string         `json:"plugin_id"`
+	PluginVersion      string         `json:"plugin_version"`
+	PolicyVersion      int            `json:"policy_version"`
+	Signature          string         `json:"signature"`
+	Recipe             string         `json:"recipe"`
+	Billing            []BillingEntry `json:"billing"`
+	Active             bool           `json:"active"`
+	DeactivationReason *string        `json:"deactivation_reason,omitempty"`
+	CreatedAt          *time.Time     `json:"created_at,omitempty"`
+	UpdatedAt          *time.Time     `json:"updated_at,omitempty"`
+}
+
+type BillingEntry struct {
+	ID        string    `json:"id"`
+	Type      string    `json:"type"`
+	Frequency *string   `json:"frequency"`
+	StartDate time.Time `json:"start_date"`
+	Amount    uint64    `json:"amount"`
+	Asset     string    `json:"asset"`
+}
+
+type PolicyList struct {
+	Policies   []Policy `json:"policies"`
+	TotalCount int      `json:"total_count"`
+}
+
+// UnmarshalJSON accepts both the {"policies": [...]} object and a bare array,
+// which older verifiers return.
+func (l *PolicyList) UnmarshalJSON(data []byte) error {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		err := unmarshal(trimmed, &l.Policies)
+		if err != nil {
+			return err
+		}
+		l.TotalCount = len(l.Policies)
+		return nil
+	}
+
+	type plain PolicyList
+	var p plain
+	err := unmarshal(trimmed, &p)
+	if err != nil {
+		return err
+	}
+	*l = PolicyList(p)
+	return nil
+}
+
+// unmarshal is json.Unmarshal honouring StrictAPI. Decode's decoder settings
+// do not reach custom UnmarshalJSON methods, which get the raw bytes, so
+// those decode through this instead.
+func unmarshal(data []byte, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if StrictAPI {
+		dec.DisallowUnknownFields()
+	}
+	return dec.Decode(v)
+}
+
+type PolicyHistoryEntry struct {
+	ID            string     `json:"id"`
+	PluginID      string     `json:"plugin_id"`
+	AppName       string     `json:"app_name"`
+	PolicyID      string     `json:"policy_id"`
+	PublicKey     string     `json:"public_key"`
+	ToPublicKey   string     `json:"to_public_key"`
+	Chain         string     `json:"chain"`
+	TokenID       string     `json:"token_id"`
+	Amount        *string    `json:"amount"`
+	TxHash        *string    `json:"tx_hash"`
+	Status        string     `json:"status"`
+	StatusOnChain *string    `json:"status_onchain"`
+	ErrorMessage  *string    `json:"error_message"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+	BroadcastedAt *time.Time `json:"broadcasted_at"`
+}
+
+type PolicyHistory struct {
+	History    []PolicyHistoryEntry `json:"history"`
+	TotalCount int                  `json:"total_count"`
+}
+
+// UnmarshalJSON accepts both the {"history": [...]} object and a bare array
+// of entries, which older verifiers return.
+func (h *PolicyHistory) UnmarshalJSON(data []byte) error {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		err := unmarshal(trimmed, &h.History)
+		if err != nil {
+			return err
+		}
+		h.TotalCount = len(h.History)
+		return nil
+	}
+
+	type plain PolicyHistory
+	var p plain
+	err := unmarshal(trimmed, &p)
+	if err != nil {
+		return err
+	}
+	*h = PolicyHistory(p)
+	return nil
+}
+
+// AuthResponse covers both the older {"token"} payload and the token pair
+// returned by current verifiers.
+type AuthResponse struct {
+	Token        string `json:"token,omitempty"`
+	AccessToken  string `json:"access_token,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	ExpiresIn    int    `json:"expires_in,omitempty"`
+}
+
+func (a AuthResponse) BearerToken() string {
+	if a.AccessToken != "" {
+		return a.AccessToken
+	}
+	return a.Token
+}
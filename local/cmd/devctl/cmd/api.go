@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/vultisig/vultisig-cluster/local/cmd/devctl/client"
+)
+
+// getAPI fetches a verifier endpoint and decodes its envelope into T with
+// client.DecodeFrom. authHeader may be empty for public endpoints. The body
+// is decoded as it streams in, bounded by max_response_bytes; a body that
+// breaks off midway is fetched again, up to getAPIAttempts times.
+func getAPI[T any](ctx context.Context, url, authHeader string) (T, error) {
+	var zero T
+	var err error
+	for attempt := 1; attempt <= getAPIAttempts; attempt++ {
+		var data T
+		data, err = getAPIOnce[T](ctx, url, authHeader)
+		if err == nil || !isTruncatedBody(err) || ctx.Err() != nil {
+			return data, err
+		}
+		if attempt < getAPIAttempts {
+			progressf("%s Response from %s broke off (%v); retrying (%d/%d)\n", warnMark(), url, err, attempt+1, getAPIAttempts)
+			if sleepErr := sleepCtx(ctx, time.Duration(attempt)*getAPIRetryDelay); sleepErr != nil {
+				return zero, sleepErr
+			}
+		}
+	}
+	return zero, err
+}
+
+func getAPIOnce[T any](ctx context.Context, url, authHeader string) (T, error) {
+	var zero T
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return zero, err
+	}
+	if authHeader != "" {
+		req.Header.Set("Authorization", authHeader)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return zero, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := readResponseBody(resp)
+		return zero, fmt.Errorf("request failed (%d): %s", resp.StatusCode, errorBody(body))
+	}
+
+	return client.DecodeFrom[T](newLimitedBody(resp))
+}
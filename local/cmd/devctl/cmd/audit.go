@@ -0,0 +1,224 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// auditedCommands are the state-changing commands written to the audit log.
+var auditedCommands = map[string]bool{
+	"devctl start":            true,
+	"devctl stop":             true,
+	"devctl services init":    true,
+	"devctl services start":   true,
+	"devctl services stop":    true,
+	"devctl vault generate":   true,
+	"devctl vault reshare":    true,
+	"devctl vault import":     true,
+	"devctl vault use":        true,
+	"devctl vault delete":     true,
+	"devctl vault send-sol":   true,
+	"devctl vault sign-psbt":  true,
+	"devctl plugin install":   true,
+	"devctl plugin uninstall": true,
+	"devctl policy create":    true,
+	"devctl policy delete":    true,
+	"devctl policy trigger":   true,
+	"devctl policy pause":     true,
+	"devctl policy resume":    true,
+	"devctl auth login":       true,
+	"devctl auth logout":      true,
+	"devctl chain fund":       true,
+	"devctl chain add":        true,
+	"devctl chain remove":     true,
+	"devctl relay orphans":    true,
+	"devctl upgrade":          true,
+	"devctl gc":               true,
+}
+
+// secretFlagWords mark flags whose values are never written to the log.
+var secretFlagWords = []string{"password", "secret", "token", "mnemonic", "private"}
+
+type AuditEntry struct {
+	Time       string            `json:"time"`
+	TraceID    string            `json:"trace_id"`
+	Command    string            `json:"command"`
+	Flags      map[string]string `json:"flags,omitempty"`
+	Resources  []string          `json:"resources,omitempty"`
+	DurationMs int64             `json:"duration_ms"`
+	Success    bool              `json:"success"`
+	Error      string            `json:"error,omitempty"`
+}
+
+func AuditPath() string {
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".vultisig", "audit.log")
+}
+
+// AppendAudit records a finished state-changing command. Read-only commands
+// are ignored. Positional arguments are recorded as the affected resources;
+// flags only when set explicitly, with secret-looking values redacted.
+func AppendAudit(c *cobra.Command, started time.Time, runErr error) error {
+	if !auditedCommands[c.CommandPath()] {
+		return nil
+	}
+
+	entry := AuditEntry{
+		Time:       started.UTC().Format(time.RFC3339),
+		TraceID:    TraceID(),
+		Command:    c.CommandPath(),
+		Flags:      map[string]string{},
+		Resources:  c.Flags().Args(),
+		DurationMs: time.Since(started).Milliseconds(),
+		Success:    runErr == nil,
+	}
+	if runErr != nil {
+		entry.Error = runErr.Error()
+	}
+
+	c.Flags().Visit(func(f *pflag.Flag) {
+		entry.Flags[f.Name] = redactFlag(f.Name, f.Value.String())
+	})
+
+	path := AuditPath()
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return fmt.Errorf("create audit dir: %w", err)
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("marshal audit entry: %w", err)
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("open audit log: %w", err)
+	}
+	defer f.Close()
+
+	_, err = f.Write(append(data, '\n'))
+	if err != nil {
+		return fmt.Errorf("write audit log: %w", err)
+	}
+	return nil
+}
+
+func redactFlag(name, value string) string {
+	lower := strings.ToLower(name)
+	for _, word := range secretFlagWords {
+		if strings.Contains(lower, word) {
+			return "[redacted]"
+		}
+	}
+	return value
+}
+
+func NewAuditCmd() *cobra.Command {
+	var filter HistoryFilter
+	var verbose bool
+
+	cmd := &cobra.Command{
+		Use:   "audit",
+		Short: "Show the log of state-changing devctl commands",
+		Long: `Show entries from ~/.vultisig/audit.log.
+
+Every state-changing command (start/stop, vault import, plugin
+install/uninstall, policy create/delete/trigger, auth login/logout, ...)
+appends one entry with its explicitly set flags (secrets redacted),
+positional arguments and outcome.
+
+Examples:
+  devctl audit
+  devctl audit --limit 50
+  devctl audit --since 2d --verbose
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runAudit(filter, verbose)
+		},
+	}
+
+	addHistoryFlags(cmd, &filter, 20, "entries")
+	cmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "Show absolute timestamps")
+	// --last is the pre-standardization name of --limit.
+	cmd.Flags().IntVar(&filter.Limit, "last", 20, "Number of most recent entries to show (0 = all)")
+	_ = cmd.Flags().MarkDeprecated("last", "use --limit")
+
+	return cmd
+}
+
+func runAudit(filter HistoryFilter, verbose bool) error {
+	f, err := os.Open(AuditPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			fmt.Println("No audit entries yet.")
+			return nil
+		}
+		return fmt.Errorf("open audit log: %w", err)
+	}
+	defer f.Close()
+
+	var entries []AuditEntry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var entry AuditEntry
+		if json.Unmarshal(scanner.Bytes(), &entry) != nil {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, entry.Time)
+		if err == nil && !filter.Includes(t) {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("read audit log: %w", err)
+	}
+
+	if filter.Limit > 0 && len(entries) > filter.Limit {
+		entries = entries[len(entries)-filter.Limit:]
+	}
+
+	for _, e := range entries {
+		status := okMark()
+		if !e.Success {
+			status = failMark()
+		}
+
+		when := e.Time
+		if t, err := time.Parse(time.RFC3339, e.Time); err == nil {
+			when = formatTimestamp(t, verbose)
+		}
+		line := fmt.Sprintf("%s %s %s", when, status, e.Command)
+		if len(e.Resources) > 0 {
+			line += " " + strings.Join(e.Resources, " ")
+		}
+		if len(e.Flags) > 0 {
+			names := make([]string, 0, len(e.Flags))
+			for name := range e.Flags {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				line += fmt.Sprintf(" --%s=%s", name, e.Flags[name])
+			}
+		}
+		line += fmt.Sprintf(" (%dms, trace %s)", e.DurationMs, e.TraceID)
+		fmt.Println(line)
+
+		if e.Error != "" {
+			fmt.Printf("    error: %s\n", e.Error)
+		}
+	}
+
+	return nil
+}
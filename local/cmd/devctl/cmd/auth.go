@@ -4,14 +4,21 @@ import (
 	"bytes"
 	"context"
 	"crypto/rand"
+	"encoding/asn1"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
+	"math/big"
 	"net/http"
+	"os"
+	"strings"
 	"time"
 
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/spf13/cobra"
+	"github.com/vultisig/mobile-tss-lib/tss"
+	"github.com/vultisig/vultisig-cluster/local/cmd/devctl/client"
 )
 
 func NewAuthCmd() *cobra.Command {
@@ -23,6 +30,7 @@ func NewAuthCmd() *cobra.Command {
 	cmd.AddCommand(newAuthLoginCmd())
 	cmd.AddCommand(newAuthStatusCmd())
 	cmd.AddCommand(newAuthLogoutCmd())
+	cmd.AddCommand(newAuthTokenCmd())
 
 	return cmd
 }
@@ -30,22 +38,48 @@ func NewAuthCmd() *cobra.Command {
 func newAuthLoginCmd() *cobra.Command {
 	var vaultID string
 	var password string
+	var scheme AuthScheme
 
 	cmd := &cobra.Command{
 		Use:   "login",
 		Short: "Authenticate with verifier using TSS keysign",
 		Long: `Authenticate with the verifier by signing a nonce message.
 
-This performs a TSS keysign with the Fast Vault Server to create an
-EIP-191 personal_sign signature, which is then used to obtain a JWT token.
+This performs a TSS keysign with the Fast Vault Server and exchanges the
+signature for a JWT token. By default the message is hashed EIP-191
+personal_sign style and the signature is sent as R+S+V, the scheme
+'vault import' uses.
+
+Verifier builds differ in what they expect. --message-format raw signs the
+Keccak-256 of the message without the EIP-191 prefix; --sig-format der sends
+a DER-encoded signature. If the verifier rejects the signature (400/401),
+login retries once with the other signature encoding, built from the same
+keysign, and reports which combination was accepted.
+
+The message expires five minutes after it is built, by the verifier's clock.
+Login compares the local clock with the verifier's Date header first: it
+warns from 10s of skew and refuses from 1m, before running the keysign.
+--server-time builds expiresAt from the verifier's time instead.
+
+The message is signed with the key at auth_derive_path in cluster.yaml
+(default m/44'/60'/0'/0/0). --derive overrides it, for verifiers that check
+signatures against another account index.
 `,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runAuthLogin(vaultID, password)
+			err := scheme.validate()
+			if err != nil {
+				return err
+			}
+			return runAuthLogin(cmd.Context(), vaultID, password, scheme)
 		},
 	}
 
-	cmd.Flags().StringVarP(&vaultID, "vault", "v", "", "Vault ID or public key prefix")
+	cmd.Flags().StringVarP(&vaultID, "vault", "v", "", "Vault name, public key prefix or fingerprint")
 	cmd.Flags().StringVarP(&password, "password", "p", "", "Fast Vault password (if required)")
+	cmd.Flags().StringVar(&scheme.SigFormat, "sig-format", authSigRSV, "Signature encoding: rsv or der")
+	cmd.Flags().StringVar(&scheme.MessageFormat, "message-format", authMessagePersonalSign, "Message hashing: personal-sign or raw")
+	cmd.Flags().BoolVar(&scheme.ServerTime, "server-time", false, "Set the message expiry from the verifier's clock (for skewed local clocks)")
+	cmd.Flags().StringVar(&scheme.DerivePath, "derive", "", "BIP44 path to sign with (default auth_derive_path from cluster.yaml)")
 
 	return cmd
 }
@@ -70,18 +104,95 @@ func newAuthLogoutCmd() *cobra.Command {
 	}
 }
 
+func newAuthTokenCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "token",
+		Short: "Access the stored verifier token",
+	}
+	cmd.AddCommand(newAuthTokenPrintCmd())
+	return cmd
+}
+
+func newAuthTokenPrintCmd() *cobra.Command {
+	var vaultID string
+	var header bool
+
+	cmd := &cobra.Command{
+		Use:   "print",
+		Short: "Print the verifier token for use with curl and other tools",
+		Long: `Print the stored verifier JWT of the current vault, or of --vault. Nothing
+else is written to stdout, so the output can be embedded in other commands.
+Exits non-zero when there is no token or it has expired. Without --vault,
+VCLI_AUTH_TOKEN overrides the stored token as for every other command.
+
+Examples:
+  devctl auth token print
+  curl -H "$(devctl auth token print --header)" http://localhost:8080/vault/...
+`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runAuthTokenPrint(vaultID, header)
+		},
+	}
+
+	cmd.Flags().StringVarP(&vaultID, "vault", "v", "", "Vault name, public key prefix or fingerprint (default: current vault)")
+	cmd.Flags().BoolVar(&header, "header", false, "Print the full 'Authorization: Bearer ...' header")
+
+	return cmd
+}
+
+func runAuthTokenPrint(vaultID string, header bool) error {
+	var authHeader string
+	if vaultID == "" {
+		var err error
+		authHeader, err = GetAuthHeader()
+		if err != nil {
+			return err
+		}
+	} else {
+		vault, err := LoadVault(vaultID)
+		if err != nil {
+			return err
+		}
+		cfg, err := LoadConfig()
+		if err != nil {
+			return fmt.Errorf("load config: %w", err)
+		}
+		token, ok := cfg.AuthTokens[vault.PublicKeyECDSA]
+		if !ok {
+			return fmt.Errorf("vault %s is not authenticated. Run 'devctl auth login --vault %s' first", vault.Name, vaultID)
+		}
+		if time.Now().After(token.ExpiresAt) {
+			return fmt.Errorf("authentication expired. Run 'devctl auth login --vault %s' to re-authenticate", vaultID)
+		}
+		authHeader = "Bearer " + token.Token
+	}
+
+	if header {
+		fmt.Println("Authorization: " + authHeader)
+		return nil
+	}
+	fmt.Println(strings.TrimPrefix(authHeader, "Bearer "))
+	return nil
+}
+
 type AuthToken struct {
 	Token     string    `json:"token"`
 	PublicKey string    `json:"public_key"`
 	ExpiresAt time.Time `json:"expires_at"`
 }
 
-func runAuthLogin(vaultID, password string) error {
+func runAuthLogin(ctx context.Context, vaultID, password string, scheme AuthScheme) error {
 	cfg, err := LoadConfig()
 	if err != nil {
 		return fmt.Errorf("load config: %w", err)
 	}
 
+	err = preflight(ctx, cfg.Verifier, "")
+	if err != nil {
+		return err
+	}
+
 	vault, err := LoadVault(vaultID)
 	if err != nil {
 		if vaultID == "" {
@@ -92,7 +203,7 @@ func runAuthLogin(vaultID, password string) error {
 			vault = vaults[0]
 			fmt.Printf("Using vault: %s\n", vault.Name)
 		} else {
-			return fmt.Errorf("vault not found: %s", vaultID)
+			return err
 		}
 	}
 
@@ -103,38 +214,217 @@ func runAuthLogin(vaultID, password string) error {
 		return fmt.Errorf("vault has no chain code")
 	}
 
+	progressf("Authenticating with verifier...\n")
+	fmt.Printf("  Public Key: %s...\n", vault.PublicKeyECDSA[:16])
+
+	authToken, err := loginVault(ctx, cfg, vault, password, scheme)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("\n%s Authentication successful!\n", symOK)
+	fmt.Printf("  Token expires: %s\n", authToken.ExpiresAt.Format(time.RFC3339))
+
+	return nil
+}
+
+// Signature encodings and message formats of 'auth login'.
+const (
+	authSigRSV              = "rsv"
+	authSigDER              = "der"
+	authMessagePersonalSign = "personal-sign"
+	authMessageRaw          = "raw"
+)
+
+// AuthScheme is how the auth message is hashed and the signature encoded
+// for the verifier's /auth endpoint.
+type AuthScheme struct {
+	SigFormat     string
+	MessageFormat string
+	// ServerTime sets the message's expiresAt from the verifier's clock
+	// rather than the local one.
+	ServerTime bool
+	// DerivePath is the key the message is signed with; empty means
+	// auth_derive_path from cluster.yaml.
+	DerivePath string
+}
+
+// defaultAuthDerivePath is the Ethereum account the verifier checks auth
+// and policy signatures against unless auth_derive_path says otherwise.
+const defaultAuthDerivePath = "m/44'/60'/0'/0/0"
+
+// validateDerivePath checks path with the BIP44 parser keysign uses.
+func validateDerivePath(path string) error {
+	_, err := tss.GetDerivePathBytes(path)
+	if err != nil {
+		return fmt.Errorf("invalid derive path %q: %w", path, err)
+	}
+	return nil
+}
+
+// authDerivePath is override, or auth_derive_path from cluster.yaml when
+// override is empty. A cluster.yaml that fails to load is an error here
+// rather than a silent fallback to the default path.
+func authDerivePath(override string) (string, error) {
+	if override == "" {
+		if findClusterConfig() == "" {
+			return defaultAuthDerivePath, nil
+		}
+		cc, err := LoadClusterConfig()
+		if err != nil {
+			return "", err
+		}
+		return cc.AuthDerivePath, nil
+	}
+	err := validateDerivePath(override)
+	if err != nil {
+		return "", fmt.Errorf("--derive: %w", err)
+	}
+	return override, nil
+}
+
+func (s AuthScheme) validate() error {
+	if s.SigFormat != authSigRSV && s.SigFormat != authSigDER {
+		return fmt.Errorf("unknown --sig-format %q (use rsv or der)", s.SigFormat)
+	}
+	if s.MessageFormat != authMessagePersonalSign && s.MessageFormat != authMessageRaw {
+		return fmt.Errorf("unknown --message-format %q (use personal-sign or raw)", s.MessageFormat)
+	}
+	if s.DerivePath != "" {
+		err := validateDerivePath(s.DerivePath)
+		if err != nil {
+			return fmt.Errorf("--derive: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s AuthScheme) String() string {
+	return fmt.Sprintf("--sig-format %s --message-format %s", s.SigFormat, s.MessageFormat)
+}
+
+// loginVault signs a nonce message with the vault through the Fast Vault
+// Server and exchanges it for a verifier token, which is saved. A signature
+// the verifier rejects with 400/401 is re-sent once in the other encoding.
+func loginVault(ctx context.Context, cfg *DevConfig, vault *LocalVault, password string, scheme AuthScheme) (*AuthToken, error) {
 	nonceBytes := make([]byte, 16)
-	_, err = rand.Read(nonceBytes)
+	_, err := rand.Read(nonceBytes)
 	if err != nil {
-		return fmt.Errorf("generate nonce: %w", err)
+		return nil, fmt.Errorf("generate nonce: %w", err)
 	}
 	nonce := hex.EncodeToString(nonceBytes)
 
-	expiryTime := time.Now().Add(5 * time.Minute)
-	message := fmt.Sprintf("%s:%d", nonce, expiryTime.Unix())
+	// The verifier checks expiresAt against its own clock, so a skewed
+	// local clock fails only after the whole keysign; check it first.
+	now := time.Now()
+	skew, err := measureClockSkew(ctx, cfg.Verifier)
+	if err != nil {
+		progressf("  %s Could not measure clock skew: %v\n", warnMark(), err)
+	} else {
+		err = checkClockSkew(skew, scheme.ServerTime)
+		if err != nil {
+			return nil, err
+		}
+		if scheme.ServerTime {
+			now = now.Add(skew)
+		}
+	}
+	expiryTime := now.Add(5 * time.Minute)
+
+	// Message must be JSON format for verifier
+	messageJSON, err := json.Marshal(map[string]string{
+		"nonce":     nonce,
+		"expiresAt": expiryTime.Format(time.RFC3339),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshal message: %w", err)
+	}
+	message := string(messageJSON)
 
-	fmt.Printf("Authenticating with verifier...\n")
 	fmt.Printf("  Vault: %s\n", vault.Name)
-	fmt.Printf("  Public Key: %s...\n", vault.PublicKeyECDSA[:16])
 	fmt.Printf("  Verifier: %s\n", cfg.Verifier)
+	warnVaultEndpoint(vault, cfg.Verifier)
+
+	signed := []byte(message)
+	if scheme.MessageFormat == authMessagePersonalSign {
+		signed = []byte(fmt.Sprintf("\x19Ethereum Signed Message:\n%d%s", len(message), message))
+	}
+	hexMessage := hex.EncodeToString(crypto.Keccak256(signed))
+
+	derivePath, err := authDerivePath(scheme.DerivePath)
+	if err != nil {
+		return nil, err
+	}
+	fmt.Printf("  Derive Path: %s\n", derivePath)
 
 	tss := NewTSSService(vault.LocalPartyID)
-	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Minute)
 	defer cancel()
 
-	fmt.Println("\nPerforming TSS keysign for authentication...")
+	fmt.Println("  Performing TSS keysign...")
 
-	derivePath := "m/44'/60'/0'/0/0"
-	results, err := tss.Keysign(ctx, vault, []string{message}, derivePath, false, password)
+	results, err := tss.KeysignWithFastVault(ctx, vault, []string{hexMessage}, derivePath, password)
 	if err != nil {
-		return fmt.Errorf("TSS keysign failed: %w", err)
+		return nil, fmt.Errorf("TSS keysign failed: %w", err)
 	}
 
 	if len(results) == 0 {
-		return fmt.Errorf("no signature result")
+		return nil, fmt.Errorf("no signature result")
+	}
+
+	status, body, err := postAuth(ctx, cfg.Verifier, vault, message, results[0], scheme.SigFormat)
+	if err != nil {
+		return nil, err
+	}
+	used := scheme
+	if status == http.StatusBadRequest || status == http.StatusUnauthorized {
+		used.SigFormat = authSigDER
+		if scheme.SigFormat == authSigDER {
+			used.SigFormat = authSigRSV
+		}
+		progressf("  %s Verifier rejected the %s signature (%d); retrying as %s\n", warnMark(), scheme.SigFormat, status, used.SigFormat)
+		status, body, err = postAuth(ctx, cfg.Verifier, vault, message, results[0], used.SigFormat)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("authentication failed (%d): %s", status, errorBody(body))
+	}
+
+	progressf("  Verifier accepted %s\n", used)
+	if used != scheme {
+		progressf("  %s The default scheme was rejected; pass %s or correct the default\n", warnMark(), used)
+	}
+
+	authResp, err := client.Decode[client.AuthResponse](body)
+	if err != nil {
+		return nil, fmt.Errorf("parse auth response: %w", err)
+	}
+
+	authToken := AuthToken{
+		Token:     authResp.BearerToken(),
+		PublicKey: vault.PublicKeyECDSA,
+		ExpiresAt: time.Now().Add(7 * 24 * time.Hour),
 	}
 
-	signature := results[0].DerSignature
+	err = SaveAuthToken(&authToken)
+	if err != nil {
+		return nil, fmt.Errorf("save auth token: %w", err)
+	}
+	recordVaultUsage(vault, cfg, vaultUsageAuth)
+
+	return &authToken, nil
+}
+
+// postAuth sends a signed auth message to the verifier and returns the
+// status and body of its response.
+func postAuth(ctx context.Context, verifierURL string, vault *LocalVault, message string, sig KeysignResult, sigFormat string) (int, []byte, error) {
+	signature, err := encodeAuthSignature(sig, sigFormat)
+	if err != nil {
+		return 0, nil, err
+	}
 
 	authReq := map[string]string{
 		"message":        message,
@@ -145,53 +435,45 @@ func runAuthLogin(vaultID, password string) error {
 
 	reqJSON, err := json.Marshal(authReq)
 	if err != nil {
-		return fmt.Errorf("marshal auth request: %w", err)
+		return 0, nil, fmt.Errorf("marshal auth request: %w", err)
 	}
 
-	url := cfg.Verifier + "/auth"
-	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(reqJSON))
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", verifierURL+"/auth", bytes.NewReader(reqJSON))
 	if err != nil {
-		return fmt.Errorf("create request: %w", err)
+		return 0, nil, fmt.Errorf("create request: %w", err)
 	}
 	httpReq.Header.Set("Content-Type", "application/json")
 
 	resp, err := http.DefaultClient.Do(httpReq)
 	if err != nil {
-		return fmt.Errorf("auth request failed: %w", err)
+		return 0, nil, fmt.Errorf("auth request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
 	body, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, body, nil
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("authentication failed (%d): %s", resp.StatusCode, string(body))
+// encodeAuthSignature renders a keysign result as 0x-prefixed R+S+V or as
+// hex DER (SEQUENCE of the R and S integers).
+func encodeAuthSignature(sig KeysignResult, sigFormat string) (string, error) {
+	if sigFormat == authSigRSV {
+		return rsvSignature(sig, vRaw, nil)
 	}
 
-	var authResp struct {
-		Data struct {
-			Token string `json:"token"`
-		} `json:"data"`
+	r, ok := new(big.Int).SetString(sig.R, 16)
+	if !ok {
+		return "", fmt.Errorf("invalid signature R: %q", sig.R)
 	}
-	err = json.Unmarshal(body, &authResp)
-	if err != nil {
-		return fmt.Errorf("parse auth response: %w", err)
+	s, ok := new(big.Int).SetString(sig.S, 16)
+	if !ok {
+		return "", fmt.Errorf("invalid signature S: %q", sig.S)
 	}
-
-	authToken := AuthToken{
-		Token:     authResp.Data.Token,
-		PublicKey: vault.PublicKeyECDSA,
-		ExpiresAt: time.Now().Add(7 * 24 * time.Hour),
-	}
-
-	err = SaveAuthToken(&authToken)
+	der, err := asn1.Marshal(struct{ R, S *big.Int }{r, s})
 	if err != nil {
-		return fmt.Errorf("save auth token: %w", err)
+		return "", fmt.Errorf("encode DER signature: %w", err)
 	}
-
-	fmt.Println("\n✓ Authentication successful!")
-	fmt.Printf("  Token expires: %s\n", authToken.ExpiresAt.Format(time.RFC3339))
-
-	return nil
+	return hex.EncodeToString(der), nil
 }
 
 func runAuthStatus() error {
@@ -210,6 +492,7 @@ func runAuthStatus() error {
 
 	fmt.Println("Authenticated:")
 	fmt.Printf("  Public Key: %s...\n", token.PublicKey[:16])
+	fmt.Printf("  Fingerprint: %s\n", VaultFingerprint(token.PublicKey))
 	fmt.Printf("  Expires: %s\n", token.ExpiresAt.Format(time.RFC3339))
 	fmt.Printf("  Token: %s...\n", token.Token[:20])
 
@@ -226,53 +509,63 @@ func runAuthLogout() error {
 	return nil
 }
 
+// SaveAuthToken stores token as the verifier token of its vault.
 func SaveAuthToken(token *AuthToken) error {
 	cfg, err := LoadConfig()
 	if err != nil {
 		cfg = DefaultConfig()
 	}
 
-	cfg.AuthToken = token.Token
-	cfg.AuthPublicKey = token.PublicKey
-	cfg.AuthExpiresAt = token.ExpiresAt.Format(time.RFC3339)
+	if cfg.AuthTokens == nil {
+		cfg.AuthTokens = map[string]AuthToken{}
+	}
+	cfg.AuthTokens[token.PublicKey] = *token
 	return SaveConfig(cfg)
 }
 
+// LoadAuthToken returns the token of the current vault (public_key_ecdsa),
+// or the most recently issued one when that vault has none.
 func LoadAuthToken() (*AuthToken, error) {
 	cfg, err := LoadConfig()
 	if err != nil {
 		return nil, err
 	}
 
-	if cfg.AuthToken == "" {
-		return nil, fmt.Errorf("no auth token found")
+	if token, ok := cfg.AuthTokens[cfg.PublicKeyECDSA]; ok {
+		return &token, nil
 	}
 
-	expiresAt, err := time.Parse(time.RFC3339, cfg.AuthExpiresAt)
-	if err != nil {
-		return nil, fmt.Errorf("parse expiry: %w", err)
+	var latest *AuthToken
+	for _, token := range cfg.AuthTokens {
+		if latest == nil || token.ExpiresAt.After(latest.ExpiresAt) {
+			latest = &token
+		}
 	}
-
-	return &AuthToken{
-		Token:     cfg.AuthToken,
-		PublicKey: cfg.AuthPublicKey,
-		ExpiresAt: expiresAt,
-	}, nil
+	if latest == nil {
+		return nil, fmt.Errorf("no auth token found")
+	}
+	return latest, nil
 }
 
+// DeleteAuthToken removes the stored tokens of every vault.
 func DeleteAuthToken() error {
 	cfg, err := LoadConfig()
 	if err != nil {
 		return nil
 	}
 
-	cfg.AuthToken = ""
-	cfg.AuthPublicKey = ""
-	cfg.AuthExpiresAt = ""
+	cfg.AuthTokens = nil
 	return SaveConfig(cfg)
 }
 
+// GetAuthHeader returns the bearer header of the current vault's token.
+// VCLI_AUTH_TOKEN overrides the stored token, for watch-only vaults that
+// cannot sign an auth message themselves.
 func GetAuthHeader() (string, error) {
+	if token := os.Getenv("VCLI_AUTH_TOKEN"); token != "" {
+		return "Bearer " + strings.TrimPrefix(token, "Bearer "), nil
+	}
+
 	token, err := LoadAuthToken()
 	if err != nil {
 		return "", fmt.Errorf("not authenticated. Run 'devctl auth login' first")
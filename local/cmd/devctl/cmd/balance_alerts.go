@@ -0,0 +1,194 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/vultisig/vultisig-go/address"
+)
+
+// nativeBalanceCache holds the balances fetched during this invocation,
+// keyed by RPC URL and address, so the alert check, report and policy create
+// query each chain once.
+var nativeBalanceCache sync.Map
+
+// NativeBalance is a vault's native balance on one chain. Err is set when
+// the address could not be derived or the RPC failed.
+type NativeBalance struct {
+	Chain   ChainInfo
+	Address string
+	Wei     *big.Int
+	Err     error
+}
+
+// defaultBalanceTimeout bounds a whole balance query across all chains, so
+// a slow public RPC shows as "timeout" instead of holding up the command.
+const defaultBalanceTimeout = 15 * time.Second
+
+func cachedEVMBalance(ctx context.Context, rpcURL, addr string) (*big.Int, error) {
+	key := rpcURL + "|" + strings.ToLower(addr)
+	if v, ok := nativeBalanceCache.Load(key); ok {
+		return v.(*big.Int), nil
+	}
+	wei, err := getEVMBalance(ctx, rpcURL, addr)
+	if err != nil {
+		return nil, err
+	}
+	nativeBalanceCache.Store(key, wei)
+	return wei, nil
+}
+
+// fetchNativeBalances fetches the vault's native balance on each chain in
+// parallel, all under ctx's deadline. Results keep the order of chains.
+func fetchNativeBalances(ctx context.Context, vault *LocalVault, chains []ChainInfo) []NativeBalance {
+	balances := make([]NativeBalance, len(chains))
+	var wg sync.WaitGroup
+	for i, c := range chains {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			b := NativeBalance{Chain: c}
+			b.Address, _, _, b.Err = address.GetAddress(vault.PublicKeyECDSA, vault.HexChainCode, c.Chain)
+			if b.Err == nil {
+				b.Wei, b.Err = cachedEVMBalance(ctx, c.RPCURL, b.Address)
+			}
+			balances[i] = b
+		}()
+	}
+	wg.Wait()
+	return balances
+}
+
+// balanceContext bounds a balance query by defaultBalanceTimeout, unless
+// the global --timeout already set a deadline on ctx.
+func balanceContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, defaultBalanceTimeout)
+}
+
+// balanceErrorText is how a failed balance fetch is shown: "timeout" once
+// the deadline has passed, otherwise the RPC error.
+func balanceErrorText(err error) string {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return "timeout"
+	}
+	return err.Error()
+}
+
+// balanceThreshold is an --alert-below value such as "0.02ETH". An empty
+// Symbol applies the amount to every chain, in its native unit.
+type balanceThreshold struct {
+	Amount string
+	Symbol string
+}
+
+func parseBalanceThreshold(s string) (balanceThreshold, error) {
+	s = strings.TrimSpace(s)
+	i := strings.IndexFunc(s, func(r rune) bool { return r != '.' && !unicode.IsDigit(r) })
+	t := balanceThreshold{Amount: s}
+	if i >= 0 {
+		t.Amount, t.Symbol = s[:i], strings.ToUpper(strings.TrimSpace(s[i:]))
+	}
+	_, err := parseUnits(t.Amount, 18)
+	if t.Amount == "" || err != nil {
+		return balanceThreshold{}, fmt.Errorf("invalid threshold %q (use an amount with an optional symbol, e.g. 0.02ETH)", s)
+	}
+	return t, nil
+}
+
+func (t balanceThreshold) appliesTo(c ChainInfo) bool {
+	return t.Symbol == "" || strings.EqualFold(t.Symbol, c.Symbol)
+}
+
+// balanceThresholds maps chain names to their alert threshold:
+// chains.<name>.alert_below from cluster.yaml, overridden by flag on the
+// chains it applies to. Chains without a threshold are not monitored.
+func balanceThresholds(chains []ChainInfo, flag *balanceThreshold) map[string]string {
+	cc := clusterConfigOrDefaults()
+	thresholds := map[string]string{}
+	for _, c := range chains {
+		if override, ok := cc.Chains[chainKey(c.Name)]; ok && override.AlertBelow != "" {
+			thresholds[c.Name] = override.AlertBelow
+		}
+		if flag != nil && flag.appliesTo(c) {
+			thresholds[c.Name] = flag.Amount
+		}
+	}
+	return thresholds
+}
+
+// BalanceAlert is a monitored chain whose balance is below its threshold.
+type BalanceAlert struct {
+	Chain     string `json:"chain"`
+	Balance   string `json:"balance"`
+	Threshold string `json:"threshold"`
+	Symbol    string `json:"symbol"`
+}
+
+func (a BalanceAlert) String() string {
+	return fmt.Sprintf("%s: %s %s, below the alert threshold of %s %s", a.Chain, a.Balance, a.Symbol, a.Threshold, a.Symbol)
+}
+
+// checkBalanceAlerts compares balances with thresholds. Chains whose balance
+// could not be fetched are skipped with a warning rather than alerted on.
+func checkBalanceAlerts(balances []NativeBalance, thresholds map[string]string) []BalanceAlert {
+	var alerts []BalanceAlert
+	for _, b := range balances {
+		threshold, ok := thresholds[b.Chain.Name]
+		if !ok {
+			continue
+		}
+		if b.Err != nil {
+			progressf("  %s %s: balance unavailable for the alert check (%s)\n", warnMark(), b.Chain.Name, balanceErrorText(b.Err))
+			continue
+		}
+		floor, err := parseUnits(threshold, b.Chain.Decimals)
+		if err != nil {
+			progressf("  %s %s: invalid alert threshold %q: %v\n", warnMark(), b.Chain.Name, threshold, err)
+			continue
+		}
+		if b.Wei.Cmp(floor) < 0 {
+			alerts = append(alerts, BalanceAlert{
+				Chain:     b.Chain.Name,
+				Balance:   formatBalance(b.Wei, b.Chain.Decimals),
+				Threshold: threshold,
+				Symbol:    b.Chain.Symbol,
+			})
+		}
+	}
+	return alerts
+}
+
+// activeVaultBalanceAlerts checks the active vault against the cluster.yaml
+// thresholds, for report. It returns nothing when no chain is monitored.
+func activeVaultBalanceAlerts(cfg *DevConfig) []BalanceAlert {
+	if cfg.PublicKeyECDSA == "" {
+		return nil
+	}
+	chains := chainRegistry()
+	thresholds := balanceThresholds(chains, nil)
+	var monitored []ChainInfo
+	for _, c := range chains {
+		if _, ok := thresholds[c.Name]; ok {
+			monitored = append(monitored, c)
+		}
+	}
+	if len(monitored) == 0 {
+		return nil
+	}
+	vault, err := LoadVault(cfg.PublicKeyECDSA[:16])
+	if err != nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), defaultBalanceTimeout)
+	defer cancel()
+	return checkBalanceAlerts(fetchNativeBalances(ctx, vault, monitored), thresholds)
+}
@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/vultisig/vultisig-go/common"
+)
+
+// rpcServers starts an RPC that answers every call with 1 ETH and one that
+// holds every call until the test ends.
+func rpcServers(t *testing.T) (fast, slow string) {
+	t.Helper()
+	fastSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"jsonrpc": "2.0", "id": 1, "result": "0xde0b6b3a7640000"}`))
+	}))
+	release := make(chan struct{})
+	slowSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	t.Cleanup(fastSrv.Close)
+	t.Cleanup(slowSrv.Close)
+	t.Cleanup(func() { close(release) })
+	return fastSrv.URL, slowSrv.URL
+}
+
+func TestBalanceContext(t *testing.T) {
+	ctx, cancel := balanceContext(context.Background())
+	defer cancel()
+	deadline, ok := ctx.Deadline()
+	if !ok || time.Until(deadline) > defaultBalanceTimeout || time.Until(deadline) < defaultBalanceTimeout-time.Second {
+		t.Errorf("without --timeout: deadline in %s, want %s", time.Until(deadline), defaultBalanceTimeout)
+	}
+
+	// A --timeout longer than the default is kept as well.
+	parent, cancelParent := context.WithTimeout(context.Background(), time.Hour)
+	defer cancelParent()
+	want, _ := parent.Deadline()
+	ctx, cancel = balanceContext(parent)
+	defer cancel()
+	got, ok := ctx.Deadline()
+	if !ok || !got.Equal(want) {
+		t.Errorf("with --timeout: deadline %v, want %v", got, want)
+	}
+}
+
+func TestFetchNativeBalancesDeadline(t *testing.T) {
+	useClusterConfig(t, &ClusterConfig{})
+	fast, slow := rpcServers(t)
+	vault, err := newDemoVault()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chains := []ChainInfo{
+		{Name: "Slow", Chain: common.Arbitrum, RPCURL: slow, Symbol: "ETH", Decimals: 18},
+		{Name: "Fast", Chain: common.Ethereum, RPCURL: fast, Symbol: "ETH", Decimals: 18},
+		{Name: "Slower", Chain: common.Base, RPCURL: slow, Symbol: "ETH", Decimals: 18},
+	}
+
+	parent, cancelParent := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancelParent()
+	ctx, cancel := balanceContext(parent)
+	defer cancel()
+
+	start := time.Now()
+	balances := fetchNativeBalances(ctx, vault, chains)
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("fetchNativeBalances took %s, want it bounded by the 300ms deadline", elapsed)
+	}
+
+	if len(balances) != 3 {
+		t.Fatalf("got %d balances, want 3", len(balances))
+	}
+	for i, want := range []string{"Slow", "Fast", "Slower"} {
+		if balances[i].Chain.Name != want {
+			t.Errorf("balance %d is %s, want %s", i, balances[i].Chain.Name, want)
+		}
+	}
+	if b := balances[1]; b.Err != nil || b.Wei.String() != "1000000000000000000" {
+		t.Errorf("fast chain: got %v, %v, want 1 ETH", b.Wei, b.Err)
+	}
+	for _, b := range []NativeBalance{balances[0], balances[2]} {
+		if !errors.Is(b.Err, context.DeadlineExceeded) || balanceErrorText(b.Err) != "timeout" {
+			t.Errorf("%s: error %v, want a timeout", b.Chain.Name, b.Err)
+		}
+	}
+}
+
+func TestFetchEVMChainBalancesDeadline(t *testing.T) {
+	// One configured token per chain, queried on the chain's RPC.
+	useClusterConfig(t, &ClusterConfig{Tokens: []TokenConfig{
+		{Chain: "arbitrum", Symbol: "PAY", Address: "0x3333333333333333333333333333333333333333"},
+		{Chain: "base", Symbol: "PAY", Address: "0x3333333333333333333333333333333333333333"},
+	}})
+	fast, slow := rpcServers(t)
+
+	chains := []ChainInfo{
+		{Name: "Arbitrum", Chain: common.Arbitrum, RPCURL: slow, Symbol: "ETH", Decimals: 18},
+		{Name: "Base", Chain: common.Base, RPCURL: fast, Symbol: "ETH", Decimals: 18},
+	}
+
+	parent, cancelParent := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancelParent()
+	ctx, cancel := balanceContext(parent)
+	defer cancel()
+
+	start := time.Now()
+	results := fetchEVMChainBalances(ctx, "0x1111111111111111111111111111111111111111", chains)
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("fetchEVMChainBalances took %s, want it bounded by the 300ms deadline", elapsed)
+	}
+
+	slowResult, fastResult := results[0], results[1]
+	if !errors.Is(slowResult.Err, context.DeadlineExceeded) {
+		t.Errorf("slow chain: error %v, want a timeout", slowResult.Err)
+	}
+	if len(slowResult.Tokens) != 1 || slowResult.Tokens[0].Balance != nil {
+		t.Errorf("slow chain tokens = %+v, want PAY without a balance", slowResult.Tokens)
+	}
+	if fastResult.Err != nil || fastResult.Native.String() != "1000000000000000000" {
+		t.Errorf("fast chain: got %v, %v, want 1 ETH", fastResult.Native, fastResult.Err)
+	}
+	if len(fastResult.Tokens) != 1 || fastResult.Tokens[0].Token.Symbol != "PAY" || fastResult.Tokens[0].Balance == nil {
+		t.Errorf("fast chain tokens = %+v, want a PAY balance", fastResult.Tokens)
+	}
+}
@@ -0,0 +1,241 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/vultisig/vultisig-go/address"
+	"github.com/vultisig/vultisig-go/common"
+)
+
+// BalanceProvider fetches the native balance of an address on a non-EVM
+// chain, in the chain's smallest unit.
+type BalanceProvider interface {
+	Balance(ctx context.Context, addr string) (*big.Int, error)
+}
+
+// nativeChain is a non-EVM chain 'vault details' shows. Names are the
+// --chain values that select it. Chains without a Provider show only the
+// address.
+type nativeChain struct {
+	Title    string
+	Names    []string
+	Chain    common.Chain
+	EdDSA    bool
+	Symbol   string
+	Decimals int
+	Provider BalanceProvider
+}
+
+// nativeChains returns the non-EVM chains of 'vault details' in display
+// order. chains.<name>.rpc in cluster.yaml replaces a chain's default
+// endpoint: an Esplora API for Bitcoin, JSON-RPC for Solana and the REST
+// (LCD) API for the Cosmos chains.
+func nativeChains() []nativeChain {
+	cc := clusterConfigOrDefaults()
+	endpoint := func(name, fallback string) string {
+		if override, ok := cc.Chains[name]; ok && override.RPC != "" {
+			return override.RPC
+		}
+		return fallback
+	}
+	bank := func(name, fallback, denom string) BalanceProvider {
+		return cosmosBankProvider{RESTURL: endpoint(name, fallback), Denom: denom}
+	}
+
+	return []nativeChain{
+		{Title: "Bitcoin", Names: []string{"bitcoin", "btc"}, Chain: common.Bitcoin, Symbol: "BTC", Decimals: 8,
+			Provider: esploraProvider{BaseURL: endpoint("bitcoin", defaultEsploraURL)}},
+		{Title: "THORChain", Names: []string{"thorchain", "rune"}, Chain: common.THORChain, Symbol: "RUNE", Decimals: 8,
+			Provider: bank("thorchain", "https://thornode.ninerealms.com", "rune")},
+		{Title: "MayaChain", Names: []string{"maya", "mayachain", "cacao"}, Chain: common.MayaChain, Symbol: "CACAO", Decimals: 10,
+			Provider: bank("mayachain", "https://mayanode.mayachain.info", "cacao")},
+		{Title: "Cosmos Hub", Names: []string{"cosmos hub", "cosmos", "gaia", "atom"}, Chain: common.GaiaChain, Symbol: "ATOM", Decimals: 6,
+			Provider: bank("cosmos_hub", "https://cosmos-rest.publicnode.com", "uatom")},
+		{Title: "Osmosis", Names: []string{"osmosis", "osmo"}, Chain: common.Osmosis, Symbol: "OSMO", Decimals: 6,
+			Provider: bank("osmosis", "https://osmosis-rest.publicnode.com", "uosmo")},
+		{Title: "Dydx", Names: []string{"dydx"}, Chain: common.Dydx, Symbol: "DYDX", Decimals: 18,
+			Provider: bank("dydx", "https://dydx-rest.publicnode.com", "adydx")},
+		{Title: "Kujira", Names: []string{"kujira", "kuji"}, Chain: common.Kujira, Symbol: "KUJI", Decimals: 6,
+			Provider: bank("kujira", "https://kujira-rest.publicnode.com", "ukuji")},
+		{Title: "Solana (EdDSA)", Names: []string{"solana", "sol"}, Chain: common.Solana, EdDSA: true, Symbol: "SOL", Decimals: 9,
+			Provider: solanaProvider{RPCURL: endpoint("solana", defaultSolanaRPC)}},
+		{Title: "Sui (EdDSA)", Names: []string{"sui"}, Chain: common.Sui, EdDSA: true, Symbol: "SUI", Decimals: 9},
+		{Title: "Polkadot (EdDSA)", Names: []string{"polkadot", "dot"}, Chain: common.Polkadot, EdDSA: true, Symbol: "DOT", Decimals: 10},
+		{Title: "TON (EdDSA)", Names: []string{"ton"}, Chain: common.Ton, EdDSA: true, Symbol: "TON", Decimals: 9},
+	}
+}
+
+// nativeChainBalance is a vault's address and balance on a nativeChain.
+// Balance is nil when the chain has no provider or Err is set.
+type nativeChainBalance struct {
+	Chain   nativeChain
+	Address string
+	Balance *big.Int
+	Err     error
+}
+
+// fetchNativeChainBalances derives the vault's address on each nativeChain
+// matching chainFilter (all when empty) and fetches the balances in parallel
+// under ctx's deadline. Chains whose address cannot be derived, and EdDSA
+// chains of a vault without an EdDSA key, are left out.
+func fetchNativeChainBalances(ctx context.Context, vault *LocalVault, chainFilter string) []nativeChainBalance {
+	var results []nativeChainBalance
+	for _, c := range nativeChains() {
+		if chainFilter != "" && !slices.Contains(c.Names, strings.ToLower(chainFilter)) {
+			continue
+		}
+		pubKey := vault.PublicKeyECDSA
+		if c.EdDSA {
+			pubKey = vault.PublicKeyEdDSA
+		}
+		if pubKey == "" {
+			continue
+		}
+		addr, _, _, err := address.GetAddress(pubKey, vault.HexChainCode, c.Chain)
+		if err != nil {
+			continue
+		}
+		results = append(results, nativeChainBalance{Chain: c, Address: addr})
+	}
+
+	var wg sync.WaitGroup
+	for i, r := range results {
+		if r.Chain.Provider == nil {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i].Balance, results[i].Err = r.Chain.Provider.Balance(ctx, r.Address)
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+// esploraProvider sums an address's confirmed and mempool UTXOs from an
+// Esplora API (blockstream.info, mempool.space).
+type esploraProvider struct {
+	BaseURL string
+}
+
+func (p esploraProvider) Balance(ctx context.Context, addr string) (*big.Int, error) {
+	type stats struct {
+		Funded int64 `json:"funded_txo_sum"`
+		Spent  int64 `json:"spent_txo_sum"`
+	}
+	var resp struct {
+		ChainStats   stats `json:"chain_stats"`
+		MempoolStats stats `json:"mempool_stats"`
+	}
+	err := getJSON(ctx, strings.TrimSuffix(p.BaseURL, "/")+"/address/"+addr, &resp)
+	if err != nil {
+		return nil, err
+	}
+	sats := resp.ChainStats.Funded - resp.ChainStats.Spent + resp.MempoolStats.Funded - resp.MempoolStats.Spent
+	return big.NewInt(sats), nil
+}
+
+// solanaProvider reads the lamports of an account with getBalance.
+type solanaProvider struct {
+	RPCURL string
+}
+
+func (p solanaProvider) Balance(ctx context.Context, addr string) (*big.Int, error) {
+	payload, err := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"method":  "getBalance",
+		"params":  []interface{}{addr},
+		"id":      1,
+	})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", p.RPCURL, strings.NewReader(string(payload)))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	var resp struct {
+		Result struct {
+			Value uint64 `json:"value"`
+		} `json:"result"`
+		Error *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	err = doJSON(req, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Error != nil {
+		return nil, fmt.Errorf("RPC error: %s", resp.Error.Message)
+	}
+	return new(big.Int).SetUint64(resp.Result.Value), nil
+}
+
+// cosmosBankProvider reads one denom from the bank module's REST API, which
+// THORChain, Maya and the Cosmos SDK chains all serve.
+type cosmosBankProvider struct {
+	RESTURL string
+	Denom   string
+}
+
+func (p cosmosBankProvider) Balance(ctx context.Context, addr string) (*big.Int, error) {
+	var resp struct {
+		Balances []struct {
+			Denom  string `json:"denom"`
+			Amount string `json:"amount"`
+		} `json:"balances"`
+	}
+	err := getJSON(ctx, strings.TrimSuffix(p.RESTURL, "/")+"/cosmos/bank/v1beta1/balances/"+addr, &resp)
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range resp.Balances {
+		if b.Denom != p.Denom {
+			continue
+		}
+		amount, ok := new(big.Int).SetString(b.Amount, 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid %s amount %q", p.Denom, b.Amount)
+		}
+		return amount, nil
+	}
+	return big.NewInt(0), nil
+}
+
+func getJSON(ctx context.Context, url string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return err
+	}
+	return doJSON(req, out)
+}
+
+// doJSON sends req and decodes a 200 response into out.
+func doJSON(req *http.Request, out interface{}) error {
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned %d: %s", req.URL.Host, resp.StatusCode, errorBody(body))
+	}
+	return json.Unmarshal(body, out)
+}
@@ -0,0 +1,203 @@
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	rtypes "github.com/vultisig/recipes/types"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// BillingSpec is one validated billing entry of a policy config file.
+type BillingSpec struct {
+	Type        rtypes.FeeType
+	Frequency   rtypes.BillingFrequency
+	Amount      int64
+	Asset       string
+	StartDate   *time.Time
+	Description string
+}
+
+var billingTypes = map[string]rtypes.FeeType{
+	"once":        rtypes.FeeType_ONCE,
+	"one_time":    rtypes.FeeType_ONCE,
+	"one-time":    rtypes.FeeType_ONCE,
+	"transaction": rtypes.FeeType_TRANSACTION,
+	"per_tx":      rtypes.FeeType_TRANSACTION,
+	"per-tx":      rtypes.FeeType_TRANSACTION,
+	"recurring":   rtypes.FeeType_RECURRING,
+}
+
+var billingFrequencies = map[string]rtypes.BillingFrequency{
+	"daily":     rtypes.BillingFrequency_DAILY,
+	"weekly":    rtypes.BillingFrequency_WEEKLY,
+	"biweekly":  rtypes.BillingFrequency_BIWEEKLY,
+	"bi-weekly": rtypes.BillingFrequency_BIWEEKLY,
+	"monthly":   rtypes.BillingFrequency_MONTHLY,
+}
+
+var billingFields = map[string]bool{
+	"type": true, "amount": true, "frequency": true,
+	"asset": true, "start_date": true, "description": true,
+}
+
+// billingItems accepts "billing" as an array of entries or a single object.
+func billingItems(billingConfig interface{}) ([]map[string]interface{}, error) {
+	var items []interface{}
+	switch v := billingConfig.(type) {
+	case nil:
+		return nil, nil
+	case []interface{}:
+		items = v
+	case map[string]interface{}:
+		items = []interface{}{v}
+	default:
+		return nil, fmt.Errorf("billing: must be an array of entries, got %T", billingConfig)
+	}
+
+	result := make([]map[string]interface{}, 0, len(items))
+	for i, item := range items {
+		entry, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("billing[%d]: must be an object, got %T", i, item)
+		}
+		result = append(result, entry)
+	}
+	return result, nil
+}
+
+// parseBillingConfig validates the "billing" section of a policy config.
+// Errors name the offending entry and field, e.g. billing[1].frequency.
+func parseBillingConfig(billingConfig interface{}) ([]BillingSpec, error) {
+	items, err := billingItems(billingConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	specs := make([]BillingSpec, 0, len(items))
+	for i, entry := range items {
+		spec, err := parseBillingEntry(entry)
+		if err != nil {
+			return nil, fmt.Errorf("billing[%d].%w", i, err)
+		}
+		specs = append(specs, spec)
+	}
+	return specs, nil
+}
+
+func parseBillingEntry(entry map[string]interface{}) (BillingSpec, error) {
+	var spec BillingSpec
+
+	for key := range entry {
+		if !billingFields[key] {
+			return spec, fmt.Errorf("%s: unknown field", key)
+		}
+	}
+
+	typeStr, ok := entry["type"].(string)
+	if !ok {
+		return spec, fmt.Errorf("type: required (once, transaction or recurring)")
+	}
+	spec.Type, ok = billingTypes[strings.ToLower(typeStr)]
+	if !ok {
+		return spec, fmt.Errorf("type: unknown billing type %q (use once, transaction or recurring)", typeStr)
+	}
+
+	// float64(math.MaxInt64) rounds up to 2^63, which no longer fits in int64.
+	amount, ok := entry["amount"].(float64)
+	if !ok || amount < 0 || amount != math.Trunc(amount) || amount >= math.MaxInt64 {
+		return spec, fmt.Errorf("amount: must be a non-negative integer in the asset's base units, got %v", entry["amount"])
+	}
+	spec.Amount = int64(amount)
+
+	if freq, present := entry["frequency"]; present {
+		freqStr, ok := freq.(string)
+		if !ok {
+			return spec, fmt.Errorf("frequency: must be a string, got %T", freq)
+		}
+		spec.Frequency, ok = billingFrequencies[strings.ToLower(freqStr)]
+		if !ok {
+			return spec, fmt.Errorf("frequency: unknown frequency %q (use daily, weekly, biweekly or monthly)", freqStr)
+		}
+	}
+	if spec.Type == rtypes.FeeType_RECURRING && spec.Frequency == rtypes.BillingFrequency_BILLING_FREQUENCY_UNSPECIFIED {
+		return spec, fmt.Errorf("frequency: required for recurring billing")
+	}
+	if spec.Type != rtypes.FeeType_RECURRING && spec.Frequency != rtypes.BillingFrequency_BILLING_FREQUENCY_UNSPECIFIED {
+		return spec, fmt.Errorf("frequency: only applies to recurring billing")
+	}
+
+	if asset, present := entry["asset"]; present {
+		spec.Asset, ok = asset.(string)
+		if !ok {
+			return spec, fmt.Errorf("asset: must be a string, got %T", asset)
+		}
+	}
+
+	if start, present := entry["start_date"]; present {
+		startStr, ok := start.(string)
+		if !ok {
+			return spec, fmt.Errorf("start_date: must be an RFC 3339 timestamp, got %T", start)
+		}
+		t, err := time.Parse(time.RFC3339, startStr)
+		if err != nil {
+			return spec, fmt.Errorf("start_date: must be an RFC 3339 timestamp: %w", err)
+		}
+		spec.StartDate = &t
+	}
+
+	if desc, present := entry["description"]; present {
+		spec.Description, ok = desc.(string)
+		if !ok {
+			return spec, fmt.Errorf("description: must be a string, got %T", desc)
+		}
+	}
+
+	return spec, nil
+}
+
+func buildFeePolicies(billingConfig interface{}) ([]*rtypes.FeePolicy, error) {
+	specs, err := parseBillingConfig(billingConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	var feePolicies []*rtypes.FeePolicy
+	for _, spec := range specs {
+		feePolicy := &rtypes.FeePolicy{
+			Id:          uuid.New().String(),
+			Type:        spec.Type,
+			Frequency:   spec.Frequency,
+			Amount:      spec.Amount,
+			Description: spec.Description,
+		}
+		if spec.StartDate != nil {
+			feePolicy.StartDate = timestamppb.New(*spec.StartDate)
+		}
+		feePolicies = append(feePolicies, feePolicy)
+	}
+
+	return feePolicies, nil
+}
+
+// buildBillingArray returns the billing entries for the verifier request.
+// Entries are validated like the fee policies and passed through as written,
+// so asset and start_date reach the verifier's billing records.
+func buildBillingArray(billingConfig interface{}) ([]map[string]interface{}, error) {
+	_, err := parseBillingConfig(billingConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := billingItems(billingConfig)
+	if err != nil {
+		return nil, err
+	}
+	if items == nil {
+		return []map[string]interface{}{}, nil
+	}
+	return items, nil
+}
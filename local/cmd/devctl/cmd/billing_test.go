@@ -0,0 +1,271 @@
+package cmd
+
+import (
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	rtypes "github.com/vultisig/recipes/types"
+)
+
+func TestParseBillingConfig(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		billing string
+		want    []BillingSpec
+		wantErr string
+	}{
+		{name: "absent", billing: `null`, want: []BillingSpec{}},
+		{name: "empty array", billing: `[]`, want: []BillingSpec{}},
+		{
+			name:    "single object",
+			billing: `{"type": "once", "amount": 100}`,
+			want:    []BillingSpec{{Type: rtypes.FeeType_ONCE, Amount: 100}},
+		},
+		{
+			name: "every type and alias",
+			billing: `[
+				{"type": "one-time", "amount": 1},
+				{"type": "PER_TX", "amount": 2},
+				{"type": "recurring", "frequency": "Bi-Weekly", "amount": 3}
+			]`,
+			want: []BillingSpec{
+				{Type: rtypes.FeeType_ONCE, Amount: 1},
+				{Type: rtypes.FeeType_TRANSACTION, Amount: 2},
+				{Type: rtypes.FeeType_RECURRING, Frequency: rtypes.BillingFrequency_BIWEEKLY, Amount: 3},
+			},
+		},
+		{
+			name:    "all optional fields",
+			billing: `[{"type": "recurring", "frequency": "monthly", "amount": 500000, "asset": "usdc", "start_date": "2026-01-01T00:00:00Z", "description": "subscription"}]`,
+			want: []BillingSpec{{
+				Type: rtypes.FeeType_RECURRING, Frequency: rtypes.BillingFrequency_MONTHLY, Amount: 500000,
+				Asset: "usdc", StartDate: &start, Description: "subscription",
+			}},
+		},
+		{name: "zero amount", billing: `[{"type": "once", "amount": 0}]`, want: []BillingSpec{{Type: rtypes.FeeType_ONCE}}},
+		{name: "integral float", billing: `[{"type": "once", "amount": 1e6}]`, want: []BillingSpec{{Type: rtypes.FeeType_ONCE, Amount: 1000000}}},
+		{
+			// 2^53 + 1 is not representable and decodes as 2^53.
+			name:    "beyond float64 precision",
+			billing: `[{"type": "once", "amount": 9007199254740993}]`,
+			want:    []BillingSpec{{Type: rtypes.FeeType_ONCE, Amount: 9007199254740992}},
+		},
+		{
+			// The largest float64 below 2^63.
+			name:    "largest amount",
+			billing: `[{"type": "once", "amount": 9223372036854774784}]`,
+			want:    []BillingSpec{{Type: rtypes.FeeType_ONCE, Amount: 9223372036854774784}},
+		},
+
+		{name: "not an array", billing: `"once"`, wantErr: "billing: must be an array of entries, got string"},
+		{name: "entry not an object", billing: `[{"type": "once", "amount": 1}, 5]`, wantErr: "billing[1]: must be an object, got float64"},
+		{name: "unknown field", billing: `[{"type": "once", "amount": 1, "currency": "usdc"}]`, wantErr: "billing[0].currency: unknown field"},
+		{name: "missing type", billing: `[{"amount": 1}]`, wantErr: "billing[0].type: required"},
+		{name: "unknown type", billing: `[{"type": "yearly", "amount": 1}]`, wantErr: `billing[0].type: unknown billing type "yearly"`},
+		{name: "missing amount", billing: `[{"type": "once"}]`, wantErr: "billing[0].amount: must be a non-negative integer in the asset's base units, got <nil>"},
+		{name: "string amount", billing: `[{"type": "once", "amount": "100"}]`, wantErr: "billing[0].amount: must be a non-negative integer in the asset's base units, got 100"},
+		{name: "negative amount", billing: `[{"type": "once", "amount": -1}]`, wantErr: "got -1"},
+		{name: "fractional amount", billing: `[{"type": "once", "amount": 1.5}]`, wantErr: "got 1.5"},
+		{name: "tiny fraction", billing: `[{"type": "once", "amount": 1e-9}]`, wantErr: "got 1e-09"},
+		{name: "amount of 2^63", billing: `[{"type": "once", "amount": 9223372036854775808}]`, wantErr: "got 9.223372036854776e+18"},
+		{name: "amount above int64", billing: `[{"type": "once", "amount": 1e19}]`, wantErr: "got 1e+19"},
+		{name: "recurring without frequency", billing: `[{"type": "recurring", "amount": 1}]`, wantErr: "billing[0].frequency: required for recurring billing"},
+		{name: "frequency on once", billing: `[{"type": "once", "frequency": "daily", "amount": 1}]`, wantErr: "billing[0].frequency: only applies to recurring billing"},
+		{name: "unknown frequency", billing: `[{"type": "recurring", "frequency": "hourly", "amount": 1}]`, wantErr: `billing[0].frequency: unknown frequency "hourly"`},
+		{name: "frequency not a string", billing: `[{"type": "recurring", "frequency": 7, "amount": 1}]`, wantErr: "billing[0].frequency: must be a string, got float64"},
+		{name: "asset not a string", billing: `[{"type": "once", "amount": 1, "asset": 1}]`, wantErr: "billing[0].asset: must be a string, got float64"},
+		{name: "bad start date", billing: `[{"type": "once", "amount": 1, "start_date": "2026-01-01"}]`, wantErr: "billing[0].start_date: must be an RFC 3339 timestamp"},
+		{name: "description not a string", billing: `[{"type": "once", "amount": 1, "description": true}]`, wantErr: "billing[0].description: must be a string, got bool"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var billing interface{}
+			err := json.Unmarshal([]byte(tt.billing), &billing)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := parseBillingConfig(billing)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("parseBillingConfig error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseBillingConfig: %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("parseBillingConfig = %+v, want %+v", got, tt.want)
+			}
+			for i := range got {
+				g, w := got[i], tt.want[i]
+				if (g.StartDate == nil) != (w.StartDate == nil) || g.StartDate != nil && !g.StartDate.Equal(*w.StartDate) {
+					t.Errorf("entry %d start date = %v, want %v", i, g.StartDate, w.StartDate)
+				}
+				g.StartDate, w.StartDate = nil, nil
+				if g != w {
+					t.Errorf("entry %d = %+v, want %+v", i, g, w)
+				}
+			}
+		})
+	}
+}
+
+func TestBuildBillingArray(t *testing.T) {
+	var billing interface{}
+	err := json.Unmarshal([]byte(`{"type": "once", "amount": 100, "asset": "usdc"}`), &billing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := buildBillingArray(billing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0]["asset"] != "usdc" || got[0]["amount"] != 100.0 {
+		t.Errorf("buildBillingArray = %v, want the entry passed through", got)
+	}
+
+	got, err = buildBillingArray(nil)
+	if err != nil || got == nil || len(got) != 0 {
+		t.Errorf("buildBillingArray(nil) = %#v, %v, want an empty array", got, err)
+	}
+
+	_, err = buildBillingArray([]interface{}{map[string]interface{}{"type": "once", "amount": 0.5}})
+	if err == nil {
+		t.Error("buildBillingArray accepted a fractional amount")
+	}
+}
+
+func TestParseUnits(t *testing.T) {
+	tests := []struct {
+		amount   string
+		decimals int
+		want     string
+		wantErr  bool
+	}{
+		{amount: "1", decimals: 18, want: "1000000000000000000"},
+		{amount: "1.5", decimals: 6, want: "1500000"},
+		{amount: " 0.02 ", decimals: 18, want: "20000000000000000"},
+		{amount: ".5", decimals: 2, want: "50"},
+		{amount: "5.", decimals: 2, want: "500"},
+		{amount: "0.000001", decimals: 6, want: "1"},
+		{amount: "0", decimals: 0, want: "0"},
+		{amount: "123", decimals: 0, want: "123"},
+		// Far beyond float64 precision, exact in base units.
+		{amount: "123456789012345678901234567890.123456789012345678", decimals: 18, want: "123456789012345678901234567890123456789012345678"},
+		{amount: "0.0000001", decimals: 6, wantErr: true},
+		{amount: "1.5", decimals: 0, wantErr: true},
+		{amount: "-1", decimals: 18, wantErr: true},
+		{amount: "1e18", decimals: 18, wantErr: true},
+		{amount: "1,5", decimals: 18, wantErr: true},
+		{amount: "1.2.3", decimals: 18, wantErr: true},
+		{amount: "abc", decimals: 18, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseUnits(tt.amount, tt.decimals)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseUnits(%q, %d) = %s, want an error", tt.amount, tt.decimals, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseUnits(%q, %d): %v", tt.amount, tt.decimals, err)
+			continue
+		}
+		if got.String() != tt.want {
+			t.Errorf("parseUnits(%q, %d) = %s, want %s", tt.amount, tt.decimals, got, tt.want)
+		}
+	}
+}
+
+func TestFormatBalance(t *testing.T) {
+	tests := []struct {
+		balance  string
+		decimals int
+		want     string
+	}{
+		{balance: "0", decimals: 18, want: "0.000000"},
+		{balance: "1000000000000000000", decimals: 18, want: "1.000000"},
+		{balance: "1500000", decimals: 6, want: "1.500000"},
+		{balance: "1", decimals: 6, want: "0.000001"},
+		// Below the sixth decimal rounds to zero or up.
+		{balance: "499999999999", decimals: 18, want: "0.000000"},
+		{balance: "500000000001", decimals: 18, want: "0.000001"},
+		{balance: "1999999999999999999", decimals: 18, want: "2.000000"},
+		{balance: "42", decimals: 0, want: "42.000000"},
+	}
+
+	for _, tt := range tests {
+		balance, _ := new(big.Int).SetString(tt.balance, 10)
+		if got := formatBalance(balance, tt.decimals); got != tt.want {
+			t.Errorf("formatBalance(%s, %d) = %q, want %q", tt.balance, tt.decimals, got, tt.want)
+		}
+	}
+}
+
+func TestWeiToUSD(t *testing.T) {
+	tests := []struct {
+		wei      string
+		decimals int
+		price    float64
+		want     float64
+	}{
+		{wei: "0", decimals: 18, price: 3000, want: 0},
+		{wei: "1000000000000000000", decimals: 18, price: 3000, want: 3000},
+		{wei: "20000000000000000", decimals: 18, price: 2500, want: 50},
+		{wei: "2500000", decimals: 6, price: 1, want: 2.5},
+		{wei: "100000000", decimals: 8, price: 60000, want: 60000},
+	}
+
+	for _, tt := range tests {
+		wei, _ := new(big.Int).SetString(tt.wei, 10)
+		got := weiToUSD(wei, tt.decimals, tt.price)
+		if diff := got - tt.want; diff > 1e-9 || diff < -1e-9 {
+			t.Errorf("weiToUSD(%s, %d, %v) = %v, want %v", tt.wei, tt.decimals, tt.price, got, tt.want)
+		}
+	}
+}
+
+func TestParseBalanceThreshold(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    balanceThreshold
+		wantErr bool
+	}{
+		{in: "0.02ETH", want: balanceThreshold{Amount: "0.02", Symbol: "ETH"}},
+		{in: "0.02 eth", want: balanceThreshold{Amount: "0.02", Symbol: "ETH"}},
+		{in: "5", want: balanceThreshold{Amount: "5"}},
+		{in: " .5 sol ", want: balanceThreshold{Amount: ".5", Symbol: "SOL"}},
+		{in: "0.000000000000000001ETH", want: balanceThreshold{Amount: "0.000000000000000001", Symbol: "ETH"}},
+		{in: "0.0000000000000000001ETH", wantErr: true},
+		{in: "ETH", wantErr: true},
+		{in: "", wantErr: true},
+		{in: "-1ETH", wantErr: true},
+		{in: "1.2.3ETH", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseBalanceThreshold(tt.in)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseBalanceThreshold(%q) = %+v, want an error", tt.in, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseBalanceThreshold(%q): %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseBalanceThreshold(%q) = %+v, want %+v", tt.in, got, tt.want)
+		}
+	}
+}
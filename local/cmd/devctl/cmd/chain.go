@@ -0,0 +1,545 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/vultisig/vultisig-go/common"
+)
+
+// IncludeTestnets adds the testnet presets to the chain registry. It is set by
+// --include-testnets; 'testnets: true' in cluster.yaml has the same effect.
+var IncludeTestnets bool
+
+var testnetChains = []ChainInfo{
+	{Name: "Sepolia", Chain: common.Ethereum, RPCURL: "https://ethereum-sepolia-rpc.publicnode.com", Symbol: "ETH", Decimals: 18, Testnet: true, ChainID: 11155111, Explorer: "https://sepolia.etherscan.io", Faucet: "https://cloud.google.com/application/web3/faucet/ethereum/sepolia"},
+	{Name: "Base Sepolia", Chain: common.Base, RPCURL: "https://base-sepolia-rpc.publicnode.com", Symbol: "ETH", Decimals: 18, Testnet: true, ChainID: 84532, Explorer: "https://sepolia.basescan.org", Faucet: "https://docs.base.org/base-chain/tools/network-faucets"},
+	{Name: "Arbitrum Sepolia", Chain: common.Arbitrum, RPCURL: "https://arbitrum-sepolia-rpc.publicnode.com", Symbol: "ETH", Decimals: 18, Testnet: true, ChainID: 421614, Explorer: "https://sepolia.arbiscan.io", Faucet: "https://faucet.quicknode.com/arbitrum/sepolia"},
+}
+
+var defaultGasLimits = map[string]uint64{
+	"transfer":       21000,
+	"erc20_transfer": 65000,
+	"approve":        50000,
+	"swap":           250000,
+}
+
+func NewChainCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "chain",
+		Short: "Chain utilities (registry, gas estimation, fork funding)",
+	}
+
+	cmd.AddCommand(newChainListCmd())
+	cmd.AddCommand(newChainAddCmd())
+	cmd.AddCommand(newChainRemoveCmd())
+
+	cmd.AddCommand(newChainGasCmd())
+	cmd.AddCommand(newChainFundCmd())
+
+	return cmd
+}
+
+func newChainGasCmd() *cobra.Command {
+	var chain string
+	var txType string
+
+	cmd := &cobra.Command{
+		Use:   "gas",
+		Short: "Estimate gas cost of an operation on an EVM chain",
+		Long: `Estimate what a single operation will cost on an EVM chain.
+
+Queries the chain RPC for the current base fee and priority fee suggestion
+and multiplies by a typical gas limit for the operation type. Limits can be
+overridden in the 'gas.limits' section of cluster.yaml; set 'gas.prices: true'
+to include a USD estimate.
+
+Examples:
+  devctl chain gas --chain ethereum
+  devctl chain gas --chain arbitrum --tx-type transfer
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runChainGas(chain, txType)
+		},
+	}
+
+	cmd.Flags().StringVarP(&chain, "chain", "c", "ethereum", "EVM chain to estimate on")
+	cmd.Flags().StringVar(&txType, "tx-type", "swap", "Operation type (transfer, erc20_transfer, approve, swap)")
+
+	return cmd
+}
+
+// GasEstimate is the expected and worst-case fee for one operation, in wei.
+// On chains without a base fee, GasPrice is set instead of BaseFee and
+// PriorityFee.
+type GasEstimate struct {
+	Chain       ChainInfo
+	TxType      string
+	GasLimit    uint64
+	BaseFee     *big.Int
+	PriorityFee *big.Int
+	GasPrice    *big.Int
+	Expected    *big.Int
+	Max         *big.Int
+}
+
+func runChainGas(chainName, txType string) error {
+	c, ok := findSupportedChain(chainName)
+	if !ok {
+		return fmt.Errorf("unsupported chain: %s (EVM chains only)", chainName)
+	}
+
+	gasCfg := loadGasConfig()
+
+	est, err := estimateGas(c, txType, gasCfg)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("=== Gas Estimate: %s ===\n", c.Name)
+	fmt.Printf("  Operation:     %s\n", est.TxType)
+	fmt.Printf("  Gas Limit:     %d\n", est.GasLimit)
+	if est.GasPrice != nil {
+		fmt.Printf("  Gas Price:     %s gwei\n", formatBalance(est.GasPrice, 9))
+	} else {
+		fmt.Printf("  Base Fee:      %s gwei\n", formatBalance(est.BaseFee, 9))
+		fmt.Printf("  Priority Fee:  %s gwei\n", formatBalance(est.PriorityFee, 9))
+	}
+	fmt.Printf("  Expected Cost: %s %s\n", formatBalance(est.Expected, c.Decimals), c.Symbol)
+	fmt.Printf("  Max Cost:      %s %s\n", formatBalance(est.Max, c.Decimals), c.Symbol)
+
+	if gasCfg.Prices && c.PriceID != "" {
+		price, err := getUSDPrice(gasCfg.PriceAPI, c.PriceID)
+		if err != nil {
+			fmt.Printf("  USD:           unavailable (%v)\n", err)
+		} else {
+			fmt.Printf("  USD:           ~$%.4f (max $%.4f)\n", weiToUSD(est.Expected, c.Decimals, price), weiToUSD(est.Max, c.Decimals, price))
+		}
+	}
+
+	return nil
+}
+
+// estimateGas prices txType on chain c from the latest block's base fee and
+// the node's priority fee suggestion. Max assumes the base fee doubles, which
+// is what wallets typically use for maxFeePerGas. Chains without a base fee
+// are priced at the legacy gas price, which already includes the tip and is
+// also the most the operation pays per gas.
+func estimateGas(c ChainInfo, txType string, gasCfg GasConfig) (*GasEstimate, error) {
+	txType = strings.ToLower(strings.ReplaceAll(txType, "-", "_"))
+	limit, ok := gasCfg.Limits[txType]
+	if !ok {
+		known := make([]string, 0, len(gasCfg.Limits))
+		for k := range gasCfg.Limits {
+			known = append(known, k)
+		}
+		sort.Strings(known)
+		return nil, fmt.Errorf("unknown tx type %q (known: %s)", txType, strings.Join(known, ", "))
+	}
+
+	baseFee, err := getEVMBaseFee(c.RPCURL)
+	if err != nil {
+		return nil, fmt.Errorf("get base fee: %w", err)
+	}
+
+	gasLimit := new(big.Int).SetUint64(limit)
+	if baseFee == nil {
+		gasPrice, err := getEVMGasPrice(c.RPCURL)
+		if err != nil {
+			return nil, fmt.Errorf("get gas price: %w", err)
+		}
+		cost := new(big.Int).Mul(gasLimit, gasPrice)
+		return &GasEstimate{
+			Chain:    c,
+			TxType:   txType,
+			GasLimit: limit,
+			GasPrice: gasPrice,
+			Expected: cost,
+			Max:      cost,
+		}, nil
+	}
+
+	priorityFee, err := getEVMPriorityFee(c.RPCURL)
+	if err != nil {
+		return nil, fmt.Errorf("get priority fee: %w", err)
+	}
+
+	expected := new(big.Int).Mul(gasLimit, new(big.Int).Add(baseFee, priorityFee))
+	maxFee := new(big.Int).Add(new(big.Int).Mul(baseFee, big.NewInt(2)), priorityFee)
+
+	return &GasEstimate{
+		Chain:       c,
+		TxType:      txType,
+		GasLimit:    limit,
+		BaseFee:     baseFee,
+		PriorityFee: priorityFee,
+		Expected:    expected,
+		Max:         new(big.Int).Mul(gasLimit, maxFee),
+	}, nil
+}
+
+// loadGasConfig returns the gas section of cluster.yaml, or the built-in
+// defaults when no cluster.yaml is present.
+func loadGasConfig() GasConfig {
+	cc, err := LoadClusterConfig()
+	if err == nil {
+		return cc.Gas
+	}
+	var g GasConfig
+	g.setDefaults()
+	return g
+}
+
+// chainRegistry returns supportedChains (plus testnet presets when enabled),
+// the chains added with 'devctl chain add' and those added under chains in
+// cluster.yaml, with any cluster.yaml overrides applied, so a forked chain
+// resolves to its local anvil RPC everywhere. chains.yaml is read on every
+// call.
+func chainRegistry() []ChainInfo {
+	chains := make([]ChainInfo, len(supportedChains))
+	copy(chains, supportedChains)
+
+	cc, err := LoadClusterConfig()
+	if err != nil {
+		if IncludeTestnets {
+			chains = append(chains, testnetChains...)
+		}
+		return append(chains, customChainsOrWarn()...)
+	}
+
+	if IncludeTestnets || cc.Testnets {
+		chains = append(chains, testnetChains...)
+	}
+	chains = append(chains, customChainsOrWarn()...)
+
+	known := map[string]bool{}
+	for i, c := range chains {
+		known[chainKey(c.Name)] = true
+		override, ok := cc.Chains[chainKey(c.Name)]
+		if !ok {
+			continue
+		}
+		if override.Fork.Enabled {
+			chains[i].RPCURL = override.Fork.URL()
+		} else if override.RPC != "" {
+			chains[i].RPCURL = override.RPC
+		}
+		if override.ChainID != 0 {
+			chains[i].ChainID = override.ChainID
+		}
+		if override.Symbol != "" {
+			chains[i].Symbol = override.Symbol
+		}
+		if override.Decimals != 0 {
+			chains[i].Decimals = override.Decimals
+		}
+	}
+	return append(chains, configChains(cc, known)...)
+}
+
+// configChains returns the chains entries of cluster.yaml that name no
+// known chain and have rpc and chain_id, as EVM chains sorted by name.
+// Symbol defaults to ETH and decimals to 18.
+func configChains(cc *ClusterConfig, known map[string]bool) []ChainInfo {
+	var names []string
+	for name, override := range cc.Chains {
+		if known[chainKey(name)] || override.ChainID == 0 || (override.RPC == "" && !override.Fork.Enabled) {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	chains := make([]ChainInfo, 0, len(names))
+	for _, name := range names {
+		override := cc.Chains[name]
+		c := CustomChain{Name: name, ChainID: override.ChainID, RPC: override.RPC, Symbol: override.Symbol, Decimals: override.Decimals}
+		if override.Fork.Enabled {
+			c.RPC = override.Fork.URL()
+		}
+		if c.Symbol == "" {
+			c.Symbol = "ETH"
+		}
+		if c.Decimals == 0 {
+			c.Decimals = 18
+		}
+		chains = append(chains, c.info())
+	}
+	return chains
+}
+
+// chainKey normalises a chain name for lookups, so "Base Sepolia",
+// "base-sepolia" and "base_sepolia" are the same chain.
+func chainKey(name string) string {
+	return strings.NewReplacer(" ", "_", "-", "_").Replace(strings.ToLower(name))
+}
+
+// knownChains returns every built-in, testnet and added chain without
+// cluster.yaml overrides, for lookups that must not depend on --include-testnets
+// or on a fork.
+func knownChains() []ChainInfo {
+	chains := append(append([]ChainInfo{}, supportedChains...), testnetChains...)
+	custom, _ := loadCustomChains()
+	return append(chains, custom...)
+}
+
+// Matches reports whether filter names this chain. Testnets and added chains
+// only match by name since they share another chain's common.Chain.
+func (c ChainInfo) Matches(filter string) bool {
+	if chainKey(c.Name) == chainKey(filter) {
+		return true
+	}
+	return !c.Testnet && !c.Custom && strings.EqualFold(string(c.Chain), filter)
+}
+
+func findSupportedChain(name string) (ChainInfo, bool) {
+	aliases := map[string]string{
+		"eth":   "ethereum",
+		"arb":   "arbitrum",
+		"matic": "polygon",
+		"bnb":   "bsc",
+		"avax":  "avalanche",
+		"op":    "optimism",
+	}
+	if full, ok := aliases[strings.ToLower(name)]; ok {
+		name = full
+	}
+	for _, c := range chainRegistry() {
+		if c.Matches(name) {
+			return c, true
+		}
+	}
+	return ChainInfo{}, false
+}
+
+// derivationChain maps a recipe chain name to the chain used for address
+// derivation. Testnet names derive exactly like their mainnet, and added
+// chains like Ethereum.
+func derivationChain(name string) (common.Chain, error) {
+	for _, c := range testnetChains {
+		if c.Matches(name) {
+			return c.Chain, nil
+		}
+	}
+	chain, err := common.FromString(name)
+	if err == nil {
+		return chain, nil
+	}
+	for _, c := range chainRegistry() {
+		if c.Custom && c.Matches(name) {
+			return c.Chain, nil
+		}
+	}
+	return chain, err
+}
+
+// jsonRPC performs a single JSON-RPC call and returns the raw result. It is
+// chain-agnostic and used for both EVM and Solana nodes.
+func jsonRPC(rpcURL, method string, params []interface{}) (json.RawMessage, error) {
+	payload := map[string]interface{}{
+		"jsonrpc": "2.0",
+		"method":  method,
+		"params":  params,
+		"id":      1,
+	}
+
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "POST", rpcURL, strings.NewReader(string(payloadBytes)))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Result json.RawMessage `json:"result"`
+		Error  *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Error != nil {
+		return nil, fmt.Errorf("RPC error: %s", result.Error.Message)
+	}
+
+	return result.Result, nil
+}
+
+func parseHexBig(s string) (*big.Int, error) {
+	v, ok := new(big.Int).SetString(strings.TrimPrefix(s, "0x"), 16)
+	if !ok {
+		return nil, fmt.Errorf("invalid hex quantity: %q", s)
+	}
+	return v, nil
+}
+
+// getEVMBaseFee returns the latest block's base fee, or nil for chains
+// without one (pre-London), which are priced with getEVMGasPrice.
+func getEVMBaseFee(rpcURL string) (*big.Int, error) {
+	raw, err := jsonRPC(rpcURL, "eth_getBlockByNumber", []interface{}{"latest", false})
+	if err != nil {
+		return nil, err
+	}
+
+	var block struct {
+		BaseFeePerGas string `json:"baseFeePerGas"`
+	}
+	err = json.Unmarshal(raw, &block)
+	if err != nil {
+		return nil, fmt.Errorf("parse block: %w", err)
+	}
+
+	if block.BaseFeePerGas == "" {
+		return nil, nil
+	}
+
+	return parseHexBig(block.BaseFeePerGas)
+}
+
+func getEVMGasPrice(rpcURL string) (*big.Int, error) {
+	raw, err := jsonRPC(rpcURL, "eth_gasPrice", []interface{}{})
+	if err != nil {
+		return nil, err
+	}
+
+	var gasPrice string
+	err = json.Unmarshal(raw, &gasPrice)
+	if err != nil {
+		return nil, fmt.Errorf("parse gas price: %w", err)
+	}
+
+	return parseHexBig(gasPrice)
+}
+
+func getEVMPriorityFee(rpcURL string) (*big.Int, error) {
+	raw, err := jsonRPC(rpcURL, "eth_maxPriorityFeePerGas", []interface{}{})
+	if err != nil {
+		return nil, err
+	}
+
+	var fee string
+	err = json.Unmarshal(raw, &fee)
+	if err != nil {
+		return nil, fmt.Errorf("parse priority fee: %w", err)
+	}
+
+	return parseHexBig(fee)
+}
+
+func getUSDPrice(priceAPI, id string) (float64, error) {
+	url := fmt.Sprintf("%s/simple/price?ids=%s&vs_currencies=usd", strings.TrimSuffix(priceAPI, "/"), id)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("price API returned %d", resp.StatusCode)
+	}
+
+	var prices map[string]struct {
+		USD float64 `json:"usd"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&prices)
+	if err != nil {
+		return 0, fmt.Errorf("parse prices: %w", err)
+	}
+
+	p, ok := prices[id]
+	if !ok {
+		return 0, fmt.Errorf("no price for %s", id)
+	}
+	return p.USD, nil
+}
+
+func weiToUSD(wei *big.Int, decimals int, price float64) float64 {
+	divisor := new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil))
+	units, _ := new(big.Float).Quo(new(big.Float).SetInt(wei), divisor).Float64()
+	return units * price
+}
+
+// printRecipeGasEstimate prints an informational per-execution gas line for
+// recipes whose source chain is EVM, and warns when the vault's balance on
+// that chain cannot cover one execution at the max fee or is below its
+// alert_below threshold. Failures are silent; this is a hint only.
+func printRecipeGasEstimate(vault *LocalVault, recipeConfig map[string]interface{}) {
+	from, _ := recipeConfig["from"].(map[string]interface{})
+	to, _ := recipeConfig["to"].(map[string]interface{})
+	fromChain, _ := from["chain"].(string)
+
+	c, ok := findSupportedChain(fromChain)
+	if !ok {
+		return
+	}
+
+	txType := "swap"
+	toChain, _ := to["chain"].(string)
+	fromToken, _ := from["token"].(string)
+	toToken, _ := to["token"].(string)
+	if strings.EqualFold(fromChain, toChain) && strings.EqualFold(fromToken, toToken) {
+		txType = "transfer"
+		if fromToken != "" {
+			txType = "erc20_transfer"
+		}
+	}
+
+	est, err := estimateGas(c, txType, loadGasConfig())
+	if err != nil {
+		return
+	}
+	progressf("  Estimated per-execution gas: %s %s (%s on %s)\n", formatBalance(est.Expected, c.Decimals), c.Symbol, txType, c.Name)
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultBalanceTimeout)
+	defer cancel()
+	balances := fetchNativeBalances(ctx, vault, []ChainInfo{c})
+	if balances[0].Err != nil {
+		return
+	}
+	if balances[0].Wei.Cmp(est.Max) < 0 {
+		progressf("  %s Vault holds %s %s on %s, less than one execution at the max fee (%s %s); executions will fail until it is funded\n",
+			warnMark(), formatBalance(balances[0].Wei, c.Decimals), c.Symbol, c.Name, formatBalance(est.Max, c.Decimals), c.Symbol)
+	}
+	for _, a := range checkBalanceAlerts(balances, balanceThresholds([]ChainInfo{c}, nil)) {
+		progressf("  %s %s\n", warnMark(), a)
+	}
+}
@@ -0,0 +1,322 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/vultisig/vultisig-go/common"
+	"gopkg.in/yaml.v3"
+)
+
+// CustomChain is an EVM chain added with 'devctl chain add'. Addresses derive
+// like Ethereum's.
+type CustomChain struct {
+	Name     string `yaml:"name"`
+	ChainID  int64  `yaml:"chain_id"`
+	RPC      string `yaml:"rpc"`
+	Symbol   string `yaml:"symbol"`
+	Decimals int    `yaml:"decimals"`
+	Explorer string `yaml:"explorer,omitempty"`
+	PriceID  string `yaml:"price_id,omitempty"`
+}
+
+type customChainsFile struct {
+	Chains []CustomChain `yaml:"chains"`
+}
+
+func (c CustomChain) info() ChainInfo {
+	return ChainInfo{
+		Name:     c.Name,
+		Chain:    common.Ethereum,
+		RPCURL:   c.RPC,
+		Symbol:   c.Symbol,
+		Decimals: c.Decimals,
+		PriceID:  c.PriceID,
+		Custom:   true,
+		ChainID:  c.ChainID,
+		Explorer: c.Explorer,
+	}
+}
+
+// customChainsPath is chains.yaml next to the cluster.yaml in use, or in
+// ~/.vultisig when there is no cluster.yaml.
+func customChainsPath() string {
+	if p := findClusterConfig(); p != "" {
+		return filepath.Join(filepath.Dir(p), "chains.yaml")
+	}
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".vultisig", "chains.yaml")
+}
+
+func loadCustomChains() ([]ChainInfo, error) {
+	file, err := readCustomChains(customChainsPath())
+	if err != nil {
+		return nil, err
+	}
+	chains := make([]ChainInfo, 0, len(file.Chains))
+	for _, c := range file.Chains {
+		chains = append(chains, c.info())
+	}
+	return chains, nil
+}
+
+// customChainsOrWarn is loadCustomChains for the registry: a broken
+// chains.yaml is reported but does not break every chain command.
+func customChainsOrWarn() []ChainInfo {
+	chains, err := loadCustomChains()
+	if err != nil {
+		progressf("%s Ignoring added chains: %v\n", warnMark(), err)
+		return nil
+	}
+	return chains
+}
+
+func readCustomChains(path string) (*customChainsFile, error) {
+	file := &customChainsFile{}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return file, nil
+		}
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+	err = yaml.Unmarshal(data, file)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return file, nil
+}
+
+func writeCustomChains(path string, file *customChainsFile) error {
+	data, err := yaml.Marshal(file)
+	if err != nil {
+		return fmt.Errorf("marshal chains: %w", err)
+	}
+	data = append([]byte("# Managed by 'devctl chain add' and 'devctl chain remove'.\n"), data...)
+
+	err = os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return fmt.Errorf("create config directory: %w", err)
+	}
+	err = os.WriteFile(path, data, 0644)
+	if err != nil {
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	return nil
+}
+
+func newChainListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List the chains in the registry",
+		Long: `List every chain devctl knows: the built-in EVM chains, the testnet
+presets when enabled, and chains added with 'devctl chain add'. RPC URLs
+include cluster.yaml overrides and forks.
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runChainList()
+		},
+	}
+}
+
+func runChainList() error {
+	rows := [][]string{}
+	for _, c := range chainRegistry() {
+		source := "built-in"
+		switch {
+		case c.Custom:
+			source = "added"
+		case c.Testnet:
+			source = "testnet"
+		}
+		chainID := "-"
+		if c.ChainID != 0 {
+			chainID = strconv.FormatInt(c.ChainID, 10)
+		}
+		rows = append(rows, []string{c.Name, chainID, c.Symbol, strconv.Itoa(c.Decimals), c.RPCURL, source})
+	}
+	printTable([]string{"NAME", "CHAIN ID", "SYMBOL", "DECIMALS", "RPC", "SOURCE"}, rows)
+	progressf("\nAdded chains: %s\n", customChainsPath())
+	return nil
+}
+
+func newChainAddCmd() *cobra.Command {
+	var c CustomChain
+	var chainID string
+
+	cmd := &cobra.Command{
+		Use:   "add",
+		Short: "Add an EVM chain to the registry",
+		Long: `Add an EVM chain to the registry, saved in chains.yaml next to cluster.yaml.
+
+The RPC is probed with eth_chainId, which must return --chain-id. Added chains
+derive the vault's Ethereum address and are picked up by 'vault balance',
+'vault address', 'vault details' and the other registry commands on their
+next run.
+
+Example:
+  devctl chain add --name MyChain --chain-id 777 --rpc http://localhost:8547 --symbol XYZ
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := strconv.ParseInt(chainID, 10, 64)
+			if err != nil || id <= 0 {
+				return fmt.Errorf("--chain-id must be a positive integer, got %q", chainID)
+			}
+			c.ChainID = id
+			return runChainAdd(c)
+		},
+	}
+
+	cmd.Flags().StringVar(&c.Name, "name", "", "Chain name (required)")
+	cmd.Flags().StringVar(&chainID, "chain-id", "", "EVM chain ID (required)")
+	cmd.Flags().StringVar(&c.RPC, "rpc", "", "JSON-RPC URL (required)")
+	cmd.Flags().StringVar(&c.Symbol, "symbol", "", "Native token symbol (required)")
+	cmd.Flags().IntVar(&c.Decimals, "decimals", 18, "Native token decimals")
+	cmd.Flags().StringVar(&c.Explorer, "explorer", "", "Block explorer base URL")
+	cmd.Flags().StringVar(&c.PriceID, "price-id", "", "CoinGecko ID of the native token, for USD values")
+	_ = cmd.MarkFlagRequired("name")
+	_ = cmd.MarkFlagRequired("chain-id")
+	_ = cmd.MarkFlagRequired("rpc")
+	_ = cmd.MarkFlagRequired("symbol")
+
+	return cmd
+}
+
+func runChainAdd(c CustomChain) error {
+	err := validateCustomChain(c)
+	if err != nil {
+		return err
+	}
+
+	path := customChainsPath()
+	file, err := readCustomChains(path)
+	if err != nil {
+		return err
+	}
+
+	for _, known := range append(append([]ChainInfo{}, supportedChains...), testnetChains...) {
+		if chainKey(known.Name) == chainKey(c.Name) {
+			return fmt.Errorf("%s is a built-in chain; override its RPC with chains.%s.rpc in cluster.yaml", known.Name, chainKey(known.Name))
+		}
+		if known.ChainID == c.ChainID {
+			return fmt.Errorf("chain ID %d is already the built-in %s", c.ChainID, known.Name)
+		}
+	}
+	for _, added := range file.Chains {
+		if chainKey(added.Name) == chainKey(c.Name) {
+			return fmt.Errorf("chain %s already added; remove it first: devctl chain remove %s", added.Name, added.Name)
+		}
+		if added.ChainID == c.ChainID {
+			return fmt.Errorf("chain ID %d is already added as %s", c.ChainID, added.Name)
+		}
+	}
+
+	progressf("Probing %s...\n", c.RPC)
+	got, err := getEVMChainID(c.RPC)
+	if err != nil {
+		return fmt.Errorf("RPC %s unreachable: %w", c.RPC, err)
+	}
+	if got != c.ChainID {
+		return fmt.Errorf("RPC %s reports chain ID %d, not %d", c.RPC, got, c.ChainID)
+	}
+
+	file.Chains = append(file.Chains, c)
+	err = writeCustomChains(path, file)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s Added %s (chain ID %d) to %s\n", okMark(), c.Name, c.ChainID, path)
+	return nil
+}
+
+func validateCustomChain(c CustomChain) error {
+	if chainKey(c.Name) == "" {
+		return fmt.Errorf("--name must not be empty")
+	}
+	if c.Decimals < 0 || c.Decimals > 36 {
+		return fmt.Errorf("--decimals must be between 0 and 36, got %d", c.Decimals)
+	}
+	for flag, raw := range map[string]string{"--rpc": c.RPC, "--explorer": c.Explorer} {
+		if raw == "" {
+			continue
+		}
+		u, err := url.Parse(raw)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("%s must be an http(s) URL, got %q", flag, raw)
+		}
+	}
+	return nil
+}
+
+func getEVMChainID(rpcURL string) (int64, error) {
+	raw, err := jsonRPC(rpcURL, "eth_chainId", []interface{}{})
+	if err != nil {
+		return 0, err
+	}
+
+	var id string
+	err = json.Unmarshal(raw, &id)
+	if err != nil {
+		return 0, fmt.Errorf("parse chain ID: %w", err)
+	}
+	v, err := parseHexBig(id)
+	if err != nil {
+		return 0, err
+	}
+	if !v.IsInt64() {
+		return 0, fmt.Errorf("chain ID out of range: %s", id)
+	}
+	return v.Int64(), nil
+}
+
+func newChainRemoveCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "remove <name>",
+		Short: "Remove a chain added with 'devctl chain add'",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runChainRemove(args[0])
+		},
+	}
+}
+
+func runChainRemove(name string) error {
+	path := customChainsPath()
+	file, err := readCustomChains(path)
+	if err != nil {
+		return err
+	}
+
+	kept := file.Chains[:0]
+	var removed *CustomChain
+	for _, c := range file.Chains {
+		if removed == nil && chainKey(c.Name) == chainKey(name) {
+			removed = &c
+			continue
+		}
+		kept = append(kept, c)
+	}
+	if removed == nil {
+		for _, known := range append(append([]ChainInfo{}, supportedChains...), testnetChains...) {
+			if known.Matches(name) {
+				return fmt.Errorf("%s is a built-in chain and cannot be removed", known.Name)
+			}
+		}
+		return fmt.Errorf("no added chain named %q in %s", name, path)
+	}
+
+	file.Chains = kept
+	err = writeCustomChains(path, file)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s Removed %s (chain ID %d) from %s\n", okMark(), removed.Name, removed.ChainID, path)
+	return nil
+}
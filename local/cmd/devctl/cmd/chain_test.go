@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// feeRPC answers eth_getBlockByNumber with baseFee (none when empty) and the
+// other fee calls from results; calls missing from results fail the test.
+func feeRPC(t *testing.T, baseFee string, results map[string]string) string {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string `json:"method"`
+		}
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			t.Errorf("decode RPC request: %v", err)
+			return
+		}
+		var result interface{}
+		switch req.Method {
+		case "eth_getBlockByNumber":
+			block := map[string]string{"number": "0x1"}
+			if baseFee != "" {
+				block["baseFeePerGas"] = baseFee
+			}
+			result = block
+		default:
+			v, ok := results[req.Method]
+			if !ok {
+				t.Errorf("unexpected RPC call %s", req.Method)
+				w.Write([]byte(`{"jsonrpc": "2.0", "id": 1, "error": {"message": "method not found"}}`))
+				return
+			}
+			result = v
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": 1, "result": result})
+	}))
+	t.Cleanup(srv.Close)
+	return srv.URL
+}
+
+func TestEstimateGas(t *testing.T) {
+	const gwei = 1_000_000_000
+	gasCfg := GasConfig{Limits: map[string]uint64{"transfer": 21000}}
+
+	tests := []struct {
+		name    string
+		baseFee string
+		results map[string]string
+		want    GasEstimate
+	}{
+		{
+			name:    "base fee and tip",
+			baseFee: "0x2540be400",                                               // 10 gwei
+			results: map[string]string{"eth_maxPriorityFeePerGas": "0x77359400"}, // 2 gwei
+			want: GasEstimate{
+				BaseFee:     big.NewInt(10 * gwei),
+				PriorityFee: big.NewInt(2 * gwei),
+				Expected:    big.NewInt(21000 * 12 * gwei),
+				Max:         big.NewInt(21000 * 22 * gwei),
+			},
+		},
+		{
+			// The legacy gas price is the whole fee: no tip is added on top
+			// and the node is not asked for one.
+			name:    "no base fee",
+			results: map[string]string{"eth_gasPrice": "0x4a817c800"}, // 20 gwei
+			want: GasEstimate{
+				GasPrice: big.NewInt(20 * gwei),
+				Expected: big.NewInt(21000 * 20 * gwei),
+				Max:      big.NewInt(21000 * 20 * gwei),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := ChainInfo{Name: "Test", RPCURL: feeRPC(t, tt.baseFee, tt.results)}
+			got, err := estimateGas(c, "transfer", gasCfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.GasLimit != 21000 {
+				t.Errorf("GasLimit = %d, want 21000", got.GasLimit)
+			}
+			for _, f := range []struct {
+				field     string
+				got, want *big.Int
+			}{
+				{"BaseFee", got.BaseFee, tt.want.BaseFee},
+				{"PriorityFee", got.PriorityFee, tt.want.PriorityFee},
+				{"GasPrice", got.GasPrice, tt.want.GasPrice},
+				{"Expected", got.Expected, tt.want.Expected},
+				{"Max", got.Max, tt.want.Max},
+			} {
+				if (f.got == nil) != (f.want == nil) || (f.got != nil && f.got.Cmp(f.want) != 0) {
+					t.Errorf("%s = %v, want %v", f.field, f.got, f.want)
+				}
+			}
+		})
+	}
+
+	_, err := estimateGas(ChainInfo{}, "teleport", gasCfg)
+	if err == nil || err.Error() != `unknown tx type "teleport" (known: transfer)` {
+		t.Errorf("unknown tx type: error = %v", err)
+	}
+}
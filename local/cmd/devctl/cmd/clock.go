@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	// clockSkewWarn is the skew from which auth login warns.
+	clockSkewWarn = 10 * time.Second
+	// clockSkewAbort is the skew from which auth login refuses to sign: the
+	// message's expiresAt is only five minutes out, and the verifier checks
+	// it against its own clock.
+	clockSkewAbort = time.Minute
+)
+
+// measureClockSkew compares the local clock with the Date header of the
+// verifier's health endpoint. A positive skew means the verifier is ahead.
+// The header has one-second resolution, so small skews read as 0 or ±1s.
+func measureClockSkew(ctx context.Context, verifierURL string) (time.Duration, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	check := clusterConfigOrDefaults().Health["verifier"]
+	req, err := http.NewRequestWithContext(ctx, "GET", verifierURL+check.Path, nil)
+	if err != nil {
+		return 0, fmt.Errorf("create request: %w", err)
+	}
+
+	sent := time.Now()
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("reach verifier: %w", err)
+	}
+	resp.Body.Close()
+	received := time.Now()
+
+	date := resp.Header.Get("Date")
+	if date == "" {
+		return 0, fmt.Errorf("verifier response has no Date header")
+	}
+	serverTime, err := http.ParseTime(date)
+	if err != nil {
+		return 0, fmt.Errorf("parse Date header %q: %w", date, err)
+	}
+
+	// The server stamped the response somewhere in the round trip, so
+	// compare with its midpoint; Date drops the fraction of the second, so
+	// add half a second back.
+	local := sent.Add(received.Sub(sent) / 2)
+	return serverTime.Add(500 * time.Millisecond).Sub(local), nil
+}
+
+// describeClockSkew renders a skew as "local clock 2m5s behind the verifier".
+func describeClockSkew(skew time.Duration) string {
+	switch {
+	case skew.Abs() < time.Second:
+		return "local clock in sync with the verifier"
+	case skew > 0:
+		return fmt.Sprintf("local clock %s behind the verifier", skew.Round(time.Second))
+	default:
+		return fmt.Sprintf("local clock %s ahead of the verifier", (-skew).Round(time.Second))
+	}
+}
+
+// checkClockSkew warns about a noticeable skew and fails on one large enough
+// for the verifier to reject a freshly signed auth message, unless the
+// message is timed by the verifier's clock.
+func checkClockSkew(skew time.Duration, serverTime bool) error {
+	if skew.Abs() < clockSkewWarn {
+		return nil
+	}
+	if skew.Abs() >= clockSkewAbort && !serverTime {
+		return fmt.Errorf("%s; the verifier would reject the auth message after the keysign.\n"+
+			"Sync the system clock (e.g. 'sudo timedatectl set-ntp true' or 'sudo sntp -sS time.apple.com'),\n"+
+			"or pass --server-time to time the message by the verifier's clock", describeClockSkew(skew))
+	}
+	progressf("  %s %s\n", warnMark(), describeClockSkew(skew))
+	return nil
+}
@@ -1,20 +1,51 @@
 package cmd
 
 import (
+	"encoding/hex"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"gopkg.in/yaml.v3"
 )
 
 type ClusterConfig struct {
-	Repos     RepoConfig     `yaml:"repos"`
-	Services  ServiceConfig  `yaml:"services"`
-	Endpoints EndpointConfig `yaml:"endpoints"`
-	Library   LibraryConfig  `yaml:"library"`
-	Ports     PortConfig     `yaml:"ports"`
+	Repos     RepoConfig               `yaml:"repos"`
+	Services  ServiceConfig            `yaml:"services"`
+	Endpoints EndpointConfig           `yaml:"endpoints"`
+	Library   LibraryConfig            `yaml:"library"`
+	Ports     PortConfig               `yaml:"ports"`
+	Gas       GasConfig                `yaml:"gas"`
+	Chains    map[string]ChainOverride `yaml:"chains"`
+	Testnets  bool                     `yaml:"testnets"`
+
+	// Tokens are ERC20 contracts 'vault details' shows balances of, and
+	// devctl resolves by symbol, in addition to the built-in mainnet list.
+	// An entry with a built-in symbol on the same chain replaces it.
+	Tokens []TokenConfig `yaml:"tokens"`
+
+	// LocalPartyPrefix starts the local party ID of new keygens
+	// ("<prefix>-<random>"); default "devctl".
+	LocalPartyPrefix string `yaml:"local_party_prefix"`
+
+	// AuthDerivePath is the BIP44 path auth login and policy signing sign
+	// with; default m/44'/60'/0'/0/0.
+	AuthDerivePath string `yaml:"auth_derive_path"`
+
+	Plugins map[string]PluginOverride `yaml:"plugins"`
+
+	// SignerRoles label vault signers by party ID, ahead of the built-in
+	// and plugin party prefixes.
+	SignerRoles []SignerRole `yaml:"signer_roles"`
+
+	Notifications NotificationConfig     `yaml:"notifications"`
+	Health        map[string]HealthCheck `yaml:"health"`
+	Upgrade       UpgradeConfig          `yaml:"upgrade"`
+	Safety        SafetyConfig           `yaml:"safety"`
+	Logs          LogScanConfig          `yaml:"logs"`
+	GC            GCConfig               `yaml:"gc"`
 }
 
 type RepoConfig struct {
@@ -22,6 +53,7 @@ type RepoConfig struct {
 	Vultiserver string `yaml:"vultiserver"`
 	Relay       string `yaml:"relay"`
 	DCA         string `yaml:"dca"`
+	Sends       string `yaml:"sends"` // defaults to the DCA repo (app-recurring in send mode)
 	GoWrappers  string `yaml:"go_wrappers"`
 }
 
@@ -37,6 +69,9 @@ type ServiceConfig struct {
 	DCAWorker      string `yaml:"dca_worker"`
 	DCAScheduler   string `yaml:"dca_scheduler"`
 	DCATxIndexer   string `yaml:"dca_tx_indexer"`
+	SendsServer    string `yaml:"sends_server"`
+	SendsWorker    string `yaml:"sends_worker"`
+	SendsScheduler string `yaml:"sends_scheduler"`
 }
 
 type EndpointConfig struct {
@@ -55,61 +90,313 @@ type PortConfig struct {
 	DCAWorkerMetrics      int `yaml:"dca_worker_metrics"`
 	DCASchedulerMetrics   int `yaml:"dca_scheduler_metrics"`
 	DCATxIndexerMetrics   int `yaml:"dca_tx_indexer_metrics"`
+	SendsServer           int `yaml:"sends_server"`
+	SendsWorkerMetrics    int `yaml:"sends_worker_metrics"`
+	SendsSchedulerMetrics int `yaml:"sends_scheduler_metrics"`
 	Vultiserver           int `yaml:"vultiserver"`
 	Relay                 int `yaml:"relay"`
 	Postgres              int `yaml:"postgres"`
 	Redis                 int `yaml:"redis"`
 	Minio                 int `yaml:"minio"`
 	MinioConsole          int `yaml:"minio_console"`
+
+	// Extra ports 'devctl stop' frees in addition to the registry's.
+	Extra []int `yaml:"extra"`
+}
+
+// HealthCheck says how to probe a service: Path is requested on the
+// service's port and Status is the response code that counts as healthy.
+// Services without a Path are only checked by PID.
+// HealthCheck is a service's health endpoint and what counts as healthy:
+// Status, and optionally a body that contains Contains, has each JSON field
+// (dotted path) equal to its value and has every one of Fields.
+type HealthCheck struct {
+	Path     string            `yaml:"path"`
+	Status   int               `yaml:"status"`
+	Contains string            `yaml:"contains"`
+	JSON     map[string]string `yaml:"json"`
+	Fields   []string          `yaml:"fields"`
+}
+
+var defaultHealthChecks = map[string]HealthCheck{
+	"relay":        {Path: "/ping", Status: 200},
+	"vultiserver":  {Path: "/ping", Status: 200},
+	"verifier":     {Path: "/healthz", Status: 200},
+	"dca_server":   {Path: "/healthz", Status: 200},
+	"sends_server": {Path: "/healthz", Status: 200},
+}
+
+// LocalService is an entry of the service registry: a service that runs on
+// localhost with the current config.
+type LocalService struct {
+	Key     string // services/health key in cluster.yaml
+	Name    string
+	Port    int
+	PIDFile string // empty for services devctl does not launch itself
+	LogFile string // empty for services devctl does not launch itself
+	Health  HealthCheck
+}
+
+func (s LocalService) HealthURL() string {
+	if s.Health.Path == "" {
+		return ""
+	}
+	return fmt.Sprintf("http://localhost:%d%s", s.Port, s.Health.Path)
+}
+
+// GasConfig drives 'devctl chain gas'. Limits are keyed by operation type
+// (transfer, erc20_transfer, approve, swap).
+type GasConfig struct {
+	Limits   map[string]uint64 `yaml:"limits"`
+	Prices   bool              `yaml:"prices"`
+	PriceAPI string            `yaml:"price_api"`
+}
+
+// NotificationConfig configures 'devctl notify daemon'. Webhook receives a
+// Slack-compatible JSON payload; Desktop fires a native OS notification.
+type NotificationConfig struct {
+	Webhook  string `yaml:"webhook"`
+	Desktop  bool   `yaml:"desktop"`
+	Interval int    `yaml:"interval"`
+}
+
+// LogScanConfig drives the recent-error scan of service logs ('status
+// --logs', 'report --logs', 'logs --errors'). Patterns are regular
+// expressions keyed by service key; services without an entry use "default".
+type LogScanConfig struct {
+	Tail     int                 `yaml:"tail"`
+	Window   int                 `yaml:"window"` // minutes
+	Patterns map[string][]string `yaml:"patterns"`
+}
+
+// GCConfig sets how many days 'devctl gc' keeps each kind of artifact.
+// History applies to the lines of history.jsonl, metrics.jsonl and
+// sessions.jsonl.
+type GCConfig struct {
+	Backups   int `yaml:"backups"`
+	Cache     int `yaml:"cache"`
+	Snapshots int `yaml:"snapshots"`
+	Profiles  int `yaml:"profiles"`
+	History   int `yaml:"history"`
+}
+
+// UpgradeConfig controls 'devctl upgrade'. Channel is stable or nightly; URL
+// replaces the GitHub releases with an artifact base URL serving a
+// latest-<channel>.json manifest.
+type UpgradeConfig struct {
+	Channel string `yaml:"channel"`
+	URL     string `yaml:"url"`
+}
+
+// SafetyConfig sets when reshare and keysign against the production Fast
+// Vault Server need --i-know-this-is-production: above ProductionThresholdUSD
+// of mainnet EVM funds, unless ProductionAck is set.
+type SafetyConfig struct {
+	ProductionThresholdUSD float64 `yaml:"production_threshold_usd"`
+	ProductionAck          bool    `yaml:"production_ack"`
+}
+
+// ChainOverride adjusts a chain registry entry, keyed by lowercase chain name
+// with spaces as underscores (e.g. "base_sepolia").
+type ChainOverride struct {
+	RPC  string     `yaml:"rpc"`
+	Fork ForkConfig `yaml:"fork"`
+	// AlertBelow is the native balance, in whole tokens, under which
+	// 'vault balance', report and policy create warn.
+	AlertBelow string `yaml:"alert_below"`
+
+	// ChainID, Symbol and Decimals replace the built-in values when set. An
+	// entry that names no known chain and has rpc and chain_id adds an EVM
+	// chain, as 'devctl chain add' does.
+	ChainID  int64  `yaml:"chain_id"`
+	Symbol   string `yaml:"symbol"`
+	Decimals int    `yaml:"decimals"`
+}
+
+// TokenConfig is an ERC20 contract from the tokens section of cluster.yaml.
+// Chain defaults to ethereum and Decimals to 18.
+type TokenConfig struct {
+	Chain    string `yaml:"chain"`
+	Symbol   string `yaml:"symbol"`
+	Address  string `yaml:"address"`
+	Decimals int    `yaml:"decimals"`
+}
+
+// PluginOverride adjusts a plugin registry entry, or adds a plugin devctl
+// does not know, keyed by plugin ID. Empty fields keep the built-in value.
+type PluginOverride struct {
+	Name           string `yaml:"name"`
+	Bucket         string `yaml:"bucket"`
+	Database       string `yaml:"database"`
+	SchedulerTable string `yaml:"scheduler_table"`
+	TxTable        string `yaml:"tx_table"`
+	PartyPrefix    string `yaml:"party_prefix"`
+
+	// AddressFields replaces the recipe fields 'policy create' fills with
+	// the vault's address: field path to chain field path. It takes
+	// precedence over the recipe specification.
+	AddressFields map[string]string `yaml:"address_fields"`
+}
+
+// ForkConfig makes 'devctl start' run an anvil fork of the chain and points
+// devctl and the plugin workers at it instead of the public RPC.
+type ForkConfig struct {
+	Enabled     bool   `yaml:"enabled"`
+	UpstreamRPC string `yaml:"upstream_rpc"`
+	Block       uint64 `yaml:"block"`
+	Port        int    `yaml:"port"`
+}
+
+func (f ForkConfig) URL() string {
+	return fmt.Sprintf("http://localhost:%d", f.Port)
 }
 
 var clusterConfig *ClusterConfig
 
+// ClusterConfigPath, set by --cluster-config, selects the cluster.yaml to
+// load instead of searching the default locations.
+var ClusterConfigPath string
+
+// clusterConfigEnv names the cluster.yaml to load when --cluster-config is
+// not given.
+const clusterConfigEnv = "VULTISIG_CLUSTER_CONFIG"
+
+// loadedClusterConfig is the path LoadClusterConfig read.
+var loadedClusterConfig string
+
+// clusterConfigOrDefaults is LoadClusterConfig for commands that also work
+// without a cluster.yaml (stop, status, report): they fall back to the
+// default ports and health checks.
+func clusterConfigOrDefaults() *ClusterConfig {
+	config, err := LoadClusterConfig()
+	if err != nil {
+		config = &ClusterConfig{}
+		config.setDefaults()
+	}
+	return config
+}
+
 func LoadClusterConfig() (*ClusterConfig, error) {
 	if clusterConfig != nil {
 		return clusterConfig, nil
 	}
 
-	configPaths := []string{
-		"cluster.yaml",
-		"local/cluster.yaml",
-		filepath.Join(os.Getenv("HOME"), ".vultisig", "cluster.yaml"),
-	}
-
-	var configPath string
-	for _, p := range configPaths {
-		if _, err := os.Stat(p); err == nil {
-			configPath = p
-			break
-		}
+	err := CheckClusterConfigPath()
+	if err != nil {
+		return nil, err
 	}
-
+	configPath := findClusterConfig()
 	if configPath == "" {
 		return nil, fmt.Errorf("cluster.yaml not found. Copy cluster.yaml.example to cluster.yaml and configure paths")
 	}
 
 	data, err := os.ReadFile(configPath)
 	if err != nil {
-		return nil, fmt.Errorf("read cluster.yaml: %w", err)
+		return nil, fmt.Errorf("read %s: %w", configPath, err)
 	}
 
 	config := &ClusterConfig{}
 	err = yaml.Unmarshal(data, config)
 	if err != nil {
-		return nil, fmt.Errorf("parse cluster.yaml: %w", err)
+		return nil, fmt.Errorf("parse %s: %w", configPath, err)
 	}
 
 	config.expandPaths()
 	config.setDefaults()
+	err = config.validateSignerRoles()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", configPath, err)
+	}
+	err = validateDerivePath(config.AuthDerivePath)
+	if err != nil {
+		return nil, fmt.Errorf("%s: auth_derive_path: %w", configPath, err)
+	}
+	err = config.validateTokens()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", configPath, err)
+	}
+	err = config.validateForkPorts()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", configPath, err)
+	}
 
 	clusterConfig = config
+	loadedClusterConfig = configPath
 	return clusterConfig, nil
 }
 
+// explicitClusterConfig returns the cluster.yaml named by --cluster-config
+// or VULTISIG_CLUSTER_CONFIG, with the source it came from, or "" when
+// neither is set.
+func explicitClusterConfig() (path, source string) {
+	if ClusterConfigPath != "" {
+		return ClusterConfigPath, "--cluster-config"
+	}
+	if p := os.Getenv(clusterConfigEnv); p != "" {
+		return p, clusterConfigEnv
+	}
+	return "", ""
+}
+
+// CheckClusterConfigPath fails when an explicitly named cluster.yaml does
+// not exist, so a typo does not silently fall back to the default search.
+func CheckClusterConfigPath() error {
+	path, source := explicitClusterConfig()
+	if path == "" {
+		return nil
+	}
+	_, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("cluster config %s (from %s) not found: %w", path, source, err)
+	}
+	return nil
+}
+
+// ClusterConfigSource describes which cluster.yaml is in use and why, for
+// config show, the start summary and 'verify all'.
+func ClusterConfigSource() string {
+	path := loadedClusterConfig
+	if path == "" {
+		path = findClusterConfig()
+	}
+	if path == "" {
+		return "none (defaults)"
+	}
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	if _, source := explicitClusterConfig(); source != "" {
+		return fmt.Sprintf("%s (%s)", path, source)
+	}
+	return path
+}
+
+// findClusterConfig returns the explicitly named cluster.yaml, else the
+// first one in the working directory, local/ or ~/.vultisig, or "" if there
+// is none.
+func findClusterConfig() string {
+	if p, _ := explicitClusterConfig(); p != "" {
+		return p
+	}
+	home, _ := os.UserHomeDir()
+	configPaths := []string{
+		"cluster.yaml",
+		filepath.Join("local", "cluster.yaml"),
+		filepath.Join(home, ".vultisig", "cluster.yaml"),
+	}
+
+	for _, p := range configPaths {
+		if _, err := os.Stat(p); err == nil {
+			return p
+		}
+	}
+	return ""
+}
+
 func (c *ClusterConfig) expandPaths() {
-	home := os.Getenv("HOME")
+	home, _ := os.UserHomeDir()
 	expand := func(p string) string {
-		if strings.HasPrefix(p, "~/") {
+		if strings.HasPrefix(p, "~/") || strings.HasPrefix(p, `~\`) {
 			return filepath.Join(home, p[2:])
 		}
 		return p
@@ -119,11 +406,16 @@ func (c *ClusterConfig) expandPaths() {
 	c.Repos.Vultiserver = expand(c.Repos.Vultiserver)
 	c.Repos.Relay = expand(c.Repos.Relay)
 	c.Repos.DCA = expand(c.Repos.DCA)
+	c.Repos.Sends = expand(c.Repos.Sends)
 	c.Repos.GoWrappers = expand(c.Repos.GoWrappers)
 	c.Library.DYLDPath = expand(c.Library.DYLDPath)
 }
 
 func (c *ClusterConfig) setDefaults() {
+	if c.Repos.Sends == "" {
+		c.Repos.Sends = c.Repos.DCA
+	}
+
 	if c.Endpoints.Relay == "" {
 		c.Endpoints.Relay = "https://api.vultisig.com/router"
 	}
@@ -146,6 +438,255 @@ func (c *ClusterConfig) setDefaults() {
 	if c.Ports.Minio == 0 {
 		c.Ports.Minio = 9000
 	}
+	if c.Ports.MinioConsole == 0 {
+		c.Ports.MinioConsole = 9090
+	}
+	if c.Ports.VerifierWorkerMetrics == 0 {
+		c.Ports.VerifierWorkerMetrics = 8089
+	}
+	if c.Ports.DCAWorkerMetrics == 0 {
+		c.Ports.DCAWorkerMetrics = 8183
+	}
+	if c.Ports.DCASchedulerMetrics == 0 {
+		c.Ports.DCASchedulerMetrics = 8185
+	}
+	if c.Ports.DCATxIndexerMetrics == 0 {
+		c.Ports.DCATxIndexerMetrics = 8187
+	}
+	if c.Ports.SendsServer == 0 {
+		c.Ports.SendsServer = 8083
+	}
+	if c.Ports.SendsWorkerMetrics == 0 {
+		c.Ports.SendsWorkerMetrics = 8188
+	}
+	if c.Ports.SendsSchedulerMetrics == 0 {
+		c.Ports.SendsSchedulerMetrics = 8189
+	}
+	if c.Ports.Vultiserver == 0 {
+		c.Ports.Vultiserver = 8081
+	}
+	if c.Ports.Relay == 0 {
+		c.Ports.Relay = 8090
+	}
+	if c.Ports.Extra == nil {
+		c.Ports.Extra = []int{8181, 8184, 8186, 8190, 8191}
+	}
+
+	if c.Health == nil {
+		c.Health = map[string]HealthCheck{}
+	}
+	for key, def := range defaultHealthChecks {
+		hc := c.Health[key]
+		if hc.Path == "" {
+			hc.Path = def.Path
+		}
+		if hc.Status == 0 {
+			hc.Status = def.Status
+		}
+		c.Health[key] = hc
+	}
+
+	c.Gas.setDefaults()
+
+	if c.Notifications.Interval == 0 {
+		c.Notifications.Interval = 30
+	}
+
+	if c.Logs.Tail == 0 {
+		c.Logs.Tail = 2000
+	}
+	if c.Logs.Window == 0 {
+		c.Logs.Window = 15
+	}
+	if c.Logs.Patterns == nil {
+		c.Logs.Patterns = map[string][]string{}
+	}
+	if _, ok := c.Logs.Patterns["default"]; !ok {
+		c.Logs.Patterns["default"] = defaultLogErrorPatterns
+	}
+
+	if c.Safety.ProductionThresholdUSD == 0 {
+		c.Safety.ProductionThresholdUSD = 100
+	}
+
+	if c.Upgrade.Channel == "" {
+		c.Upgrade.Channel = "stable"
+	}
+
+	if c.GC.Backups == 0 {
+		c.GC.Backups = 30
+	}
+	if c.GC.Cache == 0 {
+		c.GC.Cache = 7
+	}
+	if c.GC.Snapshots == 0 {
+		c.GC.Snapshots = 30
+	}
+	if c.GC.Profiles == 0 {
+		c.GC.Profiles = 14
+	}
+	if c.GC.History == 0 {
+		c.GC.History = 90
+	}
+
+	if c.AuthDerivePath == "" {
+		c.AuthDerivePath = defaultAuthDerivePath
+	}
+
+	c.assignForkPorts()
+
+	for i := range c.Tokens {
+		if c.Tokens[i].Chain == "" {
+			c.Tokens[i].Chain = "ethereum"
+		}
+		if c.Tokens[i].Decimals == 0 {
+			c.Tokens[i].Decimals = 18
+		}
+	}
+}
+
+// validateTokens checks that every tokens entry has a symbol and a 20-byte
+// hex contract address.
+func (c *ClusterConfig) validateTokens() error {
+	for i, t := range c.Tokens {
+		if t.Symbol == "" {
+			return fmt.Errorf("tokens[%d]: symbol is required", i)
+		}
+		if !isHexAddress(t.Address) {
+			return fmt.Errorf("tokens[%d] (%s): address %q is not a 20-byte hex address", i, t.Symbol, t.Address)
+		}
+		if t.Decimals < 0 || t.Decimals > 77 {
+			return fmt.Errorf("tokens[%d] (%s): decimals %d out of range", i, t.Symbol, t.Decimals)
+		}
+	}
+	return nil
+}
+
+// defaultForkPort is anvil's own default port, given to the first forked
+// chain without one.
+const defaultForkPort = 8545
+
+// assignForkPorts gives every enabled fork without a port its own, counting
+// up from defaultForkPort in chain name order and skipping ports that other
+// forks set explicitly, so each anvil listens on a different port.
+func (c *ClusterConfig) assignForkPorts() {
+	taken := map[int]bool{}
+	for _, name := range c.ForkedChains() {
+		taken[c.Chains[name].Fork.Port] = true
+	}
+	next := defaultForkPort
+	for _, name := range c.ForkedChains() {
+		override := c.Chains[name]
+		if override.Fork.Port != 0 {
+			continue
+		}
+		for taken[next] {
+			next++
+		}
+		override.Fork.Port = next
+		taken[next] = true
+		c.Chains[name] = override
+	}
+}
+
+// validateForkPorts rejects two enabled forks set to the same port, which
+// would leave the second anvil unable to start.
+func (c *ClusterConfig) validateForkPorts() error {
+	byPort := map[int]string{}
+	for _, name := range c.ForkedChains() {
+		port := c.Chains[name].Fork.Port
+		if other, ok := byPort[port]; ok {
+			return fmt.Errorf("chains.%s.fork and chains.%s.fork both use port %d; give each fork its own port", other, name, port)
+		}
+		byPort[port] = name
+	}
+	return nil
+}
+
+// isHexAddress reports whether s is a 0x-prefixed 20-byte hex address.
+func isHexAddress(s string) bool {
+	if len(s) != 42 || !strings.HasPrefix(strings.ToLower(s), "0x") {
+		return false
+	}
+	_, err := hex.DecodeString(s[2:])
+	return err == nil
+}
+
+// LocalServices returns the service registry: every service that runs on
+// localhost with this config, in start order. Start, stop, status and report
+// take ports, PID files and health checks from here.
+func (c *ClusterConfig) LocalServices() []LocalService {
+	var services []LocalService
+	add := func(key, name string, port int, pidFile string) {
+		hc := c.Health[key]
+		if hc.Path != "" && hc.Status == 0 {
+			hc.Status = 200
+		}
+		logFile := ""
+		if pidFile != "" {
+			logFile = strings.TrimSuffix(pidFile, ".pid") + ".log"
+		}
+		services = append(services, LocalService{Key: key, Name: name, Port: port, PIDFile: pidFile, LogFile: logFile, Health: hc})
+	}
+
+	if c.IsLocal("relay") {
+		add("relay", "Relay", c.Ports.Relay, "")
+	}
+	if c.IsLocal("vultiserver") {
+		add("vultiserver", "Vultiserver", c.Ports.Vultiserver, "")
+	}
+	add("verifier", "Verifier API", c.Ports.Verifier, runPath("verifier.pid"))
+	add("verifier_worker", "Verifier Worker", c.Ports.VerifierWorkerMetrics, runPath("worker.pid"))
+	if c.IsLocal("dca") {
+		add("dca_server", "DCA Plugin API", c.Ports.DCAServer, runPath("dca.pid"))
+		add("dca_worker", "DCA Plugin Worker", c.Ports.DCAWorkerMetrics, runPath("dca-worker.pid"))
+		add("dca_scheduler", "DCA Scheduler", c.Ports.DCASchedulerMetrics, runPath("dca-scheduler.pid"))
+		add("dca_tx_indexer", "DCA TX Indexer", c.Ports.DCATxIndexerMetrics, runPath("dca-tx-indexer.pid"))
+	}
+	if c.IsLocal("sends") {
+		add("sends_server", "Sends Plugin API", c.Ports.SendsServer, runPath("sends.pid"))
+		add("sends_worker", "Sends Plugin Worker", c.Ports.SendsWorkerMetrics, runPath("sends-worker.pid"))
+		add("sends_scheduler", "Sends Scheduler", c.Ports.SendsSchedulerMetrics, runPath("sends-scheduler.pid"))
+	}
+
+	return services
+}
+
+// LocalService returns the registry entry for key. ok is false when the
+// service does not run locally with this config.
+func (c *ClusterConfig) LocalService(key string) (LocalService, bool) {
+	for _, svc := range c.LocalServices() {
+		if svc.Key == key {
+			return svc, true
+		}
+	}
+	return LocalService{}, false
+}
+
+// ForkedChains returns the names of chains with an anvil fork enabled, sorted.
+func (c *ClusterConfig) ForkedChains() []string {
+	var names []string
+	for name, override := range c.Chains {
+		if override.Fork.Enabled {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (g *GasConfig) setDefaults() {
+	if g.Limits == nil {
+		g.Limits = map[string]uint64{}
+	}
+	for txType, limit := range defaultGasLimits {
+		if g.Limits[txType] == 0 {
+			g.Limits[txType] = limit
+		}
+	}
+	if g.PriceAPI == "" {
+		g.PriceAPI = "https://api.coingecko.com/api/v3"
+	}
 }
 
 func (c *ClusterConfig) IsLocal(service string) bool {
@@ -158,6 +699,8 @@ func (c *ClusterConfig) IsLocal(service string) bool {
 		return c.Services.Verifier == "local"
 	case "dca":
 		return c.Services.DCAServer == "local"
+	case "sends":
+		return c.Services.SendsServer == "local"
 	default:
 		return true
 	}
@@ -196,6 +739,9 @@ func (c *ClusterConfig) ValidateRepos() error {
 	if c.IsLocal("dca") {
 		repos["dca"] = c.Repos.DCA
 	}
+	if c.IsLocal("sends") {
+		repos["sends"] = c.Repos.Sends
+	}
 
 	for name, path := range repos {
 		if path == "" {
@@ -0,0 +1,192 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// loadClusterYAML loads data as the cluster.yaml named by --cluster-config,
+// with HOME in a temporary directory and nothing cached from earlier loads.
+func loadClusterYAML(t *testing.T, data string) (*ClusterConfig, error) {
+	t.Helper()
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+	path := filepath.Join(dir, "cluster.yaml")
+	err := os.WriteFile(path, []byte(data), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	savedPath, savedConfig := ClusterConfigPath, clusterConfig
+	ClusterConfigPath, clusterConfig = path, nil
+	t.Cleanup(func() { ClusterConfigPath, clusterConfig = savedPath, savedConfig })
+	return LoadClusterConfig()
+}
+
+func TestLoadClusterConfigDefaults(t *testing.T) {
+	cc, err := loadClusterYAML(t, `
+repos:
+  verifier: ~/src/verifier
+  dca: /src/app-recurring
+ports:
+  verifier: 9080
+health:
+  verifier:
+    path: /ping
+tokens:
+  - symbol: PAY
+    address: "0x3333333333333333333333333333333333333333"
+  - symbol: ARB
+    chain: arbitrum
+    address: "0x4444444444444444444444444444444444444444"
+    decimals: 6
+chains:
+  ethereum:
+    fork:
+      enabled: true
+  base:
+    fork:
+      enabled: true
+      port: 8545
+  arbitrum:
+    fork:
+      enabled: true
+  polygon:
+    fork:
+      enabled: false
+`)
+	if err != nil {
+		t.Fatalf("LoadClusterConfig: %v", err)
+	}
+
+	home := os.Getenv("HOME")
+	checks := []struct {
+		name      string
+		got, want interface{}
+	}{
+		{"repos.verifier expanded", cc.Repos.Verifier, filepath.Join(home, "src/verifier")},
+		{"repos.sends from repos.dca", cc.Repos.Sends, "/src/app-recurring"},
+		{"ports.verifier kept", cc.Ports.Verifier, 9080},
+		{"ports.dca_server default", cc.Ports.DCAServer, 8082},
+		{"ports.postgres default", cc.Ports.Postgres, 5432},
+		{"ports.extra default", cc.Ports.Extra, []int{8181, 8184, 8186, 8190, 8191}},
+		{"endpoints.relay default", cc.Endpoints.Relay, "https://api.vultisig.com/router"},
+		{"endpoints.vultiserver default", cc.Endpoints.Vultiserver, "https://api.vultisig.com"},
+		{"health path kept", cc.Health["verifier"].Path, "/ping"},
+		{"health status default", cc.Health["verifier"].Status, defaultHealthChecks["verifier"].Status},
+		{"logs.tail default", cc.Logs.Tail, 2000},
+		{"upgrade.channel default", cc.Upgrade.Channel, "stable"},
+		{"gc.history default", cc.GC.History, 90},
+		{"auth_derive_path default", cc.AuthDerivePath, defaultAuthDerivePath},
+		{"token chain default", cc.Tokens[0].Chain, "ethereum"},
+		{"token decimals default", cc.Tokens[0].Decimals, 18},
+		{"token chain kept", cc.Tokens[1].Chain, "arbitrum"},
+		{"token decimals kept", cc.Tokens[1].Decimals, 6},
+		{"fork port kept", cc.Chains["base"].Fork.Port, 8545},
+		{"first free fork port", cc.Chains["arbitrum"].Fork.Port, 8546},
+		{"next free fork port", cc.Chains["ethereum"].Fork.Port, 8547},
+		{"disabled fork has no port", cc.Chains["polygon"].Fork.Port, 0},
+	}
+	for _, c := range checks {
+		if !reflect.DeepEqual(c.got, c.want) {
+			t.Errorf("%s: got %v, want %v", c.name, c.got, c.want)
+		}
+	}
+}
+
+func TestLoadClusterConfigInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		wantErr string
+	}{
+		{
+			name:    "malformed yaml",
+			yaml:    "ports: [8080\n",
+			wantErr: "parse ",
+		},
+		{
+			name:    "wrong type",
+			yaml:    "ports:\n  verifier: high\n",
+			wantErr: "parse ",
+		},
+		{
+			name:    "token without symbol",
+			yaml:    "tokens:\n  - address: \"0x3333333333333333333333333333333333333333\"\n",
+			wantErr: "tokens[0]: symbol is required",
+		},
+		{
+			name:    "token address too short",
+			yaml:    "tokens:\n  - symbol: PAY\n    address: \"0x3333\"\n",
+			wantErr: `tokens[0] (PAY): address "0x3333" is not a 20-byte hex address`,
+		},
+		{
+			name:    "token address not hex",
+			yaml:    "tokens:\n  - symbol: PAY\n    address: \"0xzz33333333333333333333333333333333333333\"\n",
+			wantErr: "is not a 20-byte hex address",
+		},
+		{
+			name:    "token decimals out of range",
+			yaml:    "tokens:\n  - symbol: PAY\n    address: \"0x3333333333333333333333333333333333333333\"\n    decimals: 78\n",
+			wantErr: "tokens[0] (PAY): decimals 78 out of range",
+		},
+		{
+			name:    "signer role without role",
+			yaml:    "signer_roles:\n  - prefix: vs-\n",
+			wantErr: "signer_roles[0]: role is required",
+		},
+		{
+			name:    "signer role with prefix and pattern",
+			yaml:    "signer_roles:\n  - prefix: vs-\n    pattern: \"^vs-\"\n    role: Server\n",
+			wantErr: "set exactly one of prefix and pattern",
+		},
+		{
+			name:    "signer role with a bad pattern",
+			yaml:    "signer_roles:\n  - pattern: \"(\"\n    role: Server\n",
+			wantErr: "signer_roles[0] (Server): invalid pattern",
+		},
+		{
+			name:    "forks on one port",
+			yaml:    "chains:\n  ethereum:\n    fork:\n      enabled: true\n      port: 8545\n  base:\n    fork:\n      enabled: true\n      port: 8545\n",
+			wantErr: "chains.base.fork and chains.ethereum.fork both use port 8545",
+		},
+		{
+			name:    "bad auth derive path",
+			yaml:    "auth_derive_path: \"m/44'/sixty'/0'\"\n",
+			wantErr: "auth_derive_path: invalid derive path",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := loadClusterYAML(t, tt.yaml)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("LoadClusterConfig error = %v, want %q", err, tt.wantErr)
+			}
+			if clusterConfig != nil {
+				t.Error("an invalid cluster.yaml was cached")
+			}
+		})
+	}
+}
+
+func TestCheckClusterConfigPath(t *testing.T) {
+	saved := ClusterConfigPath
+	t.Cleanup(func() { ClusterConfigPath = saved })
+
+	ClusterConfigPath = filepath.Join(t.TempDir(), "missing.yaml")
+	err := CheckClusterConfigPath()
+	if err == nil || !strings.Contains(err.Error(), "(from --cluster-config) not found") {
+		t.Errorf("missing --cluster-config: got %v", err)
+	}
+
+	ClusterConfigPath = ""
+	t.Setenv(clusterConfigEnv, filepath.Join(t.TempDir(), "missing.yaml"))
+	err = CheckClusterConfigPath()
+	if err == nil || !strings.Contains(err.Error(), "(from "+clusterConfigEnv+") not found") {
+		t.Errorf("missing %s: got %v", clusterConfigEnv, err)
+	}
+}
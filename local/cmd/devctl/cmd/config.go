@@ -5,9 +5,29 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 )
 
+// currentConfigVersion is the devctl.json schema this build reads and
+// writes. Files without config_version are version 1.
+//
+//	1: flat auth_token, auth_public_key and auth_expires_at
+//	2: auth_tokens, one token per vault public key
+const currentConfigVersion = 2
+
+// configMigrations upgrades a decoded devctl.json from the version it is
+// keyed by to the next one.
+var configMigrations = map[int]func(raw map[string]any) error{
+	1: migrateConfigV1,
+}
+
 type DevConfig struct {
+	ConfigVersion int `json:"config_version"`
+
 	Verifier       string `json:"verifier_url"`
 	FeePlugin      string `json:"fee_plugin_url"`
 	DCAPlugin      string `json:"dca_plugin_url"`
@@ -17,13 +37,46 @@ type DevConfig struct {
 	MinioHost      string `json:"minio_host"`
 	MinioAccess    string `json:"minio_access_key"`
 	MinioSecret    string `json:"minio_secret_key"`
+	MinioRegion    string `json:"minio_region,omitempty"`
 	Encryption     string `json:"encryption_secret"`
 	VaultName      string `json:"vault_name"`
 	PublicKeyECDSA string `json:"public_key_ecdsa"`
 	PublicKeyEdDSA string `json:"public_key_eddsa"`
-	AuthToken      string `json:"auth_token,omitempty"`
-	AuthPublicKey  string `json:"auth_public_key,omitempty"`
-	AuthExpiresAt  string `json:"auth_expires_at,omitempty"`
+
+	// AuthTokens holds the verifier token of each vault, keyed by ECDSA
+	// public key.
+	AuthTokens map[string]AuthToken `json:"auth_tokens,omitempty"`
+
+	// PhaseWarnThreshold is the duration (e.g. "90s") after which a plugin
+	// install or policy create phase is reported as slow.
+	PhaseWarnThreshold string `json:"phase_warn_threshold,omitempty"`
+
+	// KeyshareUploadTimeout is how long (e.g. "90s") plugin install waits
+	// for the verifier and plugin workers to store their new keyshares.
+	KeyshareUploadTimeout string `json:"keyshare_upload_timeout,omitempty"`
+
+	// FastVaultRetryBudget is how long (e.g. "5m") keygen, keysign and
+	// reshare keep retrying while the Fast Vault Server is busy.
+	FastVaultRetryBudget string `json:"fast_vault_retry_budget,omitempty"`
+
+	// MinioVirtualHost addresses buckets as <bucket>.<host> instead of
+	// <host>/<bucket>, for S3 endpoints without path-style access.
+	MinioVirtualHost bool `json:"minio_virtual_host,omitempty"`
+
+	// VerifierBucket is the bucket the verifier stores keyshares in.
+	VerifierBucket string `json:"verifier_bucket,omitempty"`
+
+	// TSSBackend is "dkls" (default) or "mock", which signs and reshares
+	// locally for the demo vault. See 'devctl demo up'.
+	TSSBackend string `json:"tss_backend,omitempty"`
+
+	// ErrorBodyLimit caps how many characters of an HTTP response body are
+	// quoted in error messages (default 300, or VCLI_ERROR_BODY_LIMIT).
+	ErrorBodyLimit int `json:"error_body_limit,omitempty"`
+
+	// MaxResponseBytes caps the size of an API response body (default
+	// 32MiB, or VCLI_MAX_RESPONSE_BYTES).
+	MaxResponseBytes int64 `json:"max_response_bytes,omitempty"`
 }
 
 func getEnvOrDefault(key, defaultVal string) string {
@@ -35,6 +88,8 @@ func getEnvOrDefault(key, defaultVal string) string {
 
 func DefaultConfig() *DevConfig {
 	return &DevConfig{
+		ConfigVersion: currentConfigVersion,
+
 		Verifier:    getEnvOrDefault("VCLI_VERIFIER_URL", "http://localhost:8080"),
 		FeePlugin:   getEnvOrDefault("VCLI_FEE_PLUGIN_URL", "http://localhost:8085"),
 		DCAPlugin:   getEnvOrDefault("VCLI_DCA_PLUGIN_URL", "http://localhost:8082"),
@@ -44,7 +99,15 @@ func DefaultConfig() *DevConfig {
 		MinioHost:   getEnvOrDefault("VCLI_MINIO_HOST", "http://localhost:9000"),
 		MinioAccess: getEnvOrDefault("VCLI_MINIO_ACCESS_KEY", "minioadmin"),
 		MinioSecret: getEnvOrDefault("VCLI_MINIO_SECRET_KEY", "minioadmin"),
+		MinioRegion: getEnvOrDefault("VCLI_MINIO_REGION", "us-east-1"),
 		Encryption:  getEnvOrDefault("VCLI_ENCRYPTION_SECRET", "dev-encryption-secret-32b"),
+
+		PhaseWarnThreshold:    getEnvOrDefault("VCLI_PHASE_WARN_THRESHOLD", "60s"),
+		KeyshareUploadTimeout: getEnvOrDefault("VCLI_KEYSHARE_UPLOAD_TIMEOUT", "60s"),
+		FastVaultRetryBudget:  getEnvOrDefault("VCLI_FAST_VAULT_RETRY_BUDGET", "2m"),
+		MinioVirtualHost:      os.Getenv("VCLI_MINIO_VIRTUAL_HOST") == "true",
+		VerifierBucket:        getEnvOrDefault("VCLI_VERIFIER_BUCKET", "vultisig-verifier"),
+		TSSBackend:            getEnvOrDefault("VCLI_TSS_BACKEND", tssBackendDKLS),
 	}
 }
 
@@ -53,6 +116,10 @@ func ConfigPath() string {
 	return filepath.Join(home, ".vultisig", "devctl.json")
 }
 
+// LoadConfig reads devctl.json over the defaults. Files from older devctl
+// builds are migrated to currentConfigVersion and rewritten, keeping a copy
+// of the original; files from newer builds are refused. Unknown fields are
+// reported once and ignored.
 func LoadConfig() (*DevConfig, error) {
 	path := ConfigPath()
 	data, err := os.ReadFile(path)
@@ -63,14 +130,118 @@ func LoadConfig() (*DevConfig, error) {
 		return nil, fmt.Errorf("read config: %w", err)
 	}
 
+	raw := map[string]any{}
+	err = json.Unmarshal(data, &raw)
+	if err != nil {
+		return nil, fmt.Errorf("parse config %s: %w", path, err)
+	}
+
+	version, err := rawConfigVersion(raw)
+	if err != nil {
+		return nil, fmt.Errorf("parse config %s: %w", path, err)
+	}
+	if version > currentConfigVersion {
+		return nil, fmt.Errorf("%s has config_version %d: this config was written by a newer devctl (this build reads up to %d); run 'devctl upgrade'",
+			path, version, currentConfigVersion)
+	}
+
+	migrated := version < currentConfigVersion
+	for v := version; v < currentConfigVersion; v++ {
+		err = configMigrations[v](raw)
+		if err != nil {
+			return nil, fmt.Errorf("migrate config %s from version %d: %w", path, v, err)
+		}
+	}
+	raw["config_version"] = currentConfigVersion
+
+	warnUnknownConfigFields(path, raw)
+
+	normalized, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("marshal config: %w", err)
+	}
 	cfg := DefaultConfig()
-	err = json.Unmarshal(data, cfg)
+	err = json.Unmarshal(normalized, cfg)
 	if err != nil {
-		return nil, fmt.Errorf("parse config: %w", err)
+		return nil, fmt.Errorf("parse config %s: %w", path, err)
+	}
+
+	if migrated {
+		backup := fmt.Sprintf("%s.v%d.bak", path, version)
+		err = os.WriteFile(backup, data, 0600)
+		if err != nil {
+			return nil, fmt.Errorf("back up config before migration: %w", err)
+		}
+		err = SaveConfig(cfg)
+		if err != nil {
+			return nil, err
+		}
+		progressf("Migrated %s from config_version %d to %d (previous file: %s)\n", path, version, currentConfigVersion, backup)
 	}
 	return cfg, nil
 }
 
+func rawConfigVersion(raw map[string]any) (int, error) {
+	v, ok := raw["config_version"]
+	if !ok {
+		return 1, nil
+	}
+	n, ok := v.(float64)
+	if !ok || n < 1 || n != float64(int(n)) {
+		return 0, fmt.Errorf("invalid config_version %v", v)
+	}
+	return int(n), nil
+}
+
+// migrateConfigV1 moves the flat auth fields into auth_tokens.
+func migrateConfigV1(raw map[string]any) error {
+	token, _ := raw["auth_token"].(string)
+	publicKey, _ := raw["auth_public_key"].(string)
+	expiresAt, _ := raw["auth_expires_at"].(string)
+	delete(raw, "auth_token")
+	delete(raw, "auth_public_key")
+	delete(raw, "auth_expires_at")
+
+	if token == "" {
+		return nil
+	}
+	expiry, err := time.Parse(time.RFC3339, expiresAt)
+	if err != nil {
+		return fmt.Errorf("parse auth_expires_at: %w", err)
+	}
+	raw["auth_tokens"] = map[string]any{
+		publicKey: AuthToken{Token: token, PublicKey: publicKey, ExpiresAt: expiry},
+	}
+	return nil
+}
+
+var unknownConfigWarned sync.Once
+
+func warnUnknownConfigFields(path string, raw map[string]any) {
+	known := map[string]bool{}
+	t := reflect.TypeOf(DevConfig{})
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		known[name] = true
+	}
+
+	var unknown []string
+	for key := range raw {
+		if !known[key] {
+			unknown = append(unknown, key)
+		}
+	}
+	if len(unknown) == 0 {
+		return
+	}
+	sort.Strings(unknown)
+	unknownConfigWarned.Do(func() {
+		progressf("%s Ignoring unknown fields in %s: %s\n", warnMark(), path, strings.Join(unknown, ", "))
+	})
+}
+
+// SaveConfig writes devctl.json at currentConfigVersion. It refuses to
+// replace a file written by a newer devctl.
 func SaveConfig(cfg *DevConfig) error {
 	path := ConfigPath()
 	dir := filepath.Dir(path)
@@ -79,6 +250,18 @@ func SaveConfig(cfg *DevConfig) error {
 		return fmt.Errorf("create config dir: %w", err)
 	}
 
+	existing, err := os.ReadFile(path)
+	if err == nil {
+		raw := map[string]any{}
+		if json.Unmarshal(existing, &raw) == nil {
+			version, err := rawConfigVersion(raw)
+			if err == nil && version > currentConfigVersion {
+				return fmt.Errorf("not overwriting %s: this config was written by a newer devctl (config_version %d)", path, version)
+			}
+		}
+	}
+	cfg.ConfigVersion = currentConfigVersion
+
 	data, err := json.MarshalIndent(cfg, "", "  ")
 	if err != nil {
 		return fmt.Errorf("marshal config: %w", err)
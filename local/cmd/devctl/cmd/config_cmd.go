@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func NewConfigCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Show devctl configuration",
+	}
+
+	cmd.AddCommand(newConfigShowCmd())
+
+	return cmd
+}
+
+func newConfigShowCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "show",
+		Short: "Show ~/.vultisig/devctl.json with its schema version",
+		Long: `Show the effective devctl.json: defaults and VCLI_* environment overrides
+with the file on top, after migrating it to the current schema version.
+Secrets and auth tokens are masked. The cluster.yaml in use, and whether
+--cluster-config or VULTISIG_CLUSTER_CONFIG selected it, is shown above.
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runConfigShow()
+		},
+	}
+}
+
+func runConfigShow() error {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	shown := *cfg
+	shown.MinioSecret = maskSecret(shown.MinioSecret)
+	shown.Encryption = maskSecret(shown.Encryption)
+	shown.AuthTokens = map[string]AuthToken{}
+	for key, token := range cfg.AuthTokens {
+		token.Token = maskSecret(token.Token)
+		shown.AuthTokens[key] = token
+	}
+
+	data, err := json.MarshalIndent(shown, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal config: %w", err)
+	}
+
+	fmt.Printf("Config: %s\n", ConfigPath())
+	fmt.Printf("Cluster config: %s\n", ClusterConfigSource())
+	fmt.Printf("Schema version: %d\n\n", cfg.ConfigVersion)
+	fmt.Println(string(data))
+	return nil
+}
+
+func maskSecret(s string) string {
+	switch {
+	case s == "":
+		return ""
+	case len(s) <= 8:
+		return "****"
+	default:
+		return s[:4] + "****"
+	}
+}
@@ -0,0 +1,399 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/vultisig/vultisig-go/address"
+	"github.com/vultisig/vultisig-go/common"
+)
+
+const (
+	demoPluginID = "vultisig-dca-0000"
+	demoPassword = "demo"
+	demoFundETH  = "1"
+)
+
+// demoPolicy is a one-time ETH -> USDC swap on the Ethereum fork. The
+// addresses are left empty so policy create fills them from the demo vault.
+var demoPolicy = map[string]interface{}{
+	"recipe": map[string]interface{}{
+		"from":       map[string]interface{}{"chain": "Ethereum", "token": "", "address": ""},
+		"to":         map[string]interface{}{"chain": "Ethereum", "token": "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48", "address": ""},
+		"fromAmount": "1000000000000000",
+		"frequency":  "one-time",
+	},
+	"billing": []interface{}{},
+}
+
+// DemoState records what 'demo up' changed, so 'demo down' can undo it.
+type DemoState struct {
+	PublicKeyECDSA string `json:"public_key_ecdsa"`
+
+	PreviousTSSBackend     string   `json:"previous_tss_backend"`
+	PreviousVaultName      string   `json:"previous_vault_name"`
+	PreviousPublicKeyECDSA string   `json:"previous_public_key_ecdsa"`
+	PreviousPublicKeyEdDSA string   `json:"previous_public_key_eddsa"`
+	StashedVaults          []string `json:"stashed_vaults,omitempty"`
+}
+
+func demoStatePath() string {
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".vultisig", "demo.json")
+}
+
+// demoStashPath is where 'demo up' moves other vaults while the demo runs:
+// plugin install and policy create use the first vault found.
+func demoStashPath() string {
+	return filepath.Join(VaultStoragePath(), ".demo-stash")
+}
+
+func loadDemoState() (*DemoState, error) {
+	data, err := os.ReadFile(demoStatePath())
+	if err != nil {
+		return nil, err
+	}
+	var state DemoState
+	err = json.Unmarshal(data, &state)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", demoStatePath(), err)
+	}
+	return &state, nil
+}
+
+func saveDemoState(state *DemoState) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal demo state: %w", err)
+	}
+	return os.WriteFile(demoStatePath(), data, 0600)
+}
+
+func NewDemoCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "demo",
+		Short: "Run a self-contained demo on the local stack",
+	}
+
+	cmd.AddCommand(newDemoUpCmd())
+	cmd.AddCommand(newDemoDownCmd())
+
+	return cmd
+}
+
+func newDemoUpCmd() *cobra.Command {
+	var skipStart bool
+
+	cmd := &cobra.Command{
+		Use:   "up",
+		Short: "Start the stack with a mock-TSS demo vault, DCA plugin and sample policy",
+		Long: `Bring up a working demo without a Fast Vault or real TSS:
+
+  1. Start the local stack (DCA plugin, no sends plugin)
+  2. Switch tss_backend to mock, which signs locally
+  3. Create the deterministic demo vault and fund it with 1 ETH on the fork
+  4. Authenticate it against the local verifier
+  5. Install the DCA plugin with a mock reshare
+  6. Create a one-time ETH -> USDC swap policy
+
+Each step runs the same code as the matching devctl command. Other vaults
+are moved aside while the demo runs; 'devctl demo down' removes the demo
+and restores them.
+
+The mock reshare stores placeholder keyshares, so the DCA worker cannot
+co-sign the demo policy's swap. Everything up to the scheduler picking it
+up is real.
+
+Requires an Ethereum anvil fork in cluster.yaml:
+
+  chains:
+    ethereum:
+      fork:
+        enabled: true
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDemoUp(cmd.Context(), skipStart)
+		},
+	}
+
+	cmd.Flags().BoolVar(&skipStart, "skip-start", false, "Use the already running stack instead of restarting it")
+
+	return cmd
+}
+
+func newDemoDownCmd() *cobra.Command {
+	var keepRunning bool
+
+	cmd := &cobra.Command{
+		Use:   "down",
+		Short: "Remove the demo and restore the previous vaults and TSS backend",
+		Long: `Undo 'devctl demo up': uninstall the DCA plugin and delete its policies,
+remove the demo vault and its auth token, put the other vaults back,
+restore tss_backend and stop the stack.
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDemoDown(cmd.Context(), keepRunning)
+		},
+	}
+
+	cmd.Flags().BoolVar(&keepRunning, "keep-running", false, "Leave the local stack running")
+
+	return cmd
+}
+
+func runDemoUp(ctx context.Context, skipStart bool) error {
+	_, err := loadDemoState()
+	if err == nil {
+		return fmt.Errorf("a demo is already up; run 'devctl demo down' first")
+	}
+
+	cc, err := LoadClusterConfig()
+	if err != nil {
+		return fmt.Errorf("load cluster config: %w", err)
+	}
+	override, ok := cc.Chains["ethereum"]
+	if !ok || !override.Fork.Enabled {
+		return fmt.Errorf(`demo needs an Ethereum anvil fork; add to cluster.yaml:
+
+  chains:
+    ethereum:
+      fork:
+        enabled: true`)
+	}
+
+	vault, err := newDemoVault()
+	if err != nil {
+		return err
+	}
+	ethAddress, _, _, err := address.GetAddress(vault.PublicKeyECDSA, vault.HexChainCode, common.Ethereum)
+	if err != nil {
+		return fmt.Errorf("derive demo address: %w", err)
+	}
+
+	if !skipStart {
+		err = runStart(ctx, false, true, false)
+		if err != nil {
+			return fmt.Errorf("start stack: %w", err)
+		}
+	}
+
+	fmt.Println()
+	fmt.Println("============================================")
+	fmt.Println("  Setting up demo")
+	fmt.Println("============================================")
+
+	err = enterDemo(vault)
+	if err != nil {
+		return err
+	}
+
+	steps := []struct {
+		name string
+		run  func() error
+	}{
+		{"Fund demo vault", func() error { return runChainFund("ethereum", ethAddress, "", demoFundETH, "") }},
+		{"Authenticate", func() error {
+			return runAuthLogin(ctx, vault.PublicKeyECDSA, demoPassword, AuthScheme{SigFormat: authSigRSV, MessageFormat: authMessagePersonalSign})
+		}},
+		{"Install DCA plugin", func() error { return runPluginInstall(ctx, demoPluginID, demoPassword, "table") }},
+		{"Create sample policy", func() error { return createDemoPolicy(ctx) }},
+	}
+	for i, step := range steps {
+		fmt.Printf("\n[%d/%d] %s\n", i+1, len(steps), step.name)
+		err = step.run()
+		if err != nil {
+			return fmt.Errorf("%s: %w\n\nRun 'devctl demo down' to clean up", strings.ToLower(step.name), err)
+		}
+	}
+
+	printDemoSummary(vault, ethAddress, override.Fork.URL())
+	return nil
+}
+
+// enterDemo records the current vault and TSS backend, moves other vaults
+// aside, saves the demo vault and switches devctl to it and the mock backend.
+func enterDemo(vault *LocalVault) error {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	state := &DemoState{
+		PublicKeyECDSA:         vault.PublicKeyECDSA,
+		PreviousTSSBackend:     cfg.TSSBackend,
+		PreviousVaultName:      cfg.VaultName,
+		PreviousPublicKeyECDSA: cfg.PublicKeyECDSA,
+		PreviousPublicKeyEdDSA: cfg.PublicKeyEdDSA,
+	}
+
+	vaults, err := ListVaults()
+	if err != nil {
+		return err
+	}
+	if len(vaults) > 0 {
+		err = os.MkdirAll(demoStashPath(), 0700)
+		if err != nil {
+			return fmt.Errorf("create vault stash: %w", err)
+		}
+	}
+	for _, v := range vaults {
+		if v.PublicKeyECDSA == vault.PublicKeyECDSA {
+			continue
+		}
+		name := filepath.Base(vaultFilePath(v))
+		err = os.Rename(vaultFilePath(v), filepath.Join(demoStashPath(), name))
+		if err != nil {
+			_ = saveDemoState(state)
+			return fmt.Errorf("move vault %s aside: %w", v.Name, err)
+		}
+		state.StashedVaults = append(state.StashedVaults, name)
+	}
+	if len(state.StashedVaults) > 0 {
+		progressf("  Moved %d other vault(s) to %s until 'demo down'\n", len(state.StashedVaults), demoStashPath())
+	}
+
+	err = saveDemoState(state)
+	if err != nil {
+		return err
+	}
+
+	err = SaveVault(vault)
+	if err != nil {
+		return fmt.Errorf("save demo vault: %w", err)
+	}
+
+	cfg.TSSBackend = tssBackendMock
+	cfg.VaultName = vault.Name
+	cfg.PublicKeyECDSA = vault.PublicKeyECDSA
+	cfg.PublicKeyEdDSA = vault.PublicKeyEdDSA
+	err = SaveConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("save config: %w", err)
+	}
+
+	progressf("  %s Demo vault %s (%s), tss_backend: mock\n", okMark(), vault.Name, VaultFingerprint(vault.PublicKeyECDSA))
+	return nil
+}
+
+func createDemoPolicy(ctx context.Context) error {
+	data, err := json.MarshalIndent(demoPolicy, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal demo policy: %w", err)
+	}
+	path := filepath.Join(filepath.Dir(demoStatePath()), "demo-policy.json")
+	err = os.WriteFile(path, data, 0600)
+	if err != nil {
+		return fmt.Errorf("write demo policy: %w", err)
+	}
+	return runPolicyCreate(ctx, demoPluginID, path, demoPassword, policyCreateOptions{Output: "table"})
+}
+
+// demoPolicyIDs lists the demo vault's policies from the verifier database.
+func demoPolicyIDs(publicKey string) []string {
+	cmd := psqlCommand("vultisig-verifier", "-t", "-c",
+		fmt.Sprintf("SELECT id FROM plugin_policies WHERE public_key = '%s' AND plugin_id = '%s' AND deleted = false ORDER BY created_at", publicKey, demoPluginID))
+	output, err := cmd.Output()
+	if err != nil {
+		return nil
+	}
+	var ids []string
+	for _, line := range strings.Split(string(output), "\n") {
+		if id := strings.TrimSpace(line); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func printDemoSummary(vault *LocalVault, ethAddress, forkURL string) {
+	policies := demoPolicyIDs(vault.PublicKeyECDSA)
+	policyID := "<policy-id>"
+	if len(policies) > 0 {
+		policyID = policies[len(policies)-1]
+	}
+
+	fmt.Println()
+	fmt.Println("============================================")
+	fmt.Println("  Demo is up")
+	fmt.Println("============================================")
+	fmt.Println()
+	fmt.Printf("  Vault:       %s (%s)\n", vault.Name, VaultFingerprint(vault.PublicKeyECDSA))
+	fmt.Printf("  Address:     %s (%s ETH on %s)\n", ethAddress, demoFundETH, forkURL)
+	fmt.Printf("  TSS backend: mock (signs locally, password %q)\n", demoPassword)
+	fmt.Printf("  Plugin:      %s (mock reshare)\n", demoPluginID)
+	fmt.Printf("  Policy:      %s\n", policyID)
+	fmt.Println()
+	fmt.Println("Try next:")
+	fmt.Println("  devctl policy status " + policyID)
+	fmt.Println("  devctl policy schedule " + policyID)
+	fmt.Println("  devctl vault balance")
+	fmt.Println("  devctl report")
+	fmt.Println()
+	fmt.Println("The DCA worker holds a placeholder keyshare, so the swap itself will not")
+	fmt.Println("be co-signed. Remove everything with: devctl demo down")
+}
+
+func runDemoDown(ctx context.Context, keepRunning bool) error {
+	state, err := loadDemoState()
+	if errors.Is(err, os.ErrNotExist) {
+		fmt.Println("No demo is up.")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	progressln("Removing demo...")
+
+	err = runPluginUninstall(ctx, demoPluginID, true, true, demoPassword)
+	if err != nil {
+		progressf("%s Could not uninstall %s: %v\n", warnMark(), demoPluginID, err)
+	}
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	err = os.Remove(vaultFilePath(&LocalVault{PublicKeyECDSA: state.PublicKeyECDSA}))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("remove demo vault: %w", err)
+	}
+	delete(cfg.AuthTokens, state.PublicKeyECDSA)
+
+	for _, name := range state.StashedVaults {
+		err = os.Rename(filepath.Join(demoStashPath(), name), filepath.Join(VaultStoragePath(), name))
+		if err != nil {
+			progressf("%s Could not restore vault %s: %v\n", warnMark(), name, err)
+		}
+	}
+	_ = os.Remove(demoStashPath())
+
+	cfg.TSSBackend = state.PreviousTSSBackend
+	cfg.VaultName = state.PreviousVaultName
+	cfg.PublicKeyECDSA = state.PreviousPublicKeyECDSA
+	cfg.PublicKeyEdDSA = state.PreviousPublicKeyEdDSA
+	err = SaveConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("save config: %w", err)
+	}
+
+	_ = os.Remove(filepath.Join(filepath.Dir(demoStatePath()), "demo-policy.json"))
+	err = os.Remove(demoStatePath())
+	if err != nil {
+		return fmt.Errorf("remove demo state: %w", err)
+	}
+	progressf("%s Demo vault removed, %d vault(s) restored, tss_backend: %s\n", okMark(), len(state.StashedVaults), valueOr(state.PreviousTSSBackend, tssBackendDKLS))
+
+	if keepRunning {
+		return nil
+	}
+	fmt.Println()
+	return runStopWithReport(false, false)
+}
@@ -0,0 +1,205 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+const envDescriptorKind = "devctl-env"
+
+// EnvDescriptor is what 'env export' writes: the effective endpoints of the
+// local cluster, for pointing extension and mobile dev builds at it.
+type EnvDescriptor struct {
+	Kind          string            `json:"kind"`
+	DevctlVersion string            `json:"devctl_version"`
+	GeneratedAt   string            `json:"generated_at"`
+	Profile       string            `json:"profile"`
+	ClusterConfig string            `json:"cluster_config,omitempty"`
+	Verifier      string            `json:"verifier_url"`
+	Relay         string            `json:"relay_url"`
+	Vultiserver   string            `json:"vultiserver_url"`
+	Plugins       map[string]string `json:"plugin_urls"`
+	ChainRPCs     map[string]string `json:"chain_rpcs,omitempty"`
+	Minio         string            `json:"minio_url"`
+	Secrets       *EnvSecrets       `json:"secrets,omitempty"`
+}
+
+// EnvSecrets are only exported with --include-secrets.
+type EnvSecrets struct {
+	MinioAccessKey   string `json:"minio_access_key"`
+	MinioSecretKey   string `json:"minio_secret_key"`
+	EncryptionSecret string `json:"encryption_secret"`
+	DatabaseDSN      string `json:"database_dsn"`
+	RedisURI         string `json:"redis_uri"`
+}
+
+func NewEnvCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "env",
+		Short: "Describe the local cluster for other tools",
+	}
+
+	cmd.AddCommand(newEnvExportCmd())
+
+	return cmd
+}
+
+func newEnvExportCmd() *cobra.Command {
+	var format string
+	var includeSecrets bool
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Print the effective cluster endpoints as JSON or dotenv",
+		Long: `Print the endpoints devctl currently uses: verifier, relay, vultiserver,
+plugin servers, chain RPC overrides from cluster.yaml and MinIO, together
+with the devctl version and the service profile ("all local" or "hybrid"),
+so consumers can detect when they drift from the cluster.
+
+Credentials are left out unless --include-secrets is given.
+
+Example:
+  devctl env export > devctl-env.json
+  devctl env export --format dotenv > .env.devctl
+  set -a; . ./.env.devctl; set +a
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runEnvExport(format, includeSecrets)
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", "json", "Output format: json or dotenv")
+	cmd.Flags().BoolVar(&includeSecrets, "include-secrets", false, "Include MinIO credentials, the encryption secret, the database DSN and the Redis URI")
+
+	return cmd
+}
+
+func runEnvExport(format string, includeSecrets bool) error {
+	if format != "json" && format != "dotenv" {
+		return fmt.Errorf("unknown format %q (want json or dotenv)", format)
+	}
+
+	env, err := buildEnvDescriptor(includeSecrets)
+	if err != nil {
+		return err
+	}
+
+	if format == "dotenv" {
+		fmt.Print(env.dotenv())
+		return nil
+	}
+
+	data, err := json.MarshalIndent(env, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal descriptor: %w", err)
+	}
+	fmt.Println(string(data))
+	return nil
+}
+
+func buildEnvDescriptor(includeSecrets bool) (*EnvDescriptor, error) {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return nil, fmt.Errorf("load config: %w", err)
+	}
+	cc := clusterConfigOrDefaults()
+	version, _ := buildVersion()
+
+	env := &EnvDescriptor{
+		Kind:          envDescriptorKind,
+		DevctlVersion: version,
+		GeneratedAt:   time.Now().UTC().Format(time.RFC3339),
+		Profile:       clusterProfile(),
+		ClusterConfig: findClusterConfig(),
+		Verifier:      cfg.Verifier,
+		Relay:         cc.GetRelayURL(),
+		Vultiserver:   cc.GetVultiserverURL(),
+		Plugins:       map[string]string{"vultisig-fees-feee": cfg.FeePlugin},
+		Minio:         cfg.MinioHost,
+	}
+
+	for _, spec := range pluginRegistry() {
+		url, err := getPluginServerURL(cfg.Verifier, spec.ID)
+		if spec.ID == "vultisig-dca-0000" {
+			url, err = cfg.DCAPlugin, nil
+		}
+		if err == nil {
+			env.Plugins[spec.ID] = url
+		}
+	}
+
+	for _, c := range chainRegistry() {
+		if _, ok := cc.Chains[chainKey(c.Name)]; ok {
+			if env.ChainRPCs == nil {
+				env.ChainRPCs = map[string]string{}
+			}
+			env.ChainRPCs[chainKey(c.Name)] = c.RPCURL
+		}
+	}
+
+	if includeSecrets {
+		env.Secrets = &EnvSecrets{
+			MinioAccessKey:   cfg.MinioAccess,
+			MinioSecretKey:   cfg.MinioSecret,
+			EncryptionSecret: cfg.Encryption,
+			DatabaseDSN:      cfg.DatabaseDSN,
+			RedisURI:         cfg.RedisURI,
+		}
+	}
+
+	return env, nil
+}
+
+// dotenv renders the descriptor as VULTISIG_* assignments that sh can
+// source. Plugin and chain keys are upper-cased with '-' turned into '_'.
+func (e *EnvDescriptor) dotenv() string {
+	var b strings.Builder
+	line := func(key, value string) {
+		fmt.Fprintf(&b, "%s=%s\n", key, shellQuote(value))
+	}
+
+	fmt.Fprintf(&b, "# %s %s, generated %s\n", e.Kind, e.DevctlVersion, e.GeneratedAt)
+	line("VULTISIG_DEVCTL_VERSION", e.DevctlVersion)
+	line("VULTISIG_PROFILE", e.Profile)
+	line("VULTISIG_VERIFIER_URL", e.Verifier)
+	line("VULTISIG_RELAY_URL", e.Relay)
+	line("VULTISIG_VULTISERVER_URL", e.Vultiserver)
+	for _, id := range sortedKeys(e.Plugins) {
+		line("VULTISIG_PLUGIN_"+envKey(id)+"_URL", e.Plugins[id])
+	}
+	for _, chain := range sortedKeys(e.ChainRPCs) {
+		line("VULTISIG_RPC_"+envKey(chain), e.ChainRPCs[chain])
+	}
+	line("VULTISIG_MINIO_URL", e.Minio)
+	if e.Secrets != nil {
+		line("VULTISIG_MINIO_ACCESS_KEY", e.Secrets.MinioAccessKey)
+		line("VULTISIG_MINIO_SECRET_KEY", e.Secrets.MinioSecretKey)
+		line("VULTISIG_ENCRYPTION_SECRET", e.Secrets.EncryptionSecret)
+		line("VULTISIG_DATABASE_DSN", e.Secrets.DatabaseDSN)
+		line("VULTISIG_REDIS_URI", e.Secrets.RedisURI)
+	}
+	return b.String()
+}
+
+func envKey(s string) string {
+	return strings.ToUpper(strings.NewReplacer("-", "_", " ", "_", ".", "_").Replace(s))
+}
+
+// shellQuote single-quotes s for sh.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const defaultFastVaultRetryBudget = 2 * time.Minute
+
+// fastVaultURL is the Fast Vault Server keygen, keysign and reshare use: the
+// vultiserver from cluster.yaml (local or endpoints.vultiserver), which
+// defaults to FastVaultServer.
+func fastVaultURL() string {
+	return strings.TrimRight(clusterConfigOrDefaults().GetVultiserverURL(), "/")
+}
+
+// postFastVault sends a JSON request to the Fast Vault Server. While the
+// server is busy with another session for the vault (409, 429 or 503) the
+// same body is resent, so it joins the same session, backing off from 5s to
+// 30s (or the server's Retry-After) until fast_vault_retry_budget runs out.
+// Other failures are returned immediately.
+func postFastVault(ctx context.Context, path string, reqJSON []byte) error {
+	budget := defaultFastVaultRetryBudget
+	cfg, err := LoadConfig()
+	if err == nil {
+		budget = fastVaultRetryBudget(cfg)
+	}
+	deadline := time.Now().Add(budget)
+
+	backoff := 5 * time.Second
+	for attempt := 1; ; attempt++ {
+		status, body, err := sendFastVault(ctx, path, reqJSON)
+		if err != nil {
+			return err
+		}
+		if status == http.StatusOK {
+			return nil
+		}
+		if !isFastVaultBusy(status) {
+			return fastVaultError(status, body)
+		}
+
+		wait := backoff
+		if d, ok := parseRetryAfter(body.retryAfter); ok {
+			wait = d
+		}
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return fmt.Errorf("fast vault server still busy after %d attempts over %s (%d: %s)", attempt, budget, status, strings.TrimSpace(body.text))
+		}
+		if wait > remaining {
+			wait = remaining
+		}
+
+		progressf("%s fast vault busy, retrying in %s…\n", warnMark(), wait.Round(time.Second))
+		err = sleepCtx(ctx, wait)
+		if err != nil {
+			return err
+		}
+		backoff *= 2
+		if backoff > 30*time.Second {
+			backoff = 30 * time.Second
+		}
+	}
+}
+
+type fastVaultBody struct {
+	text       string
+	retryAfter string
+}
+
+func sendFastVault(ctx context.Context, path string, reqJSON []byte) (int, fastVaultBody, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", fastVaultURL()+path, bytes.NewReader(reqJSON))
+	if err != nil {
+		return 0, fastVaultBody{}, fmt.Errorf("create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(httpReq)
+	if err != nil {
+		return 0, fastVaultBody{}, fmt.Errorf("send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, fastVaultBody{text: string(data), retryAfter: resp.Header.Get("Retry-After")}, nil
+}
+
+func isFastVaultBusy(status int) bool {
+	return status == http.StatusConflict || status == http.StatusTooManyRequests || status == http.StatusServiceUnavailable
+}
+
+// fastVaultError turns a non-retryable response into an error that says
+// what to fix.
+func fastVaultError(status int, body fastVaultBody) error {
+	text := strings.TrimSpace(body.text)
+	switch status {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return fmt.Errorf("fast vault server rejected the vault password (%d: %s)", status, text)
+	case http.StatusNotFound:
+		return fmt.Errorf("vault not found on the fast vault server; is it a Fast Vault? (%d: %s)", status, text)
+	default:
+		return fmt.Errorf("fast vault server returned %d: %s", status, text)
+	}
+}
+
+// parseRetryAfter reads a Retry-After header given in seconds or as an
+// HTTP date.
+func parseRetryAfter(v string) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	secs, err := strconv.Atoi(v)
+	if err == nil {
+		return time.Duration(secs) * time.Second, secs > 0
+	}
+	t, err := http.ParseTime(v)
+	if err != nil {
+		return 0, false
+	}
+	d := time.Until(t)
+	return d, d > 0
+}
+
+// fastVaultRetryBudget reads fast_vault_retry_budget from devctl.json (or
+// VCLI_FAST_VAULT_RETRY_BUDGET). Unset or invalid values fall back to 2m;
+// "0s" disables retries.
+func fastVaultRetryBudget(cfg *DevConfig) time.Duration {
+	if cfg.FastVaultRetryBudget == "" {
+		return defaultFastVaultRetryBudget
+	}
+	d, err := time.ParseDuration(cfg.FastVaultRetryBudget)
+	if err != nil || d < 0 {
+		return defaultFastVaultRetryBudget
+	}
+	return d
+}
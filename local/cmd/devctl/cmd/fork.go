@@ -0,0 +1,279 @@
+package cmd
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/spf13/cobra"
+	"github.com/vultisig/vultisig-go/address"
+	"github.com/vultisig/vultisig-go/common"
+)
+
+func newChainFundCmd() *cobra.Command {
+	var chain string
+	var addr string
+	var vaultID string
+	var amount string
+	var token string
+
+	cmd := &cobra.Command{
+		Use:   "fund",
+		Short: "Fund an address on a local anvil fork",
+		Long: `Set the native or ERC20 balance of an address on a local anvil fork.
+
+Requires 'chains.<chain>.fork.enabled: true' in cluster.yaml and a running
+fork ('devctl start' launches it). Native balances are set with
+anvil_setBalance; ERC20 balances are written directly into the token's
+balance mapping with anvil_setStorageAt. Both set the balance to --amount
+rather than adding to it.
+
+If --address is omitted the EVM address of the vault named by --vault, or of
+the current vault, is used.
+
+Examples:
+  devctl chain fund --amount 10
+  devctl chain fund --address 0xabc... --amount 5000 --token USDC
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runChainFund(chain, addr, vaultID, amount, token)
+		},
+	}
+
+	cmd.Flags().StringVarP(&chain, "chain", "c", "ethereum", "Forked chain to fund on")
+	cmd.Flags().StringVar(&addr, "address", "", "Address to fund (default: the vault's EVM address)")
+	cmd.Flags().StringVarP(&vaultID, "vault", "v", "", "Vault name, public key prefix or fingerprint (default: current vault)")
+	cmd.Flags().StringVar(&amount, "amount", "", "Balance to set, in whole units (e.g. 10 for 10 ETH)")
+	cmd.Flags().StringVar(&token, "token", "", "ERC20 symbol or contract address (default: native)")
+	cmd.MarkFlagRequired("amount")
+
+	return cmd
+}
+
+func runChainFund(chainName, addr, vaultID, amount, token string) error {
+	cc, err := LoadClusterConfig()
+	if err != nil {
+		return fmt.Errorf("load cluster config: %w", err)
+	}
+
+	override, ok := cc.Chains[chainKey(chainName)]
+	if !ok || !override.Fork.Enabled {
+		return fmt.Errorf("no fork configured for %s. Set chains.%s.fork.enabled in cluster.yaml", chainName, chainKey(chainName))
+	}
+	rpcURL := override.Fork.URL()
+
+	if addr == "" {
+		vault, err := loadFundVault(vaultID)
+		if err != nil {
+			return err
+		}
+		addr, _, _, err = address.GetAddress(vault.PublicKeyECDSA, vault.HexChainCode, common.Ethereum)
+		if err != nil {
+			return fmt.Errorf("derive EVM address: %w", err)
+		}
+	}
+
+	if token == "" {
+		c, _ := findSupportedChain(chainName)
+		value, err := parseUnits(amount, 18)
+		if err != nil {
+			return err
+		}
+
+		_, err = jsonRPC(rpcURL, "anvil_setBalance", []interface{}{addr, "0x" + value.Text(16)})
+		if err != nil {
+			return fmt.Errorf("set balance: %w", err)
+		}
+
+		fmt.Printf("%s Set %s balance of %s to %s %s\n", okMark(), chainName, addr, amount, c.Symbol)
+		return nil
+	}
+
+	c, _ := findSupportedChain(chainName)
+	tokenAddr, decimals, symbol := resolveToken(c, token)
+	value, err := parseUnits(amount, decimals)
+	if err != nil {
+		return err
+	}
+
+	slot, err := setERC20Balance(rpcURL, tokenAddr, addr, value)
+	if err != nil {
+		return fmt.Errorf("set %s balance: %w", symbol, err)
+	}
+
+	fmt.Printf("%s Set %s balance of %s to %s (storage slot %d)\n", okMark(), symbol, addr, amount, slot)
+	return nil
+}
+
+// loadFundVault loads the vault vaultID selects, or the current vault from
+// devctl.json when vaultID is empty.
+func loadFundVault(vaultID string) (*LocalVault, error) {
+	if vaultID != "" {
+		return LoadVault(vaultID)
+	}
+	cfg, err := LoadConfig()
+	if err != nil {
+		return nil, fmt.Errorf("load config: %w", err)
+	}
+	if cfg.PublicKeyECDSA == "" {
+		return nil, fmt.Errorf("no vault configured. Pass --address or --vault, or import a vault first: devctl vault import")
+	}
+	vault, err := LoadVault(cfg.PublicKeyECDSA)
+	if err != nil {
+		return nil, fmt.Errorf("load vault: %w", err)
+	}
+	return vault, nil
+}
+
+// resolveToken maps a symbol known on chain c to its contract, or treats the
+// argument as a contract address and assumes 18 decimals.
+func resolveToken(c ChainInfo, token string) (string, int, string) {
+	for _, t := range tokenRegistry(c) {
+		if strings.EqualFold(t.Symbol, token) || strings.EqualFold(t.Address, token) {
+			return t.Address, t.Decimals, t.Symbol
+		}
+	}
+	return token, 18, token
+}
+
+// setERC20Balance writes value into the token's balances mapping for holder.
+// The mapping's storage slot differs per token, so candidate slots are probed
+// until balanceOf reflects the write; unmatched probes are rolled back.
+func setERC20Balance(rpcURL, tokenAddr, holder string, value *big.Int) (int, error) {
+	holderWord := fmt.Sprintf("%064s", strings.TrimPrefix(strings.ToLower(holder), "0x"))
+	valueWord := "0x" + fmt.Sprintf("%064s", value.Text(16))
+
+	for slot := 0; slot < 20; slot++ {
+		slotWord := fmt.Sprintf("%064x", slot)
+		keyBytes, err := hex.DecodeString(holderWord + slotWord)
+		if err != nil {
+			return 0, fmt.Errorf("encode storage key: %w", err)
+		}
+		key := "0x" + hex.EncodeToString(crypto.Keccak256(keyBytes))
+
+		raw, err := jsonRPC(rpcURL, "eth_getStorageAt", []interface{}{tokenAddr, key, "latest"})
+		if err != nil {
+			return 0, err
+		}
+		var original string
+		err = json.Unmarshal(raw, &original)
+		if err != nil {
+			return 0, fmt.Errorf("parse storage: %w", err)
+		}
+
+		_, err = jsonRPC(rpcURL, "anvil_setStorageAt", []interface{}{tokenAddr, key, valueWord})
+		if err != nil {
+			return 0, err
+		}
+
+		balance, err := getERC20Balance(context.Background(), rpcURL, tokenAddr, holder)
+		if err == nil && balance.Cmp(value) == 0 {
+			return slot, nil
+		}
+
+		_, err = jsonRPC(rpcURL, "anvil_setStorageAt", []interface{}{tokenAddr, key, original})
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return 0, fmt.Errorf("could not locate balance mapping in the first 20 storage slots")
+}
+
+// parseUnits converts a decimal string like "1.5" to base units.
+func parseUnits(amount string, decimals int) (*big.Int, error) {
+	whole, frac, _ := strings.Cut(strings.TrimSpace(amount), ".")
+	if len(frac) > decimals {
+		return nil, fmt.Errorf("amount %s has more than %d decimal places", amount, decimals)
+	}
+	frac += strings.Repeat("0", decimals-len(frac))
+
+	value, ok := new(big.Int).SetString(whole+frac, 10)
+	if !ok || value.Sign() < 0 {
+		return nil, fmt.Errorf("invalid amount: %s", amount)
+	}
+	return value, nil
+}
+
+// startAnvilForks launches one anvil process per forked chain and waits for
+// each to answer JSON-RPC.
+func startAnvilForks(ctx context.Context, config *ClusterConfig) error {
+	for _, name := range config.ForkedChains() {
+		fork := config.Chains[name].Fork
+
+		upstream := fork.UpstreamRPC
+		if upstream == "" {
+			upstream = supportedChainRPC(name)
+		}
+		if upstream == "" {
+			return fmt.Errorf("fork for %s: no upstream_rpc and no default RPC for that chain", name)
+		}
+
+		args := []string{"--fork-url", upstream, "--port", strconv.Itoa(fork.Port)}
+		if fork.Block != 0 {
+			args = append(args, "--fork-block-number", strconv.FormatUint(fork.Block, 10))
+		}
+
+		anvilCmd := exec.Command("anvil", args...)
+		logPath := runPath(fmt.Sprintf("anvil-%s.log", name))
+		anvilLog, err := os.Create(logPath)
+		if err != nil {
+			return fmt.Errorf("create anvil log: %w", err)
+		}
+		anvilCmd.Stdout = anvilLog
+		anvilCmd.Stderr = anvilLog
+
+		err = anvilCmd.Start()
+		if err != nil {
+			return fmt.Errorf("start anvil for %s (is foundry installed?): %w", name, err)
+		}
+		writePIDFile(runPath(fmt.Sprintf("anvil-%s.pid", name)), anvilCmd.Process.Pid)
+		fmt.Printf("  Anvil fork (%s): PID %d, %s\n", name, anvilCmd.Process.Pid, fork.URL())
+		fmt.Printf("  Log: %s\n", logPath)
+
+		ready := false
+		for i := 0; i < 30; i++ {
+			if _, err := jsonRPC(fork.URL(), "eth_chainId", []interface{}{}); err == nil {
+				ready = true
+				break
+			}
+			if err := sleepCtx(ctx, 1*time.Second); err != nil {
+				return err
+			}
+		}
+		if !ready {
+			return fmt.Errorf("anvil fork for %s failed to start - check %s", name, logPath)
+		}
+		fmt.Printf("  %s Anvil fork (%s) ready\n", okMark(), name)
+	}
+	return nil
+}
+
+// supportedChainRPC returns the built-in public RPC for a chain, ignoring
+// cluster.yaml overrides (which would point back at the fork itself).
+func supportedChainRPC(name string) string {
+	for _, c := range knownChains() {
+		if chainKey(c.Name) == name {
+			return c.RPCURL
+		}
+	}
+	return ""
+}
+
+// forkRPCEnv returns env overrides pointing plugin RPC variables at the local
+// forks. format is the variable pattern, e.g. "RPC_%s_URL".
+func forkRPCEnv(config *ClusterConfig, format string) []string {
+	var env []string
+	for _, name := range config.ForkedChains() {
+		env = append(env, fmt.Sprintf(format, strings.ToUpper(name))+"="+config.Chains[name].Fork.URL())
+	}
+	return env
+}
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLoadFundVault(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	_, err := loadFundVault("")
+	if err == nil || !strings.Contains(err.Error(), "no vault configured") {
+		t.Errorf("without a current vault: error = %v", err)
+	}
+
+	first, err := newDemoVault()
+	if err != nil {
+		t.Fatal(err)
+	}
+	second := *first
+	second.Name = "second"
+	second.PublicKeyECDSA = "03" + first.PublicKeyECDSA[2:]
+	for _, v := range []*LocalVault{first, &second} {
+		err = SaveVault(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The current vault is funded, not whichever vault file lists first.
+	err = SaveConfig(&DevConfig{PublicKeyECDSA: second.PublicKeyECDSA})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := loadFundVault("")
+	if err != nil || got.Name != "second" {
+		t.Errorf("current vault: got %v, %v; want second", got, err)
+	}
+
+	got, err = loadFundVault(first.Name)
+	if err != nil || got.PublicKeyECDSA != first.PublicKeyECDSA {
+		t.Errorf("--vault %s: got %v, %v", first.Name, got, err)
+	}
+}
@@ -0,0 +1,352 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vultisig/vultisig-cluster/local/cmd/devctl/format"
+)
+
+// gcArtifact is a file, or the expired lines of an append-only file, that
+// 'devctl gc' removes.
+type gcArtifact struct {
+	Kind   string
+	Path   string
+	Age    time.Duration
+	Size   int64
+	Reason string
+	// Lines is the number of expired lines for a trimmed file; 0 means the
+	// whole file is removed.
+	Lines int
+	keep  [][]byte
+}
+
+func NewGCCmd() *cobra.Command {
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "gc",
+		Short: "Remove stale devctl files from ~/.vultisig and the run directory",
+		Long: `Remove what devctl accumulates over weeks of use:
+
+  config backup     devctl.json.v<N>.bak left by config migrations
+  recipe cache      plugin recipe specifications in ~/.vultisig/cache
+  swap snapshot     balance snapshots from 'policy trigger --snapshot'
+  startup profile   traces from 'start --profile-startup'
+  history           lines of history.jsonl, metrics.jsonl and sessions.jsonl
+                    (delivered notification events in history.jsonl stay)
+  orphaned state    demo-policy.json without an active demo, and PID files
+                    of services that are no longer running
+
+Retention is set per kind, in days, under gc in cluster.yaml. Vault files
+(including party shares and the demo stash), devctl.json, cluster.yaml,
+chains.yaml, demo.json, the audit log and sessions that are still open are
+never touched.
+
+--dry-run lists every file or line range that would go, with its age, size
+and the rule that selected it.
+
+Examples:
+  devctl gc --dry-run
+  devctl gc
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runGC(dryRun)
+		},
+	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "List what would be removed without removing it")
+
+	return cmd
+}
+
+func runGC(dryRun bool) error {
+	cc := clusterConfigOrDefaults()
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return fmt.Errorf("find home directory: %w", err)
+	}
+	now := time.Now()
+
+	var artifacts []gcArtifact
+	var scanErrs []error
+	add := func(found []gcArtifact, err error) {
+		artifacts = append(artifacts, found...)
+		if err != nil {
+			scanErrs = append(scanErrs, err)
+		}
+	}
+
+	add(gcFiles("config backup", filepath.Join(home, ".vultisig", "devctl.json.v*.bak"), cc.GC.Backups, now))
+	add(gcFiles("recipe cache", filepath.Join(home, ".vultisig", "cache", "*"), cc.GC.Cache, now))
+	add(gcFiles("swap snapshot", filepath.Join(home, ".vultisig", "snapshots", "*.json"), cc.GC.Snapshots, now))
+	add(gcFiles("startup profile", filepath.Join(StartupProfileDir(), "*.json"), cc.GC.Profiles, now))
+	add(gcLines(HistoryPath(), cc.GC.History, now, historyLineTime, nil))
+	add(gcLines(MetricsPath(), cc.GC.History, now, metricsLineTime, nil))
+	add(gcLines(SessionsPath(), cc.GC.History, now, sessionLineTime, keptSessionIDs(cc.GC.History, now)))
+	artifacts = append(artifacts, gcOrphans(cc, now)...)
+
+	for _, err := range scanErrs {
+		progressf("%s %v\n", warnMark(), err)
+	}
+
+	if len(artifacts) == 0 {
+		fmt.Println("Nothing to clean up.")
+		return nil
+	}
+
+	if dryRun {
+		fmt.Println("Would remove:")
+	} else {
+		fmt.Println("Removing:")
+	}
+	fmt.Println()
+	rows := make([][]string, 0, len(artifacts))
+	for _, a := range artifacts {
+		what := a.Path
+		if a.Lines > 0 {
+			what = fmt.Sprintf("%s (%d lines)", a.Path, a.Lines)
+		}
+		rows = append(rows, []string{a.Kind, what, gcAge(a.Age), format.Bytes(a.Size), a.Reason})
+	}
+	printTable([]string{"Kind", "Path", "Age", "Size", "Reason"}, rows)
+
+	byKind := map[string]int64{}
+	var kinds []string
+	var total int64
+	var failed int
+	for _, a := range artifacts {
+		if !dryRun {
+			err := removeGCArtifact(a)
+			if err != nil {
+				progressf("%s %s: %v\n", failMark(), a.Path, err)
+				failed++
+				continue
+			}
+		}
+		if _, ok := byKind[a.Kind]; !ok {
+			kinds = append(kinds, a.Kind)
+		}
+		byKind[a.Kind] += a.Size
+		total += a.Size
+	}
+
+	fmt.Println()
+	for _, kind := range kinds {
+		fmt.Printf("  %-16s %s\n", kind, format.Bytes(byKind[kind]))
+	}
+	if dryRun {
+		fmt.Printf("\n%d item(s), %s reclaimable. Run 'devctl gc' without --dry-run to remove them.\n", len(artifacts), format.Bytes(total))
+		return nil
+	}
+	fmt.Printf("\n%s Reclaimed %s from %d item(s)\n", okMark(), format.Bytes(total), len(artifacts)-failed)
+	if failed > 0 {
+		return fmt.Errorf("%d item(s) could not be removed", failed)
+	}
+	return nil
+}
+
+// gcFiles selects the files matching pattern whose modification time is
+// more than days old.
+func gcFiles(kind, pattern string, days int, now time.Time) ([]gcArtifact, error) {
+	matches, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("scan %s: %w", pattern, err)
+	}
+	cutoff := time.Duration(days) * 24 * time.Hour
+	var found []gcArtifact
+	for _, path := range matches {
+		info, err := os.Stat(path)
+		if err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		age := now.Sub(info.ModTime())
+		if age <= cutoff {
+			continue
+		}
+		found = append(found, gcArtifact{
+			Kind:   kind,
+			Path:   path,
+			Age:    age,
+			Size:   info.Size(),
+			Reason: fmt.Sprintf("older than %dd", days),
+		})
+	}
+	return found, nil
+}
+
+// gcLines selects the lines of an append-only JSON lines file older than
+// days, by the time lineTime reads from each. Lines without a time, and
+// lines whose ID (the second value of lineTime) is in keep, stay.
+func gcLines(path string, days int, now time.Time, lineTime func([]byte) (time.Time, string), keep map[string]bool) ([]gcArtifact, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	cutoff := now.Add(-time.Duration(days) * 24 * time.Hour)
+	a := gcArtifact{Kind: "history", Path: path, Reason: fmt.Sprintf("lines older than %dd", days)}
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		line := append([]byte(nil), scanner.Bytes()...)
+		t, id := lineTime(line)
+		if t.IsZero() || !t.Before(cutoff) || keep[id] {
+			a.keep = append(a.keep, line)
+			continue
+		}
+		a.Lines++
+		a.Size += int64(len(line)) + 1
+		if age := now.Sub(t); age > a.Age {
+			a.Age = age
+		}
+	}
+	err = scanner.Err()
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+	if a.Lines == 0 {
+		return nil, nil
+	}
+	return []gcArtifact{a}, nil
+}
+
+// historyLineTime leaves notification events without a time, so they stay:
+// 'notify daemon' dedupes against them however old they are.
+func historyLineTime(line []byte) (time.Time, string) {
+	var entry HistoryEntry
+	if json.Unmarshal(line, &entry) != nil || entry.Event != "" {
+		return time.Time{}, ""
+	}
+	t, _ := time.Parse(time.RFC3339, entry.Time)
+	return t, ""
+}
+
+func metricsLineTime(line []byte) (time.Time, string) {
+	var timings PhaseTimings
+	if json.Unmarshal(line, &timings) != nil || len(timings.Phases) == 0 {
+		return time.Time{}, ""
+	}
+	return timings.Phases[0].StartedAt, ""
+}
+
+func sessionLineTime(line []byte) (time.Time, string) {
+	var rec SessionRecord
+	if json.Unmarshal(line, &rec) != nil {
+		return time.Time{}, ""
+	}
+	t, _ := time.Parse(time.RFC3339, rec.Time)
+	return t, rec.SessionID
+}
+
+// keptSessionIDs are the sessions gc leaves whole: those still open, which
+// 'relay orphans' needs however old they are, and those with any record
+// newer than days. Expired sessions go with all their records, so a session
+// is never left without its "started" line.
+func keptSessionIDs(days int, now time.Time) map[string]bool {
+	f, err := os.Open(SessionsPath())
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	cutoff := now.Add(-time.Duration(days) * 24 * time.Hour)
+	latest := map[string]SessionRecord{}
+	recent := map[string]bool{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var rec SessionRecord
+		if json.Unmarshal(scanner.Bytes(), &rec) != nil || rec.SessionID == "" {
+			continue
+		}
+		latest[rec.SessionID] = rec
+		t, err := time.Parse(time.RFC3339, rec.Time)
+		if err != nil || !t.Before(cutoff) {
+			recent[rec.SessionID] = true
+		}
+	}
+
+	kept := map[string]bool{}
+	for id, rec := range latest {
+		if rec.Status == sessionStarted || recent[id] {
+			kept[id] = true
+		}
+	}
+	return kept
+}
+
+// gcOrphans selects state files nothing refers to any more: the demo policy
+// once the demo is torn down, and PID files of services that have exited.
+func gcOrphans(cc *ClusterConfig, now time.Time) []gcArtifact {
+	var found []gcArtifact
+	orphan := func(path, reason string) {
+		info, err := os.Stat(path)
+		if err != nil || !info.Mode().IsRegular() {
+			return
+		}
+		found = append(found, gcArtifact{Kind: "orphaned state", Path: path, Age: now.Sub(info.ModTime()), Size: info.Size(), Reason: reason})
+	}
+
+	_, err := os.Stat(demoStatePath())
+	if os.IsNotExist(err) {
+		orphan(filepath.Join(filepath.Dir(demoStatePath()), "demo-policy.json"), "no active demo")
+	}
+
+	var pidFiles []string
+	for _, svc := range cc.LocalServices() {
+		if svc.PIDFile != "" {
+			pidFiles = append(pidFiles, svc.PIDFile)
+		}
+	}
+	sort.Strings(pidFiles)
+	for _, path := range pidFiles {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		pid := strings.TrimSpace(string(data))
+		if isProcessRunning(pid) {
+			continue
+		}
+		orphan(path, fmt.Sprintf("process %s not running", pid))
+	}
+	return found
+}
+
+func removeGCArtifact(a gcArtifact) error {
+	if a.Lines == 0 {
+		return os.Remove(a.Path)
+	}
+	var buf bytes.Buffer
+	for _, line := range a.keep {
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+	tmp := a.Path + ".gc"
+	err := os.WriteFile(tmp, buf.Bytes(), 0600)
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp, a.Path)
+}
+
+// gcAge renders an artifact's age in whole days, or hours below a day.
+func gcAge(d time.Duration) string {
+	if d < 24*time.Hour {
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	}
+	return fmt.Sprintf("%dd", int(d.Hours()/24))
+}
@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// healthState is the outcome of one health probe.
+type healthState int
+
+const (
+	healthDown healthState = iota
+	// healthUnhealthy: the expected status came back, but the body does not
+	// match the check, e.g. a proxy's generic 200 page or a service
+	// reporting its database down.
+	healthUnhealthy
+	healthOK
+)
+
+func (s healthState) String() string {
+	switch s {
+	case healthOK:
+		return "OK"
+	case healthUnhealthy:
+		return "UNHEALTHY"
+	default:
+		return "DOWN"
+	}
+}
+
+// maxHealthBody bounds how much of a health response is read for the body
+// checks.
+const maxHealthBody = 64 << 10
+
+// probeHealth GETs url once and checks the response against check. The
+// reason explains any state other than healthOK.
+func probeHealth(ctx context.Context, url string, check HealthCheck) (healthState, string) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return healthDown, err.Error()
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return healthDown, "not reachable"
+	}
+	defer resp.Body.Close()
+
+	status := check.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	if resp.StatusCode != status {
+		return healthDown, fmt.Sprintf("status %d, want %d", resp.StatusCode, status)
+	}
+	if !check.hasBodyChecks() {
+		return healthOK, ""
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxHealthBody))
+	if err != nil {
+		return healthUnhealthy, fmt.Sprintf("read body: %v", err)
+	}
+	err = check.validateBody(body)
+	if err != nil {
+		return healthUnhealthy, err.Error()
+	}
+	return healthOK, ""
+}
+
+// checkHealthState probes url with a short timeout.
+func checkHealthState(url string, check HealthCheck) (healthState, string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	return probeHealth(ctx, url, check)
+}
+
+func (c HealthCheck) hasBodyChecks() bool {
+	return c.Contains != "" || len(c.JSON) > 0 || len(c.Fields) > 0
+}
+
+// validateBody checks a health response body: it must contain Contains, and
+// parse as JSON with every JSON field equal to its value and every one of
+// Fields present. Field names may be dotted paths into nested objects
+// ("db.status").
+func (c HealthCheck) validateBody(body []byte) error {
+	if c.Contains != "" && !strings.Contains(string(body), c.Contains) {
+		return fmt.Errorf("body does not contain %q", c.Contains)
+	}
+	if len(c.JSON) == 0 && len(c.Fields) == 0 {
+		return nil
+	}
+
+	var doc map[string]interface{}
+	err := json.Unmarshal(body, &doc)
+	if err != nil {
+		return fmt.Errorf("body is not a JSON object")
+	}
+	for _, field := range c.Fields {
+		_, ok := jsonPath(doc, field)
+		if !ok {
+			return fmt.Errorf("field %s missing", field)
+		}
+	}
+	for field, want := range c.JSON {
+		got, ok := jsonPath(doc, field)
+		if !ok {
+			return fmt.Errorf("field %s missing", field)
+		}
+		if fmt.Sprint(got) != want {
+			return fmt.Errorf("%s is %v, want %s", field, got, want)
+		}
+	}
+	return nil
+}
+
+// jsonPath looks up a dotted path in a decoded JSON object.
+func jsonPath(doc map[string]interface{}, path string) (interface{}, bool) {
+	var cur interface{} = doc
+	for _, part := range strings.Split(path, ".") {
+		obj, ok := cur.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		cur, ok = obj[part]
+		if !ok {
+			return nil, false
+		}
+	}
+	return cur, true
+}
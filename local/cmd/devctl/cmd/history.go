@@ -0,0 +1,192 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vultisig/vultisig-cluster/local/cmd/devctl/format"
+)
+
+type HistoryEntry struct {
+	Time       string `json:"time"`
+	TraceID    string `json:"trace_id"`
+	Command    string `json:"command"`
+	DurationMs int64  `json:"duration_ms"`
+	Success    bool   `json:"success"`
+	Error      string `json:"error,omitempty"`
+	Event      string `json:"event,omitempty"`
+
+	// Relay counts the relay requests of any TSS operations the command ran.
+	Relay *RelaySummary `json:"relay,omitempty"`
+}
+
+func HistoryPath() string {
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".vultisig", "history.jsonl")
+}
+
+// AppendHistory records a finished invocation in the local history file.
+// Only the command path is stored, never flags, so passwords stay out of it.
+func AppendHistory(command string, started time.Time, runErr error) error {
+	entry := HistoryEntry{
+		Time:       started.UTC().Format(time.RFC3339),
+		TraceID:    TraceID(),
+		Command:    command,
+		DurationMs: time.Since(started).Milliseconds(),
+		Success:    runErr == nil,
+		Relay:      processRelayStats.Summary(),
+	}
+	if runErr != nil {
+		entry.Error = runErr.Error()
+	}
+
+	return appendHistoryEntry(entry)
+}
+
+// AppendHistoryEvent records that a notification event was delivered, so a
+// restarted 'notify daemon' does not send it again.
+func AppendHistoryEvent(event string) error {
+	return appendHistoryEntry(HistoryEntry{
+		Time:    time.Now().UTC().Format(time.RFC3339),
+		TraceID: TraceID(),
+		Command: "devctl notify daemon",
+		Success: true,
+		Event:   event,
+	})
+}
+
+// LoadHistoryEvents returns the set of events already recorded in the
+// history file. A missing file yields an empty set.
+func LoadHistoryEvents() (map[string]bool, error) {
+	events := map[string]bool{}
+
+	f, err := os.Open(HistoryPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return events, nil
+		}
+		return nil, fmt.Errorf("open history: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var entry HistoryEntry
+		if json.Unmarshal(scanner.Bytes(), &entry) != nil || entry.Event == "" {
+			continue
+		}
+		events[entry.Event] = true
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read history: %w", err)
+	}
+	return events, nil
+}
+
+func appendHistoryEntry(entry HistoryEntry) error {
+	path := HistoryPath()
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return fmt.Errorf("create history dir: %w", err)
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("marshal history entry: %w", err)
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("open history: %w", err)
+	}
+	defer f.Close()
+
+	_, err = f.Write(append(data, '\n'))
+	if err != nil {
+		return fmt.Errorf("write history: %w", err)
+	}
+	return nil
+}
+
+func NewHistoryCmd() *cobra.Command {
+	var filter HistoryFilter
+	var verbose bool
+
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "Show recent devctl invocations",
+		Long: `Show entries from ~/.vultisig/history.jsonl: every devctl invocation with
+its trace ID, duration, relay request counts and outcome, newest last. Use the trace ID to find an
+invocation in service logs.
+
+Examples:
+  devctl history
+  devctl history --since 1h --verbose
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runHistory(filter, verbose)
+		},
+	}
+
+	addHistoryFlags(cmd, &filter, 20, "invocations")
+	cmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "Show absolute timestamps")
+	return cmd
+}
+
+func runHistory(filter HistoryFilter, verbose bool) error {
+	f, err := os.Open(HistoryPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			fmt.Println("No history yet.")
+			return nil
+		}
+		return fmt.Errorf("open history: %w", err)
+	}
+	defer f.Close()
+
+	var entries []HistoryEntry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var entry HistoryEntry
+		if json.Unmarshal(scanner.Bytes(), &entry) != nil || entry.Event != "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, entry.Time)
+		if err == nil && !filter.Includes(t) {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("read history: %w", err)
+	}
+
+	if filter.Limit > 0 && len(entries) > filter.Limit {
+		entries = entries[len(entries)-filter.Limit:]
+	}
+
+	rows := make([][]string, 0, len(entries))
+	for _, e := range entries {
+		when := e.Time
+		if t, err := time.Parse(time.RFC3339, e.Time); err == nil {
+			when = formatTimestamp(t, verbose)
+		}
+		status := okMark()
+		if !e.Success {
+			status = failMark() + " " + format.Truncate(e.Error, 40)
+		}
+		relayCol := "-"
+		if e.Relay != nil {
+			relayCol = fmt.Sprintf("%d req, p95 %dms", e.Relay.Requests, e.Relay.P95Ms)
+		}
+		rows = append(rows, []string{when, e.Command, fmt.Sprintf("%dms", e.DurationMs), relayCol, e.TraceID, status})
+	}
+	printTable([]string{"WHEN", "COMMAND", "DURATION", "RELAY", "TRACE", "STATUS"}, rows)
+	return nil
+}
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/vultisig/vultisig-cluster/local/cmd/devctl/format"
+)
+
+// defaultMaxResponseBytes caps a response body when neither
+// max_response_bytes nor VCLI_MAX_RESPONSE_BYTES is set. Policy history of
+// long-running vaults and plugin listings with embedded images run to a few
+// megabytes.
+const defaultMaxResponseBytes = 32 << 20
+
+// getAPIAttempts is how often getAPI sends a GET whose body broke off.
+const getAPIAttempts = 3
+
+// getAPIRetryDelay is the pause before the second attempt; each later one
+// waits that much longer.
+var getAPIRetryDelay = time.Second
+
+// ResponseTooLargeError is returned when a body exceeds the response limit.
+type ResponseTooLargeError struct {
+	URL   string
+	Limit int64
+}
+
+func (e *ResponseTooLargeError) Error() string {
+	return fmt.Sprintf("response too large: %s returned more than %s (raise max_response_bytes in devctl.json or VCLI_MAX_RESPONSE_BYTES)",
+		e.URL, format.Bytes(e.Limit))
+}
+
+// maxResponseBytes is max_response_bytes from devctl.json, else
+// VCLI_MAX_RESPONSE_BYTES, else defaultMaxResponseBytes.
+func maxResponseBytes() int64 {
+	cfg, err := LoadConfig()
+	if err == nil && cfg.MaxResponseBytes > 0 {
+		return cfg.MaxResponseBytes
+	}
+	n, err := strconv.ParseInt(os.Getenv("VCLI_MAX_RESPONSE_BYTES"), 10, 64)
+	if err == nil && n > 0 {
+		return n
+	}
+	return defaultMaxResponseBytes
+}
+
+// limitedBody reads resp.Body up to the response limit and fails with a
+// ResponseTooLargeError past it, so callers can stream-decode it.
+type limitedBody struct {
+	r     io.Reader
+	url   string
+	limit int64
+	read  int64
+}
+
+func newLimitedBody(resp *http.Response) *limitedBody {
+	limit := maxResponseBytes()
+	return &limitedBody{r: io.LimitReader(resp.Body, limit+1), url: resp.Request.URL.String(), limit: limit}
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	n, err := b.r.Read(p)
+	b.read += int64(n)
+	if b.read > b.limit {
+		return n, &ResponseTooLargeError{URL: b.url, Limit: b.limit}
+	}
+	return n, err
+}
+
+// readResponseBody is io.ReadAll of resp.Body bounded by the response limit.
+func readResponseBody(resp *http.Response) ([]byte, error) {
+	body, err := io.ReadAll(newLimitedBody(resp))
+	if err != nil {
+		var tooLarge *ResponseTooLargeError
+		if errors.As(err, &tooLarge) {
+			return nil, err
+		}
+		return body, fmt.Errorf("read response: %w", err)
+	}
+	return body, nil
+}
+
+// isTruncatedBody reports whether err is a body that broke off mid-read,
+// which is worth retrying for an idempotent request.
+func isTruncatedBody(err error) bool {
+	return errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.EPIPE)
+}
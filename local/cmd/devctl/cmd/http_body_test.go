@@ -0,0 +1,218 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/vultisig/vultisig-cluster/local/cmd/devctl/client"
+)
+
+const policyBody = `{"data": {"id": "p1", "public_key": "02a1b2c3", "plugin_id": "vultisig-dca-0000", "active": true}}`
+
+// breakOff sends the first half of body under a Content-Length for all of
+// it and drops the connection: cleanly when reset is false, with a TCP RST
+// when it is true.
+func breakOff(t *testing.T, w http.ResponseWriter, body string, reset bool) {
+	w.Header().Set("Content-Length", "999")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(body[:len(body)/2]))
+	w.(http.Flusher).Flush()
+
+	conn, _, err := w.(http.Hijacker).Hijack()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if reset {
+		conn.(*net.TCPConn).SetLinger(0)
+	}
+	conn.Close()
+}
+
+func useResponseLimits(t *testing.T) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("VCLI_MAX_RESPONSE_BYTES", "")
+	saved := getAPIRetryDelay
+	getAPIRetryDelay = 10 * time.Millisecond
+	t.Cleanup(func() { getAPIRetryDelay = saved })
+}
+
+func TestGetAPIRetriesTruncatedBody(t *testing.T) {
+	useResponseLimits(t)
+
+	tests := []struct {
+		name     string
+		failures int32
+		reset    bool
+		wantErr  bool
+		requests int32
+	}{
+		{name: "complete body", failures: 0, requests: 1},
+		{name: "one break", failures: 1, requests: 2},
+		{name: "one reset", failures: 1, reset: true, requests: 2},
+		{name: "two breaks", failures: 2, requests: 3},
+		{name: "every attempt breaks", failures: 99, wantErr: true, requests: getAPIAttempts},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if requests.Add(1) <= tt.failures {
+					breakOff(t, w, policyBody, tt.reset)
+					return
+				}
+				w.Write([]byte(policyBody))
+			}))
+			defer srv.Close()
+
+			got, err := getAPI[client.Policy](context.Background(), srv.URL, "")
+			if n := requests.Load(); n != tt.requests {
+				t.Errorf("server got %d requests, want %d", n, tt.requests)
+			}
+			if tt.wantErr {
+				if !isTruncatedBody(err) {
+					t.Errorf("getAPI error = %v, want a truncated body", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("getAPI: %v", err)
+			}
+			if got.ID != "p1" || !got.Active {
+				t.Errorf("getAPI = %+v, want policy p1", got)
+			}
+		})
+	}
+}
+
+func TestGetAPIDoesNotRetry(t *testing.T) {
+	useResponseLimits(t)
+
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		wantErr string
+	}{
+		{
+			name: "server error",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				http.Error(w, "boom", http.StatusInternalServerError)
+			},
+			wantErr: "request failed (500): boom",
+		},
+		{
+			name: "malformed body",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(`{"data": nope}`))
+			},
+			wantErr: "decode response: ",
+		},
+		{
+			name: "body over the limit",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(`{"data": {"id": "` + strings.Repeat("x", 200) + `"}}`))
+			},
+			wantErr: "response too large: ",
+		},
+	}
+
+	t.Setenv("VCLI_MAX_RESPONSE_BYTES", "100")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests.Add(1)
+				tt.handler(w, r)
+			}))
+			defer srv.Close()
+
+			_, err := getAPI[client.Policy](context.Background(), srv.URL, "")
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("getAPI error = %v, want %q", err, tt.wantErr)
+			}
+			if n := requests.Load(); n != 1 {
+				t.Errorf("server got %d requests, want 1", n)
+			}
+		})
+	}
+}
+
+func TestGetAPIRetryCanceled(t *testing.T) {
+	useResponseLimits(t)
+	getAPIRetryDelay = time.Minute
+
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		breakOff(t, w, policyBody, false)
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := getAPI[client.Policy](ctx, srv.URL, "")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("getAPI error = %v, want the context's deadline", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("getAPI took %s to give up, want it to stop waiting at the deadline", elapsed)
+	}
+	if n := requests.Load(); n != 1 {
+		t.Errorf("server got %d requests, want 1", n)
+	}
+}
+
+func TestLimitedBody(t *testing.T) {
+	useResponseLimits(t)
+	t.Setenv("VCLI_MAX_RESPONSE_BYTES", "10")
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.URL.Query().Get("body")))
+	}))
+	defer srv.Close()
+
+	for body, wantErr := range map[string]bool{"": false, "0123456789": false, "0123456789a": true} {
+		resp, err := http.Get(srv.URL + "?body=" + body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := readResponseBody(resp)
+		resp.Body.Close()
+
+		var tooLarge *ResponseTooLargeError
+		if wantErr {
+			if !errors.As(err, &tooLarge) || tooLarge.Limit != 10 || !strings.HasPrefix(tooLarge.URL, srv.URL) {
+				t.Errorf("%d-byte body: error = %v, want a ResponseTooLargeError", len(body), err)
+			}
+			continue
+		}
+		if err != nil || string(got) != body {
+			t.Errorf("%d-byte body: got %q, %v", len(body), got, err)
+		}
+	}
+}
+
+func TestIsTruncatedBody(t *testing.T) {
+	for err, want := range map[error]bool{
+		io.ErrUnexpectedEOF: true,
+		errors.Join(errors.New("decode response"), io.ErrUnexpectedEOF): true,
+		io.EOF:                   false,
+		context.Canceled:         false,
+		&ResponseTooLargeError{}: false,
+	} {
+		if got := isTruncatedBody(err); got != want {
+			t.Errorf("isTruncatedBody(%v) = %v, want %v", err, got, want)
+		}
+	}
+}
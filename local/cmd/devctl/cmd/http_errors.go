@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"encoding/json"
+	"html"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/vultisig/vultisig-cluster/local/cmd/devctl/format"
+)
+
+// defaultErrorBodyLimit is how many characters of a response body an error
+// message keeps when neither error_body_limit nor VCLI_ERROR_BODY_LIMIT is set.
+const defaultErrorBodyLimit = 300
+
+var (
+	htmlDropRe  = regexp.MustCompile(`(?is)<(script|style|head)\b.*?</(script|style|head)>|<!--.*?-->`)
+	htmlTagRe   = regexp.MustCompile(`(?s)<[^>]*>`)
+	spaceRe     = regexp.MustCompile(`\s+`)
+	bearerRe    = regexp.MustCompile(`(?i)\b(bearer|basic)\s+[A-Za-z0-9._~+/=-]{8,}`)
+	jwtRe       = regexp.MustCompile(`\beyJ[A-Za-z0-9_-]{5,}\.[A-Za-z0-9_-]{5,}\.[A-Za-z0-9_-]*`)
+	secretKVRe  = regexp.MustCompile(`(?i)("[\w-]*(?:password|passwd|secret|token|api[_-]?key|private[_-]?key|keyshare|authorization)"\s*:\s*|\b[\w-]*(?:password|passwd|secret|token|api[_-]?key|private[_-]?key|keyshare)=)("[^"]*"|[^\s,;&}]+)`)
+	keyBlobRe   = regexp.MustCompile(`\b[A-Za-z0-9+/]{128,}={0,2}`)
+	pemBlockRe  = regexp.MustCompile(`(?s)-----BEGIN [A-Z ]+-----.*?-----END [A-Z ]+-----`)
+	htmlSniffRe = regexp.MustCompile(`(?i)^\s*(<!doctype|<html|<head|<body|<\?xml)`)
+)
+
+// errorBody turns a response body into something fit for an error message:
+// the error/message field of a JSON body, or the text of an HTML page,
+// with tokens and key material redacted and the result cut to the
+// configured length. Proxies and error pages echo request headers back, so
+// a raw body can carry the bearer token that was sent.
+func errorBody(body []byte) string {
+	text := strings.TrimSpace(string(body))
+	if msg := jsonErrorMessage(body); msg != "" {
+		text = msg
+	} else if htmlSniffRe.MatchString(text) {
+		text = htmlToText(text)
+	}
+	text = redactSecrets(spaceRe.ReplaceAllString(text, " "))
+	return format.Truncate(strings.TrimSpace(text), errorBodyLimit())
+}
+
+// jsonErrorMessage returns the "error" or "message" field of a JSON body,
+// also when "error" is an object with its own message (the verifier's
+// envelope). It returns "" for anything else.
+func jsonErrorMessage(body []byte) string {
+	var fields map[string]interface{}
+	err := json.Unmarshal(body, &fields)
+	if err != nil {
+		return ""
+	}
+	if e, ok := fields["error"].(map[string]interface{}); ok {
+		msg, _ := e["message"].(string)
+		if details, _ := e["details"].(string); details != "" && msg != "" {
+			return msg + ": " + details
+		}
+		if msg != "" {
+			return msg
+		}
+	}
+	for _, key := range []string{"error", "message", "msg", "detail"} {
+		if s, ok := fields[key].(string); ok && s != "" {
+			return s
+		}
+	}
+	return ""
+}
+
+func htmlToText(page string) string {
+	page = htmlDropRe.ReplaceAllString(page, " ")
+	page = htmlTagRe.ReplaceAllString(page, " ")
+	return html.UnescapeString(page)
+}
+
+// redactSecrets masks bearer/basic credentials, JWTs, secret-looking
+// key=value pairs, PEM blocks and long base64/hex runs such as keyshares.
+// Public keys (66 hex characters) and hashes stay readable.
+func redactSecrets(s string) string {
+	s = pemBlockRe.ReplaceAllString(s, "[redacted key]")
+	s = bearerRe.ReplaceAllString(s, "$1 [redacted]")
+	s = jwtRe.ReplaceAllString(s, "[redacted token]")
+	s = secretKVRe.ReplaceAllString(s, "$1[redacted]")
+	s = keyBlobRe.ReplaceAllString(s, "[redacted blob]")
+	return s
+}
+
+// errorBodyLimit is error_body_limit from devctl.json, else
+// VCLI_ERROR_BODY_LIMIT, else defaultErrorBodyLimit.
+func errorBodyLimit() int {
+	cfg, err := LoadConfig()
+	if err == nil && cfg.ErrorBodyLimit > 0 {
+		return cfg.ErrorBodyLimit
+	}
+	n, err := strconv.Atoi(os.Getenv("VCLI_ERROR_BODY_LIMIT"))
+	if err == nil && n > 0 {
+		return n
+	}
+	return defaultErrorBodyLimit
+}
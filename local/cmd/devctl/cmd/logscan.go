@@ -0,0 +1,289 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// defaultLogErrorPatterns match logrus text and JSON levels, zap/slog-style
+// upper-case levels and Go runtime crashes.
+var defaultLogErrorPatterns = []string{
+	`(?i)level=(error|fatal|panic)\b`,
+	`(?i)"level":\s*"(error|fatal|panic)"`,
+	`\b(ERROR|FATAL|PANIC)\b`,
+	`^panic: `,
+	`^fatal error: `,
+}
+
+var logTimePatterns = []*regexp.Regexp{
+	regexp.MustCompile(`time="([^"]+)"`),
+	regexp.MustCompile(`"(?:time|ts|timestamp)":\s*"([^"]+)"`),
+	regexp.MustCompile(`^(\d{4}-\d{2}-\d{2}[T ]\d{2}:\d{2}:\d{2}(?:\.\d+)?(?:Z|[+-]\d{2}:?\d{2})?)`),
+}
+
+// LogErrorSummary counts a log's error lines within the scan window.
+type LogErrorSummary struct {
+	Count int
+	Last  time.Time
+	Lines []string
+}
+
+// String renders the summary for status and report, e.g.
+// "recent errors: 3 (last 2m ago)".
+func (s LogErrorSummary) String() string {
+	if s.Count == 0 {
+		return "recent errors: 0"
+	}
+	return fmt.Sprintf("recent errors: %d (last %s)", s.Count, relativeTime(s.Last))
+}
+
+// logErrorPatterns compiles the patterns configured for a service.
+func (c *ClusterConfig) logErrorPatterns(key string) ([]*regexp.Regexp, error) {
+	patterns, ok := c.Logs.Patterns[key]
+	if !ok {
+		patterns = c.Logs.Patterns["default"]
+	}
+
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+	for _, p := range patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("logs.patterns.%s: %w", key, err)
+		}
+		compiled = append(compiled, re)
+	}
+	return compiled, nil
+}
+
+// scanServiceLog summarizes the error lines among the last logs.tail lines
+// of a service's log that fall within the last logs.window minutes.
+func (c *ClusterConfig) scanServiceLog(svc LocalService) (LogErrorSummary, error) {
+	patterns, err := c.logErrorPatterns(svc.Key)
+	if err != nil {
+		return LogErrorSummary{}, err
+	}
+
+	info, err := os.Stat(svc.LogFile)
+	if err != nil {
+		return LogErrorSummary{}, err
+	}
+	lines, err := tailLines(svc.LogFile, c.Logs.Tail)
+	if err != nil {
+		return LogErrorSummary{}, err
+	}
+
+	return summarizeLogErrors(lines, patterns, info.ModTime(), time.Duration(c.Logs.Window)*time.Minute), nil
+}
+
+// summarizeLogErrors counts lines matching any pattern within window of now.
+// Lines without a timestamp, such as panic traces, take the time of the
+// nearest earlier timestamped line, or the log's mtime if there is none.
+func summarizeLogErrors(lines []string, patterns []*regexp.Regexp, modTime time.Time, window time.Duration) LogErrorSummary {
+	var summary LogErrorSummary
+	cutoff := time.Now().Add(-window)
+
+	lineTime := time.Time{}
+	for _, line := range lines {
+		if t, ok := parseLogTime(line); ok {
+			lineTime = t
+		}
+		if !matchesAny(line, patterns) {
+			continue
+		}
+
+		t := lineTime
+		if t.IsZero() {
+			t = modTime
+		}
+		if t.Before(cutoff) {
+			continue
+		}
+
+		summary.Count++
+		summary.Lines = append(summary.Lines, line)
+		if t.After(summary.Last) {
+			summary.Last = t
+		}
+	}
+	return summary
+}
+
+func matchesAny(line string, patterns []*regexp.Regexp) bool {
+	for _, re := range patterns {
+		if re.MatchString(line) {
+			return true
+		}
+	}
+	return false
+}
+
+func parseLogTime(line string) (time.Time, bool) {
+	for _, re := range logTimePatterns {
+		m := re.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		for _, layout := range []string{time.RFC3339Nano, "2006-01-02 15:04:05.999999999Z07:00", "2006-01-02T15:04:05.999999999", "2006-01-02 15:04:05.999999999"} {
+			t, err := time.ParseInLocation(layout, m[1], time.Local)
+			if err == nil {
+				return t, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// tailLines returns the last n lines of path, reading backwards in chunks so
+// large logs are not read whole.
+func tailLines(path string, n int) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	const chunk = 64 * 1024
+	offset := info.Size()
+	var data []byte
+	for offset > 0 && bytes.Count(data, []byte("\n")) <= n {
+		size := int64(chunk)
+		if offset < size {
+			size = offset
+		}
+		offset -= size
+		buf := make([]byte, size)
+		_, err = f.ReadAt(buf, offset)
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+		data = append(buf, data...)
+	}
+
+	var lines []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	err = scanner.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	// The first line is partial when the read started mid-file.
+	if offset > 0 && len(lines) > 0 {
+		lines = lines[1:]
+	}
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return lines, nil
+}
+
+func NewLogsCmd() *cobra.Command {
+	var errorsOnly bool
+	var lines int
+
+	cmd := &cobra.Command{
+		Use:   "logs <service>",
+		Short: "Show a local service's log, or only its recent errors",
+		Long: `Show the last lines of a service's log in the run directory.
+
+--errors prints only the lines matching the service's error patterns within
+the last logs.window minutes (default 15), scanning the last logs.tail lines.
+Patterns are configured per service key in cluster.yaml under logs.patterns;
+services without an entry use logs.patterns.default.
+
+Services: verifier, verifier_worker, dca_server, dca_worker, dca_scheduler,
+dca_tx_indexer, sends_server, sends_worker, sends_scheduler.
+
+Example:
+  devctl logs verifier -n 100
+  devctl logs dca_worker --errors
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runLogs(args[0], errorsOnly, lines)
+		},
+	}
+
+	cmd.Flags().BoolVar(&errorsOnly, "errors", false, "Only show recent lines matching the error patterns")
+	cmd.Flags().IntVarP(&lines, "lines", "n", 50, "Number of lines to show")
+
+	return cmd
+}
+
+func runLogs(key string, errorsOnly bool, n int) error {
+	cc := clusterConfigOrDefaults()
+	svc, ok := cc.LocalService(key)
+	if !ok || svc.LogFile == "" {
+		var keys []string
+		for _, s := range cc.LocalServices() {
+			if s.LogFile != "" {
+				keys = append(keys, s.Key)
+			}
+		}
+		sort.Strings(keys)
+		return fmt.Errorf("no local log for service %q (known: %s)", key, strings.Join(keys, ", "))
+	}
+
+	if !errorsOnly {
+		lines, err := tailLines(svc.LogFile, n)
+		if err != nil {
+			return fmt.Errorf("read %s: %w", svc.LogFile, err)
+		}
+		for _, line := range lines {
+			fmt.Println(line)
+		}
+		return nil
+	}
+
+	summary, err := cc.scanServiceLog(svc)
+	if err != nil {
+		return fmt.Errorf("scan %s: %w", svc.LogFile, err)
+	}
+	progressf("%s: %s in the last %dm (%s)\n\n", svc.Name, summary, cc.Logs.Window, svc.LogFile)
+
+	lines := summary.Lines
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	for _, line := range lines {
+		fmt.Println(line)
+	}
+	return nil
+}
+
+// printLogErrorSummaries is the optional log scan of status and report.
+func printLogErrorSummaries(cc *ClusterConfig, prefix string) {
+	for _, svc := range cc.LocalServices() {
+		if svc.LogFile == "" {
+			continue
+		}
+		summary, err := cc.scanServiceLog(svc)
+		switch {
+		case os.IsNotExist(err):
+			continue
+		case err != nil:
+			fmt.Printf("%s%s %-20s scan failed: %v\n", prefix, symWarn, svc.Name, err)
+		case summary.Count > 0:
+			fmt.Printf("%s%s %-20s %s  (devctl logs %s --errors)\n", prefix, symWarn, svc.Name, summary, svc.Key)
+		default:
+			fmt.Printf("%s%s %-20s %s\n", prefix, symOK, svc.Name, summary)
+		}
+	}
+}
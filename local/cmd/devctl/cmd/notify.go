@@ -0,0 +1,303 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"runtime"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/vultisig/vultisig-cluster/local/cmd/devctl/client"
+)
+
+func NewNotifyCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "notify",
+		Short: "Notifications for policy executions and service health",
+	}
+
+	cmd.AddCommand(newNotifyDaemonCmd())
+
+	return cmd
+}
+
+func newNotifyDaemonCmd() *cobra.Command {
+	var interval int
+
+	cmd := &cobra.Command{
+		Use:   "daemon",
+		Short: "Poll for policy transactions and health changes and notify",
+		Long: `Run in the foreground, polling the verifier for new policy transactions
+and the local services for health transitions.
+
+Each event is posted to notifications.webhook in cluster.yaml (Slack-compatible
+JSON: {"text": ...}) and/or shown as a desktop notification when
+notifications.desktop is true. Delivered events are recorded in
+~/.vultisig/history.jsonl so a restarted daemon does not repeat them; an
+event the webhook does not accept is sent again on the next poll.
+
+On the very first run, existing transactions are recorded without notifying.
+
+Examples:
+  devctl notify daemon
+  devctl notify daemon --interval 10
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runNotifyDaemon(cmd.Context(), interval)
+		},
+	}
+
+	cmd.Flags().IntVar(&interval, "interval", 0, "Poll interval in seconds (default: notifications.interval)")
+
+	return cmd
+}
+
+type notifier struct {
+	webhook string
+	desktop bool
+	seen    map[string]bool
+}
+
+func runNotifyDaemon(ctx context.Context, interval int) error {
+	cc, err := LoadClusterConfig()
+	if err != nil {
+		return fmt.Errorf("load cluster config: %w", err)
+	}
+
+	if cc.Notifications.Webhook == "" && !cc.Notifications.Desktop {
+		return fmt.Errorf("no notification target. Set notifications.webhook or notifications.desktop in cluster.yaml")
+	}
+	if interval <= 0 {
+		interval = cc.Notifications.Interval
+	}
+
+	seen, err := LoadHistoryEvents()
+	if err != nil {
+		return err
+	}
+
+	n := &notifier{
+		webhook: cc.Notifications.Webhook,
+		desktop: cc.Notifications.Desktop,
+		seen:    seen,
+	}
+	// Without any recorded events there is nothing to dedupe against, so the
+	// first poll only records what already exists.
+	priming := len(seen) == 0
+
+	progressln("=== Notify Daemon ===")
+	if n.webhook != "" {
+		progressf("Webhook:  %s\n", n.webhook)
+	}
+	if n.desktop {
+		progressln("Desktop:  enabled")
+	}
+	progressf("Interval: %ds\n", interval)
+	progressln("\nPress Ctrl+C to stop")
+	progressln()
+
+	health := map[string]bool{}
+	authWarned := false
+
+	for {
+		n.pollHealth(health)
+
+		authHeader, err := GetAuthHeader()
+		if err != nil {
+			if !authWarned {
+				progressf("  Skipping transaction polling: %v\n", err)
+				authWarned = true
+			}
+		} else {
+			err = n.pollTransactions(ctx, authHeader, priming)
+			if err != nil {
+				progressf("  Transaction poll failed: %v\n", err)
+			} else {
+				// Only a poll that saw the existing transactions primes;
+				// until then there is still nothing to dedupe against.
+				priming = false
+			}
+		}
+
+		if sleepCtx(ctx, time.Duration(interval)*time.Second) != nil {
+			progressln("Stopped.")
+			return nil
+		}
+	}
+}
+
+// pollHealth notifies when a service changes state. The first observation of
+// each service only sets the baseline.
+func (n *notifier) pollHealth(last map[string]bool) {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return
+	}
+
+	services := []struct {
+		name string
+		url  string
+	}{
+		{"Verifier", cfg.Verifier + "/healthz"},
+		{"Fee Plugin", cfg.FeePlugin + "/healthz"},
+		{"DCA Plugin", cfg.DCAPlugin + "/healthz"},
+		{"Fast Vault Server", FastVaultServer + "/healthz"},
+		{"Relay Server", RelayServer},
+	}
+
+	for _, svc := range services {
+		up := checkHealth(svc.url)
+		prev, known := last[svc.name]
+		last[svc.name] = up
+		if !known || prev == up {
+			continue
+		}
+
+		state := "DOWN"
+		if up {
+			state = "UP"
+		}
+		n.send(fmt.Sprintf("health:%s:%s:%s", svc.name, state, time.Now().UTC().Format(time.RFC3339)),
+			fmt.Sprintf("%s is %s", svc.name, state))
+	}
+}
+
+// pollTransactions walks plugins -> policies -> history and notifies for each
+// transaction status not seen before.
+func (n *notifier) pollTransactions(ctx context.Context, authHeader string, priming bool) error {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	if cfg.PublicKeyECDSA == "" {
+		return fmt.Errorf("no vault configured")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	plugins, err := getAPI[client.PluginList](ctx, cfg.Verifier+"/plugins", "")
+	if err != nil {
+		return fmt.Errorf("list plugins: %w", err)
+	}
+
+	for _, plugin := range plugins.Plugins {
+		policies, err := getAPI[client.PolicyList](ctx,
+			fmt.Sprintf("%s/plugin/policies/%s?public_key=%s", cfg.Verifier, plugin.ID, cfg.PublicKeyECDSA), authHeader)
+		if err != nil {
+			continue
+		}
+
+		for _, policy := range policies.Policies {
+			history, err := getAPI[client.PolicyHistory](ctx,
+				fmt.Sprintf("%s/plugin/policies/%s/history?take=%d", cfg.Verifier, policy.ID, 20), authHeader)
+			if err != nil {
+				continue
+			}
+
+			for _, tx := range history.History {
+				event := fmt.Sprintf("tx:%s:%s", tx.ID, tx.Status)
+				if n.seen[event] {
+					continue
+				}
+				if priming {
+					n.record(event)
+					continue
+				}
+				n.send(event, formatTxEvent(plugin.Title, tx))
+			}
+		}
+	}
+
+	return nil
+}
+
+func formatTxEvent(pluginTitle string, tx client.PolicyHistoryEntry) string {
+	msg := fmt.Sprintf("%s: transaction %s on %s", pluginTitle, tx.Status, tx.Chain)
+	if tx.Amount != nil {
+		msg += fmt.Sprintf(" (amount %s)", *tx.Amount)
+	}
+	if tx.TxHash != nil {
+		msg += fmt.Sprintf(" tx %s", *tx.TxHash)
+	}
+	if tx.ErrorMessage != nil && *tx.ErrorMessage != "" {
+		msg += fmt.Sprintf(": %s", *tx.ErrorMessage)
+	}
+	return msg
+}
+
+// send delivers an event and records it. An event the webhook did not
+// accept is left unrecorded, so the next poll sends it again.
+func (n *notifier) send(event, text string) {
+	fmt.Printf("[%s] %s\n", time.Now().Format("15:04:05"), text)
+
+	if n.webhook != "" {
+		err := postWebhook(n.webhook, event, text)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "  webhook failed: %v\n", err)
+			return
+		}
+	}
+	if n.desktop {
+		err := desktopNotify(text)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "  desktop notification failed: %v\n", err)
+		}
+	}
+
+	n.record(event)
+}
+
+func (n *notifier) record(event string) {
+	n.seen[event] = true
+	err := AppendHistoryEvent(event)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "  record event: %v\n", err)
+	}
+}
+
+func postWebhook(url, event, text string) error {
+	payload, err := json.Marshal(map[string]string{
+		"text":  text,
+		"event": event,
+	})
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func desktopNotify(text string) error {
+	switch runtime.GOOS {
+	case "darwin":
+		return exec.Command("osascript", "-e", fmt.Sprintf("display notification %q with title \"devctl\"", text)).Run()
+	case "linux":
+		return exec.Command("notify-send", "devctl", text).Run()
+	default:
+		return fmt.Errorf("desktop notifications not supported on %s", runtime.GOOS)
+	}
+}
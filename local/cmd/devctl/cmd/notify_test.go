@@ -0,0 +1,166 @@
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// notifyVerifier serves one plugin with one policy whose history holds a
+// single signed transaction, the event "tx:t1:SIGNED", and points devctl at
+// it with a current vault.
+func notifyVerifier(t *testing.T) {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/plugins":
+			w.Write([]byte(`{"data": {"plugins": [{"id": "vultisig-dca-0000", "title": "DCA Plugin"}], "total_count": 1}}`))
+		case strings.HasSuffix(r.URL.Path, "/history"):
+			w.Write([]byte(`{"data": {"history": [{"id": "t1", "chain": "Ethereum", "status": "SIGNED"}], "total_count": 1}}`))
+		case strings.HasPrefix(r.URL.Path, "/plugin/policies/"):
+			w.Write([]byte(`{"data": {"policies": [{"id": "p1"}], "total_count": 1}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	cfg := DefaultConfig()
+	cfg.Verifier = srv.URL
+	cfg.PublicKeyECDSA = "02a1b2c3"
+	err := SaveConfig(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// countingWebhook answers the first fail posts with 500 and the rest with
+// 204, and counts all of them.
+func countingWebhook(t *testing.T, fail int32) (string, *atomic.Int32) {
+	t.Helper()
+	var posts atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if posts.Add(1) <= fail {
+			http.Error(w, "unavailable", http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(srv.Close)
+	return srv.URL, &posts
+}
+
+func TestNotifierSendRecordsDelivered(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	webhook, posts := countingWebhook(t, 1)
+	n := &notifier{webhook: webhook, seen: map[string]bool{}}
+
+	n.send("tx:t1:SIGNED", "DCA Plugin: transaction SIGNED on Ethereum")
+	events, err := LoadHistoryEvents()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n.seen["tx:t1:SIGNED"] || events["tx:t1:SIGNED"] {
+		t.Error("an event the webhook rejected was recorded")
+	}
+
+	n.send("tx:t1:SIGNED", "DCA Plugin: transaction SIGNED on Ethereum")
+	events, err = LoadHistoryEvents()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !n.seen["tx:t1:SIGNED"] || !events["tx:t1:SIGNED"] {
+		t.Error("a delivered event was not recorded")
+	}
+	if got := posts.Load(); got != 2 {
+		t.Errorf("webhook posts = %d, want 2", got)
+	}
+}
+
+func TestNotifierPollTransactions(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	notifyVerifier(t)
+	webhook, posts := countingWebhook(t, 0)
+
+	// Priming records the existing transaction without notifying.
+	n := &notifier{webhook: webhook, seen: map[string]bool{}}
+	err := n.pollTransactions(context.Background(), "Bearer token", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := posts.Load(); got != 0 {
+		t.Errorf("priming poll: %d webhook posts, want 0", got)
+	}
+
+	// A restarted daemon dedupes against the recorded events.
+	seen, err := LoadHistoryEvents()
+	if err != nil {
+		t.Fatal(err)
+	}
+	n = &notifier{webhook: webhook, seen: seen}
+	err = n.pollTransactions(context.Background(), "Bearer token", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := posts.Load(); got != 0 {
+		t.Errorf("after restart: %d webhook posts, want 0", got)
+	}
+
+	// Without the recorded events the transaction is new.
+	n = &notifier{webhook: webhook, seen: map[string]bool{}}
+	err = n.pollTransactions(context.Background(), "Bearer token", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := posts.Load(); got != 1 {
+		t.Errorf("without history: %d webhook posts, want 1", got)
+	}
+}
+
+func TestNotifierAfterGC(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	notifyVerifier(t)
+	webhook, posts := countingWebhook(t, 0)
+
+	// A year-old command and the delivery of tx:t1:SIGNED, both past the
+	// history retention.
+	old := time.Now().AddDate(-1, 0, 0).UTC().Format(time.RFC3339)
+	for _, entry := range []HistoryEntry{
+		{Time: old, Command: "devctl status", Success: true},
+		{Time: old, Command: "devctl notify daemon", Success: true, Event: "tx:t1:SIGNED"},
+	} {
+		err := appendHistoryEntry(entry)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	artifacts, err := gcLines(HistoryPath(), 90, time.Now(), historyLineTime, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(artifacts) != 1 || artifacts[0].Lines != 1 {
+		t.Fatalf("gc selected %+v, want the one command line", artifacts)
+	}
+	err = removeGCArtifact(artifacts[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	seen, err := LoadHistoryEvents()
+	if err != nil {
+		t.Fatal(err)
+	}
+	n := &notifier{webhook: webhook, seen: seen}
+	err = n.pollTransactions(context.Background(), "Bearer token", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := posts.Load(); got != 0 {
+		t.Errorf("after gc: %d webhook posts, want 0", got)
+	}
+}
@@ -0,0 +1,18 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+)
+
+// Output convention: stdout carries only a command's result (tables, JSON,
+// addresses) so it can be piped into other tools. Progress, prompts, trace
+// IDs and logs go to stderr through these helpers.
+
+func progressf(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, format, args...)
+}
+
+func progressln(args ...interface{}) {
+	fmt.Fprintln(os.Stderr, args...)
+}
@@ -22,9 +22,9 @@ func promptPassword(flagPassword string, prompt string) (string, error) {
 		return "", fmt.Errorf("password required but stdin is not a terminal. Use --password flag")
 	}
 
-	fmt.Print(prompt)
+	fmt.Fprint(os.Stderr, prompt)
 	passwordBytes, err := term.ReadPassword(int(os.Stdin.Fd()))
-	fmt.Println() // newline after password input
+	fmt.Fprintln(os.Stderr) // newline after password input
 	if err != nil {
 		return "", fmt.Errorf("failed to read password: %w", err)
 	}
@@ -32,6 +32,27 @@ func promptPassword(flagPassword string, prompt string) (string, error) {
 	return string(passwordBytes), nil
 }
 
+// promptString prompts the user for a single line of input.
+// If the flag value was provided, it returns that instead.
+func promptString(flagValue string, prompt string) (string, error) {
+	if flagValue != "" {
+		return flagValue, nil
+	}
+
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return "", fmt.Errorf("input required but stdin is not a terminal. Use the corresponding flag")
+	}
+
+	fmt.Fprint(os.Stderr, prompt)
+	reader := bufio.NewReader(os.Stdin)
+	input, err := reader.ReadString('\n')
+	if err != nil {
+		return "", fmt.Errorf("failed to read input: %w", err)
+	}
+
+	return strings.TrimSpace(input), nil
+}
+
 // promptPasswordWithConfirm prompts for password twice and confirms they match.
 func promptPasswordWithConfirm(flagPassword string) (string, error) {
 	if flagPassword != "" {
@@ -64,7 +85,7 @@ func promptYesNo(prompt string, defaultYes bool) bool {
 		suffix = " [Y/n]: "
 	}
 
-	fmt.Print(prompt + suffix)
+	fmt.Fprint(os.Stderr, prompt+suffix)
 	input, _ := reader.ReadString('\n')
 	input = strings.TrimSpace(strings.ToLower(input))
 
@@ -74,3 +95,32 @@ func promptYesNo(prompt string, defaultYes bool) bool {
 
 	return input == "y" || input == "yes"
 }
+
+// confirmDestructive asks before a destructive action. summary names the
+// concrete resource. When typed is non-empty the user must enter it verbatim
+// instead of "y". yes (from --yes) skips the prompt; without it, a
+// non-interactive stdin is an error rather than an implicit confirmation.
+func confirmDestructive(summary, typed string, yes bool) error {
+	if yes {
+		return nil
+	}
+
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return fmt.Errorf("confirmation required but stdin is not a terminal. Use --yes to proceed")
+	}
+
+	if typed == "" {
+		if !promptYesNo(summary, false) {
+			return fmt.Errorf("aborted")
+		}
+		return nil
+	}
+
+	fmt.Fprintln(os.Stderr, summary)
+	fmt.Fprintf(os.Stderr, "Type %q to confirm: ", typed)
+	input, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	if strings.TrimSpace(input) != typed {
+		return fmt.Errorf("aborted")
+	}
+	return nil
+}
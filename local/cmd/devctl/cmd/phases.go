@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/vultisig/vultisig-cluster/local/cmd/devctl/format"
+)
+
+const defaultPhaseWarnThreshold = 60 * time.Second
+
+// PhaseTiming is one timed step of a long-running command.
+type PhaseTiming struct {
+	Name       string    `json:"name"`
+	StartedAt  time.Time `json:"started_at"`
+	EndedAt    time.Time `json:"ended_at"`
+	DurationMs int64     `json:"duration_ms"`
+}
+
+func (p PhaseTiming) Duration() time.Duration {
+	return p.EndedAt.Sub(p.StartedAt)
+}
+
+// PhaseTimings collects the phases of a plugin install or policy create so
+// the report can show where the time went. A nil *PhaseTimings is valid and
+// records nothing, so TSS code can time phases unconditionally.
+type PhaseTimings struct {
+	Command string        `json:"command"`
+	TraceID string        `json:"trace_id"`
+	Phases  []PhaseTiming `json:"phases"`
+	TotalMs int64         `json:"total_ms"`
+	Relay   *RelaySummary `json:"relay,omitempty"`
+}
+
+func NewPhaseTimings(command string) *PhaseTimings {
+	return &PhaseTimings{Command: command, TraceID: TraceID()}
+}
+
+// Start begins a phase and returns the function that ends it.
+func (t *PhaseTimings) Start(name string) func() {
+	if t == nil {
+		return func() {}
+	}
+	started := time.Now()
+	return func() {
+		ended := time.Now()
+		t.Phases = append(t.Phases, PhaseTiming{
+			Name:       name,
+			StartedAt:  started,
+			EndedAt:    ended,
+			DurationMs: ended.Sub(started).Milliseconds(),
+		})
+	}
+}
+
+// Finish stamps the total time, warns about slow phases and appends the
+// timings to the local metrics file.
+func (t *PhaseTimings) Finish(total time.Duration) {
+	if t == nil {
+		return
+	}
+	t.TotalMs = total.Milliseconds()
+
+	threshold := phaseWarnThreshold()
+	for _, p := range t.Phases {
+		if p.Duration() > threshold {
+			progressf("%s Phase %q took %s (threshold %s)\n", warnMark(), p.Name, format.Duration(p.Duration()), threshold)
+		}
+	}
+
+	err := appendMetrics(t)
+	if err != nil {
+		progressf("Warning: could not write metrics: %v\n", err)
+	}
+}
+
+// printPhaseRows renders the breakdown inside a 67-column report box.
+func (t *PhaseTimings) printPhaseRows() {
+	fmt.Println("│  Phases:                                                        │")
+	for _, p := range t.Phases {
+		fmt.Printf("│    %-30s %-30s │\n", p.Name, format.Duration(p.Duration()))
+	}
+	if t.Relay != nil {
+		fmt.Printf("│    %-61s │\n", t.Relay.String())
+	}
+}
+
+// phaseWarnThreshold reads phase_warn_threshold from devctl.json (or
+// VCLI_PHASE_WARN_THRESHOLD). Unset or invalid values fall back to 60s.
+func phaseWarnThreshold() time.Duration {
+	cfg, err := LoadConfig()
+	if err != nil || cfg.PhaseWarnThreshold == "" {
+		return defaultPhaseWarnThreshold
+	}
+	d, err := time.ParseDuration(cfg.PhaseWarnThreshold)
+	if err != nil || d <= 0 {
+		return defaultPhaseWarnThreshold
+	}
+	return d
+}
+
+func MetricsPath() string {
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".vultisig", "metrics.jsonl")
+}
+
+func appendMetrics(t *PhaseTimings) error {
+	path := MetricsPath()
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return fmt.Errorf("create metrics dir: %w", err)
+	}
+
+	data, err := json.Marshal(t)
+	if err != nil {
+		return fmt.Errorf("marshal metrics: %w", err)
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("open metrics: %w", err)
+	}
+	defer f.Close()
+
+	_, err = f.Write(append(data, '\n'))
+	if err != nil {
+		return fmt.Errorf("write metrics: %w", err)
+	}
+	return nil
+}
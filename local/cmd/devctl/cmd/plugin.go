@@ -13,6 +13,9 @@ import (
 	"time"
 
 	"github.com/spf13/cobra"
+
+	"github.com/vultisig/vultisig-cluster/local/cmd/devctl/client"
+	"github.com/vultisig/vultisig-cluster/local/cmd/devctl/format"
 )
 
 func NewPluginCmd() *cobra.Command {
@@ -25,6 +28,8 @@ func NewPluginCmd() *cobra.Command {
 	cmd.AddCommand(newPluginInfoCmd())
 	cmd.AddCommand(newPluginInstallCmd())
 	cmd.AddCommand(newPluginUninstallCmd())
+	cmd.AddCommand(newPluginInstalledCmd())
+	cmd.AddCommand(newPluginResetCmd())
 	cmd.AddCommand(newPluginSpecCmd())
 
 	return cmd
@@ -35,7 +40,7 @@ func newPluginListCmd() *cobra.Command {
 		Use:   "list",
 		Short: "List available plugins",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runPluginList()
+			return runPluginList(cmd.Context())
 		},
 	}
 }
@@ -46,13 +51,15 @@ func newPluginInfoCmd() *cobra.Command {
 		Short: "Show plugin details",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runPluginInfo(args[0])
+			return runPluginInfo(cmd.Context(), args[0])
 		},
 	}
 }
 
 func newPluginInstallCmd() *cobra.Command {
 	var password string
+	var dryRun bool
+	var output string
 
 	cmd := &cobra.Command{
 		Use:   "install [plugin-id]",
@@ -66,6 +73,12 @@ This will:
 
 After installation, you can create policies for the plugin.
 
+The report breaks the total time down by phase (Fast Vault request, party
+join, reshare rounds, keyshare upload per bucket). --output json prints the same
+report as JSON. Phases slower than phase_warn_threshold in devctl.json
+(default 60s) are flagged, and every run is appended to
+~/.vultisig/metrics.jsonl.
+
 Environment variables:
   VAULT_PASSWORD  - Fast Vault password
 
@@ -73,6 +86,12 @@ Note: Requires authentication. Run 'devctl vault import' first.
 `,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if output != "table" && output != "json" {
+				return fmt.Errorf("unknown output format %q (use table or json)", output)
+			}
+			if dryRun {
+				return runPluginInstallDryRun(cmd.Context(), args[0])
+			}
 			actualPassword := password
 			if envPass := os.Getenv("VAULT_PASSWORD"); envPass != "" {
 				actualPassword = envPass
@@ -84,143 +103,218 @@ Note: Requires authentication. Run 'devctl vault import' first.
 					return err
 				}
 			}
-			return runPluginInstall(args[0], actualPassword)
+			return runPluginInstall(cmd.Context(), args[0], actualPassword, output)
 		},
 	}
 
 	cmd.Flags().StringVarP(&password, "password", "p", "", "Fast Vault password (or set VAULT_PASSWORD env var)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would be done without executing")
+	cmd.Flags().StringVarP(&output, "output", "o", "table", "Report format: table or json")
 
 	return cmd
 }
 
 func newPluginUninstallCmd() *cobra.Command {
-	return &cobra.Command{
+	var dryRun bool
+	var yes bool
+	var purge bool
+	var password string
+
+	cmd := &cobra.Command{
 		Use:   "uninstall [plugin-id]",
 		Short: "Uninstall a plugin",
-		Args:  cobra.ExactArgs(1),
+		Long: `Uninstall a plugin: remove its MinIO keyshares and installation record.
+
+The vault's policies for the plugin are left on the verifier and the plugin
+keeps trying to execute them; uninstall lists them as orphaned. With --purge,
+each policy is first deleted through the signed deletion flow (one Fast Vault
+keysign per policy) and its scheduler rows are removed from the plugin
+database.
+
+Environment variables:
+  VAULT_PASSWORD  - Fast Vault password (--purge only)
+`,
+		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runPluginUninstall(args[0])
+			if dryRun {
+				return runPluginUninstallDryRun(cmd.Context(), args[0], purge)
+			}
+			actualPassword := password
+			if envPass := os.Getenv("VAULT_PASSWORD"); envPass != "" {
+				actualPassword = envPass
+			}
+			if purge && actualPassword == "" {
+				var err error
+				actualPassword, err = promptPassword("", "Enter Fast Vault password: ")
+				if err != nil {
+					return err
+				}
+			}
+			return runPluginUninstall(cmd.Context(), args[0], yes, purge, actualPassword)
 		},
 	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would be removed without executing")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Skip the confirmation prompt")
+	cmd.Flags().BoolVar(&purge, "purge", false, "Also delete the vault's policies for the plugin and their scheduler rows")
+	cmd.Flags().StringVarP(&password, "password", "p", "", "Fast Vault password for --purge (or set VAULT_PASSWORD env var)")
+
+	return cmd
 }
 
 func newPluginSpecCmd() *cobra.Command {
-	return &cobra.Command{
+	var example bool
+
+	cmd := &cobra.Command{
 		Use:   "spec [plugin-id]",
 		Short: "Show plugin recipe specification",
-		Args:  cobra.ExactArgs(1),
+		Long: `Show a plugin's recipe specification as served by the verifier.
+
+--example prints a minimal valid 'policy create' config for the plugin instead,
+built from the specification: the plugin's own configuration example if it
+has one, else the required fields with their defaults. The recipe fields,
+their types, allowed values and descriptions are listed on stderr, so the
+example can be redirected to a file.
+
+The specification is cached in ~/.vultisig/cache for 10 minutes. When the
+verifier cannot be reached an older cached copy is used, and --example falls
+back to the static DCA example from 'policy create --help'.
+
+Example:
+  devctl plugin spec vultisig-dca-0000
+  devctl plugin spec vultisig-recurring-sends-0000 --example > policy.json
+`,
+		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runPluginSpec(args[0])
+			return runPluginSpec(cmd.Context(), args[0], example)
 		},
 	}
+
+	cmd.Flags().BoolVar(&example, "example", false, "Print an example policy config generated from the specification")
+	return cmd
 }
 
-func runPluginList() error {
+func runPluginList(ctx context.Context) error {
 	cfg, err := LoadConfig()
 	if err != nil {
 		return fmt.Errorf("load config: %w", err)
 	}
 
-	fmt.Println("Fetching available plugins...")
+	progressln("Fetching available plugins...")
 
 	url := cfg.Verifier + "/plugins"
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
-	req, _ := http.NewRequestWithContext(ctx, "GET", url, nil)
-	resp, err := http.DefaultClient.Do(req)
+	list, err := getAPI[client.PluginList](ctx, url, "")
 	if err != nil {
-		return fmt.Errorf("request failed: %w", err)
+		return err
 	}
-	defer resp.Body.Close()
-
-	body, _ := io.ReadAll(resp.Body)
-
-	var result map[string]interface{}
-	json.Unmarshal(body, &result)
 
-	if data, ok := result["data"].(map[string]interface{}); ok {
-		if plugins, ok := data["plugins"].([]interface{}); ok {
-			fmt.Printf("\nAvailable Plugins (%d):\n\n", len(plugins))
-			for _, p := range plugins {
-				plugin := p.(map[string]interface{})
-				fmt.Printf("  %s\n", plugin["id"])
-				fmt.Printf("    Name: %s\n", plugin["title"])
-				if desc, ok := plugin["description"].(string); ok && desc != "" {
-					fmt.Printf("    Description: %s\n", desc)
-				}
-				fmt.Println()
-			}
-			return nil
+	fmt.Printf("\nAvailable Plugins (%d):\n\n", len(list.Plugins))
+	for _, plugin := range list.Plugins {
+		fmt.Printf("  %s\n", plugin.ID)
+		fmt.Printf("    Name: %s\n", plugin.Title)
+		if plugin.Description != "" {
+			fmt.Printf("    Description: %s\n", plugin.Description)
 		}
+		fmt.Println()
 	}
 
-	prettyJSON, _ := json.MarshalIndent(result, "", "  ")
-	fmt.Println(string(prettyJSON))
-
 	return nil
 }
 
-func runPluginInfo(pluginID string) error {
+func runPluginInfo(ctx context.Context, pluginID string) error {
 	cfg, err := LoadConfig()
 	if err != nil {
 		return fmt.Errorf("load config: %w", err)
 	}
 
-	fmt.Printf("Fetching plugin info for %s...\n\n", pluginID)
+	progressf("Fetching plugin info for %s...\n\n", pluginID)
 
 	url := fmt.Sprintf("%s/plugins/%s", cfg.Verifier, pluginID)
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
-	req, _ := http.NewRequestWithContext(ctx, "GET", url, nil)
-	resp, err := http.DefaultClient.Do(req)
+	plugin, err := getAPI[client.Plugin](ctx, url, "")
 	if err != nil {
-		return fmt.Errorf("request failed: %w", err)
+		return err
 	}
-	defer resp.Body.Close()
-
-	body, _ := io.ReadAll(resp.Body)
 
-	var result map[string]interface{}
-	json.Unmarshal(body, &result)
-
-	prettyJSON, _ := json.MarshalIndent(result, "", "  ")
-	fmt.Println(string(prettyJSON))
+	fmt.Printf("  ID:             %s\n", plugin.ID)
+	fmt.Printf("  Name:           %s\n", plugin.Title)
+	if plugin.Description != "" {
+		fmt.Printf("  Description:    %s\n", plugin.Description)
+	}
+	fmt.Printf("  Server:         %s\n", plugin.ServerEndpoint)
+	fmt.Printf("  Category:       %s\n", plugin.Category)
+	fmt.Printf("  Audited:        %v\n", plugin.Audited)
+	fmt.Printf("  Installations:  %d\n", plugin.Installations)
+	if plugin.RatesCount > 0 {
+		fmt.Printf("  Rating:         %.1f (%d ratings)\n", plugin.AvgRating, plugin.RatesCount)
+	}
+	fmt.Printf("  Pricing Plans:  %d\n", len(plugin.Pricing))
+	for _, feature := range plugin.Features {
+		fmt.Printf("  - %s\n", feature)
+	}
+	fmt.Printf("  Updated:        %s\n", plugin.UpdatedAt.Format(time.RFC3339))
 
 	return nil
 }
 
-func runPluginInstall(pluginID string, password string) error {
+// PluginInstallReport is the JSON form of the 'plugin install' report.
+type PluginInstallReport struct {
+	PluginID         string        `json:"plugin_id"`
+	Vault            string        `json:"vault"`
+	Fingerprint      string        `json:"fingerprint"`
+	Signers          []string      `json:"signers"`
+	VerifierKeyshare string        `json:"verifier_keyshare,omitempty"`
+	PluginKeyshare   string        `json:"plugin_keyshare,omitempty"`
+	InstalledAt      string        `json:"installed_at,omitempty"`
+	Timings          *PhaseTimings `json:"timings"`
+}
+
+func runPluginInstall(ctx context.Context, pluginID string, password string, output string) error {
 	startTime := time.Now()
+	timings := NewPhaseTimings("plugin install")
 
 	cfg, err := LoadConfig()
 	if err != nil {
 		return fmt.Errorf("load config: %w", err)
 	}
 
-	authHeader, err := GetAuthHeader()
-	if err != nil {
-		return fmt.Errorf("authentication required: %w\n\nRun 'devctl vault import --password xxx' to authenticate first", err)
-	}
-
 	vaults, err := ListVaults()
 	if err != nil || len(vaults) == 0 {
 		return fmt.Errorf("no vaults found. Import a vault first: devctl vault import")
 	}
 	vault := vaults[0]
 
-	fmt.Printf("Installing plugin %s...\n", pluginID)
-	fmt.Printf("  Vault: %s (%s...)\n", vault.Name, vault.PublicKeyECDSA[:16])
-	fmt.Printf("  Verifier: %s\n", cfg.Verifier)
+	err = requireDKLS(vault, "plugin install")
+	if err != nil {
+		return err
+	}
 
-	isFastVault, err := CheckFastVaultExists(vault.PublicKeyECDSA)
+	authHeader, err := requireAuth(ctx, cfg.Verifier, "")
 	if err != nil {
-		fmt.Printf("  Warning: Could not check Fast Vault Server: %v\n", err)
-	} else if !isFastVault {
-		return fmt.Errorf("vault is not a Fast Vault. Plugin reshare requires a vault created with Fast Vault feature")
+		return err
+	}
+
+	progressf("Installing plugin %s...\n", pluginID)
+	progressf("  Vault: %s (%s, %s..., %s)\n", vault.Name, VaultFingerprint(vault.PublicKeyECDSA), vault.PublicKeyECDSA[:16], vault.LibType)
+	progressf("  Verifier: %s\n", cfg.Verifier)
+	printTraceID()
+
+	if mockTSSEnabled() {
+		progressln("  Fast Vault: skipped (mock TSS backend)")
 	} else {
-		fmt.Println("  Fast Vault: Yes")
+		isFastVault, err := CheckFastVaultExists(vault.PublicKeyECDSA)
+		if err != nil {
+			progressf("  Warning: Could not check Fast Vault Server: %v\n", err)
+		} else if !isFastVault {
+			return fmt.Errorf("vault not found on Fast Vault Server %s. Plugin reshare requires a vault created with Fast Vault feature", fastVaultURL())
+		} else {
+			progressln("  Fast Vault: Yes")
+		}
 	}
 
 	if password == "" {
@@ -236,12 +330,13 @@ func runPluginInstall(pluginID string, password string) error {
 		return nil
 	}
 
-	fmt.Println("\nChecking plugin availability...")
+	progressln("\nChecking plugin availability...")
+	endPhase := timings.Start("Plugin check")
 	pluginURL := fmt.Sprintf("%s/plugins/%s", cfg.Verifier, pluginID)
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	checkCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	req, _ := http.NewRequestWithContext(ctx, "GET", pluginURL, nil)
+	req, _ := http.NewRequestWithContext(checkCtx, "GET", pluginURL, nil)
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
 		return fmt.Errorf("check plugin: %w", err)
@@ -250,18 +345,20 @@ func runPluginInstall(pluginID string, password string) error {
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("plugin not found: %s", string(body))
+		return fmt.Errorf("plugin not found: %s", errorBody(body))
 	}
+	endPhase()
 
-	fmt.Println("  Plugin found!")
+	progressln("  Plugin found!")
 
-	fmt.Println("\nInitiating 4-party TSS reshare...")
-	fmt.Println("  Parties: CLI + Fast Vault Server + Verifier + Plugin")
+	progressln("\nInitiating 4-party TSS reshare...")
+	progressln("  Parties: CLI + Fast Vault Server + Verifier + Plugin")
 
 	tss := NewTSSService(vault.LocalPartyID)
+	tss.phases = timings
 
 	reshareStart := time.Now()
-	reshareCtx, reshareCancel := context.WithTimeout(context.Background(), 3*time.Minute)
+	reshareCtx, reshareCancel := context.WithTimeout(ctx, 3*time.Minute)
 	defer reshareCancel()
 
 	newVault, err := tss.ReshareWithDKLS(reshareCtx, vault, pluginID, cfg.Verifier, authHeader, password)
@@ -275,18 +372,58 @@ func runPluginInstall(pluginID string, password string) error {
 		return fmt.Errorf("save vault: %w", err)
 	}
 
-	totalDuration := time.Since(startTime)
-
-	// Wait for workers to upload keyshares to MinIO
-	fmt.Println("\nWaiting for keyshare uploads...")
-	time.Sleep(3 * time.Second)
-
-	// Validate storage - check MinIO buckets (with retry)
-	verifierFile, verifierSize := checkMinioFileWithRetry("vultisig-verifier", pluginID, vault.PublicKeyECDSA, 3)
-	dcaFile, dcaSize := checkMinioFileWithRetry("vultisig-dca", pluginID, vault.PublicKeyECDSA, 3)
+	// Wait for the verifier and plugin workers to store their keyshares
+	uploadTimeout := keyshareUploadTimeout(cfg)
+	progressf("\nWaiting for keyshare uploads (up to %s)...\n", uploadTimeout)
+	minioClient, err := newMinioClient(cfg)
+	if err != nil {
+		return err
+	}
+	spec := pluginSpecFor(pluginID)
+	uploads, err := waitForKeyshareUploads(ctx, minioClient, []string{cfg.VerifierBucket, spec.Bucket},
+		keyshareObjectKey(pluginID, vault.PublicKeyECDSA), uploadTimeout, timings)
+	if err != nil {
+		return fmt.Errorf("reshare completed but %w", err)
+	}
+	var verifierFile, verifierSize, pluginFile, pluginSize string
+	for _, u := range uploads {
+		if u.Bucket == spec.Bucket {
+			pluginFile, pluginSize = u.Key, format.Bytes(u.Size)
+		} else {
+			verifierFile, verifierSize = u.Key, format.Bytes(u.Size)
+		}
+	}
 
 	// Check database record
 	dbRecord = checkPluginInstallation(pluginID, vault.PublicKeyECDSA)
+	recordVaultUsage(vault, cfg, vaultUsageInstall)
+
+	totalDuration := time.Since(startTime)
+	timings.Relay = tss.RelaySummary()
+	timings.Finish(totalDuration)
+
+	if output == "json" {
+		report := PluginInstallReport{
+			PluginID:    pluginID,
+			Vault:       vault.Name,
+			Fingerprint: VaultFingerprint(vault.PublicKeyECDSA),
+			Signers:     newVault.Signers,
+			InstalledAt: dbRecord,
+			Timings:     timings,
+		}
+		if verifierFile != "" {
+			report.VerifierKeyshare = verifierSize
+		}
+		if pluginFile != "" {
+			report.PluginKeyshare = pluginSize
+		}
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal report: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
 
 	// Print completion report
 	fmt.Println()
@@ -294,6 +431,8 @@ func runPluginInstall(pluginID string, password string) error {
 	fmt.Println("│ PLUGIN INSTALL COMPLETE                                         │")
 	fmt.Println("├─────────────────────────────────────────────────────────────────┤")
 	fmt.Println("│                                                                 │")
+	fmt.Printf("│  Vault:       %-50s │\n", fmt.Sprintf("%s (%s)", vault.Name, VaultFingerprint(vault.PublicKeyECDSA)))
+	fmt.Println("│                                                                 │")
 	fmt.Println("│  TSS Reshare:                                                   │")
 	fmt.Printf("│    Parties:   %-50s │\n", fmt.Sprintf("%d (2→4 threshold)", len(newVault.Signers)))
 	for i, signer := range newVault.Signers {
@@ -304,28 +443,30 @@ func runPluginInstall(pluginID string, password string) error {
 		}
 		fmt.Printf("│      %d. %-27s %-17s │\n", i+1, signerDisplay, role)
 	}
-	fmt.Printf("│    Duration: %-50s │\n", reshareDuration.Round(time.Millisecond).String())
+	fmt.Printf("│    Duration: %-50s │\n", format.Duration(reshareDuration))
 	fmt.Println("│                                                                 │")
 	fmt.Println("│  Keyshares Stored:                                              │")
 	if verifierFile != "" {
-		fmt.Printf("│    Verifier (MinIO): ✓ %-41s │\n", verifierSize)
+		fmt.Printf("│    Verifier (MinIO): %s %-41s │\n", symOK, verifierSize)
 	} else {
-		fmt.Printf("│    Verifier (MinIO): ✗ %-41s │\n", "Not found")
+		fmt.Printf("│    Verifier (MinIO): %s %-41s │\n", symFail, "Not found")
 	}
-	if dcaFile != "" {
-		fmt.Printf("│    DCA Plugin (MinIO): ✓ %-39s │\n", dcaSize)
+	pluginLabel := fmt.Sprintf("%-21s", spec.Name+" (MinIO):")
+	if pluginFile != "" {
+		fmt.Printf("│    %s %s %-37s │\n", pluginLabel, symOK, pluginSize)
 	} else {
-		fmt.Printf("│    DCA Plugin (MinIO): ✗ %-39s │\n", "Not found")
+		fmt.Printf("│    %s %s %-37s │\n", pluginLabel, symFail, "Not found")
 	}
 	fmt.Println("│                                                                 │")
 	fmt.Println("│  Database:                                                      │")
 	if dbRecord != "" {
-		fmt.Printf("│    plugin_installations: ✓ %-37s │\n", dbRecord)
+		fmt.Printf("│    plugin_installations: %s %-37s │\n", symOK, dbRecord)
 	} else {
-		fmt.Printf("│    plugin_installations: ✗ %-37s │\n", "Not found")
+		fmt.Printf("│    plugin_installations: %s %-37s │\n", symFail, "Not found")
 	}
 	fmt.Println("│                                                                 │")
-	fmt.Printf("│  Total Time: %-51s │\n", totalDuration.Round(time.Millisecond).String())
+	timings.printPhaseRows()
+	fmt.Printf("│  Total Time: %-51s │\n", format.Duration(totalDuration))
 	fmt.Println("│                                                                 │")
 	fmt.Println("└─────────────────────────────────────────────────────────────────┘")
 	fmt.Println()
@@ -334,33 +475,129 @@ func runPluginInstall(pluginID string, password string) error {
 	return nil
 }
 
-func getSignerRole(signer, localPartyID string) string {
-	if signer == localPartyID {
-		return "(CLI)"
+// runPluginInstallDryRun reports what an install would do. It only performs
+// read-only checks: no reshare is requested and no TSS session is registered.
+func runPluginInstallDryRun(ctx context.Context, pluginID string) error {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	vaults, err := ListVaults()
+	if err != nil || len(vaults) == 0 {
+		return fmt.Errorf("no vaults found. Import a vault first: devctl vault import")
+	}
+	vault := vaults[0]
+
+	fmt.Println("=== Plugin Install (Dry Run) ===")
+	fmt.Printf("Plugin: %s\n", pluginID)
+	fmt.Printf("Vault: %s (%s...)\n", vault.Name, vault.PublicKeyECDSA[:16])
+	fmt.Printf("Verifier: %s\n", cfg.Verifier)
+	fmt.Println()
+
+	_, err = GetAuthHeader()
+	if err != nil {
+		fmt.Printf("  Auth: %s %v\n", symFail, err)
+	} else {
+		fmt.Printf("  Auth: %s\n", symOK)
 	}
-	if strings.HasPrefix(signer, "Server-") {
-		return "(Fast Vault Server)"
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/plugins/%s", cfg.Verifier, pluginID), nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		fmt.Printf("  Plugin: %s %v\n", symFail, err)
+	} else {
+		resp.Body.Close()
+		if resp.StatusCode == http.StatusOK {
+			fmt.Printf("  Plugin: %s found\n", symOK)
+		} else {
+			fmt.Printf("  Plugin: %s not found (%d)\n", symFail, resp.StatusCode)
+		}
 	}
-	if strings.HasPrefix(signer, "verifier-") {
-		return "(Verifier)"
+
+	if dbRecord := checkPluginInstallation(pluginID, vault.PublicKeyECDSA); dbRecord != "" {
+		fmt.Printf("  Already installed at %s - install would stop here.\n", dbRecord)
 	}
-	if strings.HasPrefix(signer, "dca-worker-") {
-		return "(DCA Plugin)"
+
+	fmt.Println()
+	fmt.Println("Current signers:")
+	for i, signer := range vault.Signers {
+		fmt.Printf("  %d. %s %s\n", i+1, signer, getSignerRole(signer, vault.LocalPartyID))
 	}
-	return ""
+	fmt.Println("Would add:")
+	fmt.Println("  - verifier-<session> (Verifier)")
+	fmt.Printf("  - %s worker (Plugin)\n", pluginID)
+	fmt.Println()
+	fmt.Println("Would call:")
+	fmt.Printf("  1. POST %s/vault/reshare (Fast Vault Server)\n", FastVaultServer)
+	fmt.Printf("  2. POST %s/vault/reshare (Verifier, notifies plugin)\n", cfg.Verifier)
+	fmt.Printf("  3. Relay session on %s\n", RelayServer)
+	fmt.Println()
+	fmt.Println("Expected artifacts:")
+	fmt.Printf("  MinIO:    vultisig-verifier/%s-%s.vult\n", pluginID, vault.PublicKeyECDSA)
+	fmt.Printf("  MinIO:    %s/%s-%s.vult\n", pluginSpecFor(pluginID).Bucket, pluginID, vault.PublicKeyECDSA)
+	fmt.Printf("  Postgres: vultisig-verifier.plugin_installations (plugin_id=%s)\n", pluginID)
+	fmt.Printf("  Local:    %s updated with new keyshares\n", VaultStoragePath())
+	fmt.Println()
+	fmt.Println("Run without --dry-run to execute.")
+
+	return nil
 }
 
-func checkMinioFileWithRetry(bucket, pluginID, publicKey string, maxRetries int) (string, string) {
-	for i := 0; i < maxRetries; i++ {
-		file, size := checkMinioFile(bucket, pluginID, publicKey)
-		if file != "" {
-			return file, size
-		}
-		if i < maxRetries-1 {
-			time.Sleep(time.Second)
+// runPluginUninstallDryRun lists the objects and rows an uninstall would
+// delete without touching them.
+func runPluginUninstallDryRun(ctx context.Context, pluginID string, purge bool) error {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	if cfg.PublicKeyECDSA == "" {
+		return fmt.Errorf("no vault configured. Run 'devctl vault import' first")
+	}
+
+	fmt.Println("=== Plugin Uninstall (Dry Run) ===")
+	fmt.Printf("Plugin: %s\n", pluginID)
+	fmt.Printf("Vault: %s...\n", cfg.PublicKeyECDSA[:16])
+	fmt.Println()
+
+	dbRecord := checkPluginInstallation(pluginID, cfg.PublicKeyECDSA)
+	verifierFile, verifierSize := checkMinioFile(cfg.VerifierBucket, pluginID, cfg.PublicKeyECDSA)
+	spec := pluginSpecFor(pluginID)
+	pluginFile, pluginSize := checkMinioFile(spec.Bucket, pluginID, cfg.PublicKeyECDSA)
+
+	if dbRecord == "" && verifierFile == "" && pluginFile == "" {
+		fmt.Println("Plugin is not installed for this vault. Nothing would be removed.")
+		return nil
+	}
+
+	fmt.Println("Would remove:")
+	if verifierFile != "" {
+		fmt.Printf("  MinIO:    vultisig-verifier/%s (%s)\n", verifierFile, verifierSize)
+	}
+	if pluginFile != "" {
+		fmt.Printf("  MinIO:    %s/%s (%s)\n", spec.Bucket, pluginFile, pluginSize)
+	}
+	if dbRecord != "" {
+		fmt.Printf("  Postgres: plugin_installations row (plugin_id=%s, installed %s)\n", pluginID, dbRecord)
+	}
+	policies := pluginPoliciesForUninstall(ctx, cfg, pluginID)
+	for _, policy := range policies {
+		if purge {
+			fmt.Printf("  Policy:   %s (signed delete + %s scheduler rows)\n", policy.ID, spec.Database)
 		}
 	}
-	return "", ""
+	if !purge {
+		printOrphanedPolicies(policies)
+	}
+	fmt.Println()
+	fmt.Println("Reshare back: no (local vault keeps its current keyshares)")
+	fmt.Println()
+	fmt.Println("Run without --dry-run to execute.")
+
+	return nil
 }
 
 func checkMinioFile(bucket, pluginID, publicKey string) (string, string) {
@@ -380,22 +617,12 @@ func checkMinioFile(bucket, pluginID, publicKey string) (string, string) {
 	json.Unmarshal(output, &obj)
 
 	if obj.Key != "" {
-		size := formatBytesShort(obj.Size)
+		size := format.Bytes(obj.Size)
 		return obj.Key, size
 	}
 	return "", ""
 }
 
-func formatBytesShort(bytes int64) string {
-	if bytes < 1024 {
-		return fmt.Sprintf("%dB", bytes)
-	}
-	if bytes < 1024*1024 {
-		return fmt.Sprintf("%.1fKB", float64(bytes)/1024)
-	}
-	return fmt.Sprintf("%.1fMB", float64(bytes)/(1024*1024))
-}
-
 func checkPluginInstallation(pluginID, publicKey string) string {
 	cmd := exec.Command("docker", "exec", "vultisig-postgres",
 		"psql", "-U", "vultisig", "-d", "vultisig-verifier", "-t", "-c",
@@ -418,7 +645,81 @@ func checkPluginInstallation(pluginID, publicKey string) string {
 	return t.Format("2006-01-02 15:04:05")
 }
 
-func runPluginUninstall(pluginID string) error {
+// pluginPoliciesForUninstall lists the vault's policies for pluginID. It is
+// best effort: without a token or verifier the list is empty and a warning
+// says the policies were not checked.
+func pluginPoliciesForUninstall(ctx context.Context, cfg *DevConfig, pluginID string) []client.Policy {
+	authHeader, err := GetAuthHeader()
+	if err != nil {
+		progressf("%s Could not check for policies (not authenticated): %v\n", warnMark(), err)
+		return nil
+	}
+	policies, err := listVaultPolicies(ctx, cfg.Verifier, authHeader, pluginID, cfg.PublicKeyECDSA)
+	if err != nil {
+		progressf("%s Could not check for policies: %v\n", warnMark(), err)
+		return nil
+	}
+	return policies
+}
+
+func printOrphanedPolicies(policies []client.Policy) {
+	if len(policies) == 0 {
+		return
+	}
+	fmt.Printf("\n%s %d policies will be orphaned (the plugin keeps trying to run them):\n", warnMark(), len(policies))
+	for _, policy := range policies {
+		state := "active"
+		if !policy.Active {
+			state = "inactive"
+		}
+		fmt.Printf("    %s (%s)\n", policy.ID, state)
+	}
+	fmt.Println("  Re-run with --purge to delete them, or 'devctl policy delete <id>' each.")
+}
+
+// PolicyPurgeResult is the outcome of purging one policy on uninstall.
+type PolicyPurgeResult struct {
+	PolicyID         string
+	Deleted          bool
+	SchedulerCleared bool
+	Err              error
+}
+
+// purgePluginPolicies deletes each policy through the signed flow and clears
+// its scheduler rows. Failures are recorded per policy rather than aborting.
+func purgePluginPolicies(ctx context.Context, cfg *DevConfig, spec PluginSpec, policies []client.Policy, password string) ([]PolicyPurgeResult, error) {
+	authHeader, err := requireAuth(ctx, cfg.Verifier, "")
+	if err != nil {
+		return nil, err
+	}
+	vault, err := LoadVault(cfg.PublicKeyECDSA)
+	if err != nil {
+		return nil, fmt.Errorf("load vault: %w", err)
+	}
+
+	var results []PolicyPurgeResult
+	for _, policy := range policies {
+		progressf("  Deleting policy %s...\n", policy.ID)
+		result := PolicyPurgeResult{PolicyID: policy.ID}
+
+		tss := NewTSSService(vault.LocalPartyID)
+		signCtx, cancel := context.WithTimeout(ctx, 90*time.Second)
+		result.Err = deletePolicySigned(signCtx, cfg.Verifier, authHeader, tss, vault, policy, password)
+		cancel()
+		result.Deleted = result.Err == nil
+
+		err = removeSchedulerRows(spec, policy.ID)
+		if err != nil && result.Err == nil {
+			result.Err = err
+		}
+		result.SchedulerCleared = err == nil
+
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+func runPluginUninstall(ctx context.Context, pluginID string, yes, purge bool, password string) error {
 	startTime := time.Now()
 
 	cfg, err := LoadConfig()
@@ -430,24 +731,52 @@ func runPluginUninstall(pluginID string) error {
 		return fmt.Errorf("no vault configured. Run 'devctl vault import' first")
 	}
 
-	fmt.Printf("Uninstalling plugin %s...\n", pluginID)
+	progressf("Uninstalling plugin %s...\n", pluginID)
 	fmt.Printf("  Vault: %s\n", cfg.PublicKeyECDSA[:16]+"...")
 
 	// Check current installation status
 	dbRecord := checkPluginInstallation(pluginID, cfg.PublicKeyECDSA)
-	verifierFile, _ := checkMinioFile("vultisig-verifier", pluginID, cfg.PublicKeyECDSA)
-	dcaFile, _ := checkMinioFile("vultisig-dca", pluginID, cfg.PublicKeyECDSA)
+	verifierFile, _ := checkMinioFile(cfg.VerifierBucket, pluginID, cfg.PublicKeyECDSA)
+	spec := pluginSpecFor(pluginID)
+	pluginFile, _ := checkMinioFile(spec.Bucket, pluginID, cfg.PublicKeyECDSA)
+
+	policies := pluginPoliciesForUninstall(ctx, cfg, pluginID)
 
-	if dbRecord == "" && verifierFile == "" && dcaFile == "" {
+	if dbRecord == "" && verifierFile == "" && pluginFile == "" && (!purge || len(policies) == 0) {
 		fmt.Println("\n  Plugin is not installed for this vault.")
 		return nil
 	}
 
-	fmt.Println("\nRemoving plugin data...")
+	if !purge {
+		printOrphanedPolicies(policies)
+	}
+
+	question := fmt.Sprintf("Uninstall plugin %s from vault %s... (removes its MinIO keyshares and installation record)?",
+		pluginID, cfg.PublicKeyECDSA[:16])
+	if purge && len(policies) > 0 {
+		question = fmt.Sprintf("Uninstall plugin %s from vault %s... and delete its %d policies?",
+			pluginID, cfg.PublicKeyECDSA[:16], len(policies))
+	}
+	fmt.Println()
+	err = confirmDestructive(question, "", yes)
+	if err != nil {
+		return err
+	}
+
+	var purged []PolicyPurgeResult
+	if purge && len(policies) > 0 {
+		progressf("\nPurging %d policies...\n", len(policies))
+		purged, err = purgePluginPolicies(ctx, cfg, spec, policies, password)
+		if err != nil {
+			return err
+		}
+	}
+
+	progressln("\nRemoving plugin data...")
 
 	// Remove MinIO files (verifier + plugin 2-of-4 shares)
-	verifierRemoved := removeMinioFile("vultisig-verifier", pluginID, cfg.PublicKeyECDSA)
-	dcaRemoved := removeMinioFile("vultisig-dca", pluginID, cfg.PublicKeyECDSA)
+	verifierRemoved := removeMinioFile(cfg.VerifierBucket, pluginID, cfg.PublicKeyECDSA)
+	pluginRemoved := removeMinioFile(spec.Bucket, pluginID, cfg.PublicKeyECDSA)
 
 	// Remove database record
 	dbRemoved := removePluginInstallation(pluginID, cfg.PublicKeyECDSA)
@@ -465,31 +794,55 @@ func runPluginUninstall(pluginID string) error {
 	fmt.Println("│                                                                 │")
 	fmt.Println("│  Removed:                                                       │")
 	if verifierRemoved {
-		fmt.Printf("│    Verifier keyshare (MinIO): ✓ %-32s │\n", "Deleted")
+		fmt.Printf("│    Verifier keyshare (MinIO): %s %-32s │\n", symOK, "Deleted")
 	} else if verifierFile != "" {
-		fmt.Printf("│    Verifier keyshare (MinIO): ✗ %-32s │\n", "Failed to delete")
+		fmt.Printf("│    Verifier keyshare (MinIO): %s %-32s │\n", symFail, "Failed to delete")
 	} else {
 		fmt.Printf("│    Verifier keyshare (MinIO): - %-32s │\n", "Not found")
 	}
-	if dcaRemoved {
-		fmt.Printf("│    DCA Plugin keyshare (MinIO): ✓ %-30s │\n", "Deleted")
-	} else if dcaFile != "" {
-		fmt.Printf("│    DCA Plugin keyshare (MinIO): ✗ %-30s │\n", "Failed to delete")
+	pluginLabel := fmt.Sprintf("%-30s", spec.Name+" keyshare (MinIO):")
+	if pluginRemoved {
+		fmt.Printf("│    %s %s %-28s │\n", pluginLabel, symOK, "Deleted")
+	} else if pluginFile != "" {
+		fmt.Printf("│    %s %s %-28s │\n", pluginLabel, symFail, "Failed to delete")
 	} else {
-		fmt.Printf("│    DCA Plugin keyshare (MinIO): - %-30s │\n", "Not found")
+		fmt.Printf("│    %s - %-28s │\n", pluginLabel, "Not found")
 	}
 	if dbRemoved {
-		fmt.Printf("│    Database record: ✓ %-42s │\n", "Deleted")
+		fmt.Printf("│    Database record: %s %-42s │\n", symOK, "Deleted")
 	} else if dbRecord != "" {
-		fmt.Printf("│    Database record: ✗ %-42s │\n", "Failed to delete")
+		fmt.Printf("│    Database record: %s %-42s │\n", symFail, "Failed to delete")
 	} else {
 		fmt.Printf("│    Database record: - %-42s │\n", "Not found")
 	}
+	if len(purged) > 0 {
+		fmt.Println("│                                                                 │")
+		fmt.Println("│  Policies:                                                      │")
+		for _, r := range purged {
+			status := "Deleted, scheduler cleared"
+			mark := symOK
+			if !r.Deleted {
+				status, mark = "Delete failed", symFail
+			} else if !r.SchedulerCleared {
+				status, mark = "Deleted, scheduler not cleared", symWarn
+			}
+			shortID := r.PolicyID
+			if len(shortID) > 8 {
+				shortID = shortID[:8]
+			}
+			fmt.Printf("│    %-8s %s %-50s │\n", shortID, mark, status)
+		}
+	}
 	fmt.Println("│                                                                 │")
-	fmt.Printf("│  Total Time: %-51s │\n", totalDuration.Round(time.Millisecond).String())
+	fmt.Printf("│  Total Time: %-51s │\n", format.Duration(totalDuration))
 	fmt.Println("│                                                                 │")
 	fmt.Println("└─────────────────────────────────────────────────────────────────┘")
 	fmt.Println()
+	for _, r := range purged {
+		if r.Err != nil {
+			fmt.Printf("%s Policy %s: %v\n", failMark(), r.PolicyID, r.Err)
+		}
+	}
 	fmt.Println("Local vault unchanged (still has original 2-of-2 keyshares).")
 	fmt.Println("Ready to reinstall plugin with: devctl plugin install", pluginID, "-p <password>")
 
@@ -515,36 +868,6 @@ func removePluginInstallation(pluginID, publicKey string) bool {
 	return err == nil
 }
 
-func runPluginSpec(pluginID string) error {
-	cfg, err := LoadConfig()
-	if err != nil {
-		return fmt.Errorf("load config: %w", err)
-	}
-
-	fmt.Printf("Fetching recipe specification for %s...\n\n", pluginID)
-
-	url := fmt.Sprintf("%s/plugins/%s/recipe-specification", cfg.Verifier, pluginID)
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-
-	req, _ := http.NewRequestWithContext(ctx, "GET", url, nil)
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("request failed: %w", err)
-	}
-	defer resp.Body.Close()
-
-	body, _ := io.ReadAll(resp.Body)
-
-	var result map[string]interface{}
-	json.Unmarshal(body, &result)
-
-	prettyJSON, _ := json.MarshalIndent(result, "", "  ")
-	fmt.Println(string(prettyJSON))
-
-	return nil
-}
-
 func doRequest(method, url string, body interface{}) ([]byte, int, error) {
 	cfg, err := LoadConfig()
 	if err != nil {
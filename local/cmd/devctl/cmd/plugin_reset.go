@@ -0,0 +1,196 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vultisig/vultisig-cluster/local/cmd/devctl/format"
+)
+
+func newPluginResetCmd() *cobra.Command {
+	var yes bool
+	var reinstall bool
+	var password string
+
+	cmd := &cobra.Command{
+		Use:   "reset [plugin-id]",
+		Short: "Remove all of one plugin's state for the current vault",
+		Long: `Give one plugin a clean slate for the current vault without touching the
+verifier, other plugins or the local vault:
+
+  1. Delete the vault's policies for the plugin (signed deletion, one Fast
+     Vault keysign per policy)
+  2. Remove their scheduler rows and tx indexer entries from the plugin database
+  3. Remove the plugin's keyshares from the verifier and plugin MinIO buckets
+  4. Remove the plugin_installations row
+
+Each removal is reported. With --reinstall, 'plugin install' runs right after.
+
+Example:
+  devctl plugin reset vultisig-dca-0000 --reinstall -p <password>
+
+Environment variables:
+  VAULT_PASSWORD  - Fast Vault password
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			actualPassword := password
+			if envPass := os.Getenv("VAULT_PASSWORD"); envPass != "" {
+				actualPassword = envPass
+			}
+			if actualPassword == "" {
+				var err error
+				actualPassword, err = promptPassword("", "Enter Fast Vault password: ")
+				if err != nil {
+					return err
+				}
+			}
+			return runPluginReset(cmd.Context(), args[0], yes, reinstall, actualPassword)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Skip the confirmation prompt")
+	cmd.Flags().BoolVar(&reinstall, "reinstall", false, "Run 'plugin install' after the reset")
+	cmd.Flags().StringVarP(&password, "password", "p", "", "Fast Vault password (or set VAULT_PASSWORD env var)")
+
+	return cmd
+}
+
+// resetStep is one line of the plugin reset report.
+type resetStep struct {
+	What   string
+	Result string
+	Err    error
+}
+
+func runPluginReset(ctx context.Context, pluginID string, yes, reinstall bool, password string) error {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	if cfg.PublicKeyECDSA == "" {
+		return fmt.Errorf("no vault configured. Run 'devctl vault import' first")
+	}
+	spec := pluginSpecFor(pluginID)
+
+	progressf("Resetting plugin %s...\n", pluginID)
+	fmt.Printf("  Vault: %s (%s)\n", cfg.PublicKeyECDSA[:16]+"...", VaultFingerprint(cfg.PublicKeyECDSA))
+
+	policies := pluginPoliciesForUninstall(ctx, cfg, pluginID)
+	dbRecord := checkPluginInstallation(pluginID, cfg.PublicKeyECDSA)
+	verifierFile, _ := checkMinioFile(cfg.VerifierBucket, pluginID, cfg.PublicKeyECDSA)
+	pluginFile, _ := checkMinioFile(spec.Bucket, pluginID, cfg.PublicKeyECDSA)
+
+	fmt.Println()
+	question := fmt.Sprintf("Delete %d policies, their scheduler and tx indexer rows, both keyshares and the installation record of %s?",
+		len(policies), pluginID)
+	err = confirmDestructive(question, "", yes)
+	if err != nil {
+		return err
+	}
+
+	var steps []resetStep
+
+	if len(policies) > 0 {
+		progressf("\nDeleting %d policies...\n", len(policies))
+		purged, err := purgePluginPolicies(ctx, cfg, spec, policies, password)
+		if err != nil {
+			return err
+		}
+		ids := make([]string, 0, len(purged))
+		for _, r := range purged {
+			step := resetStep{What: "Policy " + format.Truncate(r.PolicyID, 11), Result: "deleted, scheduler cleared", Err: r.Err}
+			if r.Deleted && !r.SchedulerCleared {
+				step.Result = "deleted, scheduler not cleared"
+			}
+			steps = append(steps, step)
+			// Policies that could not be fully removed keep their transactions.
+			if r.Err == nil {
+				ids = append(ids, r.PolicyID)
+			}
+		}
+
+		n, err := removeTxIndexerRows(spec, ids)
+		steps = append(steps, resetStep{What: "Tx indexer rows", Result: fmt.Sprintf("%d deleted", n), Err: err})
+	} else {
+		steps = append(steps, resetStep{What: "Policies", Result: "none"})
+	}
+
+	progressln("\nRemoving plugin data...")
+	steps = append(steps,
+		removalStep("Verifier keyshare (MinIO)", verifierFile != "", func() bool {
+			return removeMinioFile(cfg.VerifierBucket, pluginID, cfg.PublicKeyECDSA)
+		}),
+		removalStep(spec.Name+" keyshare (MinIO)", pluginFile != "", func() bool {
+			return removeMinioFile(spec.Bucket, pluginID, cfg.PublicKeyECDSA)
+		}),
+		removalStep("plugin_installations row", dbRecord != "", func() bool {
+			return removePluginInstallation(pluginID, cfg.PublicKeyECDSA)
+		}),
+	)
+
+	fmt.Println()
+	failed := 0
+	for _, s := range steps {
+		switch {
+		case s.Err != nil:
+			failed++
+			fmt.Printf("  %s %-32s %v\n", failMark(), s.What, s.Err)
+		case s.Result == "none" || s.Result == "not found":
+			fmt.Printf("  - %-32s %s\n", s.What, s.Result)
+		default:
+			fmt.Printf("  %s %-32s %s\n", okMark(), s.What, s.Result)
+		}
+	}
+	fmt.Println()
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d reset steps failed; fix the cause and re-run 'devctl plugin reset %s'", failed, len(steps), pluginID)
+	}
+	fmt.Printf("Plugin %s reset. Verifier, other plugins and the local vault are unchanged.\n", pluginID)
+
+	if !reinstall {
+		fmt.Println("Reinstall with: devctl plugin install", pluginID, "-p <password>")
+		return nil
+	}
+	fmt.Println()
+	return runPluginInstall(ctx, pluginID, password, "table")
+}
+
+// removalStep runs remove when the object exists and reports the outcome.
+func removalStep(what string, exists bool, remove func() bool) resetStep {
+	if !exists {
+		return resetStep{What: what, Result: "not found"}
+	}
+	if !remove() {
+		return resetStep{What: what, Err: fmt.Errorf("failed to delete")}
+	}
+	return resetStep{What: what, Result: "deleted"}
+}
+
+// removeTxIndexerRows deletes the tx indexer rows of the given policies and
+// returns how many were removed.
+func removeTxIndexerRows(spec PluginSpec, policyIDs []string) (int, error) {
+	if len(policyIDs) == 0 {
+		return 0, nil
+	}
+	quoted := make([]string, len(policyIDs))
+	for i, id := range policyIDs {
+		quoted[i] = "'" + strings.ReplaceAll(id, "'", "''") + "'"
+	}
+	cmd := psqlCommand(spec.Database, "-c",
+		fmt.Sprintf("DELETE FROM %s WHERE policy_id IN (%s)", spec.TxTable, strings.Join(quoted, ", ")))
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return 0, fmt.Errorf("delete tx indexer rows: %w\nOutput: %s", err, strings.TrimSpace(string(output)))
+	}
+	// psql reports "DELETE <n>".
+	n, _ := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(string(output)), "DELETE")))
+	return n, nil
+}
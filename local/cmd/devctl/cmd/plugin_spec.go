@@ -0,0 +1,359 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// recipeSpecTTL is how long a fetched recipe specification is reused, so
+// repeated 'plugin spec --example' runs do not hit the verifier each time.
+const recipeSpecTTL = 10 * time.Minute
+
+// staticPolicyExample is the DCA policy config shown by 'policy create
+// --help', and by 'plugin spec --example' when the verifier is unreachable
+// and no specification is cached.
+const staticPolicyExample = `{
+  "recipe": {
+    "from": { "chain": "Ethereum", "token": "", "address": "" },
+    "to": { "chain": "Ethereum", "token": "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48", "address": "" },
+    "fromAmount": "1000000000000000",
+    "frequency": "daily"
+  },
+  "billing": [{ "type": "once", "amount": 0 }]
+}`
+
+// recipeSpec is the part of a plugin's recipe specification needed to build
+// an example policy config and find its address fields.
+type recipeSpec struct {
+	PluginID             string                   `json:"plugin_id"`
+	PluginName           string                   `json:"plugin_name"`
+	Configuration        *jsonSchema              `json:"configuration"`
+	ConfigurationExample []map[string]interface{} `json:"configuration_example"`
+}
+
+// jsonSchema is the subset of JSON Schema that recipe configurations use.
+// Type is a string or a list of strings.
+type jsonSchema struct {
+	Type        interface{}            `json:"type"`
+	Description string                 `json:"description"`
+	Properties  map[string]*jsonSchema `json:"properties"`
+	Required    []string               `json:"required"`
+	Items       *jsonSchema            `json:"items"`
+	Enum        []interface{}          `json:"enum"`
+	Default     interface{}            `json:"default"`
+	Examples    []interface{}          `json:"examples"`
+	Format      string                 `json:"format"`
+	Pattern     string                 `json:"pattern"`
+}
+
+func (s *jsonSchema) typeName() string {
+	switch t := s.Type.(type) {
+	case string:
+		return t
+	case []interface{}:
+		for _, v := range t {
+			if name, ok := v.(string); ok && name != "null" {
+				return name
+			}
+		}
+	}
+	if s.Properties != nil {
+		return "object"
+	}
+	return "string"
+}
+
+// cachedRecipeSpec is a specification as stored under ~/.vultisig/cache.
+type cachedRecipeSpec struct {
+	Verifier  string          `json:"verifier"`
+	FetchedAt time.Time       `json:"fetched_at"`
+	Spec      json.RawMessage `json:"spec"`
+}
+
+func recipeSpecCachePath(pluginID string) string {
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".vultisig", "cache", "recipe-spec-"+pluginID+".json")
+}
+
+func readCachedRecipeSpec(cfg *DevConfig, pluginID string) (*cachedRecipeSpec, error) {
+	data, err := os.ReadFile(recipeSpecCachePath(pluginID))
+	if err != nil {
+		return nil, err
+	}
+	var cached cachedRecipeSpec
+	err = json.Unmarshal(data, &cached)
+	if err != nil {
+		return nil, err
+	}
+	if cached.Verifier != cfg.Verifier {
+		return nil, fmt.Errorf("cached for %s", cached.Verifier)
+	}
+	return &cached, nil
+}
+
+// fetchRecipeSpec returns the plugin's recipe specification, from the cache
+// if it is younger than recipeSpecTTL, else from the verifier. If the
+// verifier cannot be reached, an older cached copy is used with a warning.
+func fetchRecipeSpec(ctx context.Context, cfg *DevConfig, pluginID string) (json.RawMessage, error) {
+	cached, cacheErr := readCachedRecipeSpec(cfg, pluginID)
+	if cacheErr == nil && time.Since(cached.FetchedAt) < recipeSpecTTL {
+		return cached.Spec, nil
+	}
+
+	progressf("Fetching recipe specification for %s...\n\n", pluginID)
+
+	url := fmt.Sprintf("%s/plugins/%s/recipe-specification", cfg.Verifier, pluginID)
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	spec, err := getAPI[json.RawMessage](ctx, url, "")
+	if err != nil {
+		if cacheErr == nil {
+			progressf("%s %v; using the specification cached %s ago\n", warnMark(), err, time.Since(cached.FetchedAt).Round(time.Minute))
+			return cached.Spec, nil
+		}
+		return nil, err
+	}
+
+	data, err := json.MarshalIndent(cachedRecipeSpec{Verifier: cfg.Verifier, FetchedAt: time.Now(), Spec: spec}, "", "  ")
+	if err == nil {
+		path := recipeSpecCachePath(pluginID)
+		err = os.MkdirAll(filepath.Dir(path), 0755)
+		if err == nil {
+			err = os.WriteFile(path, data, 0644)
+		}
+	}
+	if err != nil {
+		progressf("%s Could not cache the recipe specification: %v\n", warnMark(), err)
+	}
+	return spec, nil
+}
+
+func runPluginSpec(ctx context.Context, pluginID string, example bool) error {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	raw, err := fetchRecipeSpec(ctx, cfg, pluginID)
+	if err != nil {
+		if !example {
+			return err
+		}
+		progressf("%s Could not fetch the recipe specification (%v); showing the static DCA example, which may not match %s\n\n", warnMark(), err, pluginID)
+		fmt.Println(staticPolicyExample)
+		return nil
+	}
+
+	if !example {
+		var result interface{}
+		err = json.Unmarshal(raw, &result)
+		if err != nil {
+			return fmt.Errorf("decode recipe specification: %w", err)
+		}
+		prettyJSON, _ := json.MarshalIndent(result, "", "  ")
+		fmt.Println(string(prettyJSON))
+		return nil
+	}
+
+	var spec recipeSpec
+	err = json.Unmarshal(raw, &spec)
+	if err != nil {
+		return fmt.Errorf("decode recipe specification: %w", err)
+	}
+	if spec.Configuration == nil && len(spec.ConfigurationExample) == 0 {
+		return fmt.Errorf("%s's recipe specification has no configuration schema", pluginID)
+	}
+
+	var recipe interface{}
+	if len(spec.ConfigurationExample) > 0 {
+		recipe = spec.ConfigurationExample[0]
+	} else {
+		recipe = exampleValue(spec.Configuration)
+	}
+	config := map[string]interface{}{
+		"recipe":  recipe,
+		"billing": []interface{}{map[string]interface{}{"type": "once", "amount": 0}},
+	}
+	data, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal example: %w", err)
+	}
+	fmt.Println(string(data))
+
+	if spec.Configuration != nil {
+		progressf("\nRecipe fields (* = required):\n")
+		printSchemaFields(spec.Configuration, "recipe")
+	}
+	progressf("\nSave the example to a file and pass it to 'devctl policy create --plugin %s --config <file>'.\n", pluginID)
+	return nil
+}
+
+// exampleValue builds a minimal value that satisfies s: only required
+// properties, and the default, first example or first enum value where the
+// schema gives one.
+func exampleValue(s *jsonSchema) interface{} {
+	switch {
+	case s.Default != nil:
+		return s.Default
+	case len(s.Examples) > 0:
+		return s.Examples[0]
+	case len(s.Enum) > 0:
+		return s.Enum[0]
+	}
+
+	switch s.typeName() {
+	case "object":
+		obj := map[string]interface{}{}
+		for _, name := range s.Required {
+			prop, ok := s.Properties[name]
+			if !ok {
+				continue
+			}
+			obj[name] = exampleValue(prop)
+		}
+		return obj
+	case "array":
+		if s.Items == nil {
+			return []interface{}{}
+		}
+		return []interface{}{exampleValue(s.Items)}
+	case "integer", "number":
+		return 0
+	case "boolean":
+		return false
+	}
+	return ""
+}
+
+// printSchemaFields lists every property below s with its type, allowed
+// values and description, to stderr.
+func printSchemaFields(s *jsonSchema, path string) {
+	names := make([]string, 0, len(s.Properties))
+	for name := range s.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		prop := s.Properties[name]
+		mark := " "
+		for _, r := range s.Required {
+			if r == name {
+				mark = "*"
+			}
+		}
+		field := path + "." + name
+		detail := prop.typeName()
+		if len(prop.Enum) > 0 {
+			values := make([]string, len(prop.Enum))
+			for i, v := range prop.Enum {
+				values[i] = fmt.Sprint(v)
+			}
+			detail += ", one of " + strings.Join(values, "|")
+		}
+		if prop.Format != "" {
+			detail += ", format " + prop.Format
+		}
+		progressf("  %s %-32s %s\n", mark, field, detail)
+		if prop.Description != "" {
+			progressf("      %s\n", prop.Description)
+		}
+
+		switch {
+		case prop.Properties != nil:
+			printSchemaFields(prop, field)
+		case prop.Items != nil && prop.Items.Properties != nil:
+			printSchemaFields(prop.Items, field+"[]")
+		}
+	}
+}
+
+// recipeAddressFields returns the recipe fields 'policy create' fills with
+// the vault's address, as field path to chain field path. A mapping under
+// plugins.<id>.address_fields in cluster.yaml wins; otherwise the fields come
+// from the recipe specification, with the registry's mapping supplying the
+// chain of fields the schema declares without a sibling chain. When the
+// specification cannot be fetched the registry's mapping is used as is.
+// Plugins with none of these get no fields, so nothing is filled.
+func recipeAddressFields(ctx context.Context, cfg *DevConfig, pluginID string) map[string]string {
+	cc := clusterConfigOrDefaults()
+	if override, ok := cc.Plugins[pluginID]; ok && override.AddressFields != nil {
+		return override.AddressFields
+	}
+	registered, _ := lookupPluginSpec(pluginID)
+
+	raw, err := fetchRecipeSpec(ctx, cfg, pluginID)
+	if err != nil {
+		return registered.AddressFields
+	}
+	var spec recipeSpec
+	err = json.Unmarshal(raw, &spec)
+	if err != nil || spec.Configuration == nil {
+		return registered.AddressFields
+	}
+	return specAddressFields(spec.Configuration, registered.AddressFields)
+}
+
+// specAddressFields returns the address fields the configuration schema
+// declares, mapped to their chain field. Fields of registered that the schema
+// has supply the chain of schema fields without a sibling chain; fields left
+// without a chain are dropped.
+func specAddressFields(config *jsonSchema, registered map[string]string) map[string]string {
+	fields := map[string]string{}
+	schemaAddressFields(config, "", fields)
+	for path, chainPath := range registered {
+		if schemaHasField(config, path) && fields[path] == "" {
+			fields[path] = chainPath
+		}
+	}
+	for path, chainPath := range fields {
+		if chainPath == "" {
+			delete(fields, path)
+		}
+	}
+	return fields
+}
+
+// schemaAddressFields adds the address properties below s to fields, mapped
+// to their sibling "chain" property, or to "" when there is none. A string
+// property is an address when its format is "address" or its name is, or
+// ends in, "address".
+func schemaAddressFields(s *jsonSchema, path string, fields map[string]string) {
+	for name, prop := range s.Properties {
+		if prop == nil {
+			continue
+		}
+		field := strings.TrimPrefix(path+"."+name, ".")
+		switch prop.typeName() {
+		case "object":
+			schemaAddressFields(prop, field, fields)
+		case "string":
+			if prop.Format != "address" && !strings.HasSuffix(strings.ToLower(name), "address") {
+				continue
+			}
+			fields[field] = ""
+			if chain, ok := s.Properties["chain"]; ok && chain != nil && chain.typeName() == "string" {
+				fields[field] = strings.TrimPrefix(path+".chain", ".")
+			}
+		}
+	}
+}
+
+// schemaHasField reports whether the dotted path names a property of s.
+func schemaHasField(s *jsonSchema, path string) bool {
+	for _, name := range strings.Split(path, ".") {
+		prop, ok := s.Properties[name]
+		if !ok || prop == nil {
+			return false
+		}
+		s = prop
+	}
+	return true
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// sendsSpec is the configuration schema of a recurring-sends recipe
+// specification, trimmed to the fields address discovery looks at.
+const sendsSpec = `{
+  "type": "object",
+  "properties": {
+    "asset": {
+      "type": "object",
+      "properties": {
+        "chain": { "type": "string" },
+        "token": { "type": "string" },
+        "address": { "type": "string" }
+      }
+    },
+    "recipient": { "type": "string", "format": "address" },
+    "amount": { "type": "string" },
+    "memo": { "type": ["string", "null"] }
+  }
+}`
+
+func TestSpecAddressFields(t *testing.T) {
+	tests := []struct {
+		name       string
+		schema     string
+		registered map[string]string
+		want       map[string]string
+	}{
+		{
+			name:   "sibling chain",
+			schema: sendsSpec,
+			want:   map[string]string{"asset.address": "asset.chain"},
+		},
+		{
+			name:       "registry supplies the chain",
+			schema:     sendsSpec,
+			registered: map[string]string{"asset.address": "asset.chain", "recipient": "asset.chain"},
+			want:       map[string]string{"asset.address": "asset.chain", "recipient": "asset.chain"},
+		},
+		{
+			name:       "registry fields the schema lacks",
+			schema:     sendsSpec,
+			registered: map[string]string{"from.address": "from.chain", "to.address": "to.chain"},
+			want:       map[string]string{"asset.address": "asset.chain"},
+		},
+		{
+			name: "nested and suffixed names",
+			schema: `{"properties": {"payout": {"properties": {
+				"chain": {"type": "string"},
+				"destinationAddress": {"type": "string"},
+				"addresses": {"type": "array"}
+			}}}}`,
+			want: map[string]string{"payout.destinationAddress": "payout.chain"},
+		},
+		{
+			name:   "no address fields",
+			schema: `{"properties": {"amount": {"type": "string"}, "chain": {"type": "string"}}}`,
+			want:   map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var schema jsonSchema
+			err := json.Unmarshal([]byte(tt.schema), &schema)
+			if err != nil {
+				t.Fatalf("decode schema: %v", err)
+			}
+			got := specAddressFields(&schema, tt.registered)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("specAddressFields = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSchemaHasField(t *testing.T) {
+	var schema jsonSchema
+	err := json.Unmarshal([]byte(sendsSpec), &schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for path, want := range map[string]bool{
+		"asset":         true,
+		"asset.address": true,
+		"recipient":     true,
+		"asset.amount":  false,
+		"from.address":  false,
+	} {
+		if got := schemaHasField(&schema, path); got != want {
+			t.Errorf("schemaHasField(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
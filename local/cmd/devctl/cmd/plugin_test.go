@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestPluginInstallRefusesGG20(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	// Nothing may be sent for a vault the reshare cannot use, not even the
+	// auth request.
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		http.Error(w, "unexpected request", http.StatusTeapot)
+	}))
+	defer srv.Close()
+	for _, env := range []string{"VCLI_VERIFIER_URL", "VCLI_FEE_PLUGIN_URL", "VCLI_DCA_PLUGIN_URL", "VCLI_RELAY_URL"} {
+		t.Setenv(env, srv.URL)
+	}
+
+	vault, err := newDemoVault()
+	if err != nil {
+		t.Fatal(err)
+	}
+	vault.Name = "legacy"
+	vault.LibType = libTypeGG20
+	err = SaveVault(vault)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = runPluginInstall(context.Background(), "vultisig-dca-0000", "password", "table")
+	want := "plugin install needs a DKLS vault, but legacy is GG20; migrate it to DKLS in the Vultisig app and import the new backup"
+	if err == nil || err.Error() != want {
+		t.Errorf("runPluginInstall error = %v, want %q", err, want)
+	}
+	if n := requests.Load(); n != 0 {
+		t.Errorf("%d requests sent for a GG20 vault, want none", n)
+	}
+}
+
+func TestRequireDKLS(t *testing.T) {
+	tests := []struct {
+		libType LibType
+		wantErr string
+	}{
+		{libType: libTypeDKLS},
+		{libType: libTypeGG20, wantErr: "DKLS reshare needs a DKLS vault, but v is GG20; migrate it to DKLS in the Vultisig app and import the new backup"},
+		{libType: 2, wantErr: "DKLS reshare needs a DKLS vault, but v is unknown (2); migrate it to DKLS in the Vultisig app and import the new backup"},
+	}
+	for _, tt := range tests {
+		err := requireDKLS(&LocalVault{Name: "v", LibType: tt.libType}, "DKLS reshare")
+		if tt.wantErr == "" {
+			if err != nil {
+				t.Errorf("%s vault: %v", tt.libType, err)
+			}
+			continue
+		}
+		if err == nil || err.Error() != tt.wantErr {
+			t.Errorf("%s vault: error = %v, want %q", tt.libType, err, tt.wantErr)
+		}
+	}
+}
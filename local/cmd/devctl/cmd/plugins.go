@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"fmt"
+	"os/exec"
+	"sort"
+	"strings"
+)
+
+// PluginSpec describes where a local plugin keeps its state, so install,
+// uninstall, report and policy commands check the right bucket and database.
+type PluginSpec struct {
+	ID             string
+	Name           string
+	Bucket         string // MinIO bucket holding the plugin's keyshares
+	Database       string // Postgres database with its scheduler/tx tables
+	SchedulerTable string // table with next_execution per policy
+	TxTable        string // table the tx indexer writes to
+	PartyPrefix    string // local party prefix of the plugin worker
+
+	// AddressFields maps the recipe fields 'policy create' fills with the
+	// vault's address to the field naming the chain to derive it on, as
+	// dotted paths ("from.address": "from.chain"). It is used when the
+	// recipe specification does not declare the fields itself.
+	AddressFields map[string]string
+}
+
+// pluginSpecs is the default plugin registry. Both plugins run from the
+// app-recurring repo, in swap and send mode respectively.
+var pluginSpecs = []PluginSpec{
+	{
+		ID:             "vultisig-dca-0000",
+		Name:           "DCA Plugin",
+		Bucket:         "vultisig-dca",
+		Database:       "vultisig-dca",
+		SchedulerTable: "scheduler",
+		TxTable:        "tx_indexer",
+		PartyPrefix:    "dca-worker",
+		AddressFields:  map[string]string{"from.address": "from.chain", "to.address": "to.chain"},
+	},
+	{
+		ID:             "vultisig-recurring-sends-0000",
+		Name:           "Sends Plugin",
+		Bucket:         "vultisig-sends",
+		Database:       "vultisig-sends",
+		SchedulerTable: "scheduler",
+		TxTable:        "tx_indexer",
+		PartyPrefix:    "sends-worker",
+		// An empty recipient means a send to the vault itself.
+		AddressFields: map[string]string{"asset.address": "asset.chain", "recipient": "asset.chain"},
+	},
+}
+
+// pluginRegistry is pluginSpecs with the plugins section of cluster.yaml
+// applied: entries for known IDs override fields, others add plugins.
+func pluginRegistry() []PluginSpec {
+	cc := clusterConfigOrDefaults()
+
+	specs := make([]PluginSpec, 0, len(pluginSpecs)+len(cc.Plugins))
+	seen := map[string]bool{}
+	for _, spec := range pluginSpecs {
+		if override, ok := cc.Plugins[spec.ID]; ok {
+			spec = override.apply(spec)
+		}
+		specs = append(specs, spec)
+		seen[spec.ID] = true
+	}
+
+	var added []string
+	for id := range cc.Plugins {
+		if !seen[id] {
+			added = append(added, id)
+		}
+	}
+	sort.Strings(added)
+	for _, id := range added {
+		specs = append(specs, cc.Plugins[id].apply(PluginSpec{ID: id, Name: id, SchedulerTable: "scheduler", TxTable: "tx_indexer"}))
+	}
+	return specs
+}
+
+func (o PluginOverride) apply(spec PluginSpec) PluginSpec {
+	if o.Name != "" {
+		spec.Name = o.Name
+	}
+	if o.Bucket != "" {
+		spec.Bucket = o.Bucket
+	}
+	if o.Database != "" {
+		spec.Database = o.Database
+	}
+	if o.SchedulerTable != "" {
+		spec.SchedulerTable = o.SchedulerTable
+	}
+	if o.TxTable != "" {
+		spec.TxTable = o.TxTable
+	}
+	if o.PartyPrefix != "" {
+		spec.PartyPrefix = o.PartyPrefix
+	}
+	if o.AddressFields != nil {
+		spec.AddressFields = o.AddressFields
+	}
+	return spec
+}
+
+func lookupPluginSpec(pluginID string) (PluginSpec, bool) {
+	for _, spec := range pluginRegistry() {
+		if spec.ID == pluginID {
+			return spec, true
+		}
+	}
+	return PluginSpec{}, false
+}
+
+// pluginSpecFor returns the registry entry for pluginID. Unknown plugins get
+// the DCA entry, which is where every plugin lived before the registry.
+func pluginSpecFor(pluginID string) PluginSpec {
+	spec, ok := lookupPluginSpec(pluginID)
+	if !ok {
+		return pluginRegistry()[0]
+	}
+	return spec
+}
+
+// pluginDatabaseSpec is pluginSpecFor for commands that read the plugin's
+// database, where guessing the DCA database would show the wrong data.
+func pluginDatabaseSpec(pluginID string) (PluginSpec, error) {
+	spec, ok := lookupPluginSpec(pluginID)
+	if !ok || spec.Database == "" {
+		return PluginSpec{}, fmt.Errorf(`no database mapping configured for plugin %q; add one to cluster.yaml:
+
+  plugins:
+    %s:
+      database: <postgres database>
+      scheduler_table: scheduler   # optional
+      tx_table: tx_indexer         # optional`, pluginID, pluginID)
+	}
+	return spec, nil
+}
+
+// policyPluginSpec resolves the plugin whose database holds a policy's
+// scheduler and transactions: pluginID when given, otherwise the plugin of
+// the policy's record in the verifier database.
+func policyPluginSpec(policyID, pluginID string) (PluginSpec, error) {
+	if pluginID == "" {
+		cmd := psqlCommand("vultisig-verifier", "-t", "-c",
+			fmt.Sprintf("SELECT plugin_id FROM plugin_policies WHERE id = '%s' LIMIT 1", policyID))
+		output, err := cmd.Output()
+		if err != nil {
+			return PluginSpec{}, fmt.Errorf("look up plugin of policy %s: %w (pass --plugin)", policyID, err)
+		}
+		pluginID = strings.TrimSpace(string(output))
+		if pluginID == "" {
+			return PluginSpec{}, fmt.Errorf("policy %s not found in the verifier database (pass --plugin)", policyID)
+		}
+	}
+	return pluginDatabaseSpec(pluginID)
+}
+
+// psqlCommand runs psql against a database in the postgres container.
+func psqlCommand(database string, args ...string) *exec.Cmd {
+	return exec.Command("docker", append([]string{"exec", "vultisig-postgres", "psql", "-U", "vultisig", "-d", database}, args...)...)
+}
@@ -11,11 +11,12 @@ import (
 	"net/http"
 	"os"
 	"os/exec"
+	"slices"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/ethereum/go-ethereum/crypto"
-	"github.com/google/uuid"
 	"github.com/spf13/cobra"
 	rtypes "github.com/vultisig/recipes/types"
 	"github.com/vultisig/vultisig-go/address"
@@ -23,6 +24,9 @@ import (
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/vultisig/vultisig-cluster/local/cmd/devctl/client"
+	"github.com/vultisig/vultisig-cluster/local/cmd/devctl/format"
 )
 
 func NewPolicyCmd() *cobra.Command {
@@ -33,9 +37,15 @@ func NewPolicyCmd() *cobra.Command {
 
 	cmd.AddCommand(newPolicyListCmd())
 	cmd.AddCommand(newPolicyCreateCmd())
+	cmd.AddCommand(newPolicyImportCmd())
+	cmd.AddCommand(newPolicyExportCmd())
 	cmd.AddCommand(newPolicyDeleteCmd())
 	cmd.AddCommand(newPolicyInfoCmd())
 	cmd.AddCommand(newPolicyHistoryCmd())
+	cmd.AddCommand(newPolicyPauseCmd())
+	cmd.AddCommand(newPolicyResumeCmd())
+	cmd.AddCommand(newPolicySignTestCmd())
+	cmd.AddCommand(newPolicyScheduleCmd())
 	cmd.AddCommand(newPolicyStatusCmd())
 	cmd.AddCommand(newPolicyTransactionsCmd())
 	cmd.AddCommand(newPolicyTriggerCmd())
@@ -45,16 +55,39 @@ func NewPolicyCmd() *cobra.Command {
 
 func newPolicyListCmd() *cobra.Command {
 	var pluginID string
+	var sortBy string
+	var reverse bool
+	var format, file string
 
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List policies for a plugin",
+		Long: `List the current vault's policies for a plugin.
+
+--format csv writes one row per policy with the decoded recipe: from/to
+chain, token and symbol, fromAmount in base units and in token units,
+frequency and the full configuration as JSON. Timestamps are RFC 3339 UTC.
+
+Example:
+  devctl policy list -p vultisig-dca-0000
+  devctl policy list -p vultisig-dca-0000 --format csv -o policies.csv
+`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runPolicyList(pluginID)
+			if sortBy != "created" && sortBy != "next-execution" {
+				return fmt.Errorf("unknown sort key %q (use created or next-execution)", sortBy)
+			}
+			err := checkListFormat(format, file)
+			if err != nil {
+				return err
+			}
+			return runPolicyList(cmd.Context(), pluginID, sortBy, reverse, format, file)
 		},
 	}
 
 	cmd.Flags().StringVarP(&pluginID, "plugin", "p", "", "Plugin ID (required)")
+	cmd.Flags().StringVar(&sortBy, "sort", "created", "Sort by: created or next-execution")
+	cmd.Flags().BoolVar(&reverse, "reverse", false, "Reverse the sort order")
+	addListFormatFlags(cmd, &format, &file)
 	cmd.MarkFlagRequired("plugin")
 
 	return cmd
@@ -64,6 +97,7 @@ func newPolicyCreateCmd() *cobra.Command {
 	var pluginID string
 	var configFile string
 	var password string
+	var opts policyCreateOptions
 
 	cmd := &cobra.Command{
 		Use:   "create",
@@ -80,16 +114,42 @@ The policy configuration should be a JSON file containing:
   ]
 }
 
+Billing entries take "type" (once, transaction or recurring), "amount" (a
+non-negative integer in base units), "frequency" (daily, weekly, biweekly or
+monthly; required for recurring only), and optional "asset", "start_date"
+(RFC 3339) and "description". Invalid entries fail with the offending field,
+e.g. billing[1].frequency.
+
 Example for DCA plugin (swap ETH to USDC):
-{
-  "recipe": {
-    "from": { "chain": "Ethereum", "token": "", "address": "" },
-    "to": { "chain": "Ethereum", "token": "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48", "address": "" },
-    "fromAmount": "1000000000000000",
-    "frequency": "daily"
-  },
-  "billing": [{ "type": "once", "amount": 0 }]
-}
+` + staticPolicyExample + `
+
+For an example generated from the recipe specification of the plugin you are
+targeting, with its field descriptions, run
+'devctl plugin spec <plugin-id> --example'.
+
+Policies are created active. Set "active": false in the config file or pass
+--inactive to create it paused (the plugin does not schedule inactive
+policies); activate it later with 'devctl policy resume <policy-id>'.
+
+The report breaks the total time down by phase (policy template, Fast Vault
+request, party join, keysign rounds, verifier submit); --output json prints it
+as JSON. Slow phases are flagged as for 'plugin install'.
+
+The signed message includes the policy version and plugin version. The plugin
+version is taken from the verifier's plugin record (falling back to 1.0.0 if it
+has none) and a new policy starts at version 1. --plugin-version and
+--policy-version override them, e.g. to check that the verifier rejects a
+mismatch. --dry-run resolves the template and versions, prints them and exits
+without signing or submitting.
+
+The rate limit comes from the plugin's suggested policy. Tighten it with
+--rate-limit-window / --max-txs-per-window, or "rate_limit_window" /
+"max_txs_per_window" in the config file (flags win). A longer window or fewer
+transactions is tighter; looser values are rejected unless --allow-looser is
+set, which lets you check that the verifier rejects them.
+
+The policy is signed with the key at auth_derive_path in cluster.yaml
+(default m/44'/60'/0'/0/0); --derive overrides it for this policy.
 
 Environment variables:
   VAULT_PASSWORD  - Fast Vault password
@@ -97,24 +157,39 @@ Environment variables:
 Note: Requires authentication. Run 'devctl vault import' first.
 `,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.Output != "table" && opts.Output != "json" {
+				return fmt.Errorf("unknown output format %q (use table or json)", opts.Output)
+			}
 			actualPassword := password
 			if envPass := os.Getenv("VAULT_PASSWORD"); envPass != "" {
 				actualPassword = envPass
 			}
-			if actualPassword == "" {
+			if actualPassword == "" && !opts.DryRun {
 				var err error
 				actualPassword, err = promptPassword("", "Enter Fast Vault password: ")
 				if err != nil {
 					return err
 				}
 			}
-			return runPolicyCreate(pluginID, configFile, actualPassword)
+			if opts.PolicyVersion < 0 {
+				return fmt.Errorf("--policy-version must be positive")
+			}
+			return runPolicyCreate(cmd.Context(), pluginID, configFile, actualPassword, opts)
 		},
 	}
 
 	cmd.Flags().StringVarP(&pluginID, "plugin", "p", "", "Plugin ID (required)")
 	cmd.Flags().StringVarP(&configFile, "config", "c", "", "Policy configuration file (required)")
 	cmd.Flags().StringVar(&password, "password", "", "Fast Vault password (or set VAULT_PASSWORD env var)")
+	cmd.Flags().StringVarP(&opts.Output, "output", "o", "table", "Report format: table or json")
+	cmd.Flags().BoolVar(&opts.Inactive, "inactive", false, "Create the policy inactive (overrides \"active\" in the config file)")
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "Resolve the policy and versions without signing or submitting")
+	cmd.Flags().StringVar(&opts.PluginVersion, "plugin-version", "", "Override the plugin version in the signed message")
+	cmd.Flags().IntVar(&opts.PolicyVersion, "policy-version", 0, "Override the policy version in the signed message (default 1)")
+	cmd.Flags().Uint32Var(&opts.Limits.Window, "rate-limit-window", 0, "Rate limit window in seconds (must not be shorter than suggested)")
+	cmd.Flags().Uint32Var(&opts.Limits.MaxTxs, "max-txs-per-window", 0, "Max transactions per window (must not exceed the suggestion)")
+	cmd.Flags().BoolVar(&opts.AllowLooser, "allow-looser", false, "Allow rate limits looser than the plugin suggests")
+	cmd.Flags().StringVar(&opts.DerivePath, "derive", "", "BIP44 path to sign the policy with (default auth_derive_path from cluster.yaml)")
 	cmd.MarkFlagRequired("plugin")
 	cmd.MarkFlagRequired("config")
 
@@ -122,14 +197,20 @@ Note: Requires authentication. Run 'devctl vault import' first.
 }
 
 func newPolicyDeleteCmd() *cobra.Command {
-	return &cobra.Command{
+	var yes bool
+
+	cmd := &cobra.Command{
 		Use:   "delete [policy-id]",
 		Short: "Delete a policy",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runPolicyDelete(args[0])
+			return runPolicyDelete(cmd.Context(), args[0], yes)
 		},
 	}
+
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Skip the confirmation prompt")
+
+	return cmd
 }
 
 func newPolicyInfoCmd() *cobra.Command {
@@ -138,23 +219,61 @@ func newPolicyInfoCmd() *cobra.Command {
 		Short: "Show policy details",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runPolicyInfo(args[0])
+			return runPolicyInfo(cmd.Context(), args[0])
 		},
 	}
 }
 
-func newPolicyHistoryCmd() *cobra.Command {
-	return &cobra.Command{
-		Use:   "history [policy-id]",
-		Short: "Show policy transaction history",
-		Args:  cobra.ExactArgs(1),
+func newPolicyPauseCmd() *cobra.Command {
+	var password string
+
+	cmd := &cobra.Command{
+		Use:   "pause [policy-id]",
+		Short: "Deactivate a policy so the plugin stops scheduling it",
+		Long: `Deactivate a policy without deleting it.
+
+The policy is re-signed with a bumped policy_version and updated on the
+verifier with active=false. The plugin scheduler skips inactive policies, so
+no further executions happen until 'devctl policy resume'.
+
+Environment variables:
+  VAULT_PASSWORD  - Fast Vault password
+`,
+		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runPolicyHistory(args[0])
+			return runPolicySetActive(cmd.Context(), args[0], false, password)
 		},
 	}
+
+	cmd.Flags().StringVar(&password, "password", "", "Fast Vault password (or set VAULT_PASSWORD env var)")
+	return cmd
 }
 
-func runPolicyList(pluginID string) error {
+func newPolicyResumeCmd() *cobra.Command {
+	var password string
+
+	cmd := &cobra.Command{
+		Use:   "resume [policy-id]",
+		Short: "Reactivate a paused or inactive policy",
+		Long: `Reactivate a policy created with --inactive or paused with 'devctl policy pause'.
+
+The policy is re-signed with a bumped policy_version and updated on the
+verifier with active=true. The plugin schedules it on its next poll.
+
+Environment variables:
+  VAULT_PASSWORD  - Fast Vault password
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runPolicySetActive(cmd.Context(), args[0], true, password)
+		},
+	}
+
+	cmd.Flags().StringVar(&password, "password", "", "Fast Vault password (or set VAULT_PASSWORD env var)")
+	return cmd
+}
+
+func runPolicyList(ctx context.Context, pluginID, sortBy string, reverse bool, format, file string) error {
 	cfg, err := LoadConfig()
 	if err != nil {
 		return fmt.Errorf("load config: %w", err)
@@ -171,11 +290,13 @@ func runPolicyList(pluginID string) error {
 	}
 	publicKey := vaults[0].PublicKeyECDSA
 
-	fmt.Printf("Fetching policies for plugin %s...\n", pluginID)
-	fmt.Printf("  Vault: %s...\n\n", publicKey[:20])
+	progressf("Fetching policies for plugin %s...\n", pluginID)
+	if format != "csv" {
+		fmt.Printf("  Vault: %s...\n\n", publicKey[:20])
+	}
 
 	url := fmt.Sprintf("%s/plugin/policies/%s?public_key=%s", cfg.Verifier, pluginID, publicKey)
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
@@ -193,48 +314,127 @@ func runPolicyList(pluginID string) error {
 	body, _ := io.ReadAll(resp.Body)
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("request failed (%d): %s", resp.StatusCode, string(body))
+		return fmt.Errorf("request failed (%d): %s", resp.StatusCode, errorBody(body))
 	}
 
-	var policies []map[string]interface{}
-	err = json.Unmarshal(body, &policies)
+	list, err := client.Decode[client.PolicyList](body)
 	if err != nil {
-		var result map[string]interface{}
-		json.Unmarshal(body, &result)
-		prettyJSON, _ := json.MarshalIndent(result, "", "  ")
-		fmt.Println(string(prettyJSON))
-		return nil
+		return fmt.Errorf("parse policy list: %w", err)
 	}
+	policies := list.Policies
 
-	if len(policies) == 0 {
+	if len(policies) == 0 && format != "csv" {
 		fmt.Println("No policies found for this plugin.")
 		return nil
 	}
 
+	// The next execution lives in the plugin's scheduler table, not the
+	// verifier, so it is looked up per policy.
+	next := make(map[string]*time.Time, len(policies))
+	spec, err := pluginDatabaseSpec(pluginID)
+	if err != nil {
+		progressf("%s Next executions unavailable: no database mapping configured for %s\n", warnMark(), pluginID)
+	} else {
+		for _, p := range policies {
+			if t, ok := parsePostgresTime(checkScheduler(spec, p.ID)); ok {
+				next[p.ID] = &t
+			}
+		}
+	}
+
+	sortKey := func(p client.Policy) *time.Time {
+		if sortBy == "next-execution" {
+			return next[p.ID]
+		}
+		return p.CreatedAt
+	}
+	sort.SliceStable(policies, func(i, j int) bool {
+		a, b := sortKey(policies[i]), sortKey(policies[j])
+		// Policies without a timestamp always sort last.
+		if a == nil || b == nil {
+			return a != nil
+		}
+		if reverse {
+			return a.After(*b)
+		}
+		return a.Before(*b)
+	})
+
+	if format == "csv" {
+		rows := make([][]string, 0, len(policies))
+		for _, p := range policies {
+			rows = append(rows, policyCSVRow(p, next[p.ID]))
+		}
+		return writeCSV(file, policyCSVHeaders, rows)
+	}
+
 	fmt.Printf("Found %d policies:\n\n", len(policies))
-	for i, p := range policies {
-		policyID := p["id"]
-		active := p["active"]
-		createdAt := p["created_at"]
-		fmt.Printf("  %d. Policy ID: %v\n", i+1, policyID)
-		fmt.Printf("     Active: %v\n", active)
-		fmt.Printf("     Created: %v\n\n", createdAt)
+
+	rows := make([][]string, 0, len(policies))
+	for _, p := range policies {
+		active := "yes"
+		if !p.Active {
+			active = "no"
+			if p.DeactivationReason != nil {
+				active += " (" + *p.DeactivationReason + ")"
+			}
+		}
+		rows = append(rows, []string{
+			p.ID,
+			p.PluginID,
+			active,
+			formatTime(p.CreatedAt),
+			formatTime(next[p.ID]),
+		})
 	}
+	printTable([]string{"ID", "Plugin", "Active", "Created", "Next Execution"}, rows)
 
 	return nil
 }
 
-func runPolicyCreate(pluginID, configFile string, password string) error {
+// policyCreateOptions carries the 'policy create' flags that tune the
+// submitted policy rather than select it.
+type policyCreateOptions struct {
+	Output        string
+	Inactive      bool
+	DryRun        bool
+	PluginVersion string
+	PolicyVersion int
+	Limits        RateLimits
+	AllowLooser   bool
+	DerivePath    string
+}
+
+// PolicyCreateReport is the JSON form of the 'policy create' report.
+type PolicyCreateReport struct {
+	PluginID        string        `json:"plugin_id"`
+	Vault           string        `json:"vault"`
+	PolicyID        string        `json:"policy_id,omitempty"`
+	Active          bool          `json:"active"`
+	Rules           int           `json:"rules"`
+	RateLimitWindow uint32        `json:"rate_limit_window,omitempty"`
+	MaxTxsPerWindow uint32        `json:"max_txs_per_window,omitempty"`
+	DerivePath      string        `json:"derive_path"`
+	Timings         *PhaseTimings `json:"timings"`
+}
+
+func runPolicyCreate(ctx context.Context, pluginID, configFile string, password string, opts policyCreateOptions) error {
 	startTime := time.Now()
+	timings := NewPhaseTimings("policy create")
 
 	cfg, err := LoadConfig()
 	if err != nil {
 		return fmt.Errorf("load config: %w", err)
 	}
 
-	authHeader, err := GetAuthHeader()
+	pluginServerURL, err := getPluginServerURL(cfg.Verifier, pluginID)
 	if err != nil {
-		return fmt.Errorf("authentication required: %w\n\nRun 'devctl vault import --password xxx' to authenticate first", err)
+		return fmt.Errorf("get plugin server URL: %w", err)
+	}
+
+	authHeader, err := requireAuth(ctx, cfg.Verifier, pluginServerURL)
+	if err != nil {
+		return err
 	}
 
 	vaults, err := ListVaults()
@@ -259,36 +459,84 @@ func runPolicyCreate(pluginID, configFile string, password string) error {
 		return fmt.Errorf("missing or invalid 'recipe' in config file")
 	}
 
-	// Auto-fill addresses from vault if empty
-	recipeConfig, err = fillAddressesFromVault(recipeConfig, vault)
+	active := true
+	if value, ok := policyConfig["active"]; ok {
+		active, ok = value.(bool)
+		if !ok {
+			return fmt.Errorf("'active' in config file must be true or false")
+		}
+	}
+	if opts.Inactive {
+		active = false
+	}
+
+	derivePath, err := authDerivePath(opts.DerivePath)
 	if err != nil {
-		return fmt.Errorf("fill addresses from vault: %w", err)
+		return err
 	}
 
-	fmt.Printf("Creating policy for plugin %s...\n", pluginID)
-	fmt.Printf("  Vault: %s (%s...)\n", vault.Name, vault.PublicKeyECDSA[:16])
-	fmt.Printf("  Config: %s\n", configFile)
+	_, err = parseBillingConfig(policyConfig["billing"])
+	if err != nil {
+		return fmt.Errorf("invalid config file: %w", err)
+	}
 
-	// Step 1: Get plugin server URL
-	pluginServerURL, err := getPluginServerURL(cfg.Verifier, pluginID)
+	requested, err := rateLimitsFromConfig(policyConfig)
 	if err != nil {
-		return fmt.Errorf("get plugin server URL: %w", err)
+		return err
+	}
+	if opts.Limits.Window != 0 {
+		requested.Window = opts.Limits.Window
+	}
+	if opts.Limits.MaxTxs != 0 {
+		requested.MaxTxs = opts.Limits.MaxTxs
+	}
+
+	// Auto-fill the plugin's address fields from the vault if empty
+	recipeConfig, err = fillAddressesFromVault(recipeConfig, vault, recipeAddressFields(ctx, cfg, pluginID))
+	if err != nil {
+		return fmt.Errorf("fill addresses from vault: %w", err)
 	}
-	fmt.Printf("  Plugin Server: %s\n", pluginServerURL)
+
+	consistency, err := checkVaultConsistency(ctx, cfg, vault, pluginID)
+	if err != nil {
+		progressf("%s Could not check the plugin installation: %v\n", warnMark(), err)
+	} else {
+		consistency.printWarnings()
+	}
+
+	progressf("Creating policy for plugin %s...\n", pluginID)
+	progressf("  Vault: %s (%s...)\n", vault.Name, vault.PublicKeyECDSA[:16])
+	progressf("  Config: %s\n", configFile)
+	printTraceID()
+	warnTestnetTokenMix(recipeConfig)
+	printRecipeGasEstimate(vault, recipeConfig)
+
+	// Step 1: Plugin server URL
+	progressf("  Plugin Server: %s\n", pluginServerURL)
 
 	// Step 2: Call plugin's suggest endpoint to get rules
-	fmt.Println("\nFetching policy template from plugin...")
-	policySuggest, err := getPluginPolicySuggest(pluginServerURL, recipeConfig)
+	progressln("\nFetching policy template from plugin...")
+	endPhase := timings.Start("Policy template")
+	policySuggest, err := getPluginPolicySuggest(ctx, pluginServerURL, recipeConfig)
+	endPhase()
 	if err != nil {
 		return fmt.Errorf("get policy suggest: %w", err)
 	}
-	fmt.Printf("  Rules: %d\n", len(policySuggest.GetRules()))
+	progressf("  Rules: %d\n", len(policySuggest.GetRules()))
 	if policySuggest.RateLimitWindow != nil {
-		fmt.Printf("  Rate Limit Window: %ds\n", policySuggest.GetRateLimitWindow())
+		progressf("  Rate Limit Window: %ds\n", policySuggest.GetRateLimitWindow())
 	}
 
 	// Step 3: Build protobuf Policy
-	policy, err := buildProtobufPolicy(pluginID, recipeConfig, policyConfig["billing"], policySuggest)
+	limits, err := mergeRateLimits(suggestedRateLimits(policySuggest), requested, opts.AllowLooser)
+	if err != nil {
+		return err
+	}
+	if limits.Window != 0 || limits.MaxTxs != 0 {
+		progressf("  Effective Rate Limit: %s\n", limits)
+	}
+
+	policy, err := buildProtobufPolicy(pluginID, recipeConfig, policyConfig["billing"], policySuggest, limits)
 	if err != nil {
 		return fmt.Errorf("build protobuf policy: %w", err)
 	}
@@ -300,56 +548,48 @@ func runPolicyCreate(pluginID, configFile string, password string) error {
 	}
 	recipeBase64 := base64.StdEncoding.EncodeToString(policyBytes)
 
+	// A new policy starts at version 1; updates bump the stored version.
 	policyVersion := 1
-	pluginVersion := "1.0.0"
-
-	// Step 5: Create signature message and sign
-	// Message format: {recipe}*#*{public_key}*#*{policy_version}*#*{plugin_version}
-	signatureMessage := fmt.Sprintf("%s*#*%s*#*%d*#*%s",
-		recipeBase64,
-		vault.PublicKeyECDSA,
-		policyVersion,
-		pluginVersion,
-	)
-
-	// DEBUG: print message details
-	fmt.Printf("\n  DEBUG: Signing message:\n")
-	fmt.Printf("    Recipe (first 50 chars): %s...\n", recipeBase64[:min(50, len(recipeBase64))])
-	fmt.Printf("    Public Key: %s\n", vault.PublicKeyECDSA)
-	fmt.Printf("    Policy Version: %d\n", policyVersion)
-	fmt.Printf("    Plugin Version: %s\n", pluginVersion)
-	fmt.Printf("    Full message length: %d\n", len(signatureMessage))
-
-	ethPrefixedMessage := fmt.Sprintf("\x19Ethereum Signed Message:\n%d%s", len(signatureMessage), signatureMessage)
-	messageHash := crypto.Keccak256([]byte(ethPrefixedMessage))
-	hexMessage := hex.EncodeToString(messageHash)
-	fmt.Printf("    Message hash: %s\n", hexMessage)
-
-	fmt.Println("\nSigning policy with TSS keysign (2-of-2 with Fast Vault Server)...")
+	if opts.PolicyVersion > 0 {
+		policyVersion = opts.PolicyVersion
+	}
+	pluginVersion, versionSource := resolvePluginVersion(ctx, cfg.Verifier, pluginID, authHeader)
+	if opts.PluginVersion != "" {
+		pluginVersion, versionSource = opts.PluginVersion, "--plugin-version"
+	}
+	progressf("  Policy Version: %d\n", policyVersion)
+	progressf("  Plugin Version: %s (%s)\n", pluginVersion, versionSource)
+
+	if opts.DryRun {
+		fmt.Println("\nDry run - policy not signed or submitted:")
+		fmt.Printf("  Plugin:          %s\n", pluginID)
+		fmt.Printf("  Vault:           %s (%s...)\n", vault.Name, vault.PublicKeyECDSA[:16])
+		fmt.Printf("  Rules:           %d\n", len(policySuggest.GetRules()))
+		fmt.Printf("  Active:          %v\n", active)
+		fmt.Printf("  Rate Limit:      %s\n", limits)
+		fmt.Printf("  Policy Version:  %d\n", policyVersion)
+		fmt.Printf("  Plugin Version:  %s (%s)\n", pluginVersion, versionSource)
+		fmt.Printf("  Derive Path:     %s\n", derivePath)
+		fmt.Printf("  Recipe:          %s\n", format.Truncate(recipeBase64, 60))
+		fmt.Println("\nRun without --dry-run to execute.")
+		return nil
+	}
 
+	// Step 5: Sign the policy with TSS keysign
 	if password == "" {
 		return fmt.Errorf("password is required for TSS keysign. Use --password flag")
 	}
 
 	tss := NewTSSService(vault.LocalPartyID)
-	ctx, cancel := context.WithTimeout(context.Background(), 90*time.Second)
+	tss.phases = timings
+	ctx, cancel := context.WithTimeout(ctx, 90*time.Second)
 	defer cancel()
 
-	derivePath := "m/44'/60'/0'/0/0"
-	results, err := tss.KeysignWithFastVault(ctx, vault, []string{hexMessage}, derivePath, password)
+	signature, err := signPolicy(ctx, tss, vault, recipeBase64, policyVersion, pluginVersion, derivePath, password)
 	if err != nil {
-		return fmt.Errorf("TSS keysign failed: %w", err)
+		return err
 	}
 
-	if len(results) == 0 {
-		return fmt.Errorf("no signature result")
-	}
-
-	// Build signature in Ethereum format (R + S + V) - same as auth signing
-	signature := "0x" + results[0].R + results[0].S + results[0].RecoveryID
-	fmt.Printf("  DEBUG: Signature: %s\n", signature)
-	fmt.Printf("  DEBUG: R: %s, S: %s, V: %s\n", results[0].R, results[0].S, results[0].RecoveryID)
-
 	// Step 6: Build billing array for API request
 	billingArray, err := buildBillingArray(policyConfig["billing"])
 	if err != nil {
@@ -364,7 +604,7 @@ func runPolicyCreate(pluginID, configFile string, password string) error {
 		"signature":      signature,
 		"recipe":         recipeBase64,
 		"billing":        billingArray,
-		"active":         true,
+		"active":         active,
 	}
 
 	policyJSON, err := json.Marshal(policyRequest)
@@ -373,7 +613,8 @@ func runPolicyCreate(pluginID, configFile string, password string) error {
 	}
 
 	// Step 7: Submit to verifier
-	fmt.Println("\nSubmitting policy to verifier...")
+	progressln("\nSubmitting policy to verifier...")
+	endPhase = timings.Start("Verifier submit")
 
 	url := cfg.Verifier + "/plugin/policy"
 	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(policyJSON))
@@ -392,13 +633,41 @@ func runPolicyCreate(pluginID, configFile string, password string) error {
 	body, _ := io.ReadAll(resp.Body)
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("create policy failed (%d): %s", resp.StatusCode, string(body))
+		return fmt.Errorf("create policy failed (%d): %s", resp.StatusCode, errorBody(body))
 	}
 
-	var result map[string]interface{}
-	json.Unmarshal(body, &result)
+	endPhase()
+	recordVaultUsage(vault, cfg, vaultUsagePolicy)
+
+	created, err := client.Decode[client.Policy](body)
+	if err != nil {
+		progressf("  Warning: could not parse create response: %v\n", err)
+	} else {
+		active = created.Active
+	}
 
 	totalDuration := time.Since(startTime)
+	timings.Relay = tss.RelaySummary()
+	timings.Finish(totalDuration)
+
+	if opts.Output == "json" {
+		data, err := json.MarshalIndent(PolicyCreateReport{
+			PluginID:        pluginID,
+			Vault:           vault.PublicKeyECDSA,
+			PolicyID:        created.ID,
+			Active:          active,
+			Rules:           len(policySuggest.GetRules()),
+			RateLimitWindow: limits.Window,
+			MaxTxsPerWindow: limits.MaxTxs,
+			DerivePath:      derivePath,
+			Timings:         timings,
+		}, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal report: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
 
 	// Print completion report
 	fmt.Println()
@@ -408,20 +677,98 @@ func runPolicyCreate(pluginID, configFile string, password string) error {
 	fmt.Println("│                                                                 │")
 	fmt.Printf("│  Plugin:      %-50s │\n", pluginID)
 	fmt.Printf("│  Vault:       %-50s │\n", vault.PublicKeyECDSA[:16]+"...")
-	if data, ok := result["data"].(map[string]interface{}); ok {
-		if id, ok := data["id"].(string); ok {
-			fmt.Printf("│  Policy ID:   %-50s │\n", id)
-		}
+	if created.ID != "" {
+		fmt.Printf("│  Policy ID:   %-50s │\n", created.ID)
+	}
+	if active {
+		fmt.Printf("│  Active:      %-50s │\n", "yes")
+	} else {
+		fmt.Printf("│  Active:      %-50s │\n", "no (not scheduled until resumed)")
 	}
 	fmt.Printf("│  Rules:       %-50d │\n", len(policySuggest.GetRules()))
+	fmt.Printf("│  Rate Limit:  %-50s │\n", limits.String())
+	fmt.Printf("│  Derive Path: %-50s │\n", derivePath)
 	fmt.Println("│                                                                 │")
-	fmt.Printf("│  Total Time:  %-50s │\n", totalDuration.Round(time.Millisecond).String())
+	timings.printPhaseRows()
+	fmt.Printf("│  Total Time:  %-50s │\n", format.Duration(totalDuration))
 	fmt.Println("│                                                                 │")
 	fmt.Println("└─────────────────────────────────────────────────────────────────┘")
 
+	if !active && created.ID != "" {
+		fmt.Printf("\nActivate with: devctl policy resume %s\n", created.ID)
+	}
+
 	return nil
 }
 
+// signPolicy signs a policy with a 2-of-2 Fast Vault keysign and returns the
+// signature in Ethereum format (R + S + V), as the verifier expects on create
+// and update. The message is
+// {recipe}*#*{public_key}*#*{policy_version}*#*{plugin_version}.
+func signPolicy(ctx context.Context, tss *TSSService, vault *LocalVault, recipeBase64 string, policyVersion int, pluginVersion, derivePath, password string) (string, error) {
+	signatureMessage := fmt.Sprintf("%s*#*%s*#*%d*#*%s",
+		recipeBase64,
+		vault.PublicKeyECDSA,
+		policyVersion,
+		pluginVersion,
+	)
+
+	// DEBUG: print message details
+	progressf("\n  DEBUG: Signing message:\n")
+	progressf("    Recipe (first 50 chars): %s...\n", recipeBase64[:min(50, len(recipeBase64))])
+	progressf("    Public Key: %s\n", vault.PublicKeyECDSA)
+	progressf("    Policy Version: %d\n", policyVersion)
+	progressf("    Plugin Version: %s\n", pluginVersion)
+	progressf("    Derive Path: %s\n", derivePath)
+	progressf("    Full message length: %d\n", len(signatureMessage))
+
+	ethPrefixedMessage := fmt.Sprintf("\x19Ethereum Signed Message:\n%d%s", len(signatureMessage), signatureMessage)
+	messageHash := crypto.Keccak256([]byte(ethPrefixedMessage))
+	hexMessage := hex.EncodeToString(messageHash)
+	progressf("    Message hash: %s\n", hexMessage)
+
+	progressln("\nSigning policy with TSS keysign (2-of-2 with Fast Vault Server)...")
+
+	results, err := tss.KeysignWithFastVault(ctx, vault, []string{hexMessage}, derivePath, password)
+	if err != nil {
+		return "", fmt.Errorf("TSS keysign failed: %w", err)
+	}
+
+	if len(results) == 0 {
+		return "", fmt.Errorf("no signature result")
+	}
+
+	signature, err := rsvSignature(results[0], vRaw, nil)
+	if err != nil {
+		return "", fmt.Errorf("assemble signature: %w", err)
+	}
+	progressf("  DEBUG: Signature: %s\n", signature)
+	progressf("  DEBUG: R: %s, S: %s, V: %s\n", results[0].R, results[0].S, results[0].RecoveryID)
+	return signature, nil
+}
+
+// defaultPluginVersion is signed into policies when the verifier's plugin
+// record carries no version.
+const defaultPluginVersion = "1.0.0"
+
+// resolvePluginVersion returns the plugin version to sign policies with and
+// where it came from. The verifier's plugin record is authoritative; records
+// without a version fall back to defaultPluginVersion.
+func resolvePluginVersion(ctx context.Context, verifierURL, pluginID, authHeader string) (string, string) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	plugin, err := getAPI[client.Plugin](ctx, fmt.Sprintf("%s/plugins/%s", verifierURL, pluginID), authHeader)
+	if err != nil {
+		progressf("  Warning: could not fetch plugin record, using version %s: %v\n", defaultPluginVersion, err)
+		return defaultPluginVersion, "default"
+	}
+	if plugin.Version == "" {
+		return defaultPluginVersion, "default"
+	}
+	return plugin.Version, "verifier"
+}
+
 func getPluginServerURL(verifierURL, pluginID string) (string, error) {
 	// For local dev, use hardcoded URLs
 	pluginURLs := map[string]string{
@@ -437,7 +784,7 @@ func getPluginServerURL(verifierURL, pluginID string) (string, error) {
 	return "", fmt.Errorf("unknown plugin ID: %s", pluginID)
 }
 
-func getPluginPolicySuggest(pluginServerURL string, recipeConfig map[string]interface{}) (*rtypes.PolicySuggest, error) {
+func getPluginPolicySuggest(ctx context.Context, pluginServerURL string, recipeConfig map[string]interface{}) (*rtypes.PolicySuggest, error) {
 	reqBody, err := json.Marshal(map[string]interface{}{
 		"configuration": recipeConfig,
 	})
@@ -445,7 +792,7 @@ func getPluginPolicySuggest(pluginServerURL string, recipeConfig map[string]inte
 		return nil, fmt.Errorf("marshal request: %w", err)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
 	req, err := http.NewRequestWithContext(ctx, "POST", pluginServerURL+"/plugin/recipe-specification/suggest", bytes.NewReader(reqBody))
@@ -466,7 +813,7 @@ func getPluginPolicySuggest(pluginServerURL string, recipeConfig map[string]inte
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("suggest failed (%d): %s", resp.StatusCode, string(body))
+		return nil, fmt.Errorf("suggest failed (%d): %s", resp.StatusCode, errorBody(body))
 	}
 
 	policySuggest := &rtypes.PolicySuggest{}
@@ -478,7 +825,7 @@ func getPluginPolicySuggest(pluginServerURL string, recipeConfig map[string]inte
 	return policySuggest, nil
 }
 
-func buildProtobufPolicy(pluginID string, recipeConfig map[string]interface{}, billingConfig interface{}, suggest *rtypes.PolicySuggest) (*rtypes.Policy, error) {
+func buildProtobufPolicy(pluginID string, recipeConfig map[string]interface{}, billingConfig interface{}, suggest *rtypes.PolicySuggest, limits RateLimits) (*rtypes.Policy, error) {
 	// Build Configuration from recipe config
 	configuration, err := structpb.NewStruct(recipeConfig)
 	if err != nil {
@@ -499,125 +846,90 @@ func buildProtobufPolicy(pluginID string, recipeConfig map[string]interface{}, b
 		FeePolicies:   feePolicies,
 	}
 
-	if suggest.RateLimitWindow != nil {
-		policy.RateLimitWindow = suggest.RateLimitWindow
+	if limits.Window != 0 {
+		policy.RateLimitWindow = &limits.Window
 	}
-	if suggest.MaxTxsPerWindow != nil {
-		policy.MaxTxsPerWindow = suggest.MaxTxsPerWindow
+	if limits.MaxTxs != 0 {
+		policy.MaxTxsPerWindow = &limits.MaxTxs
 	}
 
 	return policy, nil
 }
 
-func buildFeePolicies(billingConfig interface{}) ([]*rtypes.FeePolicy, error) {
-	if billingConfig == nil {
-		return nil, nil
+func min(a, b int) int {
+	if a < b {
+		return a
 	}
+	return b
+}
 
-	var billingArray []interface{}
-
-	switch v := billingConfig.(type) {
-	case []interface{}:
-		billingArray = v
-	case map[string]interface{}:
-		billingArray = []interface{}{v}
-	default:
-		return nil, fmt.Errorf("invalid billing config type: %T", billingConfig)
+func runPolicyDelete(ctx context.Context, policyID string, yes bool) error {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
 	}
 
-	var feePolicies []*rtypes.FeePolicy
-	for _, item := range billingArray {
-		billing, ok := item.(map[string]interface{})
-		if !ok {
-			continue
-		}
-
-		feePolicy := &rtypes.FeePolicy{
-			Id: uuid.New().String(),
-		}
-
-		if typeStr, ok := billing["type"].(string); ok {
-			switch strings.ToLower(typeStr) {
-			case "once", "one_time", "one-time":
-				feePolicy.Type = rtypes.FeeType_ONCE
-			case "transaction", "per_tx", "per-tx":
-				feePolicy.Type = rtypes.FeeType_TRANSACTION
-			case "recurring":
-				feePolicy.Type = rtypes.FeeType_RECURRING
-			default:
-				feePolicy.Type = rtypes.FeeType_ONCE
-			}
-		}
+	err = confirmDestructive(describePolicyForDelete(ctx, cfg, policyID), "", yes)
+	if err != nil {
+		return err
+	}
 
-		if amount, ok := billing["amount"].(float64); ok {
-			feePolicy.Amount = int64(amount)
-		}
+	progressf("Deleting policy %s...\n", policyID)
 
-		if freq, ok := billing["frequency"].(string); ok {
-			switch strings.ToLower(freq) {
-			case "daily":
-				feePolicy.Frequency = rtypes.BillingFrequency_DAILY
-			case "weekly":
-				feePolicy.Frequency = rtypes.BillingFrequency_WEEKLY
-			case "biweekly", "bi-weekly":
-				feePolicy.Frequency = rtypes.BillingFrequency_BIWEEKLY
-			case "monthly":
-				feePolicy.Frequency = rtypes.BillingFrequency_MONTHLY
-			}
-		}
+	url := fmt.Sprintf("%s/plugin/policy/%s", cfg.Verifier, policyID)
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
 
-		feePolicies = append(feePolicies, feePolicy)
+	req, _ := http.NewRequestWithContext(ctx, "DELETE", url, nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
 	}
+	defer resp.Body.Close()
 
-	return feePolicies, nil
+	body, _ := io.ReadAll(resp.Body)
+	fmt.Printf("Response (%d): %s\n", resp.StatusCode, string(body))
+
+	return nil
 }
 
-func buildBillingArray(billingConfig interface{}) ([]map[string]interface{}, error) {
-	if billingConfig == nil {
-		return []map[string]interface{}{}, nil
+// describePolicyForDelete builds the confirmation question for policy delete,
+// naming the plugin and creation date when the verifier can be reached.
+func describePolicyForDelete(ctx context.Context, cfg *DevConfig, policyID string) string {
+	short := policyID
+	if len(short) > 8 {
+		short = short[:8] + "…"
 	}
 
-	var billingArray []interface{}
-
-	switch v := billingConfig.(type) {
-	case []interface{}:
-		billingArray = v
-	case map[string]interface{}:
-		billingArray = []interface{}{v}
-	default:
-		return nil, fmt.Errorf("invalid billing config type: %T", billingConfig)
-	}
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
 
-	var result []map[string]interface{}
-	for _, item := range billingArray {
-		if billing, ok := item.(map[string]interface{}); ok {
-			result = append(result, billing)
-		}
+	authHeader, _ := GetAuthHeader()
+	policy, err := getAPI[client.Policy](ctx, fmt.Sprintf("%s/plugin/policy/%s", cfg.Verifier, policyID), authHeader)
+	if err != nil {
+		return fmt.Sprintf("Delete policy %s?", short)
 	}
 
-	return result, nil
-}
-
-func min(a, b int) int {
-	if a < b {
-		return a
+	question := fmt.Sprintf("Delete policy %s", short)
+	if policy.CreatedAt != nil {
+		question += " created " + policy.CreatedAt.Format("2006-01-02")
 	}
-	return b
+	return question + fmt.Sprintf(" for plugin %s?", policy.PluginID)
 }
 
-func runPolicyDelete(policyID string) error {
+func runPolicyInfo(ctx context.Context, policyID string) error {
 	cfg, err := LoadConfig()
 	if err != nil {
 		return fmt.Errorf("load config: %w", err)
 	}
 
-	fmt.Printf("Deleting policy %s...\n", policyID)
+	progressf("Fetching policy %s...\n\n", policyID)
 
 	url := fmt.Sprintf("%s/plugin/policy/%s", cfg.Verifier, policyID)
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
-	req, _ := http.NewRequestWithContext(ctx, "DELETE", url, nil)
+	req, _ := http.NewRequestWithContext(ctx, "GET", url, nil)
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
 		return fmt.Errorf("request failed: %w", err)
@@ -625,81 +937,225 @@ func runPolicyDelete(policyID string) error {
 	defer resp.Body.Close()
 
 	body, _ := io.ReadAll(resp.Body)
-	fmt.Printf("Response (%d): %s\n", resp.StatusCode, string(body))
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("request failed (%d): %s", resp.StatusCode, errorBody(body))
+	}
+
+	policy, err := client.Decode[client.Policy](body)
+	if err != nil {
+		return fmt.Errorf("parse policy: %w", err)
+	}
+
+	fmt.Printf("  Policy ID:       %s\n", policy.ID)
+	fmt.Printf("  Plugin:          %s\n", policy.PluginID)
+	fmt.Printf("  Public Key:      %s\n", policy.PublicKey)
+	fmt.Printf("  Active:          %v\n", policy.Active)
+	if policy.DeactivationReason != nil {
+		fmt.Printf("  Deactivated:     %s\n", *policy.DeactivationReason)
+	}
+	fmt.Printf("  Policy Version:  %d\n", policy.PolicyVersion)
+	fmt.Printf("  Plugin Version:  %s\n", policy.PluginVersion)
+	fmt.Printf("  Billing Entries: %d\n", len(policy.Billing))
+	fmt.Printf("  Recipe:          %s\n", format.Truncate(policy.Recipe, 60))
+	fmt.Printf("  Signature:       %s\n", format.Truncate(policy.Signature, 60))
 
 	return nil
 }
 
-func runPolicyInfo(policyID string) error {
-	cfg, err := LoadConfig()
+// listVaultPolicies returns the vault's policies for pluginID.
+func listVaultPolicies(ctx context.Context, verifierURL, authHeader, pluginID, publicKey string) ([]client.Policy, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	list, err := getAPI[client.PolicyList](ctx, fmt.Sprintf("%s/plugin/policies/%s?public_key=%s", verifierURL, pluginID, publicKey), authHeader)
 	if err != nil {
-		return fmt.Errorf("load config: %w", err)
+		return nil, fmt.Errorf("list policies: %w", err)
 	}
+	return list.Policies, nil
+}
 
-	fmt.Printf("Fetching policy %s...\n\n", policyID)
+// deletePolicySigned deletes a policy through the verifier's signed flow:
+// the stored recipe and versions are re-signed by the vault and the
+// signature is sent with the DELETE.
+func deletePolicySigned(ctx context.Context, verifierURL, authHeader string, tss *TSSService, vault *LocalVault, policy client.Policy, password string) error {
+	derivePath, err := authDerivePath("")
+	if err != nil {
+		return err
+	}
+	signature, err := signPolicy(ctx, tss, vault, policy.Recipe, policy.PolicyVersion, policy.PluginVersion, derivePath, password)
+	if err != nil {
+		return err
+	}
 
-	url := fmt.Sprintf("%s/plugin/policy/%s", cfg.Verifier, policyID)
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+	body, err := json.Marshal(map[string]string{"signature": signature})
+	if err != nil {
+		return fmt.Errorf("marshal delete request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s/plugin/policy/%s", verifierURL, policy.ID), bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", authHeader)
 
-	req, _ := http.NewRequestWithContext(ctx, "GET", url, nil)
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("request failed: %w", err)
+		return fmt.Errorf("delete policy: %w", err)
 	}
 	defer resp.Body.Close()
 
-	body, _ := io.ReadAll(resp.Body)
-
-	var result map[string]interface{}
-	json.Unmarshal(body, &result)
+	respBody, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("delete policy failed (%d): %s", resp.StatusCode, errorBody(respBody))
+	}
+	return nil
+}
 
-	prettyJSON, _ := json.MarshalIndent(result, "", "  ")
-	fmt.Println(string(prettyJSON))
+// removeSchedulerRows drops policyID from the plugin's scheduler table so the
+// plugin stops trying to execute it.
+func removeSchedulerRows(spec PluginSpec, policyID string) error {
+	cmd := psqlCommand(spec.Database, "-c",
+		fmt.Sprintf("DELETE FROM %s WHERE policy_id = '%s'", spec.SchedulerTable, policyID))
 
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("delete scheduler rows: %w\nOutput: %s", err, string(output))
+	}
 	return nil
 }
 
-func runPolicyHistory(policyID string) error {
+// runPolicySetActive pauses or resumes a policy. The verifier only accepts
+// updates signed by the vault, so the existing recipe is re-signed with the
+// next policy_version before the PUT.
+func runPolicySetActive(ctx context.Context, policyID string, active bool, password string) error {
 	cfg, err := LoadConfig()
 	if err != nil {
 		return fmt.Errorf("load config: %w", err)
 	}
 
-	fmt.Printf("Fetching transaction history for policy %s...\n\n", policyID)
+	authHeader, err := requireAuth(ctx, cfg.Verifier, "")
+	if err != nil {
+		return err
+	}
+
+	policy, err := getAPI[client.Policy](ctx, fmt.Sprintf("%s/plugin/policy/%s", cfg.Verifier, policyID), authHeader)
+	if err != nil {
+		return fmt.Errorf("get policy: %w", err)
+	}
+
+	action := "paused"
+	if active {
+		action = "active"
+	}
+	if policy.Active == active {
+		fmt.Printf("%s Policy %s is already %s\n", symOK, policyID, action)
+		return nil
+	}
+
+	vault, err := LoadVault(policy.PublicKey)
+	if err != nil {
+		return fmt.Errorf("load vault for policy: %w", err)
+	}
+
+	if os.Getenv("VAULT_PASSWORD") != "" {
+		password = os.Getenv("VAULT_PASSWORD")
+	}
+	if password == "" {
+		password, err = promptPassword("", "Enter Fast Vault password: ")
+		if err != nil {
+			return err
+		}
+	}
 
-	url := fmt.Sprintf("%s/plugin/policies/%s/history", cfg.Verifier, policyID)
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	tss := NewTSSService(vault.LocalPartyID)
+	signCtx, cancel := context.WithTimeout(ctx, 90*time.Second)
 	defer cancel()
 
-	req, _ := http.NewRequestWithContext(ctx, "GET", url, nil)
+	policyVersion := policy.PolicyVersion + 1
+	pluginVersion, versionSource := resolvePluginVersion(signCtx, cfg.Verifier, policy.PluginID, authHeader)
+	if versionSource == "default" && policy.PluginVersion != "" {
+		pluginVersion = policy.PluginVersion
+	}
+	derivePath, err := authDerivePath("")
+	if err != nil {
+		return err
+	}
+	signature, err := signPolicy(signCtx, tss, vault, policy.Recipe, policyVersion, pluginVersion, derivePath, password)
+	if err != nil {
+		return err
+	}
+
+	billing := make([]map[string]interface{}, 0, len(policy.Billing))
+	for _, b := range policy.Billing {
+		entry := map[string]interface{}{
+			"type":   b.Type,
+			"amount": b.Amount,
+		}
+		if b.Frequency != nil {
+			entry["frequency"] = *b.Frequency
+		}
+		if b.Asset != "" {
+			entry["asset"] = b.Asset
+		}
+		billing = append(billing, entry)
+	}
+
+	policyJSON, err := json.Marshal(map[string]interface{}{
+		"id":             policy.ID,
+		"plugin_id":      policy.PluginID,
+		"public_key":     policy.PublicKey,
+		"plugin_version": pluginVersion,
+		"policy_version": policyVersion,
+		"signature":      signature,
+		"recipe":         policy.Recipe,
+		"billing":        billing,
+		"active":         active,
+	})
+	if err != nil {
+		return fmt.Errorf("marshal policy update: %w", err)
+	}
+
+	progressln("\nSubmitting policy update to verifier...")
+
+	req, err := http.NewRequestWithContext(signCtx, "PUT", cfg.Verifier+"/plugin/policy", bytes.NewReader(policyJSON))
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", authHeader)
+
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("request failed: %w", err)
+		return fmt.Errorf("update policy: %w", err)
 	}
 	defer resp.Body.Close()
 
 	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("update policy failed (%d): %s", resp.StatusCode, errorBody(body))
+	}
 
-	var result map[string]interface{}
-	json.Unmarshal(body, &result)
-
-	prettyJSON, _ := json.MarshalIndent(result, "", "  ")
-	fmt.Println(string(prettyJSON))
+	fmt.Printf("%s Policy %s is now %s (policy_version %d)\n", symOK, policyID, action, policyVersion)
+	if active {
+		fmt.Println("  The scheduler picks it up on its next poll (every 30s).")
+	} else {
+		fmt.Println("  No further executions are scheduled. Resume with:")
+		fmt.Println("  devctl policy resume " + policyID)
+	}
 
 	return nil
 }
 
-func fillAddressesFromVault(recipeConfig map[string]interface{}, vault *LocalVault) (map[string]interface{}, error) {
-	fromAsset, hasFrom := recipeConfig["from"].(map[string]interface{})
-	toAsset, hasTo := recipeConfig["to"].(map[string]interface{})
-
-	if !hasFrom && !hasTo {
-		return recipeConfig, nil
-	}
-
+// fillAddressesFromVault sets each empty or missing recipe field in fields
+// (see recipeAddressFields) to the vault's address on the chain its chain
+// field names, and resolves token symbols next to those fields on EVM
+// chains. Fields whose parent object is absent, whose chain is unset, or
+// that already hold a value are left alone, as is the rest of the recipe.
+func fillAddressesFromVault(recipeConfig map[string]interface{}, vault *LocalVault, fields map[string]string) (map[string]interface{}, error) {
 	deriveAddress := func(chainStr string) (string, error) {
-		chain, err := common.FromString(chainStr)
+		chain, err := derivationChain(chainStr)
 		if err != nil {
 			return "", fmt.Errorf("unknown chain: %s", chainStr)
 		}
@@ -716,76 +1172,227 @@ func fillAddressesFromVault(recipeConfig map[string]interface{}, vault *LocalVau
 		return addr, nil
 	}
 
-	if hasFrom {
-		chainStr, _ := fromAsset["chain"].(string)
-		existingAddr, _ := fromAsset["address"].(string)
-		if existingAddr == "" && chainStr != "" {
-			addr, err := deriveAddress(chainStr)
-			if err != nil {
-				return nil, err
-			}
-			fromAsset["address"] = addr
-			fmt.Printf("  Auto-filled from.address: %s\n", addr)
-		}
+	paths := make([]string, 0, len(fields))
+	for path := range fields {
+		paths = append(paths, path)
 	}
+	sort.Strings(paths)
 
-	if hasTo {
-		chainStr, _ := toAsset["chain"].(string)
-		existingAddr, _ := toAsset["address"].(string)
-		if existingAddr == "" && chainStr != "" {
-			addr, err := deriveAddress(chainStr)
-			if err != nil {
-				return nil, err
+	for _, path := range paths {
+		parent, key := recipeParent(recipeConfig, path)
+		if parent == nil {
+			continue
+		}
+		if existing, ok := parent[key]; ok {
+			if s, isString := existing.(string); !isString || s != "" {
+				continue
 			}
-			toAsset["address"] = addr
-			fmt.Printf("  Auto-filled to.address: %s\n", addr)
 		}
+		chainStr, _ := recipeValue(recipeConfig, fields[path]).(string)
+		if chainStr == "" {
+			continue
+		}
+		addr, err := deriveAddress(chainStr)
+		if err != nil {
+			return nil, err
+		}
+		parent[key] = addr
+		progressf("  Auto-filled %s: %s\n", path, addr)
+	}
+
+	// A token given by symbol next to an address field on an EVM chain
+	// resolves through the chain's token list, including the tokens in
+	// cluster.yaml.
+	resolved := map[string]bool{}
+	for _, path := range paths {
+		parent, key := recipeParent(recipeConfig, path)
+		tokenPath := strings.TrimSuffix(path, key) + "token"
+		if parent == nil || resolved[tokenPath] {
+			continue
+		}
+		resolved[tokenPath] = true
+		token, _ := parent["token"].(string)
+		chainStr, _ := recipeValue(recipeConfig, fields[path]).(string)
+		c, evm := findSupportedChain(chainStr)
+		if !evm || token == "" || strings.HasPrefix(strings.ToLower(token), "0x") {
+			continue
+		}
+		tokens := tokenRegistry(c)
+		i := slices.IndexFunc(tokens, func(t TokenInfo) bool { return strings.EqualFold(t.Symbol, token) })
+		if i < 0 {
+			return nil, fmt.Errorf("%s %q is not a known token on %s; use the contract address or add it under tokens in cluster.yaml", tokenPath, token, c.Name)
+		}
+		parent["token"] = tokens[i].Address
+		progressf("  Resolved %s %s: %s\n", tokenPath, token, parent["token"])
 	}
 
 	return recipeConfig, nil
 }
 
+// recipeValue returns the value at a dotted path of the recipe, or nil.
+func recipeValue(recipeConfig map[string]interface{}, path string) interface{} {
+	parent, key := recipeParent(recipeConfig, path)
+	if parent == nil {
+		return nil
+	}
+	return parent[key]
+}
+
+// recipeParent returns the object holding the last element of a dotted path
+// and that element's key, or nil when an object along the path is missing.
+func recipeParent(recipeConfig map[string]interface{}, path string) (map[string]interface{}, string) {
+	names := strings.Split(path, ".")
+	obj := recipeConfig
+	for _, name := range names[:len(names)-1] {
+		next, ok := obj[name].(map[string]interface{})
+		if !ok {
+			return nil, ""
+		}
+		obj = next
+	}
+	return obj, names[len(names)-1]
+}
+
+// warnTestnetTokenMix warns when a recipe pairs a testnet chain with one of
+// the known mainnet token contracts, which will never hold a balance there.
+func warnTestnetTokenMix(recipeConfig map[string]interface{}) {
+	for _, side := range []string{"from", "to", "asset"} {
+		asset, ok := recipeConfig[side].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		chainStr, _ := asset["chain"].(string)
+		token, _ := asset["token"].(string)
+		if token == "" {
+			continue
+		}
+
+		for _, c := range testnetChains {
+			if !c.Matches(chainStr) {
+				continue
+			}
+			for _, t := range ethereumTokens {
+				if strings.EqualFold(t.Address, token) {
+					progressf("  %s Warning: %s.token is the mainnet %s contract but %s is a testnet\n", warnMark(), side, t.Symbol, c.Name)
+				}
+			}
+		}
+	}
+}
+
 func newPolicyStatusCmd() *cobra.Command {
+	var pluginID string
+	var all bool
+	var format, file string
+
 	cmd := &cobra.Command{
 		Use:   "status [policy-id]",
 		Short: "Show policy status including scheduler info",
-		Args:  cobra.ExactArgs(1),
+		Long: `Show a policy's verifier record, scheduler row and recent transactions.
+
+With --all, show every policy of the current vault in one table (active
+flag, next execution, last execution result, transaction counts by status)
+and flag scheduling anomalies: active policies of one plugin due in the same
+scheduler poll, active policies overdue by more than two polls (60s), and
+inactive policies that still have scheduler rows. --plugin limits --all to
+one plugin. --format csv writes the --all table as CSV, with the
+anomalies on stderr.
+
+Example:
+  devctl policy status <policy-id>
+  devctl policy status --all
+  devctl policy status --all --format csv -o schedule.csv
+`,
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runPolicyStatus(args[0])
+			if all {
+				if len(args) > 0 {
+					return fmt.Errorf("pass either a policy ID or --all")
+				}
+				err := checkListFormat(format, file)
+				if err != nil {
+					return err
+				}
+				return runPolicyStatusAll(cmd.Context(), pluginID, format, file)
+			}
+			if len(args) == 0 {
+				return fmt.Errorf("policy ID required (or pass --all)")
+			}
+			if format != "table" || file != "" {
+				return fmt.Errorf("--format and -o need --all")
+			}
+			return runPolicyStatus(args[0], pluginID)
 		},
 	}
+
+	addPolicyPluginFlag(cmd, &pluginID)
+	cmd.Flags().BoolVar(&all, "all", false, "Show every policy of the current vault with scheduling anomalies")
+	addListFormatFlags(cmd, &format, &file)
 	return cmd
 }
 
 func newPolicyTransactionsCmd() *cobra.Command {
-	var limit int
+	var filter HistoryFilter
+	var verbose bool
+	var pluginID string
+	var format, file string
 
 	cmd := &cobra.Command{
 		Use:   "transactions [policy-id]",
 		Short: "Show transactions for a policy",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runPolicyTransactions(args[0], limit)
+			err := checkListFormat(format, file)
+			if err != nil {
+				return err
+			}
+			return runPolicyTransactions(args[0], pluginID, filter, verbose, format, file)
 		},
 	}
 
-	cmd.Flags().IntVarP(&limit, "limit", "n", 10, "Number of transactions to show")
+	addHistoryFlags(cmd, &filter, 10, "transactions")
+	cmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "Show absolute timestamps")
+	addPolicyPluginFlag(cmd, &pluginID)
+	addListFormatFlags(cmd, &format, &file)
 	return cmd
 }
 
 func newPolicyTriggerCmd() *cobra.Command {
+	var pluginID string
+	var snapshot bool
+
 	cmd := &cobra.Command{
 		Use:   "trigger [policy-id]",
 		Short: "Manually trigger policy execution (set next_execution = NOW)",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runPolicyTrigger(args[0])
+			if snapshot {
+				err := takeSwapSnapshot(cmd.Context(), args[0])
+				if err != nil {
+					return fmt.Errorf("snapshot balances: %w", err)
+				}
+			}
+			return runPolicyTrigger(args[0], pluginID)
 		},
 	}
+
+	addPolicyPluginFlag(cmd, &pluginID)
+	cmd.Flags().BoolVar(&snapshot, "snapshot", false, "Record the from/to balances first, for 'devctl verify swap'")
 	return cmd
 }
 
-func runPolicyStatus(policyID string) error {
+// addPolicyPluginFlag adds --plugin to commands that read a plugin's
+// database. Without it the plugin comes from the policy's verifier record.
+func addPolicyPluginFlag(cmd *cobra.Command, pluginID *string) {
+	cmd.Flags().StringVar(pluginID, "plugin", "", "Plugin ID whose database holds the policy (default: from the verifier)")
+}
+
+func runPolicyStatus(policyID, pluginID string) error {
+	spec, err := policyPluginSpec(policyID, pluginID)
+	if err != nil {
+		return err
+	}
+
 	fmt.Printf("Policy Status: %s\n", policyID)
 	fmt.Println(strings.Repeat("=", 50))
 
@@ -795,19 +1402,27 @@ func runPolicyStatus(policyID string) error {
 		fmt.Printf("  Active:  %v\n", policyActive)
 		fmt.Printf("  Created: %s\n", policyCreated)
 	} else {
-		fmt.Printf("  ✗ Not found in database\n")
+		fmt.Printf("  %s Not found in database\n", symFail)
 	}
 
-	nextExec := checkScheduler(policyID)
+	nextExec := checkScheduler(spec, policyID)
 	fmt.Printf("\nScheduler:\n")
-	if nextExec != "" {
+	switch {
+	case policyCreated != "" && !policyActive && nextExec != "":
 		fmt.Printf("  Next Execution: %s\n", nextExec)
-	} else {
-		fmt.Printf("  ✗ Not scheduled (policy may be inactive or one-time completed)\n")
+		fmt.Printf("  %s Policy is inactive; the scheduler skips it until resumed\n", symWarn)
+		fmt.Printf("  Resume with: devctl policy resume %s\n", policyID)
+	case policyCreated != "" && !policyActive:
+		fmt.Printf("  %s Not scheduled: policy is inactive\n", symWarn)
+		fmt.Printf("  Resume with: devctl policy resume %s\n", policyID)
+	case nextExec != "":
+		fmt.Printf("  Next Execution: %s\n", nextExec)
+	default:
+		fmt.Printf("  %s Not scheduled (one-time completed or not picked up yet)\n", symFail)
 	}
 
 	fmt.Printf("\nRecent Transactions:\n")
-	txs := getRecentTransactions(policyID, 3)
+	txs := getRecentTransactions(spec, policyID, 3, time.Time{})
 	if len(txs) == 0 {
 		fmt.Printf("  No transactions found\n")
 	} else {
@@ -819,11 +1434,29 @@ func runPolicyStatus(policyID string) error {
 	return nil
 }
 
-func runPolicyTransactions(policyID string, limit int) error {
+func runPolicyTransactions(policyID, pluginID string, filter HistoryFilter, verbose bool, format, file string) error {
+	spec, err := policyPluginSpec(policyID, pluginID)
+	if err != nil {
+		return err
+	}
+
+	if format == "csv" {
+		txs := getRecentTransactions(spec, policyID, filter.Limit, filter.Since.Time())
+		rows := make([][]string, 0, len(txs))
+		for _, tx := range txs {
+			created := tx.CreatedAt
+			if t, ok := parsePostgresTime(tx.CreatedAt); ok {
+				created = isoTime(&t)
+			}
+			rows = append(rows, []string{policyID, spec.ID, tx.TxHash, tx.Status, tx.OnChainStatus, created})
+		}
+		return writeCSV(file, []string{"policy_id", "plugin_id", "tx_hash", "status", "status_onchain", "created_at"}, rows)
+	}
+
 	fmt.Printf("Transactions for Policy: %s\n", policyID)
 	fmt.Println(strings.Repeat("=", 60))
 
-	txs := getRecentTransactions(policyID, limit)
+	txs := getRecentTransactions(spec, policyID, filter.Limit, filter.Since.Time())
 	if len(txs) == 0 {
 		fmt.Println("\nNo transactions found for this policy.")
 		fmt.Println("\nPossible reasons:")
@@ -837,7 +1470,11 @@ func runPolicyTransactions(policyID string, limit int) error {
 	for i, tx := range txs {
 		fmt.Printf("%d. TX Hash: %s\n", i+1, tx.TxHash)
 		fmt.Printf("   Status: %s | On-chain: %s\n", tx.Status, tx.OnChainStatus)
-		fmt.Printf("   Created: %s\n", tx.CreatedAt)
+		created := tx.CreatedAt
+		if t, ok := parsePostgresTime(tx.CreatedAt); ok {
+			created = formatTimestamp(t, verbose)
+		}
+		fmt.Printf("   Created: %s\n", created)
 		if tx.TxHash != "" && tx.TxHash != "<nil>" {
 			fmt.Printf("   Explorer: https://etherscan.io/tx/%s\n", tx.TxHash)
 		}
@@ -847,12 +1484,16 @@ func runPolicyTransactions(policyID string, limit int) error {
 	return nil
 }
 
-func runPolicyTrigger(policyID string) error {
+func runPolicyTrigger(policyID, pluginID string) error {
+	spec, err := policyPluginSpec(policyID, pluginID)
+	if err != nil {
+		return err
+	}
+
 	fmt.Printf("Triggering policy: %s\n", policyID)
 
-	cmd := exec.Command("docker", "exec", "vultisig-postgres",
-		"psql", "-U", "vultisig", "-d", "vultisig-dca", "-c",
-		fmt.Sprintf("UPDATE scheduler SET next_execution = NOW() WHERE policy_id = '%s'", policyID))
+	cmd := psqlCommand(spec.Database, "-c",
+		fmt.Sprintf("UPDATE %s SET next_execution = NOW() WHERE policy_id = '%s'", spec.SchedulerTable, policyID))
 
 	output, err := cmd.CombinedOutput()
 	if err != nil {
@@ -861,7 +1502,7 @@ func runPolicyTrigger(policyID string) error {
 
 	result := strings.TrimSpace(string(output))
 	if strings.Contains(result, "UPDATE 0") {
-		fmt.Println("⚠ Policy not found in scheduler table.")
+		fmt.Printf("%s Policy not found in scheduler table.\n", symWarn)
 		fmt.Println("  This might mean:")
 		fmt.Println("  - Policy doesn't exist")
 		fmt.Println("  - Policy is inactive (one-time completed)")
@@ -869,10 +1510,11 @@ func runPolicyTrigger(policyID string) error {
 		return nil
 	}
 
-	fmt.Println("✓ Policy triggered! Scheduler will pick it up within 30 seconds.")
+	fmt.Printf("%s Policy triggered! Scheduler will pick it up within 30 seconds.\n", symOK)
 	fmt.Println("\nMonitor with:")
 	fmt.Println("  devctl policy status " + policyID)
 	fmt.Println("  devctl policy transactions " + policyID)
+	fmt.Println("  devctl verify swap " + policyID + " --tx <hash>")
 
 	return nil
 }
@@ -909,10 +1551,11 @@ func checkPolicyInDB(policyID string) (bool, string) {
 	return active, created
 }
 
-func checkScheduler(policyID string) string {
-	cmd := exec.Command("docker", "exec", "vultisig-postgres",
-		"psql", "-U", "vultisig", "-d", "vultisig-dca", "-t", "-c",
-		fmt.Sprintf("SELECT next_execution FROM scheduler WHERE policy_id = '%s' LIMIT 1", policyID))
+// checkScheduler returns the next execution of policyID from the scheduler
+// table of the plugin database.
+func checkScheduler(spec PluginSpec, policyID string) string {
+	cmd := psqlCommand(spec.Database, "-t", "-c",
+		fmt.Sprintf("SELECT next_execution FROM %s WHERE policy_id = '%s' LIMIT 1", spec.SchedulerTable, policyID))
 
 	output, err := cmd.CombinedOutput()
 	if err != nil {
@@ -922,14 +1565,21 @@ func checkScheduler(policyID string) string {
 	return strings.TrimSpace(string(output))
 }
 
-func getRecentTransactions(policyID string, limit int) []TxRecord {
-	cmd := exec.Command("docker", "exec", "vultisig-postgres",
-		"psql", "-U", "vultisig", "-d", "vultisig-dca", "-t", "-c",
-		fmt.Sprintf(`SELECT tx_hash, status, status_onchain, created_at
-			FROM tx_indexer
-			WHERE policy_id = '%s'
-			ORDER BY created_at DESC
-			LIMIT %d`, policyID, limit))
+// getRecentTransactions returns the newest tx indexer rows of a policy,
+// at most limit (0 = all) and none older than since (zero = no bound).
+func getRecentTransactions(spec PluginSpec, policyID string, limit int, since time.Time) []TxRecord {
+	query := fmt.Sprintf(`SELECT tx_hash, status, status_onchain, created_at
+			FROM %s
+			WHERE policy_id = '%s'`, spec.TxTable, policyID)
+	if !since.IsZero() {
+		query += fmt.Sprintf(" AND created_at >= '%s'", since.UTC().Format(time.RFC3339))
+	}
+	query += " ORDER BY created_at DESC"
+	if limit > 0 {
+		query += fmt.Sprintf(" LIMIT %d", limit)
+	}
+
+	cmd := psqlCommand(spec.Database, "-t", "-c", query)
 
 	output, err := cmd.CombinedOutput()
 	if err != nil {
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"encoding/json"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vultisig/vultisig-cluster/local/cmd/devctl/client"
+)
+
+var policyCSVHeaders = []string{
+	"policy_id", "plugin_id", "plugin_version", "policy_version", "active", "deactivation_reason",
+	"created_at", "updated_at", "next_execution",
+	"from_chain", "from_token", "from_symbol", "to_chain", "to_token", "to_symbol",
+	"from_amount", "from_amount_human", "frequency", "configuration",
+}
+
+// policyCSVRow flattens a policy and its decoded recipe into the columns of
+// policyCSVHeaders. Amounts are base units; from_amount_human divides by the
+// token's decimals. Recipe columns are empty when the recipe does not decode.
+func policyCSVRow(p client.Policy, next *time.Time) []string {
+	reason := ""
+	if p.DeactivationReason != nil {
+		reason = *p.DeactivationReason
+	}
+	row := []string{
+		p.ID, p.PluginID, p.PluginVersion, strconv.Itoa(p.PolicyVersion), strconv.FormatBool(p.Active), reason,
+		isoTime(p.CreatedAt), isoTime(p.UpdatedAt), isoTime(next),
+	}
+
+	recipe, err := decodePolicyRecipe(p.Recipe)
+	if err != nil {
+		return append(row, make([]string, len(policyCSVHeaders)-len(row))...)
+	}
+	config := recipe.GetConfiguration().AsMap()
+
+	fromChain, fromToken, fromSymbol, fromDecimals := recipeAsset(config, "from")
+	toChain, toToken, toSymbol, _ := recipeAsset(config, "to")
+	amount, _ := config["fromAmount"].(string)
+	human := ""
+	if raw, ok := new(big.Int).SetString(amount, 10); ok && fromDecimals >= 0 {
+		human = decimalString(raw, fromDecimals)
+	}
+	frequency, _ := config["frequency"].(string)
+	configJSON, _ := json.Marshal(config)
+
+	return append(row,
+		fromChain, fromToken, fromSymbol, toChain, toToken, toSymbol,
+		amount, human, frequency, string(configJSON))
+}
+
+// recipeAsset reads a from/to asset of a recipe configuration. decimals is
+// -1 when the chain is not in the registry.
+func recipeAsset(config map[string]interface{}, side string) (chain, token, symbol string, decimals int) {
+	asset, _ := config[side].(map[string]interface{})
+	chain, _ = asset["chain"].(string)
+	token, _ = asset["token"].(string)
+
+	info, ok := findSupportedChain(chain)
+	if !ok {
+		return chain, token, "", -1
+	}
+	if token == "" {
+		return chain, token, info.Symbol, info.Decimals
+	}
+	symbol, decimals = erc20Metadata(info.RPCURL, token)
+	return chain, token, symbol, decimals
+}
+
+// decimalString renders raw base units as an exact decimal number, without
+// trailing zeros.
+func decimalString(raw *big.Int, decimals int) string {
+	divisor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+	s := new(big.Rat).SetFrac(raw, divisor).FloatString(decimals)
+	if strings.Contains(s, ".") {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+	return s
+}
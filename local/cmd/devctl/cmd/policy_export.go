@@ -0,0 +1,246 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vultisig/vultisig-cluster/local/cmd/devctl/client"
+	"github.com/vultisig/vultisig-cluster/local/cmd/devctl/format"
+)
+
+func newPolicyExportCmd() *cobra.Command {
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "export <policy-id>",
+		Short: "Write a policy as a portable config file",
+		Long: `Fetch a policy, decode its recipe and write it as a config file that
+'policy create --config' (or 'policy import') accepts.
+
+Vault-specific data is left out: the public key, the signature and every
+address of the vault in the recipe, including the from/to/asset addresses,
+are cleared. Policy create fills them in from the importer's vault. The
+billing entries, rate limits and active flag are kept; billing start dates
+are dropped so the new policy bills from its own creation.
+
+Example:
+  devctl policy export <policy-id> -o dca-daily.json
+  devctl policy import --config dca-daily.json
+
+Note: Requires authentication. Run 'devctl auth login' first.
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runPolicyExport(cmd.Context(), args[0], output)
+		},
+	}
+
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Output file (default: stdout)")
+
+	return cmd
+}
+
+func newPolicyImportCmd() *cobra.Command {
+	cmd := newPolicyCreateCmd()
+	cmd.Use = "import"
+	cmd.Short = "Create a policy from a 'policy export' file for the current vault"
+	cmd.Long = `Create a policy from a file written by 'policy export'. This is 'policy
+create' with --plugin defaulting to the plugin_id recorded in the file; the
+vault addresses the export cleared are filled in from the current vault.
+
+All 'policy create' flags apply.
+
+Example:
+  devctl policy import --config dca-daily.json
+  devctl policy import --config dca-daily.json --dry-run
+
+Environment variables:
+  VAULT_PASSWORD  - Fast Vault password
+`
+	delete(cmd.Flags().Lookup("plugin").Annotations, cobra.BashCompOneRequiredFlag)
+
+	create := cmd.RunE
+	cmd.RunE = func(c *cobra.Command, args []string) error {
+		if !c.Flags().Changed("plugin") {
+			configFile, _ := c.Flags().GetString("config")
+			pluginID, err := exportedPluginID(configFile)
+			if err != nil {
+				return err
+			}
+			err = c.Flags().Set("plugin", pluginID)
+			if err != nil {
+				return err
+			}
+		}
+		return create(c, args)
+	}
+
+	return cmd
+}
+
+// exportedPluginID reads the plugin_id 'policy export' records in a config.
+func exportedPluginID(configFile string) (string, error) {
+	data, err := os.ReadFile(configFile)
+	if err != nil {
+		return "", fmt.Errorf("read config file: %w", err)
+	}
+	var probe struct {
+		PluginID string `json:"plugin_id"`
+	}
+	err = json.Unmarshal(data, &probe)
+	if err != nil {
+		return "", fmt.Errorf("parse config file: %w", err)
+	}
+	if probe.PluginID == "" {
+		return "", fmt.Errorf("%s has no plugin_id; pass --plugin", configFile)
+	}
+	return probe.PluginID, nil
+}
+
+func runPolicyExport(ctx context.Context, policyID, output string) error {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	authHeader, err := requireAuth(ctx, cfg.Verifier, "")
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	policy, err := getAPI[client.Policy](ctx, fmt.Sprintf("%s/plugin/policy/%s", cfg.Verifier, policyID), authHeader)
+	if err != nil {
+		return fmt.Errorf("get policy: %w", err)
+	}
+	recipe, err := decodePolicyRecipe(policy.Recipe)
+	if err != nil {
+		return err
+	}
+
+	recipeConfig := recipe.GetConfiguration().AsMap()
+	vaultAddrs := map[string]bool{}
+	vault, err := LoadVault(policy.PublicKey)
+	if err == nil {
+		addrs, _ := vaultAddresses(vault, "")
+		for _, a := range addrs {
+			vaultAddrs[strings.ToLower(a.Address)] = true
+		}
+	} else {
+		progressf("%s Vault %s... is not stored locally; only from/to/asset addresses are cleared\n", warnMark(), format.Truncate(policy.PublicKey, 16))
+	}
+	stripped := stripVaultFields(recipeConfig, "recipe", vaultAddrs)
+
+	exported := map[string]interface{}{
+		"plugin_id": policy.PluginID,
+		"recipe":    recipeConfig,
+		"billing":   exportBilling(policy.Billing),
+		"active":    policy.Active,
+	}
+	if w := recipe.GetRateLimitWindow(); w != 0 {
+		exported["rate_limit_window"] = w
+	}
+	if n := recipe.GetMaxTxsPerWindow(); n != 0 {
+		exported["max_txs_per_window"] = n
+	}
+
+	data, err := json.MarshalIndent(exported, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal policy: %w", err)
+	}
+
+	// Check the billing as policy create will read it back.
+	var reread map[string]interface{}
+	err = json.Unmarshal(data, &reread)
+	if err == nil {
+		_, err = parseBillingConfig(reread["billing"])
+	}
+	if err != nil {
+		progressf("%s Exported billing will not pass 'policy create': %v\n", warnMark(), err)
+	}
+
+	sort.Strings(stripped)
+	if len(stripped) > 0 {
+		progressf("Cleared vault-specific fields: %s\n", strings.Join(stripped, ", "))
+	}
+
+	if output == "" {
+		fmt.Println(string(data))
+		return nil
+	}
+	err = os.WriteFile(output, append(data, '\n'), 0644)
+	if err != nil {
+		return fmt.Errorf("write file: %w", err)
+	}
+	fmt.Printf("Policy %s exported to: %s\n", policyID, output)
+	return nil
+}
+
+// stripVaultFields clears, in place, the address of each from/to/asset side
+// and every string equal to one of the vault's addresses, and returns the
+// paths it cleared. Cleared values are set to "" so policy create fills
+// them in for the importing vault.
+func stripVaultFields(config map[string]interface{}, path string, vaultAddrs map[string]bool) []string {
+	var stripped []string
+	for key, value := range config {
+		field := path + "." + key
+		switch v := value.(type) {
+		case map[string]interface{}:
+			if key == "from" || key == "to" || key == "asset" {
+				if addr, ok := v["address"].(string); ok && addr != "" {
+					v["address"] = ""
+					stripped = append(stripped, field+".address")
+				}
+			}
+			stripped = append(stripped, stripVaultFields(v, field, vaultAddrs)...)
+		case []interface{}:
+			for i, item := range v {
+				itemField := fmt.Sprintf("%s[%d]", field, i)
+				switch iv := item.(type) {
+				case map[string]interface{}:
+					stripped = append(stripped, stripVaultFields(iv, itemField, vaultAddrs)...)
+				case string:
+					if vaultAddrs[strings.ToLower(iv)] {
+						v[i] = ""
+						stripped = append(stripped, itemField)
+					}
+				}
+			}
+		case string:
+			if vaultAddrs[strings.ToLower(v)] {
+				config[key] = ""
+				stripped = append(stripped, field)
+			}
+		}
+	}
+	return stripped
+}
+
+// exportBilling turns the verifier's billing entries back into config
+// entries, without IDs and start dates.
+func exportBilling(entries []client.BillingEntry) []interface{} {
+	billing := make([]interface{}, 0, len(entries))
+	for _, e := range entries {
+		entry := map[string]interface{}{
+			"type":   strings.ToLower(e.Type),
+			"amount": e.Amount,
+		}
+		if e.Frequency != nil && *e.Frequency != "" {
+			entry["frequency"] = strings.ToLower(*e.Frequency)
+		}
+		if e.Asset != "" {
+			entry["asset"] = e.Asset
+		}
+		billing = append(billing, entry)
+	}
+	return billing
+}
@@ -0,0 +1,244 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vultisig/vultisig-cluster/local/cmd/devctl/client"
+	"github.com/vultisig/vultisig-cluster/local/cmd/devctl/format"
+)
+
+// historyPageSize is how many entries are requested per history page.
+const historyPageSize = 50
+
+// PolicyTimelineEvent is one row of the 'policy history' timeline.
+type PolicyTimelineEvent struct {
+	Time     time.Time `json:"time"`
+	Kind     string    `json:"kind"` // created, signed, updated or execution
+	Detail   string    `json:"detail,omitempty"`
+	Status   string    `json:"status,omitempty"`
+	TxHash   string    `json:"tx_hash,omitempty"`
+	Explorer string    `json:"explorer,omitempty"`
+	Error    string    `json:"error,omitempty"`
+}
+
+// PolicyTimeline is the JSON form of 'policy history'.
+type PolicyTimeline struct {
+	PolicyID   string                `json:"policy_id"`
+	PluginID   string                `json:"plugin_id,omitempty"`
+	TotalCount int                   `json:"total_count"`
+	Events     []PolicyTimelineEvent `json:"events"`
+}
+
+func newPolicyHistoryCmd() *cobra.Command {
+	var filter HistoryFilter
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "history [policy-id]",
+		Short: "Show a policy's timeline: creation, updates and executions",
+		Long: `Show a policy's timeline in chronological order: when it was created and
+signed, when it was last updated, and each execution with its status, tx hash
+and explorer link.
+
+History is paged from the verifier until --limit executions are collected
+(0 fetches all). --since drops events before a duration ago (90m, 2d, 1w) or a
+date.
+
+Note: Requires authentication. Run 'devctl auth login' first.
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if output != "table" && output != "json" {
+				return fmt.Errorf("unknown output format %q (use table or json)", output)
+			}
+			if filter.Limit < 0 {
+				return fmt.Errorf("--limit must not be negative")
+			}
+			return runPolicyHistory(cmd.Context(), args[0], filter, output)
+		},
+	}
+
+	addHistoryFlags(cmd, &filter, 100, "executions")
+	cmd.Flags().StringVarP(&output, "output", "o", "table", "Output format: table or json")
+	return cmd
+}
+
+func runPolicyHistory(ctx context.Context, policyID string, filter HistoryFilter, output string) error {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	authHeader, err := requireAuth(ctx, cfg.Verifier, "")
+	if err != nil {
+		return err
+	}
+
+	progressf("Fetching history for policy %s...\n\n", policyID)
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	policy, err := getAPI[client.Policy](ctx, fmt.Sprintf("%s/plugin/policy/%s", cfg.Verifier, policyID), authHeader)
+	if err != nil {
+		return fmt.Errorf("get policy: %w", err)
+	}
+
+	entries, total, err := fetchPolicyHistory(ctx, cfg.Verifier, policyID, authHeader, filter.Limit)
+	if err != nil {
+		return err
+	}
+
+	timeline := PolicyTimeline{
+		PolicyID:   policyID,
+		PluginID:   policy.PluginID,
+		TotalCount: total,
+	}
+	for _, e := range policyTimelineEvents(policy, entries) {
+		if filter.Includes(e.Time) {
+			timeline.Events = append(timeline.Events, e)
+		}
+	}
+
+	if output == "json" {
+		data, err := json.MarshalIndent(timeline, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal timeline: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	printPolicyTimeline(timeline, len(entries))
+	return nil
+}
+
+// fetchPolicyHistory pages through the verifier's history endpoint until
+// limit entries are collected (0 = all) or the history is exhausted.
+func fetchPolicyHistory(ctx context.Context, verifierURL, policyID, authHeader string, limit int) ([]client.PolicyHistoryEntry, int, error) {
+	var entries []client.PolicyHistoryEntry
+	total := 0
+	if limit == 0 {
+		limit = math.MaxInt
+	}
+
+	for len(entries) < limit {
+		take := min(historyPageSize, limit-len(entries))
+		url := fmt.Sprintf("%s/plugin/policies/%s/history?skip=%d&take=%d", verifierURL, policyID, len(entries), take)
+
+		page, err := getAPI[client.PolicyHistory](ctx, url, authHeader)
+		if err != nil {
+			return nil, 0, fmt.Errorf("get policy history: %w", err)
+		}
+
+		total = page.TotalCount
+		entries = append(entries, page.History...)
+		if len(page.History) < take || len(entries) >= total {
+			break
+		}
+	}
+
+	return entries, total, nil
+}
+
+func policyTimelineEvents(policy client.Policy, entries []client.PolicyHistoryEntry) []PolicyTimelineEvent {
+	var events []PolicyTimelineEvent
+
+	if policy.CreatedAt != nil {
+		events = append(events,
+			PolicyTimelineEvent{Time: *policy.CreatedAt, Kind: "created", Detail: "plugin " + policy.PluginID},
+			PolicyTimelineEvent{Time: *policy.CreatedAt, Kind: "signed", Detail: "signature " + format.Truncate(policy.Signature, 20)},
+		)
+	}
+	if policy.UpdatedAt != nil && (policy.CreatedAt == nil || policy.UpdatedAt.After(*policy.CreatedAt)) {
+		detail := fmt.Sprintf("policy_version %d", policy.PolicyVersion)
+		if !policy.Active {
+			detail += ", inactive"
+		}
+		events = append(events, PolicyTimelineEvent{Time: *policy.UpdatedAt, Kind: "updated", Detail: detail})
+	}
+
+	for _, tx := range entries {
+		event := PolicyTimelineEvent{
+			Time:   tx.CreatedAt,
+			Kind:   "execution",
+			Status: tx.Status,
+		}
+		if tx.StatusOnChain != nil {
+			event.Status += "/" + *tx.StatusOnChain
+		}
+		if tx.Amount != nil {
+			event.Detail = strings.TrimSpace(fmt.Sprintf("%s %s %s", tx.Chain, *tx.Amount, tx.TokenID))
+		} else {
+			event.Detail = tx.Chain
+		}
+		if tx.TxHash != nil && *tx.TxHash != "" {
+			event.TxHash = *tx.TxHash
+			event.Explorer = txExplorerURL(tx.Chain, *tx.TxHash)
+		}
+		if tx.ErrorMessage != nil {
+			event.Error = *tx.ErrorMessage
+		}
+		events = append(events, event)
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Time.Before(events[j].Time)
+	})
+	return events
+}
+
+func printPolicyTimeline(timeline PolicyTimeline, fetched int) {
+	fmt.Printf("Policy %s (%s)\n", timeline.PolicyID, timeline.PluginID)
+	if fetched < timeline.TotalCount {
+		fmt.Printf("Showing %d of %d executions (raise --limit for more)\n", fetched, timeline.TotalCount)
+	}
+	fmt.Println()
+
+	if len(timeline.Events) == 0 {
+		fmt.Println("No history found for this policy.")
+		return
+	}
+
+	for _, e := range timeline.Events {
+		fmt.Printf("  %-20s %-12s %-10s %s\n", e.Time.Local().Format("2006-01-02 15:04:05"), relativeTime(e.Time), e.Kind, e.Detail)
+		if e.Status != "" {
+			fmt.Printf("  %44s Status:   %s\n", "", e.Status)
+		}
+		if e.TxHash != "" {
+			fmt.Printf("  %44s Tx Hash:  %s\n", "", e.TxHash)
+		}
+		if e.Explorer != "" {
+			fmt.Printf("  %44s Explorer: %s\n", "", e.Explorer)
+		}
+		if e.Error != "" {
+			fmt.Printf("  %44s Error:    %s\n", "", e.Error)
+		}
+	}
+}
+
+// txExplorerURL links a transaction on the explorer of the named chain, or
+// returns "" for chains without a known explorer.
+func txExplorerURL(chainName, txHash string) string {
+	switch strings.ToLower(chainName) {
+	case "solana":
+		return solanaExplorerURL(txHash, "")
+	case "bitcoin":
+		return "https://mempool.space/tx/" + txHash
+	}
+
+	for _, c := range knownChains() {
+		if strings.EqualFold(c.Name, chainName) && c.Explorer != "" {
+			return c.Explorer + "/tx/" + txHash
+		}
+	}
+	return ""
+}
@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	rtypes "github.com/vultisig/recipes/types"
+)
+
+// RateLimits is a policy's rate limit. Zero means unset: the verifier then
+// applies no window or no per-window cap.
+type RateLimits struct {
+	Window uint32 // seconds
+	MaxTxs uint32
+}
+
+func (l RateLimits) String() string {
+	var parts []string
+	if l.Window != 0 {
+		parts = append(parts, fmt.Sprintf("%ds window", l.Window))
+	}
+	if l.MaxTxs != 0 {
+		parts = append(parts, fmt.Sprintf("max %d txs", l.MaxTxs))
+	}
+	if len(parts) == 0 {
+		return "none"
+	}
+	return strings.Join(parts, ", ")
+}
+
+func suggestedRateLimits(suggest *rtypes.PolicySuggest) RateLimits {
+	return RateLimits{
+		Window: suggest.GetRateLimitWindow(),
+		MaxTxs: suggest.GetMaxTxsPerWindow(),
+	}
+}
+
+// rateLimitsFromConfig reads rate_limit_window and max_txs_per_window from a
+// policy config file. Missing keys are left unset.
+func rateLimitsFromConfig(policyConfig map[string]interface{}) (RateLimits, error) {
+	var limits RateLimits
+	var err error
+
+	limits.Window, err = configUint32(policyConfig, "rate_limit_window")
+	if err != nil {
+		return RateLimits{}, err
+	}
+	limits.MaxTxs, err = configUint32(policyConfig, "max_txs_per_window")
+	if err != nil {
+		return RateLimits{}, err
+	}
+	return limits, nil
+}
+
+func configUint32(config map[string]interface{}, key string) (uint32, error) {
+	value, ok := config[key]
+	if !ok {
+		return 0, nil
+	}
+	n, ok := value.(float64)
+	if !ok || n <= 0 || n > math.MaxUint32 || n != math.Trunc(n) {
+		return 0, fmt.Errorf("'%s' in config file must be a positive integer", key)
+	}
+	return uint32(n), nil
+}
+
+// mergeRateLimits applies requested over suggested. A requested value may
+// only tighten the suggestion (a longer window, fewer transactions) unless
+// allowLooser is set. Values the plugin does not suggest are taken as given.
+func mergeRateLimits(suggested, requested RateLimits, allowLooser bool) (RateLimits, error) {
+	merged := suggested
+
+	if requested.Window != 0 {
+		if suggested.Window != 0 && requested.Window < suggested.Window && !allowLooser {
+			return RateLimits{}, fmt.Errorf("rate limit window %ds is looser than the plugin's suggested %ds (use --allow-looser to submit it anyway)",
+				requested.Window, suggested.Window)
+		}
+		merged.Window = requested.Window
+	}
+
+	if requested.MaxTxs != 0 {
+		if suggested.MaxTxs != 0 && requested.MaxTxs > suggested.MaxTxs && !allowLooser {
+			return RateLimits{}, fmt.Errorf("max txs per window %d is looser than the plugin's suggested %d (use --allow-looser to submit it anyway)",
+				requested.MaxTxs, suggested.MaxTxs)
+		}
+		merged.MaxTxs = requested.MaxTxs
+	}
+
+	return merged, nil
+}
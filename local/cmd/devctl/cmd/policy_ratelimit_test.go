@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestMergeRateLimits(t *testing.T) {
+	tests := []struct {
+		name        string
+		suggested   RateLimits
+		requested   RateLimits
+		allowLooser bool
+		want        RateLimits
+		wantErr     string
+	}{
+		{
+			name:      "nothing requested",
+			suggested: RateLimits{Window: 3600, MaxTxs: 5},
+			want:      RateLimits{Window: 3600, MaxTxs: 5},
+		},
+		{
+			name:      "tighter window and cap",
+			suggested: RateLimits{Window: 3600, MaxTxs: 5},
+			requested: RateLimits{Window: 7200, MaxTxs: 2},
+			want:      RateLimits{Window: 7200, MaxTxs: 2},
+		},
+		{
+			name:      "equal values",
+			suggested: RateLimits{Window: 3600, MaxTxs: 5},
+			requested: RateLimits{Window: 3600, MaxTxs: 5},
+			want:      RateLimits{Window: 3600, MaxTxs: 5},
+		},
+		{
+			name:      "only the cap requested",
+			suggested: RateLimits{Window: 3600, MaxTxs: 5},
+			requested: RateLimits{MaxTxs: 1},
+			want:      RateLimits{Window: 3600, MaxTxs: 1},
+		},
+		{
+			name:      "nothing suggested",
+			requested: RateLimits{Window: 60, MaxTxs: 100},
+			want:      RateLimits{Window: 60, MaxTxs: 100},
+		},
+		{
+			name:      "shorter window",
+			suggested: RateLimits{Window: 3600, MaxTxs: 5},
+			requested: RateLimits{Window: 60},
+			wantErr:   "rate limit window 60s is looser than the plugin's suggested 3600s",
+		},
+		{
+			name:      "higher cap",
+			suggested: RateLimits{Window: 3600, MaxTxs: 5},
+			requested: RateLimits{MaxTxs: 6},
+			wantErr:   "max txs per window 6 is looser than the plugin's suggested 5",
+		},
+		{
+			name:        "looser with allowLooser",
+			suggested:   RateLimits{Window: 3600, MaxTxs: 5},
+			requested:   RateLimits{Window: 60, MaxTxs: 50},
+			allowLooser: true,
+			want:        RateLimits{Window: 60, MaxTxs: 50},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := mergeRateLimits(tt.suggested, tt.requested, tt.allowLooser)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("mergeRateLimits error = %v, want %q", err, tt.wantErr)
+				}
+				if !strings.Contains(err.Error(), "--allow-looser") {
+					t.Errorf("error %q does not mention --allow-looser", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("mergeRateLimits: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("mergeRateLimits = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRateLimitsFromConfig(t *testing.T) {
+	tests := []struct {
+		config  string
+		want    RateLimits
+		wantErr string
+	}{
+		{config: `{}`, want: RateLimits{}},
+		{config: `{"rate_limit_window": 3600, "max_txs_per_window": 2}`, want: RateLimits{Window: 3600, MaxTxs: 2}},
+		{config: `{"max_txs_per_window": 1}`, want: RateLimits{MaxTxs: 1}},
+		{config: `{"rate_limit_window": 4294967295}`, want: RateLimits{Window: 4294967295}},
+		{config: `{"rate_limit_window": 0}`, wantErr: "'rate_limit_window' in config file must be a positive integer"},
+		{config: `{"rate_limit_window": -60}`, wantErr: "'rate_limit_window' in config file must be a positive integer"},
+		{config: `{"rate_limit_window": 1.5}`, wantErr: "'rate_limit_window' in config file must be a positive integer"},
+		{config: `{"rate_limit_window": 4294967296}`, wantErr: "'rate_limit_window' in config file must be a positive integer"},
+		{config: `{"max_txs_per_window": "2"}`, wantErr: "'max_txs_per_window' in config file must be a positive integer"},
+		{config: `{"max_txs_per_window": null}`, wantErr: "'max_txs_per_window' in config file must be a positive integer"},
+	}
+
+	for _, tt := range tests {
+		var config map[string]interface{}
+		err := json.Unmarshal([]byte(tt.config), &config)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := rateLimitsFromConfig(config)
+		if tt.wantErr != "" {
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("rateLimitsFromConfig(%s) error = %v, want %q", tt.config, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("rateLimitsFromConfig(%s): %v", tt.config, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("rateLimitsFromConfig(%s) = %+v, want %+v", tt.config, got, tt.want)
+		}
+	}
+}
+
+func TestRateLimitsString(t *testing.T) {
+	for limits, want := range map[RateLimits]string{
+		{}:                        "none",
+		{Window: 3600}:            "3600s window",
+		{MaxTxs: 2}:               "max 2 txs",
+		{Window: 3600, MaxTxs: 2}: "3600s window, max 2 txs",
+	} {
+		if got := limits.String(); got != want {
+			t.Errorf("%+v.String() = %q, want %q", limits, got, want)
+		}
+	}
+}
@@ -0,0 +1,261 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/vultisig/vultisig-cluster/local/cmd/devctl/client"
+)
+
+// schedulerPollInterval is how often the plugin schedulers look for due
+// policies, so an execution fires up to this long after next_execution.
+const schedulerPollInterval = 30 * time.Second
+
+// ScheduledExecution is one projected run of a policy.
+type ScheduledExecution struct {
+	Index              int       `json:"index"`
+	At                 time.Time `json:"at"`
+	Latest             time.Time `json:"latest"`
+	ExceedsRateLimit   bool      `json:"exceeds_rate_limit"`
+	ExecutionsInWindow int       `json:"executions_in_window,omitempty"`
+	Note               string    `json:"note,omitempty"`
+}
+
+// PolicySchedule is the JSON form of 'policy schedule'.
+type PolicySchedule struct {
+	PolicyID      string               `json:"policy_id"`
+	PluginID      string               `json:"plugin_id"`
+	Active        bool                 `json:"active"`
+	Frequency     string               `json:"frequency"`
+	NextExecution *time.Time           `json:"next_execution"`
+	RateLimit     string               `json:"rate_limit"`
+	Executions    []ScheduledExecution `json:"executions"`
+}
+
+func newPolicyScheduleCmd() *cobra.Command {
+	var count int
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "schedule <policy-id>",
+		Short: "Preview when a policy's next executions will fire",
+		Long: `Project the next executions of a recurring policy.
+
+The first execution is the scheduler's next_execution for the policy; later
+ones follow the recipe's frequency. The scheduler polls every 30s, so each
+execution fires up to 30s after its projected time. Executions that would
+exceed the policy's rate limit (max_txs_per_window within rate_limit_window,
+counting transactions already sent) are flagged.
+
+Times are shown in local time with UTC in parentheses.
+
+Example:
+  devctl policy schedule <policy-id>
+  devctl policy schedule <policy-id> --count 10 --output json
+
+Note: Requires authentication. Run 'devctl auth login' first.
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if output != "table" && output != "json" {
+				return fmt.Errorf("unknown output format %q (use table or json)", output)
+			}
+			if count < 1 {
+				return fmt.Errorf("--count must be at least 1")
+			}
+			return runPolicySchedule(cmd.Context(), args[0], count, output == "json")
+		},
+	}
+
+	cmd.Flags().IntVarP(&count, "count", "n", 5, "Number of executions to project")
+	cmd.Flags().StringVarP(&output, "output", "o", "table", "Output format: table or json")
+
+	return cmd
+}
+
+func runPolicySchedule(ctx context.Context, policyID string, count int, asJSON bool) error {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	authHeader, err := requireAuth(ctx, cfg.Verifier, "")
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	policy, err := getAPI[client.Policy](ctx, fmt.Sprintf("%s/plugin/policy/%s", cfg.Verifier, policyID), authHeader)
+	if err != nil {
+		return fmt.Errorf("get policy: %w", err)
+	}
+	recipe, err := decodePolicyRecipe(policy.Recipe)
+	if err != nil {
+		return err
+	}
+
+	frequency := ""
+	if v, ok := recipe.GetConfiguration().GetFields()["frequency"]; ok {
+		frequency = v.GetStringValue()
+	}
+	limits := RateLimits{Window: recipe.GetRateLimitWindow(), MaxTxs: recipe.GetMaxTxsPerWindow()}
+
+	schedule := PolicySchedule{
+		PolicyID:  policyID,
+		PluginID:  policy.PluginID,
+		Active:    policy.Active,
+		Frequency: frequency,
+		RateLimit: limits.String(),
+	}
+
+	spec, err := pluginDatabaseSpec(policy.PluginID)
+	if err != nil {
+		return err
+	}
+	if t, ok := parsePostgresTime(checkScheduler(spec, policyID)); ok {
+		schedule.NextExecution = &t
+
+		var past []time.Time
+		if limits.Window != 0 {
+			window := time.Duration(limits.Window) * time.Second
+			for _, tx := range getRecentTransactions(spec, policyID, 0, time.Now().Add(-window)) {
+				if created, ok := parsePostgresTime(tx.CreatedAt); ok {
+					past = append(past, created)
+				}
+			}
+		}
+
+		schedule.Executions, err = projectExecutions(t, frequency, count, time.Now())
+		if err != nil {
+			return err
+		}
+		flagRateLimit(schedule.Executions, past, limits)
+	}
+
+	if asJSON {
+		data, err := json.MarshalIndent(schedule, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal schedule: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	fmt.Printf("Policy:     %s (%s)\n", policyID, policy.PluginID)
+	fmt.Printf("Frequency:  %s\n", valueOr(frequency, "(not set in recipe)"))
+	fmt.Printf("Rate limit: %s\n", limits)
+	if !policy.Active {
+		fmt.Printf("%s Policy is inactive; the scheduler skips it until resumed\n", symWarn)
+	}
+	if schedule.NextExecution == nil {
+		fmt.Printf("\n%s Not scheduled (one-time completed or not picked up yet)\n", symFail)
+		return nil
+	}
+	fmt.Println()
+
+	rows := make([][]string, 0, len(schedule.Executions))
+	for _, e := range schedule.Executions {
+		note := e.Note
+		if e.ExceedsRateLimit {
+			note = strings.TrimSpace(fmt.Sprintf("%s %d in window, over the limit %s", warnMark(), e.ExecutionsInWindow, note))
+		}
+		rows = append(rows, []string{fmt.Sprintf("%d", e.Index), formatLocalUTC(e.At), "by " + e.Latest.Local().Format("15:04:05"), note})
+	}
+	printTable([]string{"#", "WHEN", "FIRES", "NOTE"}, rows)
+	return nil
+}
+
+// projectExecutions lists count executions from next on. The scheduler
+// advances next_execution by the frequency after each run, so the
+// projection steps from the scheduled time rather than from when the
+// previous run fired.
+func projectExecutions(next time.Time, frequency string, count int, now time.Time) ([]ScheduledExecution, error) {
+	step, err := frequencyStep(frequency)
+	if err != nil {
+		return nil, err
+	}
+
+	var executions []ScheduledExecution
+	at := next
+	for i := 1; i <= count; i++ {
+		e := ScheduledExecution{Index: i, At: at, Latest: at.Add(schedulerPollInterval)}
+		if at.Before(now) {
+			e.Latest = now.Add(schedulerPollInterval)
+			e.Note = "overdue, fires on the next poll"
+		}
+		executions = append(executions, e)
+
+		if step == nil {
+			break
+		}
+		at = step(at)
+	}
+	return executions, nil
+}
+
+// frequencyStep returns the function that advances a time by one period of
+// a recipe frequency, or nil for one-time policies.
+func frequencyStep(frequency string) (func(time.Time) time.Time, error) {
+	switch strings.ToLower(strings.ReplaceAll(frequency, "_", "-")) {
+	case "", "one-time", "onetime":
+		return nil, nil
+	case "minutely":
+		return func(t time.Time) time.Time { return t.Add(time.Minute) }, nil
+	case "hourly":
+		return func(t time.Time) time.Time { return t.Add(time.Hour) }, nil
+	case "daily":
+		return func(t time.Time) time.Time { return t.AddDate(0, 0, 1) }, nil
+	case "weekly":
+		return func(t time.Time) time.Time { return t.AddDate(0, 0, 7) }, nil
+	case "biweekly", "bi-weekly":
+		return func(t time.Time) time.Time { return t.AddDate(0, 0, 14) }, nil
+	case "monthly":
+		return func(t time.Time) time.Time { return t.AddDate(0, 1, 0) }, nil
+	default:
+		return nil, fmt.Errorf("unknown recipe frequency %q", frequency)
+	}
+}
+
+// flagRateLimit marks executions after which more than limits.MaxTxs
+// executions, past transactions included, fall within one window.
+func flagRateLimit(executions []ScheduledExecution, past []time.Time, limits RateLimits) {
+	if limits.Window == 0 || limits.MaxTxs == 0 {
+		return
+	}
+	window := time.Duration(limits.Window) * time.Second
+
+	for i := range executions {
+		at := executions[i].At
+		n := 0
+		for _, t := range past {
+			if t.After(at.Add(-window)) && !t.After(at) {
+				n++
+			}
+		}
+		for _, other := range executions[:i+1] {
+			if other.At.After(at.Add(-window)) {
+				n++
+			}
+		}
+		executions[i].ExecutionsInWindow = n
+		executions[i].ExceedsRateLimit = n > int(limits.MaxTxs)
+	}
+}
+
+// formatLocalUTC renders t in local time with the UTC time in parentheses.
+func formatLocalUTC(t time.Time) string {
+	return fmt.Sprintf("%s (%s UTC)", t.Local().Format("2006-01-02 15:04:05 MST"), t.UTC().Format("2006-01-02 15:04:05"))
+}
+
+func valueOr(s, fallback string) string {
+	if s == "" {
+		return fallback
+	}
+	return s
+}
@@ -0,0 +1,309 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/vultisig/recipes/engine"
+	rtypes "github.com/vultisig/recipes/types"
+	"github.com/vultisig/vultisig-cluster/local/cmd/devctl/client"
+	"github.com/vultisig/vultisig-go/common"
+	"google.golang.org/protobuf/proto"
+)
+
+// SignTestRequest is the part of a plugin keysign request the verifier's
+// rule engine looks at: the unsigned transaction and the chain of its
+// messages.
+type SignTestRequest struct {
+	Transaction string `json:"transactions"`
+	Messages    []struct {
+		Chain common.Chain `json:"chain"`
+	} `json:"messages"`
+}
+
+// SignTestResult is the rule engine's verdict on one proposed transaction.
+type SignTestResult struct {
+	Index    int    `json:"index"`
+	Chain    string `json:"chain"`
+	Accepted bool   `json:"accepted"`
+	RuleID   string `json:"rule_id,omitempty"`
+	Resource string `json:"resource,omitempty"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+func newPolicySignTestCmd() *cobra.Command {
+	var payloadFile string
+	var noBuild bool
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "sign-test <policy-id>",
+		Short: "Check whether the verifier would sign a plugin's proposed transactions",
+		Long: `Simulate the verifier's keysign check for a policy without signing or
+broadcasting anything.
+
+The payload is sent to the plugin server's build endpoint (POST /plugin/buildtx)
+to construct the keysign request(s) it would send the verifier. Plugins without
+that endpoint are skipped and the payload is used as the keysign request(s)
+directly, as does --no-build. A keysign request is an object (or array of
+objects) with "transactions" (the unsigned tx, base64 or 0x-hex) and
+"messages" (each with its "chain").
+
+Each transaction is then evaluated against the policy's recipe with the rule
+engine the verifier runs before it joins a keysign. The command fails if any
+transaction is rejected.
+
+Example:
+  devctl policy sign-test <policy-id> --payload tx.json
+  devctl policy sign-test <policy-id> --payload keysign.json --no-build -o json
+
+Note: Requires authentication. Run 'devctl auth login' first.
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if output != "table" && output != "json" {
+				return fmt.Errorf("unknown output format %q (use table or json)", output)
+			}
+			return runPolicySignTest(cmd.Context(), args[0], payloadFile, noBuild, output == "json")
+		},
+	}
+
+	cmd.Flags().StringVar(&payloadFile, "payload", "", "JSON payload for the plugin's build endpoint, or keysign request(s) with --no-build (required)")
+	cmd.Flags().BoolVar(&noBuild, "no-build", false, "Treat --payload as keysign request(s) instead of asking the plugin server")
+	cmd.Flags().StringVarP(&output, "output", "o", "table", "Output format: table or json")
+	_ = cmd.MarkFlagRequired("payload")
+
+	return cmd
+}
+
+func runPolicySignTest(ctx context.Context, policyID, payloadFile string, noBuild, asJSON bool) error {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	payload, err := os.ReadFile(payloadFile)
+	if err != nil {
+		return fmt.Errorf("read payload: %w", err)
+	}
+
+	authHeader, err := requireAuth(ctx, cfg.Verifier, "")
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	policy, err := getAPI[client.Policy](ctx, fmt.Sprintf("%s/plugin/policy/%s", cfg.Verifier, policyID), authHeader)
+	if err != nil {
+		return fmt.Errorf("get policy: %w", err)
+	}
+	recipe, err := decodePolicyRecipe(policy.Recipe)
+	if err != nil {
+		return err
+	}
+	if !policy.Active {
+		progressf("%s Policy is inactive; the verifier refuses every keysign until it is resumed\n", warnMark())
+	}
+
+	proposed := payload
+	if !noBuild {
+		pluginURL, err := getPluginServerURL(cfg.Verifier, policy.PluginID)
+		if err != nil {
+			return fmt.Errorf("get plugin server URL: %w", err)
+		}
+		built, ok, err := buildPluginTx(ctx, pluginURL, authHeader, payload)
+		if err != nil {
+			return err
+		}
+		if ok {
+			proposed = built
+		} else {
+			progressf("%s %s has no build endpoint; using the payload as keysign request(s)\n", warnMark(), policy.PluginID)
+		}
+	}
+
+	requests, err := parseSignTestRequests(proposed)
+	if err != nil {
+		return err
+	}
+
+	results, err := evaluateSignTest(recipe, requests)
+	if err != nil {
+		return err
+	}
+
+	rejected := 0
+	for _, r := range results {
+		if !r.Accepted {
+			rejected++
+		}
+	}
+
+	if asJSON {
+		data, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal results: %w", err)
+		}
+		fmt.Println(string(data))
+	} else {
+		fmt.Printf("Policy %s (%s), recipe with %d rules\n\n", policyID, policy.PluginID, len(recipe.GetRules()))
+		rows := make([][]string, 0, len(results))
+		for _, r := range results {
+			verdict := okMark() + " accepted"
+			detail := strings.TrimSpace(r.RuleID + " " + r.Resource)
+			if !r.Accepted {
+				verdict = failMark() + " rejected"
+				detail = r.Reason
+			}
+			rows = append(rows, []string{fmt.Sprintf("%d", r.Index), r.Chain, verdict, detail})
+		}
+		printTable([]string{"#", "CHAIN", "VERDICT", "RULE / REASON"}, rows)
+	}
+
+	if rejected > 0 {
+		return fmt.Errorf("%d of %d transactions would be rejected by the verifier", rejected, len(results))
+	}
+	return nil
+}
+
+// decodePolicyRecipe decodes a policy's base64 protobuf recipe.
+func decodePolicyRecipe(recipeBase64 string) (*rtypes.Policy, error) {
+	data, err := base64.StdEncoding.DecodeString(recipeBase64)
+	if err != nil {
+		return nil, fmt.Errorf("decode recipe: %w", err)
+	}
+	var recipe rtypes.Policy
+	err = proto.Unmarshal(data, &recipe)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal recipe: %w", err)
+	}
+	return &recipe, nil
+}
+
+// buildPluginTx asks the plugin server to build the keysign request(s) for a
+// payload. ok is false when the plugin does not expose the build endpoint.
+func buildPluginTx(ctx context.Context, pluginURL, authHeader string, payload []byte) ([]byte, bool, error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", pluginURL+"/plugin/buildtx", bytes.NewReader(payload))
+	if err != nil {
+		return nil, false, fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", authHeader)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, false, fmt.Errorf("plugin build request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, false, fmt.Errorf("read plugin build response: %w", err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return body, true, nil
+	case http.StatusNotFound, http.StatusMethodNotAllowed:
+		return nil, false, nil
+	default:
+		return nil, false, fmt.Errorf("plugin build endpoint returned %d: %s", resp.StatusCode, errorBody(body))
+	}
+}
+
+// parseSignTestRequests accepts a keysign request, an array of them, or an
+// API envelope around either.
+func parseSignTestRequests(data []byte) ([]SignTestRequest, error) {
+	var envelope struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if json.Unmarshal(data, &envelope) == nil && len(envelope.Data) > 0 {
+		data = envelope.Data
+	}
+
+	var requests []SignTestRequest
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("[")) {
+		err := json.Unmarshal(data, &requests)
+		if err != nil {
+			return nil, fmt.Errorf("parse keysign requests: %w", err)
+		}
+	} else {
+		var single SignTestRequest
+		err := json.Unmarshal(data, &single)
+		if err != nil {
+			return nil, fmt.Errorf("parse keysign request: %w", err)
+		}
+		requests = []SignTestRequest{single}
+	}
+
+	for i, r := range requests {
+		if r.Transaction == "" {
+			return nil, fmt.Errorf("keysign request %d: missing \"transactions\"", i)
+		}
+		if len(r.Messages) == 0 {
+			return nil, fmt.Errorf("keysign request %d: missing \"messages\"", i)
+		}
+	}
+	return requests, nil
+}
+
+// evaluateSignTest runs each transaction through the recipe engine the way
+// the verifier does before signing: the chain of the first message selects
+// the chain engine and the first matching rule allows the transaction.
+func evaluateSignTest(recipe *rtypes.Policy, requests []SignTestRequest) ([]SignTestResult, error) {
+	ngn, err := engine.NewEngine()
+	if err != nil {
+		return nil, fmt.Errorf("create rule engine: %w", err)
+	}
+
+	results := make([]SignTestResult, 0, len(requests))
+	for i, r := range requests {
+		chain := r.Messages[0].Chain
+		result := SignTestResult{Index: i + 1, Chain: chain.String()}
+
+		txBytes, err := decodeTxBytes(r.Transaction)
+		if err != nil {
+			result.Reason = err.Error()
+			results = append(results, result)
+			continue
+		}
+
+		rule, err := ngn.Evaluate(recipe, chain, txBytes)
+		if err != nil {
+			result.Reason = err.Error()
+		} else {
+			result.Accepted = true
+			result.RuleID = rule.GetId()
+			result.Resource = rule.GetResource()
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+func decodeTxBytes(tx string) ([]byte, error) {
+	if strings.HasPrefix(tx, "0x") {
+		data, err := hex.DecodeString(tx[2:])
+		if err != nil {
+			return nil, fmt.Errorf("decode hex transaction: %w", err)
+		}
+		return data, nil
+	}
+	data, err := base64.StdEncoding.DecodeString(tx)
+	if err != nil {
+		return nil, fmt.Errorf("decode base64 transaction: %w", err)
+	}
+	return data, nil
+}
@@ -0,0 +1,236 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vultisig/vultisig-cluster/local/cmd/devctl/client"
+)
+
+// policyOverview is one row of 'policy status --all'.
+type policyOverview struct {
+	Policy   client.Policy
+	Spec     PluginSpec
+	Next     time.Time // zero when the policy has no scheduler row
+	NextRaw  string
+	Last     *TxRecord
+	TxCounts map[string]int
+}
+
+func runPolicyStatusAll(ctx context.Context, pluginID, format, file string) error {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	if cfg.PublicKeyECDSA == "" {
+		return fmt.Errorf("no vault configured. Run 'devctl vault import' first")
+	}
+
+	authHeader, err := requireAuth(ctx, cfg.Verifier, "")
+	if err != nil {
+		return err
+	}
+
+	specs := pluginRegistry()
+	if pluginID != "" {
+		specs = []PluginSpec{pluginSpecFor(pluginID)}
+	}
+
+	var rows []policyOverview
+	for _, spec := range specs {
+		policies, err := listVaultPolicies(ctx, cfg.Verifier, authHeader, spec.ID, cfg.PublicKeyECDSA)
+		if err != nil {
+			progressf("%s %s: %v\n", warnMark(), spec.Name, err)
+			continue
+		}
+		for _, p := range policies {
+			row := policyOverview{Policy: p, Spec: spec, TxCounts: txStatusCounts(spec, p.ID)}
+			row.NextRaw = checkScheduler(spec, p.ID)
+			if t, ok := parsePostgresTime(row.NextRaw); ok {
+				row.Next = t
+			}
+			if txs := getRecentTransactions(spec, p.ID, 1, time.Time{}); len(txs) > 0 {
+				row.Last = &txs[0]
+			}
+			rows = append(rows, row)
+		}
+	}
+
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].Spec.ID != rows[j].Spec.ID {
+			return rows[i].Spec.ID < rows[j].Spec.ID
+		}
+		return rows[i].Policy.ID < rows[j].Policy.ID
+	})
+
+	if format == "csv" {
+		for _, a := range schedulingAnomalies(rows, time.Now()) {
+			progressf("%s %s\n", warnMark(), a)
+		}
+		return writeCSV(file, policyOverviewCSVHeaders, policyOverviewCSVRows(rows))
+	}
+
+	fmt.Printf("Policies for vault %s (%s)\n\n", cfg.VaultName, VaultFingerprint(cfg.PublicKeyECDSA))
+	if len(rows) == 0 {
+		fmt.Println("No policies found.")
+		return nil
+	}
+
+	table := make([][]string, 0, len(rows))
+	for _, r := range rows {
+		active := "no"
+		if r.Policy.Active {
+			active = "yes"
+		}
+		next := "-"
+		if !r.Next.IsZero() {
+			next = r.Next.Local().Format("2006-01-02 15:04:05")
+		} else if r.NextRaw != "" {
+			next = r.NextRaw
+		}
+		last := "-"
+		if r.Last != nil {
+			last = r.Last.Status
+			if t, ok := parsePostgresTime(r.Last.CreatedAt); ok {
+				last += " " + relativeTime(t)
+			}
+		}
+		table = append(table, []string{shortID(r.Policy.ID, 8), r.Spec.Name, active, next, last, formatTxCounts(r.TxCounts)})
+	}
+	printTable([]string{"Policy", "Plugin", "Active", "Next execution", "Last execution", "Txs"}, table)
+
+	anomalies := schedulingAnomalies(rows, time.Now())
+	fmt.Println()
+	if len(anomalies) == 0 {
+		fmt.Printf("%s No scheduling anomalies\n", okMark())
+		return nil
+	}
+	fmt.Println("Scheduling anomalies:")
+	for _, a := range anomalies {
+		fmt.Printf("  %s %s\n", warnMark(), a)
+	}
+	return nil
+}
+
+var policyOverviewCSVHeaders = []string{
+	"policy_id", "plugin_id", "active", "next_execution",
+	"last_tx_hash", "last_status", "last_status_onchain", "last_created_at", "tx_total", "tx_counts",
+}
+
+// policyOverviewCSVRows renders the 'policy status --all' table as CSV rows.
+// tx_counts is "STATUS=n" pairs joined by ';', ordered by status.
+func policyOverviewCSVRows(rows []policyOverview) [][]string {
+	out := make([][]string, 0, len(rows))
+	for _, r := range rows {
+		next := r.NextRaw
+		if !r.Next.IsZero() {
+			next = isoTime(&r.Next)
+		}
+		var lastHash, lastStatus, lastOnChain, lastAt string
+		if r.Last != nil {
+			lastHash, lastStatus, lastOnChain, lastAt = r.Last.TxHash, r.Last.Status, r.Last.OnChainStatus, r.Last.CreatedAt
+			if t, ok := parsePostgresTime(r.Last.CreatedAt); ok {
+				lastAt = isoTime(&t)
+			}
+		}
+		total := 0
+		statuses := make([]string, 0, len(r.TxCounts))
+		for s, n := range r.TxCounts {
+			total += n
+			statuses = append(statuses, s)
+		}
+		sort.Strings(statuses)
+		counts := make([]string, len(statuses))
+		for i, s := range statuses {
+			counts[i] = fmt.Sprintf("%s=%d", s, r.TxCounts[s])
+		}
+		out = append(out, []string{
+			r.Policy.ID, r.Spec.ID, strconv.FormatBool(r.Policy.Active), next,
+			lastHash, lastStatus, lastOnChain, lastAt, strconv.Itoa(total), strings.Join(counts, ";"),
+		})
+	}
+	return out
+}
+
+// schedulingAnomalies flags active policies of one plugin due in the same
+// scheduler poll, active policies overdue by more than two polls and
+// inactive policies that still have scheduler rows.
+func schedulingAnomalies(rows []policyOverview, now time.Time) []string {
+	var anomalies []string
+
+	ticks := map[string][]string{}
+	var tickKeys []string
+	for _, r := range rows {
+		id := shortID(r.Policy.ID, 8)
+		switch {
+		case !r.Policy.Active && r.NextRaw != "":
+			anomalies = append(anomalies, fmt.Sprintf("%s is inactive but still has a scheduler row (next_execution %s)", id, r.NextRaw))
+		case r.Policy.Active && !r.Next.IsZero():
+			if overdue := now.Sub(r.Next); overdue > 2*schedulerPollInterval {
+				anomalies = append(anomalies, fmt.Sprintf("%s is overdue by %s (more than two %s scheduler polls); is the %s scheduler running?",
+					id, overdue.Round(time.Second), schedulerPollInterval, r.Spec.Name))
+			} else {
+				key := r.Spec.ID + "@" + r.Next.Truncate(schedulerPollInterval).UTC().Format(time.RFC3339)
+				if _, ok := ticks[key]; !ok {
+					tickKeys = append(tickKeys, key)
+				}
+				ticks[key] = append(ticks[key], id)
+			}
+		}
+	}
+
+	for _, key := range tickKeys {
+		ids := ticks[key]
+		if len(ids) < 2 {
+			continue
+		}
+		pluginID, at, _ := strings.Cut(key, "@")
+		anomalies = append(anomalies, fmt.Sprintf("%s are due in the same %s scheduler tick (%s); they execute back to back",
+			strings.Join(ids, ", "), pluginSpecFor(pluginID).Name, at))
+	}
+	return anomalies
+}
+
+// txStatusCounts counts a policy's tx indexer rows by status.
+func txStatusCounts(spec PluginSpec, policyID string) map[string]int {
+	cmd := psqlCommand(spec.Database, "-t", "-A", "-F", "|", "-c",
+		fmt.Sprintf("SELECT status, count(*) FROM %s WHERE policy_id = '%s' GROUP BY status", spec.TxTable, policyID))
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil
+	}
+
+	counts := map[string]int{}
+	for _, line := range strings.Split(string(output), "\n") {
+		status, n, ok := strings.Cut(strings.TrimSpace(line), "|")
+		if !ok {
+			continue
+		}
+		count, err := strconv.Atoi(n)
+		if err == nil {
+			counts[status] = count
+		}
+	}
+	return counts
+}
+
+// formatTxCounts renders counts as "2 MINED, 1 SIGNED", by status name.
+func formatTxCounts(counts map[string]int) string {
+	if len(counts) == 0 {
+		return "-"
+	}
+	statuses := make([]string, 0, len(counts))
+	for s := range counts {
+		statuses = append(statuses, s)
+	}
+	sort.Strings(statuses)
+	parts := make([]string, len(statuses))
+	for i, s := range statuses {
+		parts[i] = fmt.Sprintf("%d %s", counts[s], s)
+	}
+	return strings.Join(parts, ", ")
+}
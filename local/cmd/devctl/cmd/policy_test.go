@@ -0,0 +1,167 @@
+package cmd
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/vultisig/vultisig-go/address"
+	"github.com/vultisig/vultisig-go/common"
+)
+
+// builtinAddressFields returns the address fields of a plugin in the
+// built-in registry, without cluster.yaml applied.
+func builtinAddressFields(t *testing.T, pluginID string) map[string]string {
+	t.Helper()
+	for _, spec := range pluginSpecs {
+		if spec.ID == pluginID {
+			return spec.AddressFields
+		}
+	}
+	t.Fatalf("plugin %s is not in the built-in registry", pluginID)
+	return nil
+}
+
+// recipeFromJSON decodes a recipe config the way policy create reads one.
+func recipeFromJSON(t *testing.T, data string) map[string]interface{} {
+	t.Helper()
+	var recipe map[string]interface{}
+	err := json.Unmarshal([]byte(data), &recipe)
+	if err != nil {
+		t.Fatalf("decode recipe: %v", err)
+	}
+	return recipe
+}
+
+const sendsRecipe = `{
+  "asset": { "chain": "Ethereum", "token": "", "address": "" },
+  "recipient": "",
+  "amount": "1000000000000000",
+  "frequency": "weekly",
+  "memo": "rent",
+  "schedule": { "weekday": "monday", "address": "" }
+}`
+
+func TestFillAddressesFromVaultSendsRecipe(t *testing.T) {
+	vault, err := newDemoVault()
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr, _, _, err := address.GetAddress(vault.PublicKeyECDSA, vault.HexChainCode, common.Ethereum)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := recipeFromJSON(t, sendsRecipe)
+	want["asset"].(map[string]interface{})["address"] = addr
+	want["recipient"] = addr
+
+	got, err := fillAddressesFromVault(recipeFromJSON(t, sendsRecipe), vault, builtinAddressFields(t, "vultisig-recurring-sends-0000"))
+	if err != nil {
+		t.Fatalf("fillAddressesFromVault: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("filled recipe:\n got %v\nwant %v", got, want)
+	}
+}
+
+func TestFillAddressesFromVaultNoCrossTalk(t *testing.T) {
+	vault, err := newDemoVault()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The DCA fields name from/to objects a sends recipe does not have.
+	got, err := fillAddressesFromVault(recipeFromJSON(t, sendsRecipe), vault, builtinAddressFields(t, "vultisig-dca-0000"))
+	if err != nil {
+		t.Fatalf("fillAddressesFromVault: %v", err)
+	}
+	if want := recipeFromJSON(t, sendsRecipe); !reflect.DeepEqual(got, want) {
+		t.Errorf("recipe changed:\n got %v\nwant %v", got, want)
+	}
+}
+
+func TestFillAddressesFromVaultKeepsValues(t *testing.T) {
+	vault, err := newDemoVault()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const recipe = `{
+  "asset": { "chain": "Ethereum", "address": "0x1111111111111111111111111111111111111111" },
+  "recipient": "0x2222222222222222222222222222222222222222",
+  "payout": { "address": "" }
+}`
+	fields := map[string]string{
+		"asset.address":   "asset.chain",
+		"recipient":       "asset.chain",
+		"payout.address":  "payout.chain",
+		"missing.address": "missing.chain",
+	}
+	got, err := fillAddressesFromVault(recipeFromJSON(t, recipe), vault, fields)
+	if err != nil {
+		t.Fatalf("fillAddressesFromVault: %v", err)
+	}
+	if want := recipeFromJSON(t, recipe); !reflect.DeepEqual(got, want) {
+		t.Errorf("recipe changed:\n got %v\nwant %v", got, want)
+	}
+}
+
+func TestFillAddressesFromVaultUnknownChain(t *testing.T) {
+	vault, err := newDemoVault()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	recipe := recipeFromJSON(t, `{"asset": {"chain": "Nochain", "address": ""}}`)
+	_, err = fillAddressesFromVault(recipe, vault, map[string]string{"asset.address": "asset.chain"})
+	if err == nil {
+		t.Fatal("fillAddressesFromVault accepted an unknown chain")
+	}
+}
+
+// useClusterConfig makes LoadClusterConfig return cc for the rest of the
+// test, instead of whatever cluster.yaml the machine has.
+func useClusterConfig(t *testing.T, cc *ClusterConfig) {
+	t.Helper()
+	cc.setDefaults()
+	saved := clusterConfig
+	clusterConfig = cc
+	t.Cleanup(func() { clusterConfig = saved })
+}
+
+func TestFillAddressesFromVaultResolvesTokens(t *testing.T) {
+	useClusterConfig(t, &ClusterConfig{Tokens: []TokenConfig{
+		{Chain: "ethereum", Symbol: "PAY", Address: "0x3333333333333333333333333333333333333333"},
+	}})
+	vault, err := newDemoVault()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	recipe := recipeFromJSON(t, `{
+  "payout": { "chain": "Ethereum", "token": "pay", "address": "0x1111111111111111111111111111111111111111" },
+  "fee": { "chain": "Ethereum", "token": "usdc" },
+  "token": "USDC"
+}`)
+	got, err := fillAddressesFromVault(recipe, vault, map[string]string{"payout.address": "payout.chain"})
+	if err != nil {
+		t.Fatalf("fillAddressesFromVault: %v", err)
+	}
+
+	want := recipeFromJSON(t, `{
+  "payout": { "chain": "Ethereum", "token": "0x3333333333333333333333333333333333333333", "address": "0x1111111111111111111111111111111111111111" },
+  "fee": { "chain": "Ethereum", "token": "usdc" },
+  "token": "USDC"
+}`)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("resolved recipe:\n got %v\nwant %v", got, want)
+	}
+
+	recipe = recipeFromJSON(t, `{"payout": {"chain": "Ethereum", "token": "NOPE", "address": "0x1111111111111111111111111111111111111111"}}`)
+	_, err = fillAddressesFromVault(recipe, vault, map[string]string{"payout.address": "payout.chain"})
+	if err == nil || !strings.Contains(err.Error(), "payout.token") {
+		t.Errorf("unknown symbol: got error %v, want one naming payout.token", err)
+	}
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// SkipPreflight disables the reachability checks below (--skip-preflight),
+// for topologies where the health endpoints are not exposed.
+var SkipPreflight bool
+
+// preflight checks that the verifier, and the plugin server when pluginURL
+// is set, answer their health checks before a command starts a TSS session.
+// A verifier that is still compiling fails here in seconds instead of at the
+// end of a session timeout.
+func preflight(ctx context.Context, verifierURL, pluginURL string) error {
+	if SkipPreflight {
+		return nil
+	}
+
+	health := clusterConfigOrDefaults().Health
+
+	err := pingService(ctx, "verifier", verifierURL, health["verifier"])
+	if err != nil {
+		return err
+	}
+	if pluginURL != "" {
+		err = pingService(ctx, "plugin server", pluginURL, health["dca_server"])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// requireAuth is the gate for commands that talk to the verifier as the
+// vault owner: services must be reachable and the auth token valid.
+func requireAuth(ctx context.Context, verifierURL, pluginURL string) (string, error) {
+	err := preflight(ctx, verifierURL, pluginURL)
+	if err != nil {
+		return "", err
+	}
+
+	authHeader, err := GetAuthHeader()
+	if err != nil {
+		return "", fmt.Errorf("authentication required: %w\n\nRun 'devctl vault import --password xxx' to authenticate first", err)
+	}
+
+	vaults, err := ListVaults()
+	if err == nil && len(vaults) > 0 {
+		warnVaultEndpoint(vaults[0], verifierURL)
+	}
+	return authHeader, nil
+}
+
+func pingService(ctx context.Context, name, baseURL string, check HealthCheck) error {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	state, reason := probeHealth(ctx, baseURL+check.Path, check)
+	switch {
+	case state == healthOK:
+		return nil
+	case state == healthUnhealthy:
+		return fmt.Errorf("%s at %s is responding but unhealthy (health check %s: %s) — see devctl status",
+			name, baseURL, check.Path, reason)
+	case reason == "not reachable":
+		return fmt.Errorf("%s not reachable at %s — is `devctl start` finished? (see devctl status, or pass --skip-preflight)", name, baseURL)
+	default:
+		return fmt.Errorf("%s at %s is not ready (health check %s: %s) — is `devctl start` finished? (see devctl status)",
+			name, baseURL, check.Path, reason)
+	}
+}
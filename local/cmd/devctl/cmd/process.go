@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// runPath returns the location of a PID or log file written by 'devctl start'.
+func runPath(name string) string {
+	return filepath.Join(runDir(), name)
+}
+
+// libraryPathEnv returns the environment entry that puts dir on the dynamic
+// library search path of child processes, keeping the inherited value.
+func libraryPathEnv(dir string) string {
+	name := libraryPathVar()
+	return name + "=" + dir + string(os.PathListSeparator) + os.Getenv(name)
+}
+
+// killPIDString is killPID for PIDs read from PID files or command output.
+func killPIDString(pid string, force bool) error {
+	n, err := strconv.Atoi(pid)
+	if err != nil {
+		return err
+	}
+	return killPID(n, force)
+}
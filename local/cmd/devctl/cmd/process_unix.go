@@ -0,0 +1,60 @@
+//go:build !windows
+
+package cmd
+
+import (
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+func runDir() string {
+	return "/tmp"
+}
+
+func libraryPathVar() string {
+	if runtime.GOOS == "darwin" {
+		return "DYLD_LIBRARY_PATH"
+	}
+	return "LD_LIBRARY_PATH"
+}
+
+// killPID sends SIGTERM, or SIGKILL when force is set.
+func killPID(pid int, force bool) error {
+	sig := syscall.SIGTERM
+	if force {
+		sig = syscall.SIGKILL
+	}
+	return syscall.Kill(pid, sig)
+}
+
+func pidAlive(pid int) bool {
+	return syscall.Kill(pid, 0) == nil
+}
+
+// killMatching kills every process whose command line matches pattern
+// (an extended regular expression, as for pkill -f).
+func killMatching(pattern string, force bool) {
+	args := []string{"-f", pattern}
+	if force {
+		args = append([]string{"-9"}, args...)
+	}
+	exec.Command("pkill", args...).Run()
+}
+
+// portPIDs returns the PIDs of processes with a socket on the local port.
+func portPIDs(port string) []string {
+	out, err := exec.Command("lsof", "-ti:"+port).Output()
+	if err != nil {
+		return nil
+	}
+	var pids []string
+	for _, pid := range strings.Fields(string(out)) {
+		if _, err := strconv.Atoi(pid); err == nil {
+			pids = append(pids, pid)
+		}
+	}
+	return pids
+}
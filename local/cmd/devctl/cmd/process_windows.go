@@ -0,0 +1,90 @@
+//go:build windows
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+func runDir() string {
+	return os.TempDir()
+}
+
+// libraryPathVar is PATH on Windows: DLLs are resolved through it.
+func libraryPathVar() string {
+	return "PATH"
+}
+
+// killPID terminates the process and its children (go run spawns the
+// compiled binary as a child). Without force, taskkill asks the process
+// to close first.
+func killPID(pid int, force bool) error {
+	args := []string{"/PID", strconv.Itoa(pid), "/T"}
+	if force {
+		args = append(args, "/F")
+	}
+	return exec.Command("taskkill", args...).Run()
+}
+
+func pidAlive(pid int) bool {
+	out, err := exec.Command("tasklist", "/FI", fmt.Sprintf("PID eq %d", pid), "/NH", "/FO", "CSV").Output()
+	if err != nil {
+		return false
+	}
+	return strings.Contains(string(out), fmt.Sprintf("\"%d\"", pid))
+}
+
+// killMatching kills every process whose command line matches pattern
+// (a regular expression, as for pkill -f on Unix).
+func killMatching(pattern string, force bool) {
+	stop := "Stop-Process -Id $_.ProcessId"
+	if force {
+		stop += " -Force"
+	}
+	script := fmt.Sprintf("Get-CimInstance Win32_Process | Where-Object { $_.CommandLine -match '%s' } | ForEach-Object { %s }",
+		strings.ReplaceAll(pattern, "'", "''"), stop)
+	exec.Command("powershell", "-NoProfile", "-NonInteractive", "-Command", script).Run()
+}
+
+// portPIDs returns the PIDs of processes listening on the local port, using
+// Get-NetTCPConnection and falling back to netstat where it is unavailable.
+func portPIDs(port string) []string {
+	script := fmt.Sprintf("Get-NetTCPConnection -LocalPort %s -ErrorAction SilentlyContinue | Select-Object -ExpandProperty OwningProcess -Unique", port)
+	out, err := exec.Command("powershell", "-NoProfile", "-NonInteractive", "-Command", script).Output()
+	if err == nil {
+		return validPIDs(strings.Fields(string(out)))
+	}
+
+	out, err = exec.Command("netstat", "-ano", "-p", "TCP").Output()
+	if err != nil {
+		return nil
+	}
+	var pids []string
+	for _, line := range strings.Split(string(out), "\n") {
+		fields := strings.Fields(line)
+		// Proto  Local Address  Foreign Address  State  PID
+		if len(fields) < 5 || !strings.HasSuffix(fields[1], ":"+port) {
+			continue
+		}
+		pids = append(pids, fields[len(fields)-1])
+	}
+	return validPIDs(pids)
+}
+
+func validPIDs(fields []string) []string {
+	seen := map[string]bool{}
+	var pids []string
+	for _, pid := range fields {
+		n, err := strconv.Atoi(pid)
+		if err != nil || n == 0 || seen[pid] {
+			continue
+		}
+		seen[pid] = true
+		pids = append(pids, pid)
+	}
+	return pids
+}
@@ -0,0 +1,331 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/ecdsa"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/spf13/cobra"
+	"github.com/vultisig/vultisig-go/address"
+	"github.com/vultisig/vultisig-go/common"
+)
+
+const defaultEsploraURL = "https://blockstream.info/api"
+
+func newVaultSignPSBTCmd() *cobra.Command {
+	var file string
+	var output string
+	var vaultPassword string
+	var finalize bool
+	var broadcast bool
+	var esplora string
+
+	cmd := &cobra.Command{
+		Use:   "sign-psbt",
+		Short: "Sign the vault's P2WPKH inputs in a PSBT",
+		Long: `Sign a Bitcoin PSBT with the vault as co-signer.
+
+Inputs spending the vault's native segwit (P2WPKH) address are signed with
+ECDSA keysign on the Bitcoin derive path (m/84'/0'/0'/0/0); other inputs are
+left untouched. Signatures are added as partial signatures and the updated
+PSBT is written to --output (base64 or binary, matching the input).
+
+With --finalize the PSBT is finalized and the raw transaction printed;
+--broadcast additionally pushes it to an esplora endpoint.
+
+Examples:
+  devctl vault sign-psbt --file tx.psbt --password "vault-password"
+  devctl vault sign-psbt --file tx.psbt --password "vault-password" --finalize --broadcast
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if broadcast && !finalize {
+				return fmt.Errorf("--broadcast requires --finalize")
+			}
+			password, err := promptPassword(vaultPassword, "Fast Vault password: ")
+			if err != nil {
+				return err
+			}
+			return runVaultSignPSBT(cmd.Context(), file, output, password, finalize, broadcast, esplora)
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "file", "f", "", "PSBT file, binary or base64 (required)")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Output file (default: <file>.signed)")
+	cmd.Flags().StringVarP(&vaultPassword, "password", "p", "", "Fast Vault password")
+	cmd.Flags().BoolVar(&finalize, "finalize", false, "Finalize the PSBT after signing")
+	cmd.Flags().BoolVar(&broadcast, "broadcast", false, "Broadcast the finalized transaction (requires --finalize)")
+	cmd.Flags().StringVar(&esplora, "esplora", defaultEsploraURL, "Esplora API base URL for --broadcast")
+	cmd.MarkFlagRequired("file")
+
+	return cmd
+}
+
+func runVaultSignPSBT(ctx context.Context, file, output, vaultPassword string, finalize, broadcast bool, esplora string) error {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	if cfg.PublicKeyECDSA == "" {
+		return fmt.Errorf("no vault configured. Run 'devctl vault import' first")
+	}
+
+	vault, err := LoadVault(cfg.PublicKeyECDSA[:16])
+	if err != nil {
+		return fmt.Errorf("load vault: %w", err)
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("read psbt: %w", err)
+	}
+
+	isBase64 := !bytes.HasPrefix(data, []byte("psbt\xff"))
+	if isBase64 {
+		data = bytes.TrimSpace(data)
+	}
+
+	packet, err := psbt.NewFromRawBytes(bytes.NewReader(data), isBase64)
+	if err != nil {
+		return fmt.Errorf("parse psbt: %w", err)
+	}
+
+	btcAddr, pubKeyHex, _, err := address.GetAddress(vault.PublicKeyECDSA, vault.HexChainCode, common.Bitcoin)
+	if err != nil {
+		return fmt.Errorf("derive bitcoin key: %w", err)
+	}
+	pubKeyBytes, err := hex.DecodeString(pubKeyHex)
+	if err != nil {
+		return fmt.Errorf("decode bitcoin public key: %w", err)
+	}
+	pubKey, err := btcec.ParsePubKey(pubKeyBytes)
+	if err != nil {
+		return fmt.Errorf("parse bitcoin public key: %w", err)
+	}
+
+	pubKeyHash := btcutil.Hash160(pubKeyBytes)
+	witnessProgram, err := txscript.NewScriptBuilder().AddOp(txscript.OP_0).AddData(pubKeyHash).Script()
+	if err != nil {
+		return fmt.Errorf("build p2wpkh script: %w", err)
+	}
+	// BIP143 script code for P2WPKH is the equivalent P2PKH script.
+	scriptCode, err := txscript.NewScriptBuilder().
+		AddOp(txscript.OP_DUP).AddOp(txscript.OP_HASH160).AddData(pubKeyHash).
+		AddOp(txscript.OP_EQUALVERIFY).AddOp(txscript.OP_CHECKSIG).Script()
+	if err != nil {
+		return fmt.Errorf("build script code: %w", err)
+	}
+
+	progressln("=== Sign PSBT ===")
+	progressf("Vault:   %s\n", vault.Name)
+	progressf("Address: %s\n", btcAddr)
+	progressf("Inputs:  %d\n", len(packet.Inputs))
+	printTraceID()
+	progressln()
+
+	prevOuts := psbtPrevOutputFetcher(packet)
+	sigHashes := txscript.NewTxSigHashes(packet.UnsignedTx, prevOuts)
+
+	type vaultInput struct {
+		index    int
+		hashType txscript.SigHashType
+		sighash  []byte
+	}
+	var inputs []vaultInput
+
+	for i, in := range packet.Inputs {
+		prevOut := prevOuts.FetchPrevOutput(packet.UnsignedTx.TxIn[i].PreviousOutPoint)
+		if prevOut == nil {
+			progressf("  Input %d: no UTXO information, skipping\n", i)
+			continue
+		}
+		if !bytes.Equal(prevOut.PkScript, witnessProgram) {
+			progressf("  Input %d: not a vault P2WPKH input, skipping\n", i)
+			continue
+		}
+
+		hashType := in.SighashType
+		if hashType == 0 {
+			hashType = txscript.SigHashAll
+		}
+
+		sighash, err := txscript.CalcWitnessSigHash(scriptCode, sigHashes, hashType, packet.UnsignedTx, i, prevOut.Value)
+		if err != nil {
+			return fmt.Errorf("compute sighash for input %d: %w", i, err)
+		}
+
+		progressf("  Input %d: %d sats, sighash %s\n", i, prevOut.Value, hex.EncodeToString(sighash))
+		inputs = append(inputs, vaultInput{index: i, hashType: hashType, sighash: sighash})
+	}
+
+	if len(inputs) == 0 {
+		return fmt.Errorf("no inputs in the PSBT belong to %s", btcAddr)
+	}
+
+	messages := make([]string, len(inputs))
+	for i, in := range inputs {
+		messages[i] = hex.EncodeToString(in.sighash)
+	}
+
+	progressf("\nSigning %d input(s) with ECDSA keysign (Fast Vault Server)...\n", len(inputs))
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+	defer cancel()
+
+	tss := NewTSSService(vault.LocalPartyID)
+	results, err := tss.KeysignWithFastVault(ctx, vault, messages, common.Bitcoin.GetDerivePath(), vaultPassword)
+	if err != nil {
+		return fmt.Errorf("keysign failed: %w", err)
+	}
+
+	updater, err := psbt.NewUpdater(packet)
+	if err != nil {
+		return fmt.Errorf("create psbt updater: %w", err)
+	}
+
+	for i, in := range inputs {
+		sig, err := derSignatureFromResult(results[i])
+		if err != nil {
+			return fmt.Errorf("input %d: %w", in.index, err)
+		}
+		if !sig.Verify(in.sighash, pubKey) {
+			return fmt.Errorf("input %d: signature does not verify against the vault's bitcoin key", in.index)
+		}
+
+		sigBytes := append(sig.Serialize(), byte(in.hashType))
+		_, err = updater.Sign(in.index, sigBytes, pubKeyBytes, nil, nil)
+		if err != nil {
+			return fmt.Errorf("add signature to input %d: %w", in.index, err)
+		}
+	}
+	fmt.Printf("%s Signed %d input(s)\n", okMark(), len(inputs))
+
+	if finalize {
+		err = psbt.MaybeFinalizeAll(packet)
+		if err != nil {
+			return fmt.Errorf("finalize psbt: %w", err)
+		}
+		fmt.Printf("%s PSBT finalized\n", okMark())
+	}
+
+	if output == "" {
+		output = file + ".signed"
+	}
+	var buf bytes.Buffer
+	if isBase64 {
+		encoded, err := packet.B64Encode()
+		if err != nil {
+			return fmt.Errorf("encode psbt: %w", err)
+		}
+		buf.WriteString(encoded)
+	} else {
+		err = packet.Serialize(&buf)
+		if err != nil {
+			return fmt.Errorf("serialize psbt: %w", err)
+		}
+	}
+	err = os.WriteFile(output, buf.Bytes(), 0644)
+	if err != nil {
+		return fmt.Errorf("write psbt: %w", err)
+	}
+	fmt.Printf("  Written: %s\n", output)
+
+	if !finalize {
+		return nil
+	}
+
+	tx, err := psbt.Extract(packet)
+	if err != nil {
+		return fmt.Errorf("extract transaction: %w", err)
+	}
+	var rawTx bytes.Buffer
+	err = tx.Serialize(&rawTx)
+	if err != nil {
+		return fmt.Errorf("serialize transaction: %w", err)
+	}
+	rawTxHex := hex.EncodeToString(rawTx.Bytes())
+	fmt.Printf("  TXID:   %s\n", tx.TxHash().String())
+	fmt.Printf("  Raw TX: %s\n", rawTxHex)
+
+	if broadcast {
+		txid, err := broadcastEsplora(esplora, rawTxHex)
+		if err != nil {
+			return fmt.Errorf("broadcast: %w", err)
+		}
+		fmt.Printf("%s Broadcast via %s: %s\n", okMark(), esplora, txid)
+	}
+
+	return nil
+}
+
+// psbtPrevOutputFetcher collects the outputs the packet's inputs spend, from
+// each input's witness UTXO or, failing that, its non-witness UTXO. Inputs
+// with neither are left out, so FetchPrevOutput returns nil for them.
+func psbtPrevOutputFetcher(packet *psbt.Packet) *txscript.MultiPrevOutFetcher {
+	fetcher := txscript.NewMultiPrevOutFetcher(nil)
+	for i, txIn := range packet.UnsignedTx.TxIn {
+		in := packet.Inputs[i]
+		switch {
+		case in.WitnessUtxo != nil:
+			fetcher.AddPrevOut(txIn.PreviousOutPoint, in.WitnessUtxo)
+		case in.NonWitnessUtxo != nil && int(txIn.PreviousOutPoint.Index) < len(in.NonWitnessUtxo.TxOut):
+			fetcher.AddPrevOut(txIn.PreviousOutPoint, in.NonWitnessUtxo.TxOut[txIn.PreviousOutPoint.Index])
+		}
+	}
+	return fetcher
+}
+
+// derSignatureFromResult rebuilds an ECDSA signature from keysign R and S.
+// Serialize() canonicalises to low-S as Bitcoin standardness requires.
+func derSignatureFromResult(result KeysignResult) (*ecdsa.Signature, error) {
+	rBytes, err := hex.DecodeString(result.R)
+	if err != nil {
+		return nil, fmt.Errorf("decode signature R: %w", err)
+	}
+	sBytes, err := hex.DecodeString(result.S)
+	if err != nil {
+		return nil, fmt.Errorf("decode signature S: %w", err)
+	}
+
+	var r, s btcec.ModNScalar
+	if r.SetByteSlice(rBytes) || s.SetByteSlice(sBytes) {
+		return nil, fmt.Errorf("signature scalar overflows curve order")
+	}
+	return ecdsa.NewSignature(&r, &s), nil
+}
+
+func broadcastEsplora(esplora, rawTxHex string) (string, error) {
+	url := strings.TrimSuffix(esplora, "/") + "/tx"
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, strings.NewReader(rawTxHex))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "text/plain")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("esplora returned %d: %s", resp.StatusCode, errorBody(body))
+	}
+	return strings.TrimSpace(string(body)), nil
+}
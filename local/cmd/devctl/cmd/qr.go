@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"fmt"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"os"
+	"strings"
+
+	"github.com/makiuchi-d/gozxing"
+	"github.com/makiuchi-d/gozxing/qrcode"
+)
+
+// decodeQRImages reads the QR code in each image and joins the payloads in
+// the given order, so a backup split across several QR codes is reassembled
+// from one --qr-image flag per part.
+func decodeQRImages(paths []string) ([]byte, error) {
+	var payload strings.Builder
+	for i, path := range paths {
+		text, err := decodeQRImage(path)
+		if err != nil {
+			return nil, fmt.Errorf("decode QR image %d/%d (%s): %w", i+1, len(paths), path, err)
+		}
+		payload.WriteString(strings.TrimSpace(text))
+	}
+	return []byte(payload.String()), nil
+}
+
+func decodeQRImage(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	img, _, err := image.Decode(f)
+	if err != nil {
+		return "", fmt.Errorf("read image: %w", err)
+	}
+
+	bmp, err := gozxing.NewBinaryBitmapFromImage(img)
+	if err != nil {
+		return "", fmt.Errorf("binarize image: %w", err)
+	}
+
+	hints := map[gozxing.DecodeHintType]interface{}{
+		gozxing.DecodeHintType_TRY_HARDER: true,
+	}
+	result, err := qrcode.NewQRCodeReader().Decode(bmp, hints)
+	if err != nil {
+		return "", fmt.Errorf("no readable QR code found: %w", err)
+	}
+
+	return result.GetText(), nil
+}
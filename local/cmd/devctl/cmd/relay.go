@@ -0,0 +1,281 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/vultisig/vultisig-go/relay"
+)
+
+// Session states recorded in the sessions file.
+const (
+	sessionStarted   = "started"
+	sessionCompleted = "completed"
+	sessionFailed    = "failed"
+	sessionEnded     = "ended"
+)
+
+// SessionRecord tracks a relay session devctl registered. The sessions file
+// is append-only; the last record for a session ID is its current state.
+type SessionRecord struct {
+	SessionID string `json:"session_id"`
+	Operation string `json:"operation"`
+	Vault     string `json:"vault,omitempty"`
+	PID       int    `json:"pid"`
+	TraceID   string `json:"trace_id"`
+	Status    string `json:"status"`
+	Time      string `json:"time"`
+}
+
+func SessionsPath() string {
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".vultisig", "sessions.jsonl")
+}
+
+func appendSessionRecord(rec SessionRecord) error {
+	path := SessionsPath()
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return fmt.Errorf("create sessions dir: %w", err)
+	}
+
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("marshal session record: %w", err)
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("open sessions file: %w", err)
+	}
+	defer f.Close()
+
+	_, err = f.Write(append(data, '\n'))
+	if err != nil {
+		return fmt.Errorf("write sessions file: %w", err)
+	}
+	return nil
+}
+
+// loadSessionRecords returns the latest record of every tracked session,
+// oldest first. A missing file yields no sessions.
+func loadSessionRecords() ([]SessionRecord, error) {
+	f, err := os.Open(SessionsPath())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("open sessions file: %w", err)
+	}
+	defer f.Close()
+
+	latest := map[string]SessionRecord{}
+	started := map[string]string{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var rec SessionRecord
+		if json.Unmarshal(scanner.Bytes(), &rec) != nil || rec.SessionID == "" {
+			continue
+		}
+		if _, ok := started[rec.SessionID]; !ok {
+			started[rec.SessionID] = rec.Time
+		}
+		latest[rec.SessionID] = rec
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read sessions file: %w", err)
+	}
+
+	records := make([]SessionRecord, 0, len(latest))
+	for id, rec := range latest {
+		rec.Time = started[id]
+		records = append(records, rec)
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i].Time < records[j].Time })
+	return records, nil
+}
+
+// orphanedSessions are sessions that were started or failed but never
+// completed or ended, excluding those whose devctl process is still running.
+func orphanedSessions(vault string) ([]SessionRecord, error) {
+	records, err := loadSessionRecords()
+	if err != nil {
+		return nil, err
+	}
+
+	var orphans []SessionRecord
+	for _, rec := range records {
+		if rec.Status != sessionStarted && rec.Status != sessionFailed {
+			continue
+		}
+		if vault != "" && rec.Vault != vault {
+			continue
+		}
+		if rec.PID != os.Getpid() && rec.Status == sessionStarted && pidAlive(rec.PID) {
+			continue
+		}
+		orphans = append(orphans, rec)
+	}
+	return orphans, nil
+}
+
+// endOrphanedSession ends a session on the relay and records it as ended.
+// Sessions the relay no longer knows are recorded as ended too.
+func endOrphanedSession(client *relay.Client, rec SessionRecord) error {
+	_, getErr := client.GetSession(rec.SessionID)
+	if getErr == nil {
+		err := client.EndSession(rec.SessionID)
+		if err != nil {
+			return err
+		}
+	}
+	rec.Status = sessionEnded
+	rec.Time = time.Now().UTC().Format(time.RFC3339)
+	return appendSessionRecord(rec)
+}
+
+// trackedSession records the lifecycle of one relay session.
+type trackedSession struct {
+	rec       SessionRecord
+	completed bool
+}
+
+// trackSession ends this vault's stale sessions on the relay, so a crashed
+// earlier attempt cannot collide with the new one, then records sessionID as
+// started. Call complete once the protocol succeeded and defer end.
+func (t *TSSService) trackSession(operation, vault, sessionID string) *trackedSession {
+	if vault != "" {
+		orphans, err := orphanedSessions(vault)
+		if err != nil {
+			t.logger.WithError(err).Warn("Failed to read tracked sessions")
+		}
+		for _, rec := range orphans {
+			err = endOrphanedSession(t.relayClient.Client, rec)
+			if err != nil {
+				t.logger.WithError(err).WithField("session_id", rec.SessionID).Warn("Failed to end stale session")
+				continue
+			}
+			t.logger.WithField("session_id", rec.SessionID).Info("Ended stale session from an earlier run")
+		}
+	}
+
+	s := &trackedSession{rec: SessionRecord{
+		SessionID: sessionID,
+		Operation: operation,
+		Vault:     vault,
+		PID:       os.Getpid(),
+		TraceID:   TraceID(),
+	}}
+	s.record(sessionStarted)
+	return s
+}
+
+func (s *trackedSession) complete() {
+	s.completed = true
+	s.record(sessionCompleted)
+}
+
+func (s *trackedSession) end() {
+	if !s.completed {
+		s.record(sessionFailed)
+	}
+}
+
+func (s *trackedSession) record(status string) {
+	s.rec.Status = status
+	s.rec.Time = time.Now().UTC().Format(time.RFC3339)
+	err := appendSessionRecord(s.rec)
+	if err != nil {
+		progressf("Warning: could not record relay session: %v\n", err)
+	}
+}
+
+func NewRelayCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "relay",
+		Short: "Inspect relay sessions started by devctl",
+	}
+
+	cmd.AddCommand(newRelayOrphansCmd())
+
+	return cmd
+}
+
+func newRelayOrphansCmd() *cobra.Command {
+	var cleanup bool
+
+	cmd := &cobra.Command{
+		Use:   "orphans",
+		Short: "List relay sessions devctl started but never completed",
+		Long: `List relay sessions devctl registered that never completed, e.g. because a
+keysign crashed or was interrupted. Each is checked against the relay, which
+may still list it with parties polling.
+
+Sessions are tracked in ~/.vultisig/sessions.jsonl. Sessions of a devctl
+process that is still running are not reported. --cleanup ends the orphaned
+sessions on the relay. TSS operations also end their vault's orphaned
+sessions before starting.
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRelayOrphans(cleanup)
+		},
+	}
+
+	cmd.Flags().BoolVar(&cleanup, "cleanup", false, "End orphaned sessions on the relay")
+	return cmd
+}
+
+func runRelayOrphans(cleanup bool) error {
+	orphans, err := orphanedSessions("")
+	if err != nil {
+		return err
+	}
+
+	if len(orphans) == 0 {
+		fmt.Printf("%s No orphaned relay sessions\n", okMark())
+		return nil
+	}
+
+	client := relay.NewRelayClient(RelayServer)
+	rows := make([][]string, 0, len(orphans))
+	for _, rec := range orphans {
+		onRelay := "gone"
+		parties, err := client.GetSession(rec.SessionID)
+		if err == nil {
+			onRelay = fmt.Sprintf("%d parties", len(parties))
+		}
+		vault := rec.Vault
+		if len(vault) > 16 {
+			vault = vault[:16] + "..."
+		}
+		rows = append(rows, []string{rec.SessionID, rec.Operation, vault, rec.Status, rec.Time, onRelay})
+	}
+	printTable([]string{"SESSION", "OPERATION", "VAULT", "STATUS", "STARTED", "RELAY"}, rows)
+
+	if !cleanup {
+		fmt.Println("\nEnd them with: devctl relay orphans --cleanup")
+		return nil
+	}
+
+	fmt.Println()
+	failed := 0
+	for _, rec := range orphans {
+		err := endOrphanedSession(client, rec)
+		if err != nil {
+			fmt.Printf("%s %s: %v\n", failMark(), rec.SessionID, err)
+			failed++
+			continue
+		}
+		fmt.Printf("%s %s ended\n", okMark(), rec.SessionID)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d sessions could not be ended", failed, len(orphans))
+	}
+	return nil
+}
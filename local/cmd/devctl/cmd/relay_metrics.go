@@ -0,0 +1,203 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	vsrelay "github.com/vultisig/vultiserver/relay"
+	"github.com/vultisig/vultisig-go/relay"
+
+	"github.com/vultisig/vultisig-cluster/local/cmd/devctl/format"
+)
+
+// Verbose logs every relay request of a TSS operation with its duration.
+var Verbose bool
+
+// processRelayStats accumulates the relay requests of every TSS operation
+// in this invocation, for the history record.
+var processRelayStats = &RelayStats{}
+
+// RelayStats counts and times relay requests. It is safe for concurrent
+// use; protocol rounds send and poll from several goroutines.
+type RelayStats struct {
+	mu        sync.Mutex
+	durations []time.Duration
+	errors    int
+	calls     map[string]int
+}
+
+// RelaySummary is the JSON form of RelayStats in metrics and history.
+// TotalMs sums the request durations, which overlap when requests run
+// concurrently.
+type RelaySummary struct {
+	Requests int            `json:"requests"`
+	Errors   int            `json:"errors,omitempty"`
+	TotalMs  int64          `json:"total_ms"`
+	P50Ms    int64          `json:"p50_ms"`
+	P95Ms    int64          `json:"p95_ms"`
+	MaxMs    int64          `json:"max_ms"`
+	Calls    map[string]int `json:"calls,omitempty"`
+}
+
+func (s *RelayStats) observe(call string, d time.Duration, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.durations = append(s.durations, d)
+	if err != nil {
+		s.errors++
+	}
+	if s.calls == nil {
+		s.calls = map[string]int{}
+	}
+	s.calls[call]++
+}
+
+// Summary returns the counters so far, or nil if no request was made.
+func (s *RelayStats) Summary() *RelaySummary {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.durations) == 0 {
+		return nil
+	}
+
+	sorted := append([]time.Duration(nil), s.durations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	var total time.Duration
+	for _, d := range sorted {
+		total += d
+	}
+	percentile := func(p float64) time.Duration {
+		return sorted[int(p*float64(len(sorted)-1))]
+	}
+	calls := make(map[string]int, len(s.calls))
+	for k, v := range s.calls {
+		calls[k] = v
+	}
+	return &RelaySummary{
+		Requests: len(sorted),
+		Errors:   s.errors,
+		TotalMs:  total.Milliseconds(),
+		P50Ms:    percentile(0.50).Milliseconds(),
+		P95Ms:    percentile(0.95).Milliseconds(),
+		MaxMs:    sorted[len(sorted)-1].Milliseconds(),
+		Calls:    calls,
+	}
+}
+
+// String renders the summary for the phase report, e.g. "142 relay
+// requests, total 38s, p95 420ms".
+func (r *RelaySummary) String() string {
+	s := fmt.Sprintf("%d relay requests, total %s, p95 %s", r.Requests,
+		format.Duration(time.Duration(r.TotalMs)*time.Millisecond),
+		time.Duration(r.P95Ms)*time.Millisecond)
+	if r.Errors > 0 {
+		s += fmt.Sprintf(", %d failed", r.Errors)
+	}
+	return s
+}
+
+// timedRelayClient is a relay.Client that records every request in stats
+// and in processRelayStats, and logs it with --verbose.
+type timedRelayClient struct {
+	*relay.Client
+	stats  *RelayStats
+	logger *logrus.Entry
+}
+
+func (c *timedRelayClient) observe(call string, started time.Time, err error) {
+	d := time.Since(started)
+	c.stats.observe(call, d, err)
+	processRelayStats.observe(call, d, err)
+	if Verbose {
+		entry := c.logger.WithFields(logrus.Fields{"call": call, "duration": d.Round(time.Millisecond)})
+		if err != nil {
+			entry = entry.WithError(err)
+		}
+		entry.Info("relay request")
+	}
+}
+
+func (c *timedRelayClient) RegisterSession(sessionID, key string) error {
+	started := time.Now()
+	err := c.Client.RegisterSession(sessionID, key)
+	c.observe("register", started, err)
+	return err
+}
+
+func (c *timedRelayClient) GetSession(sessionID string) ([]string, error) {
+	started := time.Now()
+	parties, err := c.Client.GetSession(sessionID)
+	c.observe("get_session", started, err)
+	return parties, err
+}
+
+func (c *timedRelayClient) StartSession(sessionID string, parties []string) error {
+	started := time.Now()
+	err := c.Client.StartSession(sessionID, parties)
+	c.observe("start", started, err)
+	return err
+}
+
+func (c *timedRelayClient) CompleteSession(sessionID, localPartyID string) error {
+	started := time.Now()
+	err := c.Client.CompleteSession(sessionID, localPartyID)
+	c.observe("complete", started, err)
+	return err
+}
+
+func (c *timedRelayClient) EndSession(sessionID string) error {
+	started := time.Now()
+	err := c.Client.EndSession(sessionID)
+	c.observe("end", started, err)
+	return err
+}
+
+func (c *timedRelayClient) UploadSetupMessage(sessionID, messageID, payload string) error {
+	started := time.Now()
+	err := c.Client.UploadSetupMessage(sessionID, messageID, payload)
+	c.observe("upload_setup", started, err)
+	return err
+}
+
+// WaitForSetupMessage polls inside the library; it is recorded as one
+// request lasting until the message arrived.
+func (c *timedRelayClient) WaitForSetupMessage(ctx context.Context, sessionID, messageID string) (string, error) {
+	started := time.Now()
+	msg, err := c.Client.WaitForSetupMessage(ctx, sessionID, messageID)
+	c.observe("wait_setup", started, err)
+	return msg, err
+}
+
+func (c *timedRelayClient) DownloadMessages(sessionID, localPartyID, messageID string) ([]relay.Message, error) {
+	started := time.Now()
+	messages, err := c.Client.DownloadMessages(sessionID, localPartyID, messageID)
+	c.observe("download", started, err)
+	return messages, err
+}
+
+func (c *timedRelayClient) DeleteMessageFromServer(sessionID, localPartyID, hash, messageID string) error {
+	started := time.Now()
+	err := c.Client.DeleteMessageFromServer(sessionID, localPartyID, hash, messageID)
+	c.observe("delete_message", started, err)
+	return err
+}
+
+// timedMessenger records the protocol message uploads of a messenger.
+type timedMessenger struct {
+	*vsrelay.MessengerImp
+	client *timedRelayClient
+}
+
+func (m *timedMessenger) Send(from, to, body string) error {
+	started := time.Now()
+	err := m.MessengerImp.Send(from, to, body)
+	m.client.observe("upload", started, err)
+	return err
+}
@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/vultisig/vultisig-go/address"
+)
+
+// AckProduction skips the production balance check below. It is set by the
+// root --i-know-this-is-production flag.
+var AckProduction bool
+
+// productionChecked caches the guard's verdict per vault, so commands that
+// sign several times (auth, then policy signing) fetch balances only once.
+var productionChecked sync.Map
+
+// ChainBalance is one chain's native balance as seen by the production guard.
+// Err is set when the balance could not be fetched.
+type ChainBalance struct {
+	Chain   string
+	Address string
+	Amount  string
+	Symbol  string
+	USD     float64
+	Priced  bool
+	Err     error
+}
+
+// usesProductionFastVault reports whether TSS sessions go through the
+// production Fast Vault Server, i.e. vultiserver is neither local nor
+// pointed at another endpoint.
+func usesProductionFastVault(cc *ClusterConfig) bool {
+	return strings.TrimRight(cc.GetVultiserverURL(), "/") == FastVaultServer
+}
+
+// guardProduction refuses reshare and keysign sessions against the
+// production Fast Vault Server when the vault's mainnet EVM balances exceed
+// safety.production_threshold_usd, unless acknowledged with
+// --i-know-this-is-production or safety.production_ack. A balance that
+// cannot be fetched or priced blocks too, since it may hold anything.
+func guardProduction(ctx context.Context, vault *LocalVault) error {
+	cc := clusterConfigOrDefaults()
+	if AckProduction || cc.Safety.ProductionAck || !usesProductionFastVault(cc) {
+		return nil
+	}
+
+	if verdict, ok := productionChecked.Load(vault.PublicKeyECDSA); ok {
+		if verdict == nil {
+			return nil
+		}
+		return verdict.(error)
+	}
+
+	progressf("Checking vault balances (production Fast Vault Server)...\n")
+	balances, total, unknown := mainnetBalances(ctx, vault, cc.Gas.PriceAPI)
+
+	var verdict error
+	switch {
+	case total > cc.Safety.ProductionThresholdUSD:
+		printProductionBalances(vault, balances, total, cc.Safety.ProductionThresholdUSD)
+		verdict = fmt.Errorf("vault %s holds ~$%.2f on mainnet and devctl is using the production Fast Vault Server (%s); "+
+			"pass --i-know-this-is-production or set safety.production_ack in cluster.yaml to proceed",
+			vault.Name, total, FastVaultServer)
+	case unknown:
+		printProductionBalances(vault, balances, total, cc.Safety.ProductionThresholdUSD)
+		verdict = fmt.Errorf("could not check all of vault %s's mainnet balances and devctl is using the production Fast Vault Server (%s); "+
+			"pass --i-know-this-is-production or set safety.production_ack in cluster.yaml to proceed",
+			vault.Name, FastVaultServer)
+	}
+	if ctx.Err() != nil {
+		// A cancelled check says nothing about the vault; don't cache it.
+		return verdict
+	}
+	productionChecked.Store(vault.PublicKeyECDSA, verdict)
+	return verdict
+}
+
+// mainnetBalances fetches the vault's native balance on each mainnet EVM
+// chain and sums their USD value. unknown is set when a balance could not be
+// fetched, or a non-zero one could not be priced; the guard then treats the
+// vault as funded.
+func mainnetBalances(ctx context.Context, vault *LocalVault, priceAPI string) ([]ChainBalance, float64, bool) {
+	ctx, cancel := balanceContext(ctx)
+	defer cancel()
+
+	prices := map[string]float64{}
+	var balances []ChainBalance
+	var total float64
+	unknown := false
+
+	for _, c := range chainRegistry() {
+		if c.Testnet {
+			continue
+		}
+
+		addr, _, _, err := address.GetAddress(vault.PublicKeyECDSA, vault.HexChainCode, c.Chain)
+		if err != nil {
+			continue
+		}
+		wei, err := getEVMBalance(ctx, c.RPCURL, addr)
+		if err != nil {
+			unknown = true
+			balances = append(balances, ChainBalance{Chain: c.Name, Address: addr, Symbol: c.Symbol, Err: err})
+			continue
+		}
+		if wei.Sign() == 0 {
+			continue
+		}
+
+		b := ChainBalance{
+			Chain:   c.Name,
+			Address: addr,
+			Amount:  formatBalance(wei, c.Decimals),
+			Symbol:  c.Symbol,
+		}
+		price, ok := prices[c.PriceID]
+		if !ok && c.PriceID != "" {
+			price, err = getUSDPrice(priceAPI, c.PriceID)
+			if err == nil {
+				prices[c.PriceID] = price
+				ok = true
+			}
+		}
+		if ok {
+			b.USD = weiToUSD(wei, c.Decimals, price)
+			b.Priced = true
+			total += b.USD
+		} else {
+			unknown = true
+		}
+		balances = append(balances, b)
+	}
+
+	return balances, total, unknown
+}
+
+func printProductionBalances(vault *LocalVault, balances []ChainBalance, total, threshold float64) {
+	progressf("\n%s Vault %s has funds on mainnet and the Fast Vault Server is production:\n\n", warnMark(), vault.Name)
+	for _, b := range balances {
+		if b.Err != nil {
+			progressf("  %-10s balance unavailable (%s) (%s)\n", b.Chain, balanceErrorText(b.Err), b.Address)
+			continue
+		}
+		usd := "price unavailable"
+		if b.Priced {
+			usd = fmt.Sprintf("~$%.2f", b.USD)
+		}
+		progressf("  %-10s %s %s (%s) %s\n", b.Chain, b.Amount, b.Symbol, b.Address, usd)
+	}
+	progressf("\n  Total: ~$%.2f (threshold $%.2f)\n\n", total, threshold)
+}
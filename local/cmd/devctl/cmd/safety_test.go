@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// emptyMainnet returns a cluster config that points every mainnet chain at
+// an RPC reporting a zero balance.
+func emptyMainnet(t *testing.T) *ClusterConfig {
+	t.Helper()
+	empty := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"jsonrpc": "2.0", "id": 1, "result": "0x0"}`))
+	}))
+	t.Cleanup(empty.Close)
+	cc := &ClusterConfig{Chains: map[string]ChainOverride{}}
+	for _, c := range supportedChains {
+		cc.Chains[chainKey(c.Name)] = ChainOverride{RPC: empty.URL}
+	}
+	return cc
+}
+
+func TestGuardProductionUnavailableBalance(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer down.Close()
+
+	// Every mainnet chain is empty except Ethereum, whose RPC is down.
+	cc := emptyMainnet(t)
+	cc.Chains["ethereum"] = ChainOverride{RPC: down.URL}
+	useClusterConfig(t, cc)
+	if !usesProductionFastVault(cc) {
+		t.Fatal("the default config does not use the production Fast Vault Server")
+	}
+
+	vault, err := newDemoVault()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { productionChecked.Delete(vault.PublicKeyECDSA) })
+
+	err = guardProduction(context.Background(), vault)
+	if err == nil || !strings.Contains(err.Error(), "could not check all of vault") {
+		t.Errorf("with a balance unavailable: error = %v, want the guard to block", err)
+	}
+
+	AckProduction = true
+	defer func() { AckProduction = false }()
+	err = guardProduction(context.Background(), vault)
+	if err != nil {
+		t.Errorf("acknowledged: %v", err)
+	}
+}
+
+func TestMainnetBalancesEmpty(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	cc := emptyMainnet(t)
+	useClusterConfig(t, cc)
+
+	vault, err := newDemoVault()
+	if err != nil {
+		t.Fatal(err)
+	}
+	balances, total, unknown := mainnetBalances(context.Background(), vault, "")
+	if len(balances) != 0 || total != 0 || unknown {
+		t.Errorf("empty vault: got %v, $%.2f, unknown=%v; want nothing", balances, total, unknown)
+	}
+}
@@ -159,14 +159,14 @@ func runServicesStart(services []string) error {
 	feeRoot := findServiceRoot("feeplugin")
 	dcaRoot := findServiceRoot("app-recurring")
 
-	dyldPath := os.Getenv("DYLD_LIBRARY_PATH")
+	libPath := os.Getenv(libraryPathVar())
 	goWrappersPath := "/Users/dev/dev/vultisig/go-wrappers/includes/darwin/"
-	if !strings.Contains(dyldPath, goWrappersPath) {
-		dyldPath = goWrappersPath + ":" + dyldPath
+	if !strings.Contains(libPath, goWrappersPath) {
+		libPath = goWrappersPath + string(os.PathListSeparator) + libPath
 	}
 
 	for _, svc := range services {
-		fmt.Printf("Starting %s...\n", svc)
+		progressf("Starting %s...\n", svc)
 
 		switch svc {
 		case "infra":
@@ -181,11 +181,11 @@ func runServicesStart(services []string) error {
 
 		case "verifier":
 			fmt.Printf("  cd %s && VS_CONFIG_NAME=devenv/config/verifier go run cmd/verifier/main.go\n", verifierRoot)
-			fmt.Println("  [Run in separate terminal with DYLD_LIBRARY_PATH set]")
+			fmt.Printf("  [Run in separate terminal with %s set]\n", libraryPathVar())
 
 		case "worker":
 			fmt.Printf("  cd %s && VS_WORKER_CONFIG_NAME=devenv/config/worker go run cmd/worker/main.go\n", verifierRoot)
-			fmt.Println("  [Run in separate terminal with DYLD_LIBRARY_PATH set]")
+			fmt.Printf("  [Run in separate terminal with %s set]\n", libraryPathVar())
 
 		case "fee":
 			if feeRoot != "" {
@@ -216,8 +216,8 @@ func runServicesStart(services []string) error {
 		}
 	}
 
-	fmt.Println("\nNote: Set DYLD_LIBRARY_PATH before running Go services:")
-	fmt.Printf("  export DYLD_LIBRARY_PATH=%s:$DYLD_LIBRARY_PATH\n", goWrappersPath)
+	fmt.Printf("\nNote: Set %s before running Go services:\n", libraryPathVar())
+	fmt.Printf("  %s=%s\n", libraryPathVar(), libPath)
 
 	return nil
 }
@@ -226,28 +226,28 @@ func runServicesStop(all bool) error {
 	verifierRoot := findVerifierRoot()
 
 	if all {
-		fmt.Println("Stopping all services...")
+		progressln("Stopping all services...")
 
-		fmt.Println("Stopping Go processes...")
-		exec.Command("pkill", "-f", "go run cmd/verifier").Run()
-		exec.Command("pkill", "-f", "go run cmd/worker").Run()
-		exec.Command("pkill", "-f", "go run cmd/server").Run()
-		exec.Command("pkill", "-f", "go run cmd/scheduler").Run()
+		progressln("Stopping Go processes...")
+		killMatching("go run cmd/verifier", false)
+		killMatching("go run cmd/worker", false)
+		killMatching("go run cmd/server", false)
+		killMatching("go run cmd/scheduler", false)
 
 		if verifierRoot != "" {
 			composeFile := filepath.Join(verifierRoot, "devenv", "docker-compose.yaml")
-			fmt.Println("Stopping Docker infrastructure...")
+			progressln("Stopping Docker infrastructure...")
 			cmd := exec.Command("docker", "compose", "-f", composeFile, "down")
 			cmd.Stdout = os.Stdout
 			cmd.Stderr = os.Stderr
 			cmd.Run()
 		}
 	} else {
-		fmt.Println("Stopping Go processes...")
-		exec.Command("pkill", "-f", "go run cmd/verifier").Run()
-		exec.Command("pkill", "-f", "go run cmd/worker").Run()
-		exec.Command("pkill", "-f", "go run cmd/server").Run()
-		exec.Command("pkill", "-f", "go run cmd/scheduler").Run()
+		progressln("Stopping Go processes...")
+		killMatching("go run cmd/verifier", false)
+		killMatching("go run cmd/worker", false)
+		killMatching("go run cmd/server", false)
+		killMatching("go run cmd/scheduler", false)
 		fmt.Println("Done. Use --all to also stop Docker infrastructure.")
 	}
 
@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/ecdsa"
+)
+
+// vConvention is how the V byte of an R+S+V signature encodes the recovery
+// ID.
+type vConvention int
+
+const (
+	// vRaw is the bare recovery ID, 0 or 1 (what DKLS returns and what the
+	// verifier expects for auth and policy signatures).
+	vRaw vConvention = iota
+	// vEthereum is 27 or 28, as in personal_sign and legacy transactions.
+	vEthereum
+	// vEIP155 is chainID*2 + 35 + recovery ID, for replay-protected legacy
+	// transactions.
+	vEIP155
+)
+
+// recoveryBit reads a hex V in any of the conventions: 00/01, 1b/1c (27/28)
+// or an EIP-155 value (35 and up, whatever the chain ID).
+func recoveryBit(v string) (uint, error) {
+	trimmed := strings.TrimPrefix(strings.ToLower(strings.TrimSpace(v)), "0x")
+	n, ok := new(big.Int).SetString(trimmed, 16)
+	if !ok || trimmed == "" {
+		return 0, fmt.Errorf("invalid recovery ID %q", v)
+	}
+	switch {
+	case n.Cmp(big.NewInt(1)) <= 0:
+		return uint(n.Uint64()), nil
+	case n.Cmp(big.NewInt(27)) == 0 || n.Cmp(big.NewInt(28)) == 0:
+		return uint(n.Uint64() - 27), nil
+	case n.Cmp(big.NewInt(35)) >= 0:
+		return uint(new(big.Int).Sub(n, big.NewInt(35)).Bit(0)), nil
+	default:
+		return 0, fmt.Errorf("recovery ID %q is not 0/1, 27/28 or an EIP-155 value", v)
+	}
+}
+
+// normalizeRecoveryID converts a hex V from any convention to conv, as
+// even-length hex. chainID is only used, and required, for vEIP155.
+func normalizeRecoveryID(v string, conv vConvention, chainID *big.Int) (string, error) {
+	bit, err := recoveryBit(v)
+	if err != nil {
+		return "", err
+	}
+	switch conv {
+	case vRaw:
+		return fmt.Sprintf("%02x", bit), nil
+	case vEthereum:
+		return fmt.Sprintf("%02x", bit+27), nil
+	case vEIP155:
+		if chainID == nil || chainID.Sign() <= 0 {
+			return "", fmt.Errorf("EIP-155 V needs a positive chain ID")
+		}
+		out := new(big.Int).Mul(chainID, big.NewInt(2))
+		out.Add(out, big.NewInt(int64(35+bit)))
+		h := out.Text(16)
+		if len(h)%2 == 1 {
+			h = "0" + h
+		}
+		return h, nil
+	default:
+		return "", fmt.Errorf("unknown V convention %d", conv)
+	}
+}
+
+// rsvSignature renders a keysign result as 0x-prefixed R+S+V with V in conv.
+func rsvSignature(sig KeysignResult, conv vConvention, chainID *big.Int) (string, error) {
+	v, err := normalizeRecoveryID(sig.RecoveryID, conv, chainID)
+	if err != nil {
+		return "", err
+	}
+	return "0x" + sig.R + sig.S + v, nil
+}
+
+// ecdsaRecoveryBit finds the recovery ID (0 or 1) under which the result's R
+// and S recover pubKey from hash, which is what ecrecover-based checks such
+// as the verifier's EIP-191 auth need in V.
+func ecdsaRecoveryBit(sig KeysignResult, hash []byte, pubKey *btcec.PublicKey) (uint, error) {
+	r, err := hex.DecodeString(sig.R)
+	if err != nil || len(r) > 32 {
+		return 0, fmt.Errorf("invalid signature R %q", sig.R)
+	}
+	s, err := hex.DecodeString(sig.S)
+	if err != nil || len(s) > 32 {
+		return 0, fmt.Errorf("invalid signature S %q", sig.S)
+	}
+
+	compact := make([]byte, 65)
+	copy(compact[33-len(r):33], r)
+	copy(compact[65-len(s):], s)
+	for bit := uint(0); bit < 2; bit++ {
+		compact[0] = byte(27 + bit)
+		recovered, _, err := ecdsa.RecoverCompact(compact, hash)
+		if err == nil && recovered.IsEqual(pubKey) {
+			return bit, nil
+		}
+	}
+	return 0, fmt.Errorf("signature does not recover the signing key under either recovery ID")
+}
@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/ecdsa"
+)
+
+func TestRSVSignature(t *testing.T) {
+	var (
+		r = strings.Repeat("ab", 32)
+		s = strings.Repeat("cd", 32)
+	)
+
+	tests := []struct {
+		name    string
+		v       string
+		conv    vConvention
+		chainID *big.Int
+		want    string
+		wantErr string
+	}{
+		{name: "raw from raw", v: "01", conv: vRaw, want: "01"},
+		{name: "raw from 0x-prefixed", v: "0x1", conv: vRaw, want: "01"},
+		{name: "raw from ethereum", v: "1c", conv: vRaw, want: "01"},
+		{name: "raw from eip155", v: "25", conv: vRaw, want: "00"},
+		{name: "ethereum from raw 0", v: "00", conv: vEthereum, want: "1b"},
+		{name: "ethereum from raw 1", v: "01", conv: vEthereum, want: "1c"},
+		{name: "ethereum from ethereum", v: "1B", conv: vEthereum, want: "1b"},
+		{name: "ethereum from eip155 of another chain", v: "0136", conv: vEthereum, want: "1c"},
+		{name: "eip155 mainnet", v: "1c", conv: vEIP155, chainID: big.NewInt(1), want: "26"},
+		{name: "eip155 padded to even length", v: "00", conv: vEIP155, chainID: big.NewInt(137), want: "0135"},
+		{name: "eip155 large chain ID", v: "01", conv: vEIP155, chainID: big.NewInt(11155111), want: "01546d72"},
+
+		{name: "eip155 without chain ID", v: "00", conv: vEIP155, wantErr: "EIP-155 V needs a positive chain ID"},
+		{name: "eip155 zero chain ID", v: "00", conv: vEIP155, chainID: big.NewInt(0), wantErr: "EIP-155 V needs a positive chain ID"},
+		{name: "empty V", v: "", conv: vRaw, wantErr: `invalid recovery ID ""`},
+		{name: "V not hex", v: "zz", conv: vRaw, wantErr: `invalid recovery ID "zz"`},
+		{name: "V between conventions", v: "02", conv: vRaw, wantErr: `recovery ID "02" is not 0/1, 27/28 or an EIP-155 value`},
+		{name: "V of 29", v: "1d", conv: vRaw, wantErr: "is not 0/1, 27/28 or an EIP-155 value"},
+		{name: "unknown convention", v: "00", conv: vConvention(9), wantErr: "unknown V convention 9"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := rsvSignature(KeysignResult{R: r, S: s, RecoveryID: tt.v}, tt.conv, tt.chainID)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("rsvSignature error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("rsvSignature: %v", err)
+			}
+			if want := "0x" + r + s + tt.want; got != want {
+				t.Errorf("rsvSignature = %s, want %s", got, want)
+			}
+		})
+	}
+}
+
+// signedResult signs hash with the key derived from seed and returns the
+// result as the TSS layer reports it, with the recovery ID it must have.
+func signedResult(t *testing.T, seed, hash []byte) (KeysignResult, *btcec.PublicKey, uint) {
+	t.Helper()
+	key, pubKey := btcec.PrivKeyFromBytes(seed)
+	compact := ecdsa.SignCompact(key, hash, false)
+	sig := KeysignResult{R: hex.EncodeToString(compact[1:33]), S: hex.EncodeToString(compact[33:])}
+	return sig, pubKey, uint(compact[0] - 27)
+}
+
+func TestECDSARecoveryBit(t *testing.T) {
+	// Enough keys and messages to cover both recovery IDs.
+	seen := map[uint]bool{}
+	for i := 1; i <= 16; i++ {
+		seed := sha256.Sum256([]byte{byte(i)})
+		hash := sha256.Sum256([]byte(strings.Repeat("message", i)))
+		sig, pubKey, want := signedResult(t, seed[:], hash[:])
+
+		got, err := ecdsaRecoveryBit(sig, hash[:], pubKey)
+		if err != nil {
+			t.Fatalf("key %d: %v", i, err)
+		}
+		if got != want {
+			t.Errorf("key %d: recovery bit = %d, want %d", i, got, want)
+		}
+		seen[got] = true
+	}
+	if !seen[0] || !seen[1] {
+		t.Errorf("recovery bits seen = %v, want both 0 and 1", seen)
+	}
+}
+
+func TestECDSARecoveryBitShortR(t *testing.T) {
+	// R and S are integers; a leading zero byte may be dropped.
+	seed := sha256.Sum256([]byte("seed"))
+	for i := 0; i < 2000; i++ {
+		hash := sha256.Sum256([]byte{byte(i), byte(i >> 8)})
+		sig, pubKey, want := signedResult(t, seed[:], hash[:])
+		if !strings.HasPrefix(sig.R, "00") {
+			continue
+		}
+		sig.R = sig.R[2:]
+		got, err := ecdsaRecoveryBit(sig, hash[:], pubKey)
+		if err != nil || got != want {
+			t.Errorf("R without its leading zero: got %d, %v, want %d", got, err, want)
+		}
+		return
+	}
+	t.Skip("no signature with a leading zero byte in R")
+}
+
+func TestECDSARecoveryBitInvalid(t *testing.T) {
+	seed := sha256.Sum256([]byte("seed"))
+	hash := sha256.Sum256([]byte("message"))
+	sig, pubKey, _ := signedResult(t, seed[:], hash[:])
+	otherHash := sha256.Sum256([]byte("other message"))
+	otherSeed := sha256.Sum256([]byte("other seed"))
+	_, otherKey := btcec.PrivKeyFromBytes(otherSeed[:])
+
+	tests := []struct {
+		name    string
+		sig     KeysignResult
+		hash    []byte
+		pubKey  *btcec.PublicKey
+		wantErr string
+	}{
+		{name: "R not hex", sig: KeysignResult{R: "zz", S: sig.S}, hash: hash[:], pubKey: pubKey, wantErr: `invalid signature R "zz"`},
+		{name: "R too long", sig: KeysignResult{R: "00" + sig.R, S: sig.S}, hash: hash[:], pubKey: pubKey, wantErr: "invalid signature R"},
+		{name: "S not hex", sig: KeysignResult{R: sig.R, S: "0x01"}, hash: hash[:], pubKey: pubKey, wantErr: `invalid signature S "0x01"`},
+		{name: "S too long", sig: KeysignResult{R: sig.R, S: sig.S + "00"}, hash: hash[:], pubKey: pubKey, wantErr: "invalid signature S"},
+		{name: "other message", sig: sig, hash: otherHash[:], pubKey: pubKey, wantErr: "does not recover the signing key"},
+		{name: "other key", sig: sig, hash: hash[:], pubKey: otherKey, wantErr: "does not recover the signing key"},
+		{name: "zero R", sig: KeysignResult{R: "", S: sig.S}, hash: hash[:], pubKey: pubKey, wantErr: "does not recover the signing key"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ecdsaRecoveryBit(tt.sig, tt.hash, tt.pubKey)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ecdsaRecoveryBit error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const unknownPartyRole = "unknown party"
+
+// SignerRole maps party IDs to the role shown next to them. Exactly one of
+// Prefix and Pattern (a regular expression) is set.
+type SignerRole struct {
+	Prefix  string `yaml:"prefix"`
+	Pattern string `yaml:"pattern"`
+	Role    string `yaml:"role"`
+}
+
+// defaultSignerRoles classifies the parties of the stock servers. Plugin
+// workers are classified by their registry party_prefix.
+var defaultSignerRoles = []SignerRole{
+	{Prefix: "Server-", Role: "Fast Vault Server"},
+	{Prefix: "verifier-", Role: "Verifier"},
+}
+
+func (r SignerRole) matches(signer string) bool {
+	if r.Pattern != "" {
+		re, err := regexp.Compile(r.Pattern)
+		return err == nil && re.MatchString(signer)
+	}
+	return r.Prefix != "" && strings.HasPrefix(signer, r.Prefix)
+}
+
+// validateSignerRoles checks the signer_roles section of cluster.yaml.
+func (c *ClusterConfig) validateSignerRoles() error {
+	for i, r := range c.SignerRoles {
+		if r.Role == "" {
+			return fmt.Errorf("signer_roles[%d]: role is required", i)
+		}
+		if (r.Prefix == "") == (r.Pattern == "") {
+			return fmt.Errorf("signer_roles[%d] (%s): set exactly one of prefix and pattern", i, r.Role)
+		}
+		if r.Pattern != "" {
+			_, err := regexp.Compile(r.Pattern)
+			if err != nil {
+				return fmt.Errorf("signer_roles[%d] (%s): invalid pattern: %w", i, r.Role, err)
+			}
+		}
+	}
+	return nil
+}
+
+// signerRoles returns the role mappings in match order: signer_roles from
+// cluster.yaml, then the plugin registry's party prefixes, then the
+// defaults.
+func signerRoles() []SignerRole {
+	cc := clusterConfigOrDefaults()
+	roles := append([]SignerRole(nil), cc.SignerRoles...)
+	for _, spec := range pluginRegistry() {
+		if spec.PartyPrefix != "" {
+			roles = append(roles, SignerRole{Prefix: spec.PartyPrefix + "-", Role: spec.Name})
+		}
+	}
+	return append(roles, defaultSignerRoles...)
+}
+
+// getSignerRole returns the parenthesized display role of signer, e.g.
+// "(Verifier)", or "(unknown party)" when no mapping matches.
+func getSignerRole(signer, localPartyID string) string {
+	if signer == localPartyID {
+		return "(CLI)"
+	}
+	for _, r := range signerRoles() {
+		if r.matches(signer) {
+			return "(" + r.Role + ")"
+		}
+	}
+	return "(" + unknownPartyRole + ")"
+}
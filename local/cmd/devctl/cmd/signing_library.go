@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"debug/elf"
+	"debug/macho"
+	"debug/pe"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// SigningLibrary is the go-wrappers build found by probeSigningLibrary.
+// Version is empty when the go-wrappers repo is not configured or not a git
+// checkout.
+type SigningLibrary struct {
+	Dir     string
+	Files   []string
+	Version string
+}
+
+// signingLibraryFiles are the go-wrappers shared libraries that the
+// verifier, its worker and the plugins load through cgo.
+func signingLibraryFiles() []string {
+	switch runtime.GOOS {
+	case "darwin":
+		return []string{"libgodkls.dylib", "libgoschnorr.dylib"}
+	case "windows":
+		return []string{"godkls.dll", "goschnorr.dll"}
+	default:
+		return []string{"libgodkls.so", "libgoschnorr.so"}
+	}
+}
+
+// probeSigningLibrary checks that library.dyld_path holds the go-wrappers
+// libraries built for this OS and architecture. Without it the services
+// started from source die with a dynamic linker error that only shows up in
+// their logs.
+func probeSigningLibrary(cc *ClusterConfig) (*SigningLibrary, error) {
+	dir := cc.GetDYLDPath()
+	if dir == "" {
+		return nil, fmt.Errorf("library.dyld_path is not set in %s; set it to go-wrappers' includes/%s directory (devctl passes it to services as %s)",
+			ClusterConfigSource(), runtime.GOOS, libraryPathVar())
+	}
+
+	lib := &SigningLibrary{Dir: dir}
+	for _, name := range signingLibraryFiles() {
+		path := filepath.Join(dir, name)
+		_, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("signing library %s not found: build go-wrappers, or set library.dyld_path in %s to the directory that holds it (devctl passes it to services as %s)",
+				path, ClusterConfigSource(), libraryPathVar())
+		}
+		err = checkLibraryArch(path)
+		if err != nil {
+			return nil, fmt.Errorf("signing library %s cannot be loaded on %s/%s: %w", path, runtime.GOOS, runtime.GOARCH, err)
+		}
+		lib.Files = append(lib.Files, name)
+	}
+	lib.Version = goWrappersVersion(cc)
+	return lib, nil
+}
+
+// checkLibraryArch reads the library's object header and checks that it is
+// a shared library for runtime.GOARCH, which is what the dynamic linker
+// rejects it for short of a missing file.
+func checkLibraryArch(path string) error {
+	switch runtime.GOOS {
+	case "darwin":
+		want := map[string]macho.Cpu{"amd64": macho.CpuAmd64, "arm64": macho.CpuArm64}[runtime.GOARCH]
+		f, err := macho.Open(path)
+		if err == nil {
+			defer f.Close()
+			if f.Cpu != want {
+				return fmt.Errorf("built for %s", f.Cpu)
+			}
+			return nil
+		}
+		fat, fatErr := macho.OpenFat(path)
+		if fatErr != nil {
+			return fmt.Errorf("not a Mach-O library: %w", err)
+		}
+		defer fat.Close()
+		for _, arch := range fat.Arches {
+			if arch.Cpu == want {
+				return nil
+			}
+		}
+		return fmt.Errorf("universal library without a %s slice", runtime.GOARCH)
+	case "windows":
+		want := map[string]uint16{"amd64": pe.IMAGE_FILE_MACHINE_AMD64, "arm64": pe.IMAGE_FILE_MACHINE_ARM64}[runtime.GOARCH]
+		f, err := pe.Open(path)
+		if err != nil {
+			return fmt.Errorf("not a PE library: %w", err)
+		}
+		defer f.Close()
+		if f.Machine != want {
+			return fmt.Errorf("built for machine type %#x", f.Machine)
+		}
+		return nil
+	default:
+		want := map[string]elf.Machine{"amd64": elf.EM_X86_64, "arm64": elf.EM_AARCH64}[runtime.GOARCH]
+		f, err := elf.Open(path)
+		if err != nil {
+			return fmt.Errorf("not an ELF library: %w", err)
+		}
+		defer f.Close()
+		if f.Type != elf.ET_DYN {
+			return fmt.Errorf("not a shared object (%s)", f.Type)
+		}
+		if f.Machine != want {
+			return fmt.Errorf("built for %s", f.Machine)
+		}
+		return nil
+	}
+}
+
+// goWrappersVersion is 'git describe' of repos.go_wrappers, if configured.
+func goWrappersVersion(cc *ClusterConfig) string {
+	if cc.Repos.GoWrappers == "" {
+		return ""
+	}
+	out, err := exec.Command("git", "-C", cc.Repos.GoWrappers, "describe", "--tags", "--always", "--dirty").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+func (l *SigningLibrary) String() string {
+	if l.Version == "" {
+		return l.Dir
+	}
+	return fmt.Sprintf("%s (go-wrappers %s)", l.Dir, l.Version)
+}
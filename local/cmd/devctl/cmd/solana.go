@@ -0,0 +1,316 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mr-tron/base58"
+	"github.com/spf13/cobra"
+)
+
+const defaultSolanaRPC = "https://api.mainnet-beta.solana.com"
+
+func newVaultSendSolCmd() *cobra.Command {
+	var to string
+	var amount string
+	var rpcURL string
+	var vaultPassword string
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "send-sol",
+		Short: "Send SOL from the vault (EdDSA keysign end-to-end)",
+		Long: `Send SOL from the vault's Solana address.
+
+Builds a system transfer, fetches a recent blockhash, signs the serialized
+message with the vault's EdDSA key via the Fast Vault Server, broadcasts the
+transaction and waits for confirmation.
+
+With --dry-run, nothing is signed or sent: the base64 message and the exact
+bytes that keysign would receive are printed instead.
+
+Examples:
+  devctl vault send-sol --to <address> --amount 0.001 --password "vault-password"
+  devctl vault send-sol --to <address> --amount 0.001 --dry-run
+  devctl vault send-sol --to <address> --amount 0.1 --rpc https://api.devnet.solana.com
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !dryRun && vaultPassword == "" {
+				var err error
+				vaultPassword, err = promptPassword("", "Fast Vault password: ")
+				if err != nil {
+					return err
+				}
+			}
+			return runVaultSendSol(cmd.Context(), to, amount, rpcURL, vaultPassword, dryRun)
+		},
+	}
+
+	cmd.Flags().StringVar(&to, "to", "", "Recipient Solana address (required)")
+	cmd.Flags().StringVar(&amount, "amount", "", "Amount in SOL (required)")
+	cmd.Flags().StringVar(&rpcURL, "rpc", defaultSolanaRPC, "Solana JSON-RPC endpoint")
+	cmd.Flags().StringVarP(&vaultPassword, "password", "p", "", "Fast Vault password")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Print the message and signing payload without signing or sending")
+	cmd.MarkFlagRequired("to")
+	cmd.MarkFlagRequired("amount")
+
+	return cmd
+}
+
+func runVaultSendSol(ctx context.Context, to, amount, rpcURL, vaultPassword string, dryRun bool) error {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	if cfg.PublicKeyECDSA == "" {
+		return fmt.Errorf("no vault configured. Run 'devctl vault import' first")
+	}
+
+	vault, err := LoadVault(cfg.PublicKeyECDSA[:16])
+	if err != nil {
+		return fmt.Errorf("load vault: %w", err)
+	}
+
+	if vault.PublicKeyEdDSA == "" {
+		return fmt.Errorf("vault has no EdDSA key")
+	}
+
+	fromKey, err := hex.DecodeString(vault.PublicKeyEdDSA)
+	if err != nil || len(fromKey) != ed25519.PublicKeySize {
+		return fmt.Errorf("invalid EdDSA public key in vault")
+	}
+
+	toKey, err := base58.Decode(to)
+	if err != nil || len(toKey) != ed25519.PublicKeySize {
+		return fmt.Errorf("invalid Solana address: %s", to)
+	}
+
+	lamports, err := parseUnits(amount, 9)
+	if err != nil {
+		return err
+	}
+	if !lamports.IsUint64() || lamports.Sign() == 0 {
+		return fmt.Errorf("invalid amount: %s", amount)
+	}
+
+	fmt.Println("=== Send SOL ===")
+	fmt.Printf("Vault:  %s\n", vault.Name)
+	fmt.Printf("From:   %s\n", base58.Encode(fromKey))
+	fmt.Printf("To:     %s\n", to)
+	fmt.Printf("Amount: %s SOL (%d lamports)\n", amount, lamports.Uint64())
+	fmt.Printf("RPC:    %s\n", rpcURL)
+	printTraceID()
+	fmt.Println()
+
+	blockhash, err := getSolanaLatestBlockhash(rpcURL)
+	if err != nil {
+		return fmt.Errorf("get recent blockhash: %w", err)
+	}
+	fmt.Printf("Recent blockhash: %s\n", base58.Encode(blockhash))
+
+	message := buildSolanaTransferMessage(fromKey, toKey, lamports.Uint64(), blockhash)
+	messageHex := hex.EncodeToString(message)
+
+	if dryRun {
+		fmt.Println()
+		fmt.Println("=== Dry Run ===")
+		fmt.Printf("Message (base64): %s\n", base64.StdEncoding.EncodeToString(message))
+		fmt.Printf("Message length:   %d bytes\n", len(message))
+		fmt.Println("Keysign payload (hex, Ed25519 signs these bytes directly, no pre-hash):")
+		fmt.Printf("  %s\n", messageHex)
+		return nil
+	}
+
+	fmt.Println()
+	progressln("Signing with EdDSA keysign (Fast Vault Server)...")
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Minute)
+	defer cancel()
+
+	tss := NewTSSService(vault.LocalPartyID)
+	results, err := tss.KeysignEdDSAWithFastVault(ctx, vault, []string{messageHex}, vaultPassword)
+	if err != nil {
+		return fmt.Errorf("keysign failed: %w", err)
+	}
+
+	signature, err := ed25519SignatureFromResult(results[0], fromKey, message)
+	if err != nil {
+		return err
+	}
+
+	tx := make([]byte, 0, 1+len(signature)+len(message))
+	tx = append(tx, 1)
+	tx = append(tx, signature...)
+	tx = append(tx, message...)
+
+	txSig, err := sendSolanaTransaction(rpcURL, tx)
+	if err != nil {
+		return fmt.Errorf("send transaction: %w", err)
+	}
+
+	fmt.Printf("%s Transaction sent\n", okMark())
+	fmt.Printf("  Signature: %s\n", txSig)
+	fmt.Printf("  Explorer:  %s\n", solanaExplorerURL(txSig, rpcURL))
+
+	progressln("\nWaiting for confirmation...")
+	status, err := waitForSolanaConfirmation(rpcURL, txSig, 60*time.Second)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%s Transaction %s\n", okMark(), status)
+
+	return nil
+}
+
+// buildSolanaTransferMessage serializes a legacy message holding a single
+// system program transfer from -> to.
+func buildSolanaTransferMessage(from, to []byte, lamports uint64, recentBlockhash []byte) []byte {
+	systemProgram := make([]byte, 32)
+
+	var buf bytes.Buffer
+
+	// Header: 1 required signature, 0 read-only signed, 1 read-only unsigned
+	// (the system program).
+	buf.Write([]byte{1, 0, 1})
+
+	buf.WriteByte(3)
+	buf.Write(from)
+	buf.Write(to)
+	buf.Write(systemProgram)
+
+	buf.Write(recentBlockhash)
+
+	// One instruction: program index 2, accounts [0, 1], data = Transfer.
+	data := make([]byte, 12)
+	binary.LittleEndian.PutUint32(data[0:4], 2)
+	binary.LittleEndian.PutUint64(data[4:12], lamports)
+
+	buf.WriteByte(1)
+	buf.WriteByte(2)
+	buf.WriteByte(2)
+	buf.Write([]byte{0, 1})
+	buf.WriteByte(byte(len(data)))
+	buf.Write(data)
+
+	return buf.Bytes()
+}
+
+// ed25519SignatureFromResult assembles R||S from a keysign result and checks
+// it against the vault's EdDSA key. The MPC library may return the scalars in
+// little-endian order, so both encodings are tried.
+func ed25519SignatureFromResult(result KeysignResult, pubKey, message []byte) ([]byte, error) {
+	r, err := hex.DecodeString(result.R)
+	if err != nil {
+		return nil, fmt.Errorf("decode signature R: %w", err)
+	}
+	s, err := hex.DecodeString(result.S)
+	if err != nil {
+		return nil, fmt.Errorf("decode signature S: %w", err)
+	}
+
+	candidates := [][]byte{
+		append(append([]byte{}, r...), s...),
+		append(reverseBytes(r), reverseBytes(s)...),
+	}
+	for _, sig := range candidates {
+		if ed25519.Verify(pubKey, message, sig) {
+			return sig, nil
+		}
+	}
+
+	return nil, fmt.Errorf("keysign returned a signature that does not verify against the vault EdDSA key")
+}
+
+func reverseBytes(b []byte) []byte {
+	out := make([]byte, len(b))
+	for i := range b {
+		out[i] = b[len(b)-1-i]
+	}
+	return out
+}
+
+func getSolanaLatestBlockhash(rpcURL string) ([]byte, error) {
+	raw, err := jsonRPC(rpcURL, "getLatestBlockhash", []interface{}{map[string]string{"commitment": "finalized"}})
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Value struct {
+			Blockhash string `json:"blockhash"`
+		} `json:"value"`
+	}
+	err = json.Unmarshal(raw, &result)
+	if err != nil {
+		return nil, fmt.Errorf("parse blockhash: %w", err)
+	}
+
+	blockhash, err := base58.Decode(result.Value.Blockhash)
+	if err != nil || len(blockhash) != 32 {
+		return nil, fmt.Errorf("invalid blockhash: %q", result.Value.Blockhash)
+	}
+	return blockhash, nil
+}
+
+func sendSolanaTransaction(rpcURL string, tx []byte) (string, error) {
+	raw, err := jsonRPC(rpcURL, "sendTransaction", []interface{}{
+		base64.StdEncoding.EncodeToString(tx),
+		map[string]string{"encoding": "base64"},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	var sig string
+	err = json.Unmarshal(raw, &sig)
+	if err != nil {
+		return "", fmt.Errorf("parse signature: %w", err)
+	}
+	return sig, nil
+}
+
+func waitForSolanaConfirmation(rpcURL, sig string, timeout time.Duration) (string, error) {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		raw, err := jsonRPC(rpcURL, "getSignatureStatuses", []interface{}{[]string{sig}})
+		if err == nil {
+			var result struct {
+				Value []*struct {
+					ConfirmationStatus string          `json:"confirmationStatus"`
+					Err                json.RawMessage `json:"err"`
+				} `json:"value"`
+			}
+			if json.Unmarshal(raw, &result) == nil && len(result.Value) > 0 && result.Value[0] != nil {
+				status := result.Value[0]
+				if len(status.Err) > 0 && string(status.Err) != "null" {
+					return "", fmt.Errorf("transaction failed: %s", string(status.Err))
+				}
+				if status.ConfirmationStatus == "confirmed" || status.ConfirmationStatus == "finalized" {
+					return status.ConfirmationStatus, nil
+				}
+			}
+		}
+		time.Sleep(2 * time.Second)
+	}
+	return "", fmt.Errorf("transaction not confirmed within %s", timeout)
+}
+
+func solanaExplorerURL(sig, rpcURL string) string {
+	url := "https://explorer.solana.com/tx/" + sig
+	switch {
+	case strings.Contains(rpcURL, "devnet"):
+		url += "?cluster=devnet"
+	case strings.Contains(rpcURL, "testnet"):
+		url += "?cluster=testnet"
+	}
+	return url
+}
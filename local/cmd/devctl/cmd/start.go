@@ -3,7 +3,7 @@ package cmd
 import (
 	"context"
 	"fmt"
-	"net/http"
+	"net"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -11,19 +11,14 @@ import (
 	"time"
 
 	"github.com/spf13/cobra"
-)
 
-const (
-	colorRed    = "\033[0;31m"
-	colorGreen  = "\033[0;32m"
-	colorYellow = "\033[1;33m"
-	colorCyan   = "\033[0;36m"
-	colorBold   = "\033[1m"
-	colorReset  = "\033[0m"
+	"github.com/vultisig/vultisig-cluster/local/cmd/devctl/format"
 )
 
 func NewStartCmd() *cobra.Command {
 	var skipDCA bool
+	var skipSends bool
+	var profileStartup bool
 
 	cmd := &cobra.Command{
 		Use:   "start",
@@ -43,21 +38,71 @@ Services started:
 5. DCA Plugin Worker
 6. DCA Scheduler
 7. DCA TX Indexer
+8. Recurring Sends Plugin Server, Worker and Scheduler (if sends_server is
+   local; app-recurring in send mode, database and bucket vultisig-sends)
+
+Chains with 'fork.enabled: true' under 'chains' in cluster.yaml also get an
+anvil fork, and devctl and the DCA worker/indexer use it as that chain's RPC.
+
+All services run in the background with logs in /tmp/*.log (the system
+temp directory on Windows)
 
-All services run in the background with logs in /tmp/*.log
+Ctrl-C (or --timeout) during startup stops everything launched so far and
+prints what had been started.
+
+--profile-startup times every step (compose up, each readiness wait, each
+service launch and health wait, which includes its 'go run' compile),
+prints the five slowest and writes all spans to
+~/.vultisig/profiles/startup-<time>.json. The file loads in chrome://tracing
+or Perfetto; attach it to startup performance issues.
 `,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runStart(skipDCA)
+			return runStart(cmd.Context(), skipDCA, skipSends, profileStartup)
 		},
 	}
 
 	cmd.Flags().BoolVar(&skipDCA, "skip-dca", false, "Skip starting DCA plugin services")
+	cmd.Flags().BoolVar(&skipSends, "skip-sends", false, "Skip starting recurring-sends plugin services")
+	cmd.Flags().BoolVar(&profileStartup, "profile-startup", false, "Time every startup step and write a Chrome trace to ~/.vultisig/profiles")
 
 	return cmd
 }
 
-func runStart(skipDCA bool) error {
+func runStart(ctx context.Context, skipDCA, skipSends, profileStartup bool) error {
 	startTime := time.Now()
+	// profile stays nil without --profile-startup; its Start is then a no-op.
+	var profile *PhaseTimings
+	if profileStartup {
+		profile = NewPhaseTimings("start")
+	}
+	var launched []string
+	dockerStarted := false
+	composeFile := ""
+
+	// abort tears down whatever was launched before an interrupt or timeout,
+	// using the same cleanup as 'devctl stop'.
+	abort := func(err error) error {
+		fmt.Println()
+		fmt.Printf("%sStartup interrupted: %v%s\n", colorYellow, err, colorReset)
+		fmt.Println("Launched before interrupt:")
+		if dockerStarted {
+			fmt.Println("  - Docker infrastructure")
+		}
+		for _, name := range launched {
+			fmt.Printf("  - %s\n", name)
+		}
+		if !dockerStarted && len(launched) == 0 {
+			fmt.Println("  (nothing)")
+		}
+
+		fmt.Println("Stopping launched services...")
+		runStop()
+		if dockerStarted {
+			exec.Command("docker", "compose", "-f", composeFile, "down").Run()
+		}
+		fmt.Printf("%s Partial startup cleaned up after %s\n", okMark(), format.Duration(time.Since(startTime)))
+		return fmt.Errorf("startup interrupted: %w", err)
+	}
 
 	fmt.Println("============================================")
 	fmt.Println("  Vultisig Local Dev Environment Startup")
@@ -80,139 +125,206 @@ func runStart(skipDCA bool) error {
 	dyldPath := config.GetDYLDPath()
 
 	fmt.Printf("Using config:\n")
+	fmt.Printf("  Cluster:  %s\n", ClusterConfigSource())
 	fmt.Printf("  Verifier: %s\n", verifierRoot)
 	if config.IsLocal("dca") {
 		fmt.Printf("  DCA:      %s\n", dcaRoot)
 	}
+	if config.IsLocal("sends") {
+		fmt.Printf("  Sends:    %s\n", config.Repos.Sends)
+	}
 	fmt.Printf("  Relay:    %s\n", config.GetRelayURL())
 	fmt.Printf("  Vault:    %s\n", config.GetVultiserverURL())
+
+	// The verifier and plugins load go-wrappers through cgo; without it
+	// they exit with a linker error that only shows up in their logs.
+	signingLib, err := probeSigningLibrary(config)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("  Library:  %s\n", signingLib)
 	fmt.Println()
 
 	// Step 0: Stop existing services
-	fmt.Printf("%s[0/8]%s Cleaning up existing processes...\n", colorYellow, colorReset)
+	fmt.Printf("%s[0/9]%s Cleaning up existing processes...\n", colorYellow, colorReset)
+	endPhase := profile.Start("Cleanup")
 	runStop()
-	time.Sleep(2 * time.Second)
-	fmt.Printf("%s✓%s Cleanup complete\n", colorGreen, colorReset)
+	if err := sleepCtx(ctx, 2*time.Second); err != nil {
+		return abort(err)
+	}
+	endPhase()
+	fmt.Printf("%s Cleanup complete\n", okMark())
 
 	// Step 1: Start Docker infrastructure
 	fmt.Println()
-	fmt.Printf("%s[1/8]%s Starting Docker infrastructure...\n", colorYellow, colorReset)
+	fmt.Printf("%s[1/9]%s Starting Docker infrastructure...\n", colorYellow, colorReset)
 
-	composeFile := filepath.Join(configsDir, "docker-compose.yaml")
+	composeFile = filepath.Join(configsDir, "docker-compose.yaml")
 	if _, err := os.Stat(composeFile); os.IsNotExist(err) {
 		return fmt.Errorf("docker-compose.yaml not found at %s", composeFile)
 	}
 
+	endPhase = profile.Start("Compose down")
 	dockerCmd := exec.Command("docker", "compose", "-f", composeFile, "down", "-v", "--remove-orphans")
 	dockerCmd.Run()
-	time.Sleep(1 * time.Second)
+	if err := sleepCtx(ctx, 1*time.Second); err != nil {
+		return abort(err)
+	}
+	endPhase()
 
+	endPhase = profile.Start("Compose up")
 	dockerCmd = exec.Command("docker", "compose", "-f", composeFile, "up", "-d")
 	dockerCmd.Stdout = os.Stdout
 	dockerCmd.Stderr = os.Stderr
 	err = dockerCmd.Run()
+	endPhase()
 	if err != nil {
 		return fmt.Errorf("failed to start docker: %w", err)
 	}
+	dockerStarted = true
 
 	// Wait for PostgreSQL
-	fmt.Println("Waiting for PostgreSQL...")
-	time.Sleep(3 * time.Second)
+	progressln("Waiting for PostgreSQL...")
+	endPhase = profile.Start("Wait PostgreSQL")
+	if err := sleepCtx(ctx, 3*time.Second); err != nil {
+		return abort(err)
+	}
 	for i := 0; i < 30; i++ {
-		checkCmd := exec.Command("docker", "exec", "vultisig-postgres", "pg_isready", "-U", "vultisig", "-d", "vultisig")
+		checkCmd := exec.CommandContext(ctx, "docker", "exec", "vultisig-postgres", "pg_isready", "-U", "vultisig", "-d", "vultisig")
 		if checkCmd.Run() == nil {
 			break
 		}
-		time.Sleep(1 * time.Second)
+		if err := sleepCtx(ctx, 1*time.Second); err != nil {
+			return abort(err)
+		}
 	}
-	fmt.Printf("%s✓%s PostgreSQL is ready\n", colorGreen, colorReset)
+	endPhase()
+	fmt.Printf("%s PostgreSQL is ready\n", okMark())
 
 	// Wait for Redis
-	fmt.Println("Waiting for Redis...")
+	progressln("Waiting for Redis...")
+	endPhase = profile.Start("Wait Redis")
 	for i := 0; i < 30; i++ {
-		checkCmd := exec.Command("docker", "exec", "vultisig-redis", "redis-cli", "-a", "vultisig", "ping")
+		checkCmd := exec.CommandContext(ctx, "docker", "exec", "vultisig-redis", "redis-cli", "-a", "vultisig", "ping")
 		if out, _ := checkCmd.Output(); strings.TrimSpace(string(out)) == "PONG" {
 			break
 		}
-		time.Sleep(1 * time.Second)
+		if err := sleepCtx(ctx, 1*time.Second); err != nil {
+			return abort(err)
+		}
 	}
-	fmt.Printf("%s✓%s Redis is ready\n", colorGreen, colorReset)
+	endPhase()
+	fmt.Printf("%s Redis is ready\n", okMark())
 
 	// Wait for MinIO
-	fmt.Println("Waiting for MinIO...")
-	time.Sleep(2 * time.Second)
-	fmt.Printf("%s✓%s MinIO is ready\n", colorGreen, colorReset)
+	progressln("Waiting for MinIO...")
+	endPhase = profile.Start("Wait MinIO")
+	if err := sleepCtx(ctx, 2*time.Second); err != nil {
+		return abort(err)
+	}
+	endPhase()
+	fmt.Printf("%s MinIO is ready\n", okMark())
+
+	if len(config.ForkedChains()) > 0 {
+		progressln("Starting anvil forks...")
+		endPhase = profile.Start("Anvil forks")
+		err = startAnvilForks(ctx, config)
+		endPhase()
+		for _, name := range config.ForkedChains() {
+			launched = append(launched, "Anvil fork ("+name+")")
+		}
+		if ctx.Err() != nil {
+			return abort(ctx.Err())
+		}
+		if err != nil {
+			return err
+		}
+	}
 
 	// Step 2: Start Verifier Server
 	fmt.Println()
-	fmt.Printf("%s[2/8]%s Starting Verifier Server...\n", colorYellow, colorReset)
+	fmt.Printf("%s[2/9]%s Starting Verifier Server...\n", colorYellow, colorReset)
 
 	verifierCmd := exec.Command("go", "run", "cmd/verifier/main.go")
 	verifierCmd.Dir = verifierRoot
 	verifierCmd.Env = append(os.Environ(),
-		"DYLD_LIBRARY_PATH="+dyldPath+":"+os.Getenv("DYLD_LIBRARY_PATH"),
+		libraryPathEnv(dyldPath),
 		"VS_VERIFIER_CONFIG_NAME=devenv/config/verifier",
 	)
 
-	verifierLog, err := os.Create("/tmp/verifier.log")
+	verifierLog, err := os.Create(runPath("verifier.log"))
 	if err != nil {
 		return fmt.Errorf("create verifier log: %w", err)
 	}
 	verifierCmd.Stdout = verifierLog
 	verifierCmd.Stderr = verifierLog
 
+	endPhase = profile.Start("Verifier Server launch")
 	err = verifierCmd.Start()
+	endPhase()
 	if err != nil {
 		return fmt.Errorf("start verifier: %w", err)
 	}
-	writePIDFile("/tmp/verifier.pid", verifierCmd.Process.Pid)
+	writePIDFile(runPath("verifier.pid"), verifierCmd.Process.Pid)
+	launched = append(launched, fmt.Sprintf("Verifier Server (PID %d)", verifierCmd.Process.Pid))
 	fmt.Printf("  PID: %d\n", verifierCmd.Process.Pid)
-	fmt.Println("  Log: /tmp/verifier.log")
+	fmt.Printf("  Log: %s\n", runPath("verifier.log"))
 
 	// Wait for Verifier API
-	verifierURL := fmt.Sprintf("http://localhost:%d/plugins", config.Ports.Verifier)
-	fmt.Println("  Waiting for Verifier API (compiling + migrations)...")
-	if !waitForHealthy(verifierURL, 60*time.Second) {
-		return fmt.Errorf("verifier failed to start - check /tmp/verifier.log")
+	verifierSvc, _ := config.LocalService("verifier")
+	progressln("  Waiting for Verifier API (compiling + migrations)...")
+	endPhase = profile.Start("Verifier Server build+health")
+	ready := waitForService(ctx, verifierSvc, 60*time.Second)
+	endPhase()
+	if !ready {
+		if ctx.Err() != nil {
+			return abort(ctx.Err())
+		}
+		return fmt.Errorf("verifier failed to start - check %s", runPath("verifier.log"))
 	}
-	fmt.Printf("  %s✓%s Verifier API ready\n", colorGreen, colorReset)
+	fmt.Printf("  %s Verifier API ready\n", okMark())
 
 	// Seed plugins
 	fmt.Println("  Seeding plugins...")
+	endPhase = profile.Start("Seed plugins")
 	seedFile := filepath.Join(configsDir, "seed-plugins.sql")
 	seedCmd := exec.Command("docker", "exec", "-i", "vultisig-postgres", "psql", "-U", "vultisig", "-d", "vultisig-verifier")
 	seedData, _ := os.ReadFile(seedFile)
 	seedCmd.Stdin = strings.NewReader(string(seedData))
 	seedCmd.Run()
-	fmt.Printf("  %s✓%s Plugins seeded\n", colorGreen, colorReset)
+	endPhase()
+	fmt.Printf("  %s Plugins seeded\n", okMark())
 
 	// Step 3: Start Verifier Worker
 	fmt.Println()
-	fmt.Printf("%s[3/8]%s Starting Verifier Worker...\n", colorYellow, colorReset)
+	fmt.Printf("%s[3/9]%s Starting Verifier Worker...\n", colorYellow, colorReset)
 
 	workerCmd := exec.Command("go", "run", "cmd/worker/main.go")
 	workerCmd.Dir = verifierRoot
 	workerCmd.Env = append(os.Environ(),
-		"DYLD_LIBRARY_PATH="+dyldPath+":"+os.Getenv("DYLD_LIBRARY_PATH"),
+		libraryPathEnv(dyldPath),
 		"VS_WORKER_CONFIG_NAME=devenv/config/worker",
 	)
 
-	workerLog, _ := os.Create("/tmp/worker.log")
+	workerLog, _ := os.Create(runPath("worker.log"))
 	workerCmd.Stdout = workerLog
 	workerCmd.Stderr = workerLog
 
+	endPhase = profile.Start("Verifier Worker launch")
 	err = workerCmd.Start()
+	endPhase()
 	if err != nil {
 		return fmt.Errorf("start worker: %w", err)
 	}
-	writePIDFile("/tmp/worker.pid", workerCmd.Process.Pid)
+	writePIDFile(runPath("worker.pid"), workerCmd.Process.Pid)
+	launched = append(launched, fmt.Sprintf("Verifier Worker (PID %d)", workerCmd.Process.Pid))
 	fmt.Printf("  PID: %d\n", workerCmd.Process.Pid)
-	fmt.Println("  Log: /tmp/worker.log")
+	fmt.Printf("  Log: %s\n", runPath("worker.log"))
 
 	// Step 4-8: Start DCA Plugin services
 	if !skipDCA && config.IsLocal("dca") && dcaRoot != "" {
 		fmt.Println()
-		fmt.Printf("%s[4/8]%s Starting DCA Plugin Server...\n", colorYellow, colorReset)
+		fmt.Printf("%s[4/9]%s Starting DCA Plugin Server...\n", colorYellow, colorReset)
 
 		dcaEnvFile := filepath.Join(configsDir, "dca-server.env")
 		dcaEnv := loadEnvFile(dcaEnvFile)
@@ -220,56 +332,68 @@ func runStart(skipDCA bool) error {
 		dcaCmd := exec.Command("go", "run", "cmd/server/main.go")
 		dcaCmd.Dir = dcaRoot
 		dcaCmd.Env = append(os.Environ(), dcaEnv...)
-		dcaCmd.Env = append(dcaCmd.Env, "DYLD_LIBRARY_PATH="+dyldPath+":"+os.Getenv("DYLD_LIBRARY_PATH"))
+		dcaCmd.Env = append(dcaCmd.Env, libraryPathEnv(dyldPath))
 
-		dcaLog, _ := os.Create("/tmp/dca.log")
+		dcaLog, _ := os.Create(runPath("dca.log"))
 		dcaCmd.Stdout = dcaLog
 		dcaCmd.Stderr = dcaLog
 
+		endPhase = profile.Start("DCA Plugin Server launch")
 		err = dcaCmd.Start()
+		endPhase()
 		if err != nil {
-			fmt.Printf("  %s!%s Failed to start DCA server: %v\n", colorYellow, colorReset, err)
+			fmt.Printf("  %s Failed to start DCA server: %v\n", warnMark(), err)
 		} else {
-			writePIDFile("/tmp/dca.pid", dcaCmd.Process.Pid)
+			writePIDFile(runPath("dca.pid"), dcaCmd.Process.Pid)
+			launched = append(launched, fmt.Sprintf("DCA Plugin Server (PID %d)", dcaCmd.Process.Pid))
 			fmt.Printf("  PID: %d\n", dcaCmd.Process.Pid)
-			fmt.Println("  Log: /tmp/dca.log")
-
-			dcaURL := fmt.Sprintf("http://localhost:%d/healthz", config.Ports.DCAServer)
-			fmt.Println("  Waiting for DCA Plugin API (compiling + migrations)...")
-			if waitForHealthy(dcaURL, 60*time.Second) {
-				fmt.Printf("  %s✓%s DCA Plugin API ready\n", colorGreen, colorReset)
+			fmt.Printf("  Log: %s\n", runPath("dca.log"))
+
+			dcaSvc, _ := config.LocalService("dca_server")
+			progressln("  Waiting for DCA Plugin API (compiling + migrations)...")
+			endPhase = profile.Start("DCA Plugin Server build+health")
+			ready = waitForService(ctx, dcaSvc, 60*time.Second)
+			endPhase()
+			if ready {
+				fmt.Printf("  %s DCA Plugin API ready\n", okMark())
+			} else if ctx.Err() != nil {
+				return abort(ctx.Err())
 			} else {
-				fmt.Printf("  %s!%s DCA Plugin failed to start - check /tmp/dca.log\n", colorYellow, colorReset)
+				fmt.Printf("  %s DCA Plugin failed to start - check %s\n", warnMark(), runPath("dca.log"))
 			}
 		}
 
 		fmt.Println()
-		fmt.Printf("%s[5/8]%s Starting DCA Plugin Worker...\n", colorYellow, colorReset)
+		fmt.Printf("%s[5/9]%s Starting DCA Plugin Worker...\n", colorYellow, colorReset)
 
 		dcaWorkerEnvFile := filepath.Join(configsDir, "dca-worker.env")
 		dcaWorkerEnv := loadEnvFile(dcaWorkerEnvFile)
+		dcaWorkerEnv = append(dcaWorkerEnv, forkRPCEnv(config, "RPC_%s_URL")...)
 
 		dcaWorkerCmd := exec.Command("go", "run", "cmd/worker/main.go")
 		dcaWorkerCmd.Dir = dcaRoot
 		dcaWorkerCmd.Env = append(os.Environ(), dcaWorkerEnv...)
-		dcaWorkerCmd.Env = append(dcaWorkerCmd.Env, "DYLD_LIBRARY_PATH="+dyldPath+":"+os.Getenv("DYLD_LIBRARY_PATH"))
+		dcaWorkerCmd.Env = append(dcaWorkerCmd.Env, libraryPathEnv(dyldPath))
 
-		dcaWorkerLog, _ := os.Create("/tmp/dca-worker.log")
+		dcaWorkerLog, _ := os.Create(runPath("dca-worker.log"))
 		dcaWorkerCmd.Stdout = dcaWorkerLog
 		dcaWorkerCmd.Stderr = dcaWorkerLog
 
+		endPhase = profile.Start("DCA Plugin Worker launch")
 		err = dcaWorkerCmd.Start()
+		endPhase()
 		if err != nil {
-			fmt.Printf("  %s!%s Failed to start DCA worker: %v\n", colorYellow, colorReset, err)
+			fmt.Printf("  %s Failed to start DCA worker: %v\n", warnMark(), err)
 		} else {
-			writePIDFile("/tmp/dca-worker.pid", dcaWorkerCmd.Process.Pid)
+			writePIDFile(runPath("dca-worker.pid"), dcaWorkerCmd.Process.Pid)
+			launched = append(launched, fmt.Sprintf("DCA Plugin Worker (PID %d)", dcaWorkerCmd.Process.Pid))
 			fmt.Printf("  PID: %d\n", dcaWorkerCmd.Process.Pid)
-			fmt.Println("  Log: /tmp/dca-worker.log")
+			fmt.Printf("  Log: %s\n", runPath("dca-worker.log"))
 		}
 
 		// Step 6: Start DCA Scheduler
 		fmt.Println()
-		fmt.Printf("%s[6/8]%s Starting DCA Scheduler...\n", colorYellow, colorReset)
+		fmt.Printf("%s[6/9]%s Starting DCA Scheduler...\n", colorYellow, colorReset)
 
 		dcaSchedulerEnvFile := filepath.Join(configsDir, "dca-scheduler.env")
 		dcaSchedulerEnv := loadEnvFile(dcaSchedulerEnvFile)
@@ -278,62 +402,193 @@ func runStart(skipDCA bool) error {
 		dcaSchedulerCmd.Dir = dcaRoot
 		dcaSchedulerCmd.Env = append(os.Environ(), dcaSchedulerEnv...)
 
-		dcaSchedulerLog, _ := os.Create("/tmp/dca-scheduler.log")
+		dcaSchedulerLog, _ := os.Create(runPath("dca-scheduler.log"))
 		dcaSchedulerCmd.Stdout = dcaSchedulerLog
 		dcaSchedulerCmd.Stderr = dcaSchedulerLog
 
+		endPhase = profile.Start("DCA Scheduler launch")
 		err = dcaSchedulerCmd.Start()
+		endPhase()
 		if err != nil {
-			fmt.Printf("  %s!%s Failed to start DCA scheduler: %v\n", colorYellow, colorReset, err)
+			fmt.Printf("  %s Failed to start DCA scheduler: %v\n", warnMark(), err)
 		} else {
-			writePIDFile("/tmp/dca-scheduler.pid", dcaSchedulerCmd.Process.Pid)
+			writePIDFile(runPath("dca-scheduler.pid"), dcaSchedulerCmd.Process.Pid)
+			launched = append(launched, fmt.Sprintf("DCA Scheduler (PID %d)", dcaSchedulerCmd.Process.Pid))
 			fmt.Printf("  PID: %d\n", dcaSchedulerCmd.Process.Pid)
-			fmt.Println("  Log: /tmp/dca-scheduler.log")
+			fmt.Printf("  Log: %s\n", runPath("dca-scheduler.log"))
 		}
 
 		// Step 7: Start DCA TX Indexer
 		fmt.Println()
-		fmt.Printf("%s[7/8]%s Starting DCA TX Indexer...\n", colorYellow, colorReset)
+		fmt.Printf("%s[7/9]%s Starting DCA TX Indexer...\n", colorYellow, colorReset)
 
 		dcaTxIndexerEnvFile := filepath.Join(configsDir, "dca-tx-indexer.env")
 		dcaTxIndexerEnv := loadEnvFile(dcaTxIndexerEnvFile)
+		dcaTxIndexerEnv = append(dcaTxIndexerEnv, forkRPCEnv(config, "BASE_RPC_%s_URL")...)
 
 		dcaTxIndexerCmd := exec.Command("go", "run", "cmd/tx_indexer/main.go")
 		dcaTxIndexerCmd.Dir = dcaRoot
 		dcaTxIndexerCmd.Env = append(os.Environ(), dcaTxIndexerEnv...)
 
-		dcaTxIndexerLog, _ := os.Create("/tmp/dca-tx-indexer.log")
+		dcaTxIndexerLog, _ := os.Create(runPath("dca-tx-indexer.log"))
 		dcaTxIndexerCmd.Stdout = dcaTxIndexerLog
 		dcaTxIndexerCmd.Stderr = dcaTxIndexerLog
 
+		endPhase = profile.Start("DCA TX Indexer launch")
 		err = dcaTxIndexerCmd.Start()
+		endPhase()
 		if err != nil {
-			fmt.Printf("  %s!%s Failed to start DCA TX indexer: %v\n", colorYellow, colorReset, err)
+			fmt.Printf("  %s Failed to start DCA TX indexer: %v\n", warnMark(), err)
 		} else {
-			writePIDFile("/tmp/dca-tx-indexer.pid", dcaTxIndexerCmd.Process.Pid)
+			writePIDFile(runPath("dca-tx-indexer.pid"), dcaTxIndexerCmd.Process.Pid)
+			launched = append(launched, fmt.Sprintf("DCA TX Indexer (PID %d)", dcaTxIndexerCmd.Process.Pid))
 			fmt.Printf("  PID: %d\n", dcaTxIndexerCmd.Process.Pid)
-			fmt.Println("  Log: /tmp/dca-tx-indexer.log")
+			fmt.Printf("  Log: %s\n", runPath("dca-tx-indexer.log"))
 		}
 	} else {
 		fmt.Println()
-		fmt.Printf("%s[4/8]%s Skipping DCA Plugin Server\n", colorYellow, colorReset)
+		fmt.Printf("%s[4/9]%s Skipping DCA Plugin Server\n", colorYellow, colorReset)
 		fmt.Println()
-		fmt.Printf("%s[5/8]%s Skipping DCA Plugin Worker\n", colorYellow, colorReset)
+		fmt.Printf("%s[5/9]%s Skipping DCA Plugin Worker\n", colorYellow, colorReset)
 		fmt.Println()
-		fmt.Printf("%s[6/8]%s Skipping DCA Scheduler\n", colorYellow, colorReset)
+		fmt.Printf("%s[6/9]%s Skipping DCA Scheduler\n", colorYellow, colorReset)
 		fmt.Println()
-		fmt.Printf("%s[7/8]%s Skipping DCA TX Indexer\n", colorYellow, colorReset)
+		fmt.Printf("%s[7/9]%s Skipping DCA TX Indexer\n", colorYellow, colorReset)
+	}
+
+	// Step 8: Start Recurring Sends Plugin services
+	fmt.Println()
+	if !skipSends && config.IsLocal("sends") && config.Repos.Sends != "" {
+		fmt.Printf("%s[8/9]%s Starting Recurring Sends Plugin...\n", colorYellow, colorReset)
+		launched = append(launched, startSendsServices(ctx, config, configsDir, dyldPath, profile)...)
+		if ctx.Err() != nil {
+			return abort(ctx.Err())
+		}
+	} else {
+		fmt.Printf("%s[8/9]%s Skipping Recurring Sends Plugin\n", colorYellow, colorReset)
 	}
 
 	// Wait for workers to compile
 	fmt.Println()
-	fmt.Printf("%s[8/8]%s Waiting for workers to compile...\n", colorYellow, colorReset)
-	time.Sleep(10 * time.Second)
+	fmt.Printf("%s[9/9]%s Waiting for workers to compile...\n", colorYellow, colorReset)
+	endPhase = profile.Start("Worker compile wait")
+	if err := sleepCtx(ctx, 10*time.Second); err != nil {
+		return abort(err)
+	}
+	endPhase()
 
 	// Print summary
 	elapsed := time.Since(startTime)
-	printStartupSummary(elapsed, skipDCA, config)
+	printStartupSummary(elapsed, skipDCA, skipSends, config)
+
+	if profile != nil {
+		profile.TotalMs = elapsed.Milliseconds()
+		printSlowestPhases(profile, 5)
+		path, err := writeStartupProfile(profile, startTime)
+		if err != nil {
+			fmt.Printf("%s Could not write startup profile: %v\n", warnMark(), err)
+		} else {
+			fmt.Printf("Startup trace: %s (open in chrome://tracing or Perfetto)\n", path)
+		}
+		fmt.Println()
+	}
+
+	return nil
+}
+
+// startSendsServices launches the recurring-sends server, worker and
+// scheduler from the app-recurring repo in send mode, after making sure its
+// database and bucket exist. It returns the names of the launched processes.
+// Each step is timed into profile, which may be nil.
+func startSendsServices(ctx context.Context, config *ClusterConfig, configsDir, dyldPath string, profile *PhaseTimings) []string {
+	spec := pluginSpecFor("vultisig-recurring-sends-0000")
+	endPhase := profile.Start("Sends storage")
+	err := ensurePluginStorage(spec)
+	endPhase()
+	if err != nil {
+		fmt.Printf("  %s Could not prepare %s storage: %v\n", warnMark(), spec.Name, err)
+	}
+
+	processes := []struct {
+		name    string
+		main    string
+		envFile string
+		log     string
+		pid     string
+		rpcEnv  bool
+	}{
+		{"Sends Plugin Server", "cmd/server/main.go", "sends-server.env", "sends.log", "sends.pid", false},
+		{"Sends Plugin Worker", "cmd/worker/main.go", "sends-worker.env", "sends-worker.log", "sends-worker.pid", true},
+		{"Sends Scheduler", "cmd/scheduler/main.go", "sends-scheduler.env", "sends-scheduler.log", "sends-scheduler.pid", false},
+	}
+
+	var launched []string
+	for _, p := range processes {
+		env := loadEnvFile(filepath.Join(configsDir, p.envFile))
+		if p.rpcEnv {
+			env = append(env, forkRPCEnv(config, "RPC_%s_URL")...)
+		}
+
+		cmd := exec.Command("go", "run", p.main)
+		cmd.Dir = config.Repos.Sends
+		cmd.Env = append(os.Environ(), env...)
+		cmd.Env = append(cmd.Env, libraryPathEnv(dyldPath))
+
+		logFile, _ := os.Create(runPath(p.log))
+		cmd.Stdout = logFile
+		cmd.Stderr = logFile
+
+		endPhase = profile.Start(p.name + " launch")
+		err := cmd.Start()
+		endPhase()
+		if err != nil {
+			fmt.Printf("  %s Failed to start %s: %v\n", warnMark(), p.name, err)
+			continue
+		}
+		writePIDFile(runPath(p.pid), cmd.Process.Pid)
+		launched = append(launched, fmt.Sprintf("%s (PID %d)", p.name, cmd.Process.Pid))
+		fmt.Printf("  %s: PID %d, log %s\n", p.name, cmd.Process.Pid, runPath(p.log))
+	}
+
+	sendsSvc, ok := config.LocalService("sends_server")
+	if ok {
+		progressln("  Waiting for Sends Plugin API (compiling + migrations)...")
+		endPhase = profile.Start("Sends Plugin Server build+health")
+		ready := waitForService(ctx, sendsSvc, 60*time.Second)
+		endPhase()
+		if ready {
+			fmt.Printf("  %s Sends Plugin API ready\n", okMark())
+		} else if ctx.Err() == nil {
+			fmt.Printf("  %s Sends Plugin failed to start - check %s\n", warnMark(), runPath("sends.log"))
+		}
+	}
+
+	return launched
+}
+
+// ensurePluginStorage creates the plugin's Postgres database and MinIO
+// bucket when the Docker volumes predate them.
+func ensurePluginStorage(spec PluginSpec) error {
+	check := exec.Command("docker", "exec", "vultisig-postgres",
+		"psql", "-U", "vultisig", "-d", "vultisig-verifier", "-t", "-c",
+		fmt.Sprintf("SELECT 1 FROM pg_database WHERE datname = '%s'", spec.Database))
+	out, err := check.Output()
+	if err != nil {
+		return fmt.Errorf("check database %s: %w", spec.Database, err)
+	}
+	if strings.TrimSpace(string(out)) == "" {
+		create := exec.Command("docker", "exec", "vultisig-postgres",
+			"psql", "-U", "vultisig", "-d", "vultisig-verifier", "-c",
+			fmt.Sprintf(`CREATE DATABASE "%s"`, spec.Database))
+		if out, err := create.CombinedOutput(); err != nil {
+			return fmt.Errorf("create database %s: %w: %s", spec.Database, err, strings.TrimSpace(string(out)))
+		}
+	}
 
+	mb := exec.Command("docker", "exec", "vultisig-minio", "mc", "mb", "--ignore-existing", "local/"+spec.Bucket)
+	if out, err := mb.CombinedOutput(); err != nil {
+		return fmt.Errorf("create bucket %s: %w: %s", spec.Bucket, err, strings.TrimSpace(string(out)))
+	}
 	return nil
 }
 
@@ -341,24 +596,49 @@ func writePIDFile(path string, pid int) {
 	os.WriteFile(path, []byte(fmt.Sprintf("%d", pid)), 0644)
 }
 
-func waitForHealthy(url string, timeout time.Duration) bool {
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+// waitForService polls the registry health check of svc. Services without a
+// health path count as ready once their port accepts connections.
+func waitForService(ctx context.Context, svc LocalService, timeout time.Duration) bool {
+	if svc.HealthURL() == "" {
+		return waitForPort(ctx, svc.Port, timeout)
+	}
+	return waitForHealthy(ctx, svc.HealthURL(), svc.Health, timeout)
+}
+
+func waitForPort(ctx context.Context, port int, timeout time.Duration) bool {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
+	var d net.Dialer
 	for {
-		select {
-		case <-ctx.Done():
+		conn, err := d.DialContext(ctx, "tcp", fmt.Sprintf("localhost:%d", port))
+		if err == nil {
+			conn.Close()
+			return true
+		}
+		if sleepCtx(ctx, 1*time.Second) != nil {
 			return false
-		default:
-			resp, err := http.Get(url)
-			if err == nil && resp.StatusCode == http.StatusOK {
-				resp.Body.Close()
-				return true
-			}
-			if resp != nil {
-				resp.Body.Close()
+		}
+	}
+}
+
+// waitForHealthy polls url until it passes check, the timeout passes or ctx is
+// cancelled. Callers distinguish the last case via ctx.Err(). A service that
+// answers with an unhealthy payload when the wait ends is reported as such.
+func waitForHealthy(ctx context.Context, url string, check HealthCheck, timeout time.Duration) bool {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for {
+		state, reason := probeHealth(ctx, url, check)
+		if state == healthOK {
+			return true
+		}
+		if sleepCtx(ctx, 1*time.Second) != nil {
+			if state == healthUnhealthy {
+				progressf("  %s %s is responding but its payload is unhealthy: %s\n", warnMark(), url, reason)
 			}
-			time.Sleep(1 * time.Second)
+			return false
 		}
 	}
 }
@@ -382,10 +662,11 @@ func loadEnvFile(path string) []string {
 }
 
 func findConfigsDir() string {
+	home, _ := os.UserHomeDir()
 	paths := []string{
 		"configs",
-		"local/configs",
-		filepath.Join(os.Getenv("HOME"), ".vultisig", "configs"),
+		filepath.Join("local", "configs"),
+		filepath.Join(home, ".vultisig", "configs"),
 	}
 
 	for _, p := range paths {
@@ -398,7 +679,7 @@ func findConfigsDir() string {
 	return "configs"
 }
 
-func printStartupSummary(elapsed time.Duration, skipDCA bool, config *ClusterConfig) {
+func printStartupSummary(elapsed time.Duration, skipDCA, skipSends bool, config *ClusterConfig) {
 	fmt.Println()
 	fmt.Printf("%s┌─────────────────────────────────────────────────────────────────┐%s\n", colorCyan, colorReset)
 	fmt.Printf("%s│%s %sSTARTUP COMPLETE%s                                                %s│%s\n", colorCyan, colorReset, colorBold, colorReset, colorCyan, colorReset)
@@ -406,14 +687,11 @@ func printStartupSummary(elapsed time.Duration, skipDCA bool, config *ClusterCon
 	fmt.Printf("%s│%s                                                                 %s│%s\n", colorCyan, colorReset, colorCyan, colorReset)
 	fmt.Printf("%s│%s  Services Started:                                             %s│%s\n", colorCyan, colorReset, colorCyan, colorReset)
 
-	printServiceLine("Verifier API", "/tmp/verifier.pid", fmt.Sprintf("%d", config.Ports.Verifier))
-	printServiceLine("Verifier Worker", "/tmp/worker.pid", fmt.Sprintf("%d", config.Ports.VerifierWorkerMetrics))
-
-	if !skipDCA && config.IsLocal("dca") {
-		printServiceLine("DCA Plugin API", "/tmp/dca.pid", fmt.Sprintf("%d", config.Ports.DCAServer))
-		printServiceLine("DCA Plugin Worker", "/tmp/dca-worker.pid", fmt.Sprintf("%d", config.Ports.DCAWorkerMetrics))
-		printServiceLine("DCA Scheduler", "/tmp/dca-scheduler.pid", fmt.Sprintf("%d", config.Ports.DCASchedulerMetrics))
-		printServiceLine("DCA TX Indexer", "/tmp/dca-tx-indexer.pid", fmt.Sprintf("%d", config.Ports.DCATxIndexerMetrics))
+	for _, svc := range config.LocalServices() {
+		if svc.PIDFile == "" || (skipDCA && strings.HasPrefix(svc.Key, "dca_")) || (skipSends && strings.HasPrefix(svc.Key, "sends_")) {
+			continue
+		}
+		printServiceLine(svc.Name, svc.PIDFile, fmt.Sprintf("%d", svc.Port))
 	}
 
 	fmt.Printf("%s│%s                                                                 %s│%s\n", colorCyan, colorReset, colorCyan, colorReset)
@@ -421,11 +699,15 @@ func printStartupSummary(elapsed time.Duration, skipDCA bool, config *ClusterCon
 	fmt.Printf("%s│%s    PostgreSQL          localhost:%d                          %s│%s\n", colorCyan, colorReset, config.Ports.Postgres, colorCyan, colorReset)
 	fmt.Printf("%s│%s    Redis               localhost:%d                          %s│%s\n", colorCyan, colorReset, config.Ports.Redis, colorCyan, colorReset)
 	fmt.Printf("%s│%s    MinIO               localhost:%d (console: %d)          %s│%s\n", colorCyan, colorReset, config.Ports.Minio, config.Ports.MinioConsole, colorCyan, colorReset)
+	for _, name := range config.ForkedChains() {
+		printServiceLine("Anvil ("+name+")", runPath("anvil-"+name+".pid"), fmt.Sprintf("%d", config.Chains[name].Fork.Port))
+	}
 	fmt.Printf("%s│%s                                                                 %s│%s\n", colorCyan, colorReset, colorCyan, colorReset)
 	fmt.Printf("%s│%s  External Services:                                            %s│%s\n", colorCyan, colorReset, colorCyan, colorReset)
 	fmt.Printf("%s│%s    Relay:       %s%s\n", colorCyan, colorReset, config.GetRelayURL(), strings.Repeat(" ", 48-len(config.GetRelayURL()))+fmt.Sprintf("%s│%s", colorCyan, colorReset))
 	fmt.Printf("%s│%s    Vultiserver: %s%s\n", colorCyan, colorReset, config.GetVultiserverURL(), strings.Repeat(" ", 48-len(config.GetVultiserverURL()))+fmt.Sprintf("%s│%s", colorCyan, colorReset))
 	fmt.Printf("%s│%s                                                                 %s│%s\n", colorCyan, colorReset, colorCyan, colorReset)
+	fmt.Printf("%s│%s  Cluster config: %-46s %s│%s\n", colorCyan, colorReset, format.Truncate(ClusterConfigSource(), 46), colorCyan, colorReset)
 	fmt.Printf("%s│%s  Total startup time: %s%ds%s                                        %s│%s\n", colorCyan, colorReset, colorBold, int(elapsed.Seconds()), colorReset, colorCyan, colorReset)
 	fmt.Printf("%s│%s                                                                 %s│%s\n", colorCyan, colorReset, colorCyan, colorReset)
 	fmt.Printf("%s└─────────────────────────────────────────────────────────────────┘%s\n", colorCyan, colorReset)
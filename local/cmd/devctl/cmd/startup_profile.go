@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/vultisig/vultisig-cluster/local/cmd/devctl/format"
+)
+
+// chromeTraceEvent is a complete ("X") event of the Chrome trace format,
+// which chrome://tracing and Perfetto load directly.
+type chromeTraceEvent struct {
+	Name string `json:"name"`
+	Ph   string `json:"ph"`
+	Ts   int64  `json:"ts"`  // microseconds
+	Dur  int64  `json:"dur"` // microseconds
+	Pid  int    `json:"pid"`
+	Tid  int    `json:"tid"`
+}
+
+// startupProfile is the file 'start --profile-startup' writes: the spans as
+// Chrome trace events, plus the raw phase timings.
+type startupProfile struct {
+	TraceEvents []chromeTraceEvent `json:"traceEvents"`
+	Timings     *PhaseTimings      `json:"timings"`
+}
+
+func StartupProfileDir() string {
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".vultisig", "profiles")
+}
+
+// writeStartupProfile writes the spans of a 'devctl start' to
+// ~/.vultisig/profiles and returns the file's path.
+func writeStartupProfile(t *PhaseTimings, started time.Time) (string, error) {
+	dir := StartupProfileDir()
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return "", fmt.Errorf("create profile dir: %w", err)
+	}
+
+	profile := startupProfile{Timings: t}
+	for _, p := range t.Phases {
+		profile.TraceEvents = append(profile.TraceEvents, chromeTraceEvent{
+			Name: p.Name,
+			Ph:   "X",
+			Ts:   p.StartedAt.Sub(started).Microseconds(),
+			Dur:  p.Duration().Microseconds(),
+			Pid:  1,
+			Tid:  1,
+		})
+	}
+
+	data, err := json.MarshalIndent(profile, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("marshal profile: %w", err)
+	}
+	path := filepath.Join(dir, "startup-"+started.Format("20060102-150405")+".json")
+	err = os.WriteFile(path, data, 0644)
+	if err != nil {
+		return "", fmt.Errorf("write profile: %w", err)
+	}
+	return path, nil
+}
+
+// printSlowestPhases prints the n longest phases, slowest first.
+func printSlowestPhases(t *PhaseTimings, n int) {
+	phases := append([]PhaseTiming(nil), t.Phases...)
+	sort.Slice(phases, func(i, j int) bool { return phases[i].Duration() > phases[j].Duration() })
+	if len(phases) > n {
+		phases = phases[:n]
+	}
+
+	fmt.Printf("Slowest startup steps:\n")
+	for i, p := range phases {
+		fmt.Printf("  %d. %-40s %s\n", i+1, p.Name, format.Duration(p.Duration()))
+	}
+}
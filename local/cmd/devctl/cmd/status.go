@@ -10,51 +10,75 @@ import (
 )
 
 func NewStatusCmd() *cobra.Command {
-	return &cobra.Command{
+	var scanLogs bool
+
+	cmd := &cobra.Command{
 		Use:   "status",
 		Short: "Check status of all services",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runStatus()
+			return runStatus(scanLogs)
 		},
 	}
+
+	cmd.Flags().BoolVar(&scanLogs, "logs", false, "Also count recent errors in each service's log")
+	return cmd
 }
 
-func runStatus() error {
+func runStatus(scanLogs bool) error {
 	cfg, err := LoadConfig()
 	if err != nil {
 		return fmt.Errorf("load config: %w", err)
 	}
 
 	fmt.Println("Service Status")
-	fmt.Println("==============\n")
+	fmt.Println("==============")
+	fmt.Println()
 
-	services := []struct {
-		name string
-		url  string
-	}{
-		{"Verifier", cfg.Verifier + "/healthz"},
-		{"Fee Plugin", cfg.FeePlugin + "/healthz"},
-		{"DCA Plugin", cfg.DCAPlugin + "/healthz"},
+	cluster := clusterConfigOrDefaults()
+
+	type healthTarget struct {
+		name  string
+		url   string
+		check HealthCheck
+	}
+	services := []healthTarget{
+		{"Verifier", cfg.Verifier + cluster.Health["verifier"].Path, cluster.Health["verifier"]},
+		{"Fee Plugin", cfg.FeePlugin + "/healthz", HealthCheck{Path: "/healthz", Status: http.StatusOK}},
+		{"DCA Plugin", cfg.DCAPlugin + cluster.Health["dca_server"].Path, cluster.Health["dca_server"]},
+	}
+	for _, key := range []string{"sends_server", "relay", "vultiserver"} {
+		svc, ok := cluster.LocalService(key)
+		if ok && svc.HealthURL() != "" {
+			services = append(services, healthTarget{svc.Name, svc.HealthURL(), svc.Health})
+		}
 	}
 
 	for _, svc := range services {
-		status := checkHealth(svc.url)
-		if status {
+		state, reason := checkHealthState(svc.url, svc.check)
+		switch state {
+		case healthOK:
 			fmt.Printf("  %-15s %s\n", svc.name+":", "OK")
-		} else {
+		case healthUnhealthy:
+			fmt.Printf("  %-15s %s (responding but unhealthy payload: %s)\n", svc.name+":", "UNHEALTHY", reason)
+		default:
 			fmt.Printf("  %-15s %s\n", svc.name+":", "DOWN")
 		}
 	}
 
+	if scanLogs {
+		fmt.Printf("\nLogs (last %dm):\n", cluster.Logs.Window)
+		printLogErrorSummaries(cluster, "  ")
+	}
+
 	fmt.Println("\nInfrastructure:")
 
 	infraServices := []struct {
 		name string
 		url  string
 	}{
-		{"PostgreSQL", "localhost:5432"},
-		{"Redis", "localhost:6379"},
-		{"MinIO", "http://localhost:9000/minio/health/live"},
+		{"PostgreSQL", fmt.Sprintf("localhost:%d", cluster.Ports.Postgres)},
+		{"Redis", fmt.Sprintf("localhost:%d", cluster.Ports.Redis)},
+		{"MinIO", fmt.Sprintf("http://localhost:%d/minio/health/live", cluster.Ports.Minio)},
 	}
 
 	for _, svc := range infraServices {
@@ -84,18 +108,10 @@ func runStatus() error {
 	return nil
 }
 
+// checkHealth reports whether url answers 200.
 func checkHealth(url string) bool {
-	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
-	defer cancel()
-
-	req, _ := http.NewRequestWithContext(ctx, "GET", url, nil)
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return false
-	}
-	defer resp.Body.Close()
-
-	return resp.StatusCode == http.StatusOK
+	state, _ := checkHealthState(url, HealthCheck{Status: http.StatusOK})
+	return state == healthOK
 }
 
 func checkPort(addr string) bool {
@@ -15,6 +15,7 @@ import (
 func NewStopCmd() *cobra.Command {
 	var keepInfra bool
 	var clean bool
+	var yes bool
 
 	cmd := &cobra.Command{
 		Use:   "stop",
@@ -24,7 +25,7 @@ func NewStopCmd() *cobra.Command {
 This command:
 1. Stops Go services by PID files
 2. Kills any orphaned go run processes
-3. Releases ports (8080, 8082, 8089, 8181, 8183-8187)
+3. Releases the ports of all local services (see 'ports' in cluster.yaml)
 4. Stops Docker infrastructure (unless --keep-infra)
 
 With --clean flag:
@@ -33,12 +34,19 @@ With --clean flag:
 - Keeps the original imported vault file intact
 `,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if clean {
+				err := confirmDestructive("This wipes PostgreSQL, Redis and MinIO volumes and "+VaultStoragePath()+".", "clean", yes)
+				if err != nil {
+					return err
+				}
+			}
 			return runStopWithReport(keepInfra, clean)
 		},
 	}
 
 	cmd.Flags().BoolVar(&keepInfra, "keep-infra", false, "Keep Docker infrastructure running")
 	cmd.Flags().BoolVar(&clean, "clean", false, "Clean all data (databases, MinIO, local vault cache)")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Skip the --clean confirmation prompt")
 
 	return cmd
 }
@@ -46,38 +54,36 @@ With --clean flag:
 func runStop() {
 	// Stop Go services by PID files
 	pidFiles := []string{
-		"/tmp/verifier.pid",
-		"/tmp/worker.pid",
-		"/tmp/dca.pid",
-		"/tmp/dca-worker.pid",
-		"/tmp/dca-scheduler.pid",
-		"/tmp/dca-tx-indexer.pid",
+		runPath("verifier.pid"),
+		runPath("worker.pid"),
+		runPath("dca.pid"),
+		runPath("dca-worker.pid"),
+		runPath("dca-scheduler.pid"),
+		runPath("dca-tx-indexer.pid"),
+		runPath("sends.pid"),
+		runPath("sends-worker.pid"),
+		runPath("sends-scheduler.pid"),
 	}
+	anvilPIDFiles, _ := filepath.Glob(runPath("anvil-*.pid"))
+	pidFiles = append(pidFiles, anvilPIDFiles...)
 
 	for _, pidFile := range pidFiles {
 		if data, err := os.ReadFile(pidFile); err == nil {
 			pid := strings.TrimSpace(string(data))
-			if pidInt, err := strconv.Atoi(pid); err == nil {
-				exec.Command("kill", "-9", strconv.Itoa(pidInt)).Run()
-			}
+			killPIDString(pid, true)
 			os.Remove(pidFile)
 		}
 	}
 
 	// Kill orphaned go run processes
-	exec.Command("pkill", "-9", "-f", "go run.*verifier").Run()
-	exec.Command("pkill", "-9", "-f", "go run.*app-recurring").Run()
-	exec.Command("pkill", "-9", "-f", "go-build.*main").Run()
+	killMatching("go run.*verifier", true)
+	killMatching("go run.*app-recurring", true)
+	killMatching("go-build.*main", true)
 
 	// Release ports
-	ports := []string{"8080", "8082", "8089", "8181", "8183", "8184", "8185", "8186", "8187"}
-	for _, port := range ports {
-		cmd := exec.Command("lsof", "-ti:"+port)
-		if out, err := cmd.Output(); err == nil {
-			pids := strings.Fields(strings.TrimSpace(string(out)))
-			for _, pid := range pids {
-				exec.Command("kill", "-9", pid).Run()
-			}
+	for _, port := range stopPorts() {
+		for _, pid := range portPIDs(port) {
+			killPIDString(pid, true)
 		}
 	}
 
@@ -90,6 +96,23 @@ func runStop() {
 	}
 }
 
+// stopPorts lists the ports freed by stop: every registry service plus
+// ports.extra from cluster.yaml.
+func stopPorts() []string {
+	config := clusterConfigOrDefaults()
+
+	var ports []string
+	for _, svc := range config.LocalServices() {
+		if svc.Port != 0 {
+			ports = append(ports, strconv.Itoa(svc.Port))
+		}
+	}
+	for _, port := range config.Ports.Extra {
+		ports = append(ports, strconv.Itoa(port))
+	}
+	return ports
+}
+
 func runStopWithReport(keepInfra bool, clean bool) error {
 	startTime := time.Now()
 
@@ -110,12 +133,19 @@ func runStopWithReport(keepInfra bool, clean bool) error {
 	fmt.Printf("%sStopping services by PID...%s\n", colorYellow, colorReset)
 
 	pidFiles := map[string]string{
-		"/tmp/verifier.pid":       "verifier",
-		"/tmp/worker.pid":         "worker",
-		"/tmp/dca.pid":            "dca",
-		"/tmp/dca-worker.pid":     "dca-worker",
-		"/tmp/dca-scheduler.pid":  "dca-scheduler",
-		"/tmp/dca-tx-indexer.pid": "dca-tx-indexer",
+		runPath("verifier.pid"):        "verifier",
+		runPath("worker.pid"):          "worker",
+		runPath("dca.pid"):             "dca",
+		runPath("dca-worker.pid"):      "dca-worker",
+		runPath("dca-scheduler.pid"):   "dca-scheduler",
+		runPath("dca-tx-indexer.pid"):  "dca-tx-indexer",
+		runPath("sends.pid"):           "sends",
+		runPath("sends-worker.pid"):    "sends-worker",
+		runPath("sends-scheduler.pid"): "sends-scheduler",
+	}
+	anvilPIDFiles, _ := filepath.Glob(runPath("anvil-*.pid"))
+	for _, pidFile := range anvilPIDFiles {
+		pidFiles[pidFile] = strings.TrimSuffix(filepath.Base(pidFile), ".pid")
 	}
 
 	for pidFile, serviceName := range pidFiles {
@@ -123,9 +153,9 @@ func runStopWithReport(keepInfra bool, clean bool) error {
 			pid := strings.TrimSpace(string(data))
 			if pidInt, err := strconv.Atoi(pid); err == nil {
 				// Check if process exists
-				if exec.Command("kill", "-0", pid).Run() == nil {
-					fmt.Printf("  Stopping %s (PID %s)...\n", serviceName, pid)
-					exec.Command("kill", strconv.Itoa(pidInt)).Run()
+				if pidAlive(pidInt) {
+					progressf("  Stopping %s (PID %s)...\n", serviceName, pid)
+					killPID(pidInt, false)
 					stoppedServices = append(stoppedServices, serviceName)
 					stoppedPIDs = append(stoppedPIDs, pid)
 				}
@@ -137,19 +167,17 @@ func runStopWithReport(keepInfra bool, clean bool) error {
 	// Kill orphaned go run processes
 	fmt.Println()
 	fmt.Printf("%sKilling orphaned processes...%s\n", colorYellow, colorReset)
-	exec.Command("pkill", "-9", "-f", "go run.*verifier").Run()
-	exec.Command("pkill", "-9", "-f", "go run.*app-recurring").Run()
-	exec.Command("pkill", "-9", "-f", "go-build.*main").Run()
+	killMatching("go run.*verifier", true)
+	killMatching("go run.*app-recurring", true)
+	killMatching("go-build.*main", true)
 
 	// Release ports
 	fmt.Printf("%sReleasing ports...%s\n", colorYellow, colorReset)
-	ports := []string{"8080", "8082", "8089", "8181", "8183", "8184", "8185", "8186", "8187"}
-	for _, port := range ports {
-		cmd := exec.Command("lsof", "-ti:" + port)
-		if out, err := cmd.Output(); err == nil && len(out) > 0 {
-			pids := strings.Fields(strings.TrimSpace(string(out)))
+	for _, port := range stopPorts() {
+		pids := portPIDs(port)
+		if len(pids) > 0 {
 			for _, pid := range pids {
-				exec.Command("kill", "-9", pid).Run()
+				killPIDString(pid, true)
 			}
 			releasedPorts = append(releasedPorts, port)
 		}
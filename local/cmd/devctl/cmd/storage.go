@@ -0,0 +1,178 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+
+	"github.com/vultisig/vultisig-cluster/local/cmd/devctl/format"
+)
+
+const defaultKeyshareUploadTimeout = 60 * time.Second
+
+// newMinioClient returns an S3 client for the MinIO or S3 endpoint in
+// devctl.json (minio_host, minio_access_key, minio_secret_key, minio_region),
+// path-style unless minio_virtual_host is set.
+func newMinioClient(cfg *DevConfig) (*s3.S3, error) {
+	region := cfg.MinioRegion
+	if region == "" {
+		region = "us-east-1"
+	}
+	sess, err := session.NewSession(&aws.Config{
+		Endpoint:         aws.String(cfg.MinioHost),
+		Region:           aws.String(region),
+		Credentials:      credentials.NewStaticCredentials(cfg.MinioAccess, cfg.MinioSecret, ""),
+		S3ForcePathStyle: aws.Bool(!cfg.MinioVirtualHost),
+		DisableSSL:       aws.Bool(strings.HasPrefix(cfg.MinioHost, "http://")),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create MinIO session: %w", err)
+	}
+	return s3.New(sess), nil
+}
+
+// keyshareObjectKey is the name the verifier and plugin workers store a
+// vault's keyshare under.
+func keyshareObjectKey(pluginID, publicKey string) string {
+	return fmt.Sprintf("%s-%s.vult", pluginID, publicKey)
+}
+
+// KeyshareUpload is a keyshare object found in a bucket after a reshare.
+type KeyshareUpload struct {
+	Bucket  string
+	Key     string
+	Size    int64
+	Latency time.Duration
+}
+
+// waitForKeyshareUploads polls each bucket with HeadObject until the key
+// appears, backing off from 250ms to 5s between rounds. Each bucket's
+// latency is recorded as a phase. When timeout passes, the error names the
+// buckets that never received the object.
+func waitForKeyshareUploads(ctx context.Context, client *s3.S3, buckets []string, key string, timeout time.Duration, timings *PhaseTimings) ([]KeyshareUpload, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	started := time.Now()
+	pending := map[string]func(){}
+	lastErr := map[string]error{}
+	for _, b := range buckets {
+		pending[b] = timings.Start("Upload " + b)
+	}
+
+	var uploads []KeyshareUpload
+	backoff := 250 * time.Millisecond
+	for {
+		for _, bucket := range buckets {
+			endPhase, ok := pending[bucket]
+			if !ok {
+				continue
+			}
+			out, err := client.HeadObjectWithContext(ctx, &s3.HeadObjectInput{
+				Bucket: aws.String(bucket),
+				Key:    aws.String(key),
+			})
+			if err != nil {
+				if !isS3NotFound(err) && ctx.Err() == nil {
+					lastErr[bucket] = err
+				}
+				continue
+			}
+
+			endPhase()
+			delete(pending, bucket)
+			u := KeyshareUpload{Bucket: bucket, Key: key, Size: aws.Int64Value(out.ContentLength), Latency: time.Since(started)}
+			uploads = append(uploads, u)
+			progressf("  %s %s: %s (%s after %s)\n", okMark(), bucket, key, format.Bytes(u.Size), format.Duration(u.Latency))
+		}
+
+		if len(pending) == 0 {
+			return uploads, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return uploads, keyshareUploadError(pending, lastErr, key, timeout)
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		if backoff > 5*time.Second {
+			backoff = 5 * time.Second
+		}
+	}
+}
+
+func keyshareUploadError(pending map[string]func(), lastErr map[string]error, key string, timeout time.Duration) error {
+	var missing []string
+	for bucket := range pending {
+		missing = append(missing, bucket)
+	}
+	sort.Strings(missing)
+
+	var details []string
+	for _, bucket := range missing {
+		if err, ok := lastErr[bucket]; ok {
+			details = append(details, fmt.Sprintf("%s: %v", bucket, err))
+		}
+	}
+
+	msg := fmt.Sprintf("keyshare %s never reached bucket %s within %s", key, strings.Join(missing, ", "), timeout)
+	if len(details) > 0 {
+		msg += " (last error: " + strings.Join(details, "; ") + ")"
+	}
+	return errors.New(msg)
+}
+
+// listBucketObjects lists every object in bucket.
+func listBucketObjects(ctx context.Context, client *s3.S3, bucket string) ([]*s3.Object, error) {
+	var objects []*s3.Object
+	err := client.ListObjectsV2PagesWithContext(ctx, &s3.ListObjectsV2Input{Bucket: aws.String(bucket)},
+		func(page *s3.ListObjectsV2Output, lastPage bool) bool {
+			objects = append(objects, page.Contents...)
+			return true
+		})
+	if err != nil {
+		return nil, s3ErrorMessage(err)
+	}
+	return objects, nil
+}
+
+// s3ErrorMessage shortens AWS errors to their code and message, which is
+// what fits in a report line.
+func s3ErrorMessage(err error) error {
+	var aerr awserr.Error
+	if errors.As(err, &aerr) {
+		return fmt.Errorf("%s: %s", aerr.Code(), aerr.Message())
+	}
+	return err
+}
+
+func isS3NotFound(err error) bool {
+	var aerr awserr.Error
+	if errors.As(err, &aerr) {
+		return aerr.Code() == "NotFound" || aerr.Code() == s3.ErrCodeNoSuchKey
+	}
+	return false
+}
+
+// keyshareUploadTimeout reads keyshare_upload_timeout from devctl.json (or
+// VCLI_KEYSHARE_UPLOAD_TIMEOUT). Unset or invalid values fall back to 60s.
+func keyshareUploadTimeout(cfg *DevConfig) time.Duration {
+	if cfg.KeyshareUploadTimeout == "" {
+		return defaultKeyshareUploadTimeout
+	}
+	d, err := time.ParseDuration(cfg.KeyshareUploadTimeout)
+	if err != nil || d <= 0 {
+		return defaultKeyshareUploadTimeout
+	}
+	return d
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"os"
+	"strings"
+)
+
+// Terminal colors. SetPlainOutput blanks them for --no-color.
+var (
+	colorRed    = "\033[0;31m"
+	colorGreen  = "\033[0;32m"
+	colorYellow = "\033[1;33m"
+	colorCyan   = "\033[0;36m"
+	colorBold   = "\033[1m"
+	colorReset  = "\033[0m"
+)
+
+// Status glyphs used by every command. Each ASCII fallback is one column
+// wide like the glyph it replaces, so boxed reports stay aligned.
+var (
+	symOK   = "✓"
+	symFail = "✗"
+	symWarn = "!"
+)
+
+func init() {
+	if os.Getenv("NO_COLOR") != "" {
+		disableColor()
+	}
+	if !utf8Locale() {
+		useASCIISymbols()
+	}
+}
+
+// SetPlainOutput implements --no-color: no escape codes and ASCII glyphs.
+func SetPlainOutput() {
+	disableColor()
+	useASCIISymbols()
+}
+
+func disableColor() {
+	colorRed, colorGreen, colorYellow, colorCyan, colorBold, colorReset = "", "", "", "", "", ""
+}
+
+func useASCIISymbols() {
+	symOK, symFail, symWarn = "+", "x", "!"
+}
+
+// utf8Locale reports whether the locale allows UTF-8 output. An unset
+// locale counts as UTF-8, which is what every supported terminal uses.
+func utf8Locale() bool {
+	for _, name := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		value := os.Getenv(name)
+		if value == "" {
+			continue
+		}
+		value = strings.ToLower(value)
+		return strings.Contains(value, "utf-8") || strings.Contains(value, "utf8")
+	}
+	return true
+}
+
+// okMark, failMark and warnMark are the colored status glyphs.
+func okMark() string {
+	return colorGreen + symOK + colorReset
+}
+
+func failMark() string {
+	return colorRed + symFail + colorReset
+}
+
+func warnMark() string {
+	return colorYellow + symWarn + colorReset
+}
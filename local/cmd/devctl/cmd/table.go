@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// printTable writes rows as aligned columns under an upper-cased header.
+func printTable(headers []string, rows [][]string) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	upper := make([]string, len(headers))
+	for i, h := range headers {
+		upper[i] = strings.ToUpper(h)
+	}
+	fmt.Fprintln(w, strings.Join(upper, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	w.Flush()
+}
+
+// shortID truncates long identifiers for table cells.
+func shortID(id string, n int) string {
+	if len(id) <= n {
+		return id
+	}
+	return id[:n] + "…"
+}
+
+// formatTime renders a timestamp for tables; zero or nil times become "-".
+func formatTime(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return "-"
+	}
+	return t.Local().Format("2006-01-02 15:04")
+}
+
+// isoTime renders a timestamp for CSV cells as RFC 3339 in UTC; zero or nil
+// times become "".
+func isoTime(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// addListFormatFlags adds --format (table or csv) and -o, the CSV output
+// file, to listing commands.
+func addListFormatFlags(cmd *cobra.Command, format, file *string) {
+	cmd.Flags().StringVar(format, "format", "table", "Output format: table or csv")
+	cmd.Flags().StringVarP(file, "output", "o", "", "Write the CSV to a file instead of stdout")
+}
+
+func checkListFormat(format, file string) error {
+	if format != "table" && format != "csv" {
+		return fmt.Errorf("unknown format %q (want table or csv)", format)
+	}
+	if file != "" && format != "csv" {
+		return fmt.Errorf("-o needs --format csv")
+	}
+	return nil
+}
+
+// writeCSV writes RFC 4180 CSV (CRLF line ends, fields with commas, quotes or
+// newlines quoted) with one header row to file, or to stdout if file is "".
+func writeCSV(file string, headers []string, rows [][]string) error {
+	out := os.Stdout
+	if file != "" {
+		f, err := os.Create(file)
+		if err != nil {
+			return fmt.Errorf("create %s: %w", file, err)
+		}
+		defer f.Close()
+		out = f
+	}
+
+	w := csv.NewWriter(out)
+	w.UseCRLF = true
+	err := w.Write(headers)
+	if err != nil {
+		return fmt.Errorf("write csv: %w", err)
+	}
+	err = w.WriteAll(rows)
+	if err != nil {
+		return fmt.Errorf("write csv: %w", err)
+	}
+
+	if file != "" {
+		progressf("Wrote %d rows to %s\n", len(rows), file)
+	}
+	return nil
+}
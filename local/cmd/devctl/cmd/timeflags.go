@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// HistoryFilter holds the --limit and --since flags shared by the history
+// views (policy transactions, policy history, verify transactions, audit,
+// history).
+type HistoryFilter struct {
+	Limit int
+	Since SinceFlag
+}
+
+// addHistoryFlags registers --limit/-n and --since on cmd.
+func addHistoryFlags(cmd *cobra.Command, f *HistoryFilter, defaultLimit int, what string) {
+	cmd.Flags().IntVarP(&f.Limit, "limit", "n", defaultLimit, fmt.Sprintf("Maximum number of %s to show (0 = no limit)", what))
+	cmd.Flags().Var(&f.Since, "since", fmt.Sprintf("Only show %s since a duration ago (90m, 2d, 1w) or a date (2006-01-02, RFC 3339)", what))
+}
+
+// Includes reports whether t passes the --since filter.
+func (f HistoryFilter) Includes(t time.Time) bool {
+	return f.Since.IsZero() || !t.Before(f.Since.Time())
+}
+
+// SinceFlag is a point in time given as an age or a date. It implements
+// pflag.Value.
+type SinceFlag struct {
+	raw string
+	t   time.Time
+}
+
+func (s *SinceFlag) String() string { return s.raw }
+
+func (s *SinceFlag) Type() string { return "duration|date" }
+
+func (s *SinceFlag) Set(value string) error {
+	t, err := parseSince(value, time.Now())
+	if err != nil {
+		return err
+	}
+	s.raw = value
+	s.t = t
+	return nil
+}
+
+func (s SinceFlag) Time() time.Time { return s.t }
+
+func (s SinceFlag) IsZero() bool { return s.t.IsZero() }
+
+// sinceDateLayouts are the date forms --since accepts, in local time unless
+// they carry a zone.
+var sinceDateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// parseSince turns "90m", "36h", "2d" or "1w" into now minus that age, and a
+// date or RFC 3339 timestamp into that time.
+func parseSince(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, fmt.Errorf("empty --since")
+	}
+
+	if age, ok := parseAge(value); ok {
+		if age < 0 {
+			return time.Time{}, fmt.Errorf("--since %q: age must not be negative", value)
+		}
+		return now.Add(-age), nil
+	}
+
+	for _, layout := range sinceDateLayouts {
+		t, err := time.ParseInLocation(layout, value, time.Local)
+		if err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("--since %q: use a duration (90m, 2d, 1w) or a date (2006-01-02, RFC 3339)", value)
+}
+
+// parseAge accepts Go durations plus whole days ("2d") and weeks ("1w").
+func parseAge(value string) (time.Duration, bool) {
+	d, err := time.ParseDuration(value)
+	if err == nil {
+		return d, true
+	}
+
+	unit := time.Duration(0)
+	switch {
+	case strings.HasSuffix(value, "d"):
+		unit = 24 * time.Hour
+	case strings.HasSuffix(value, "w"):
+		unit = 7 * 24 * time.Hour
+	default:
+		return 0, false
+	}
+	n, err := strconv.Atoi(value[:len(value)-1])
+	if err != nil {
+		return 0, false
+	}
+	return time.Duration(n) * unit, true
+}
+
+// relativeTime renders t as a coarse age such as "5m ago" or "3d ago".
+func relativeTime(t time.Time) string {
+	d := time.Since(t)
+	switch {
+	case d < 0:
+		return "in future"
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd ago", int(d.Hours()/24))
+	}
+}
+
+// formatTimestamp renders t for the history views: the relative age, with
+// the absolute local time appended when verbose.
+func formatTimestamp(t time.Time, verbose bool) string {
+	if t.IsZero() {
+		return "-"
+	}
+	if verbose {
+		return fmt.Sprintf("%s (%s)", relativeTime(t), t.Local().Format("2006-01-02 15:04:05"))
+	}
+	return relativeTime(t)
+}
+
+// parsePostgresTime parses a timestamptz as printed by psql.
+func parsePostgresTime(s string) (time.Time, bool) {
+	for _, layout := range []string{"2006-01-02 15:04:05.999999-07", "2006-01-02 15:04:05.999999-07:00", "2006-01-02 15:04:05.999999"} {
+		t, err := time.Parse(layout, strings.TrimSpace(s))
+		if err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	local := func(s string) time.Time {
+		t, err := time.ParseInLocation("2006-01-02 15:04:05", s, time.Local)
+		if err != nil {
+			panic(err)
+		}
+		return t
+	}
+
+	tests := []struct {
+		value   string
+		want    time.Time
+		wantErr string
+	}{
+		{value: "90m", want: now.Add(-90 * time.Minute)},
+		{value: "36h", want: now.Add(-36 * time.Hour)},
+		{value: "1h30m", want: now.Add(-90 * time.Minute)},
+		{value: "0s", want: now},
+		{value: "2d", want: now.Add(-48 * time.Hour)},
+		{value: "0d", want: now},
+		{value: "1w", want: now.Add(-7 * 24 * time.Hour)},
+		{value: " 2d ", want: now.Add(-48 * time.Hour)},
+		{value: "2026-03-01", want: local("2026-03-01 00:00:00")},
+		{value: "2026-03-01 08:30", want: local("2026-03-01 08:30:00")},
+		{value: "2026-03-01 08:30:15", want: local("2026-03-01 08:30:15")},
+		{value: "2026-03-01T08:30:15", want: local("2026-03-01 08:30:15")},
+		{value: "2026-03-01T08:30:15Z", want: time.Date(2026, 3, 1, 8, 30, 15, 0, time.UTC)},
+		{value: "2026-03-01T08:30:15+02:00", want: time.Date(2026, 3, 1, 6, 30, 15, 0, time.UTC)},
+
+		{value: "", wantErr: "empty --since"},
+		{value: "  ", wantErr: "empty --since"},
+		{value: "-2h", wantErr: `--since "-2h": age must not be negative`},
+		{value: "-1d", wantErr: `--since "-1d": age must not be negative`},
+		{value: "1.5d", wantErr: "use a duration"},
+		{value: "d", wantErr: "use a duration"},
+		{value: "2y", wantErr: "use a duration"},
+		{value: "yesterday", wantErr: "use a duration"},
+		{value: "2026-13-01", wantErr: "use a duration"},
+		{value: "01/03/2026", wantErr: "use a duration"},
+	}
+
+	for _, tt := range tests {
+		got, err := parseSince(tt.value, now)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("parseSince(%q) error = %v, want %q", tt.value, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseSince(%q): %v", tt.value, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("parseSince(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestSinceFlag(t *testing.T) {
+	var f HistoryFilter
+	if !f.Since.IsZero() || !f.Includes(time.Time{}) {
+		t.Error("an unset --since filters entries")
+	}
+
+	err := f.Since.Set("2h")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.Since.String() != "2h" {
+		t.Errorf("String() = %q, want the value as given", f.Since.String())
+	}
+	if !f.Includes(time.Now().Add(-time.Hour)) {
+		t.Error("an entry from 1h ago is filtered by --since 2h")
+	}
+	if f.Includes(time.Now().Add(-3 * time.Hour)) {
+		t.Error("an entry from 3h ago passes --since 2h")
+	}
+
+	err = f.Since.Set("soon")
+	if err == nil {
+		t.Fatal("Set accepted an invalid value")
+	}
+	if f.Since.String() != "2h" {
+		t.Errorf("a failed Set changed the flag to %q", f.Since.String())
+	}
+}
+
+func TestRelativeTime(t *testing.T) {
+	tests := []struct {
+		age  time.Duration
+		want string
+	}{
+		{age: -time.Hour, want: "in future"},
+		{age: 0, want: "just now"},
+		{age: 59 * time.Second, want: "just now"},
+		{age: time.Minute + time.Second, want: "1m ago"},
+		{age: 59*time.Minute + 59*time.Second, want: "59m ago"},
+		{age: time.Hour + time.Second, want: "1h ago"},
+		{age: 23*time.Hour + 59*time.Minute, want: "23h ago"},
+		{age: 24*time.Hour + time.Second, want: "1d ago"},
+		{age: 47 * time.Hour, want: "1d ago"},
+		{age: 400 * 24 * time.Hour, want: "400d ago"},
+	}
+
+	for _, tt := range tests {
+		if got := relativeTime(time.Now().Add(-tt.age)); got != tt.want {
+			t.Errorf("relativeTime(now - %s) = %q, want %q", tt.age, got, tt.want)
+		}
+	}
+}
+
+func TestFormatTimestamp(t *testing.T) {
+	if got := formatTimestamp(time.Time{}, true); got != "-" {
+		t.Errorf("zero time = %q, want -", got)
+	}
+
+	ts := time.Now().Add(-3 * time.Hour).Truncate(time.Second)
+	if got := formatTimestamp(ts, false); got != "3h ago" {
+		t.Errorf("formatTimestamp = %q, want 3h ago", got)
+	}
+	want := "3h ago (" + ts.Local().Format("2006-01-02 15:04:05") + ")"
+	if got := formatTimestamp(ts.UTC(), true); got != want {
+		t.Errorf("verbose formatTimestamp = %q, want %q", got, want)
+	}
+}
+
+func TestParsePostgresTime(t *testing.T) {
+	want := time.Date(2026, 3, 1, 8, 30, 15, 123456000, time.UTC)
+	for _, s := range []string{
+		"2026-03-01 08:30:15.123456+00",
+		"2026-03-01 10:30:15.123456+02",
+		"2026-03-01 08:30:15.123456+00:00",
+		" 2026-03-01 08:30:15.123456 ",
+	} {
+		got, ok := parsePostgresTime(s)
+		if !ok || !got.Equal(want) {
+			t.Errorf("parsePostgresTime(%q) = %v, %v, want %v", s, got, ok, want)
+		}
+	}
+
+	got, ok := parsePostgresTime("2026-03-01 08:30:15+00")
+	if !ok || !got.Equal(want.Truncate(time.Second)) {
+		t.Errorf("without fraction: got %v, %v", got, ok)
+	}
+
+	_, ok = parsePostgresTime("yesterday")
+	if ok {
+		t.Error("parsePostgresTime accepted yesterday")
+	}
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+const (
+	RequestIDHeader = "X-Request-ID"
+	TraceIDHeader   = "X-Trace-ID"
+)
+
+var traceID string
+
+// TraceID returns the correlation ID for this devctl invocation. It is
+// generated once per process, or taken from VCLI_TRACE_ID when set so a
+// wrapper script can tie several invocations together.
+func TraceID() string {
+	if traceID == "" {
+		traceID = getEnvOrDefault("VCLI_TRACE_ID", uuid.New().String())
+	}
+	return traceID
+}
+
+// traceTransport stamps every outbound request with the invocation trace ID.
+type traceTransport struct {
+	base http.RoundTripper
+}
+
+func (t *traceTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	id := TraceID()
+	req = req.Clone(req.Context())
+	if req.Header.Get(RequestIDHeader) == "" {
+		req.Header.Set(RequestIDHeader, id)
+	}
+	if req.Header.Get(TraceIDHeader) == "" {
+		req.Header.Set(TraceIDHeader, id)
+	}
+	return t.base.RoundTrip(req)
+}
+
+// InitTracing wraps the shared default transport so that every HTTP client in
+// the process (including the relay client, which builds its own http.Client
+// on top of http.DefaultTransport) carries the trace headers.
+func InitTracing() {
+	if _, ok := http.DefaultTransport.(*traceTransport); ok {
+		return
+	}
+	http.DefaultTransport = &traceTransport{base: http.DefaultTransport}
+	http.DefaultClient.Transport = http.DefaultTransport
+}
+
+// printTraceID prints the trace ID at the start of a long-running operation so
+// it can be quoted when grepping relay, fast vault, verifier and plugin logs.
+func printTraceID() {
+	progressf("  Trace ID: %s\n", TraceID())
+}
@@ -3,7 +3,7 @@ package cmd
 import (
 	"bytes"
 	"context"
-	"crypto/rand"
+	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
@@ -11,14 +11,17 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
+	"sync"
 	"time"
 
-	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 
+	vsrelay "github.com/vultisig/vultiserver/relay"
 	"github.com/vultisig/vultisig-go/relay"
-	vgtypes "github.com/vultisig/vultisig-go/types"
+
+	"github.com/vultisig/vultisig-cluster/local/cmd/devctl/format"
 )
 
 const (
@@ -27,6 +30,7 @@ const (
 	DefaultLocalParty  = "devctl"
 	KeygenTimeout      = 3 * time.Minute
 	MessagePollTimeout = 2 * time.Minute
+	CompletionTimeout  = 2 * time.Minute
 )
 
 type KeyShare struct {
@@ -35,16 +39,25 @@ type KeyShare struct {
 }
 
 type LocalVault struct {
-	Name           string      `json:"name"`
-	PublicKeyECDSA string      `json:"pubKeyECDSA"`
-	PublicKeyEdDSA string      `json:"pubKeyEdDSA"`
-	HexChainCode   string      `json:"hexChainCode"`
-	LocalPartyID   string      `json:"localPartyID"`
-	Signers        []string    `json:"signers"`
-	KeyShares      []KeyShare  `json:"keyshares"`
-	ResharePrefix  string      `json:"resharePrefix,omitempty"`
-	CreatedAt      string      `json:"createdAt"`
-	LibType        int         `json:"libType"` // 0 = GG20, 1 = DKLS
+	Name           string     `json:"name"`
+	PublicKeyECDSA string     `json:"pubKeyECDSA"`
+	PublicKeyEdDSA string     `json:"pubKeyEdDSA"`
+	HexChainCode   string     `json:"hexChainCode"`
+	LocalPartyID   string     `json:"localPartyID"`
+	Signers        []string   `json:"signers"`
+	KeyShares      []KeyShare `json:"keyshares"`
+	ResharePrefix  string     `json:"resharePrefix,omitempty"`
+	CreatedAt      string     `json:"createdAt"`
+	LibType        LibType    `json:"libType"` // 0 = GG20, 1 = DKLS
+	WatchOnly      bool       `json:"watchOnly,omitempty"`
+
+	// Source is the backup the vault was imported from; nil for generated
+	// vaults and imports that predate it.
+	Source *VaultSource `json:"source,omitempty"`
+
+	// LastUsed is local metadata, keyed by operation (auth, install,
+	// policy); it is not part of exports.
+	LastUsed map[string]VaultUsage `json:"lastUsed,omitempty"`
 }
 
 type BackupVault struct {
@@ -53,94 +66,77 @@ type BackupVault struct {
 }
 
 type TSSService struct {
-	relayClient  *relay.Client
+	relayClient  *timedRelayClient
 	localPartyID string
 	logger       *logrus.Entry
+
+	// relayStats counts the relay requests of this service's operations.
+	relayStats *RelayStats
+
+	// phases, when set, receives the timing of each reshare/keysign phase.
+	phases *PhaseTimings
 }
 
 func NewTSSService(localPartyID string) *TSSService {
 	logger := logrus.New()
+	logger.SetOutput(os.Stderr)
 	logger.SetLevel(logrus.DebugLevel)
 	logger.SetFormatter(&logrus.TextFormatter{
 		FullTimestamp: true,
 	})
 
-	return &TSSService{
-		relayClient:  relay.NewRelayClient(RelayServer),
+	t := &TSSService{
 		localPartyID: localPartyID,
-		logger:       logger.WithField("component", "tss"),
-	}
+		logger: logger.WithFields(logrus.Fields{
+			"component": "tss",
+			"trace_id":  TraceID(),
+		}),
+		relayStats: &RelayStats{},
+	}
+	t.relayClient = t.newRelayClient()
+	return t
 }
 
-func (t *TSSService) Keygen(ctx context.Context, vaultName string) (*LocalVault, error) {
-	sessionID := uuid.New().String()
-
-	encryptionKey := make([]byte, 32)
-	_, err := rand.Read(encryptionKey)
-	if err != nil {
-		return nil, fmt.Errorf("generate encryption key: %w", err)
-	}
-	hexEncryptionKey := hex.EncodeToString(encryptionKey)
-
-	chainCode := make([]byte, 32)
-	_, err = rand.Read(chainCode)
-	if err != nil {
-		return nil, fmt.Errorf("generate chain code: %w", err)
-	}
-	hexChainCode := hex.EncodeToString(chainCode)
-
-	t.logger.WithFields(logrus.Fields{
-		"session_id":  sessionID,
-		"local_party": t.localPartyID,
-		"vault_name":  vaultName,
-	}).Info("Starting keygen session")
-
-	err = t.relayClient.RegisterSession(sessionID, t.localPartyID)
-	if err != nil {
-		return nil, fmt.Errorf("register session: %w", err)
-	}
-
-	t.logger.Info("Requesting Fast Vault Server to join keygen...")
-	err = t.requestFastVaultKeygen(ctx, vaultName, sessionID, hexEncryptionKey, hexChainCode)
-	if err != nil {
-		return nil, fmt.Errorf("request fast vault keygen: %w", err)
+// newRelayClient returns a relay client whose requests count toward this
+// service's relay stats.
+func (t *TSSService) newRelayClient() *timedRelayClient {
+	return &timedRelayClient{
+		Client: relay.NewRelayClient(RelayServer),
+		stats:  t.relayStats,
+		logger: t.logger,
 	}
+}
 
-	t.logger.Info("Waiting for Fast Vault Server to join...")
-	parties, err := t.waitForParties(ctx, sessionID, 2)
-	if err != nil {
-		return nil, fmt.Errorf("wait for parties: %w", err)
+func (t *TSSService) newMessenger(sessionID, hexEncryptionKey, messageID string) *timedMessenger {
+	return &timedMessenger{
+		MessengerImp: vsrelay.NewMessenger(RelayServer, sessionID, hexEncryptionKey, true, messageID),
+		client:       t.newRelayClient(),
 	}
+}
 
-	t.logger.WithField("parties", parties).Info("All parties joined, starting keygen")
-
-	err = t.relayClient.StartSession(sessionID, parties)
-	if err != nil {
-		return nil, fmt.Errorf("start session: %w", err)
+// shareRelayStats makes the local parties of a multi-party operation count
+// their relay requests toward t.
+func (t *TSSService) shareRelayStats(parties ...*TSSService) {
+	for _, p := range parties {
+		p.relayStats = t.relayStats
+		p.relayClient.stats = t.relayStats
 	}
+}
 
-	t.logger.Info("Running DKLS keygen protocol...")
-	t.logger.Info("[NOTE: This requires go-wrappers CGO library]")
-	t.logger.Info("The keygen protocol would execute here with the DKLS library")
-
-	t.logger.Info("For full TSS operation, ensure DYLD_LIBRARY_PATH is set:")
-	t.logger.Infof("export DYLD_LIBRARY_PATH=/Users/dev/dev/vultisig/go-wrappers/includes/darwin/:$DYLD_LIBRARY_PATH")
+// RelaySummary returns the relay counters of this service's operations, or
+// nil if none reached the relay.
+func (t *TSSService) RelaySummary() *RelaySummary {
+	return t.relayStats.Summary()
+}
 
-	err = t.relayClient.CompleteSession(sessionID, t.localPartyID)
+// Keygen runs a 2-of-2 DKLS keygen with the Fast Vault Server and returns
+// the CLI's vault, with both ECDSA and EdDSA keyshares. See KeygenWithDKLS.
+func (t *TSSService) Keygen(ctx context.Context, vaultName, email, backupPassword string) (*LocalVault, error) {
+	vaults, err := t.KeygenWithDKLS(ctx, vaultName, email, backupPassword, 0)
 	if err != nil {
-		t.logger.WithError(err).Warn("Failed to complete session")
-	}
-
-	vault := &LocalVault{
-		Name:         vaultName,
-		HexChainCode: hexChainCode,
-		LocalPartyID: t.localPartyID,
-		Signers:      parties,
-		CreatedAt:    time.Now().UTC().Format(time.RFC3339),
-		LibType:      1,
+		return nil, err
 	}
-
-	return vault, nil
+	return vaults[0], nil
 }
 
 func generateServerPartyID(sessionID string) string {
@@ -158,19 +154,20 @@ func generateServerPartyID(sessionID string) string {
 	return fmt.Sprintf("Server-%s", suffix)
 }
 
-func (t *TSSService) requestFastVaultKeygen(ctx context.Context, name, sessionID, hexEncKey, hexChainCode string) error {
+func (t *TSSService) requestFastVaultKeygen(ctx context.Context, name, sessionID, hexEncKey, hexChainCode, email, backupPassword string, libType LibType) error {
 	serverPartyID := generateServerPartyID(sessionID)
 	t.logger.WithField("server_party_id", serverPartyID).Debug("Generated server party ID")
 
-	req := vgtypes.VaultCreateRequest{
+	req := FastVaultCreateRequest{
 		Name:               name,
 		SessionID:          sessionID,
 		HexEncryptionKey:   hexEncKey,
 		HexChainCode:       hexChainCode,
 		LocalPartyId:       serverPartyID,
-		EncryptionPassword: "",
-		Email:              "",
-		LibType:            1, // DKLS
+		EncryptionPassword: backupPassword,
+		Email:              email,
+		LibType:            libType,
+		TraceID:            TraceID(),
 	}
 
 	reqJSON, err := json.Marshal(req)
@@ -178,25 +175,7 @@ func (t *TSSService) requestFastVaultKeygen(ctx context.Context, name, sessionID
 		return fmt.Errorf("marshal request: %w", err)
 	}
 
-	url := FastVaultServer + "/vault/create"
-	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(reqJSON))
-	if err != nil {
-		return fmt.Errorf("create request: %w", err)
-	}
-	httpReq.Header.Set("Content-Type", "application/json")
-
-	resp, err := http.DefaultClient.Do(httpReq)
-	if err != nil {
-		return fmt.Errorf("send request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("fast vault server returned %d: %s", resp.StatusCode, string(body))
-	}
-
-	return nil
+	return postFastVault(ctx, "/vault/create", reqJSON)
 }
 
 func (t *TSSService) waitForParties(ctx context.Context, sessionID string, expected int) ([]string, error) {
@@ -212,107 +191,126 @@ func (t *TSSService) waitForParties(ctx context.Context, sessionID string, expec
 			parties, err := t.relayClient.GetSession(sessionID)
 			if err != nil {
 				t.logger.WithError(err).Debug("Failed to get session")
-				time.Sleep(time.Second)
-				continue
-			}
-
-			if len(parties) >= expected {
+			} else if len(parties) >= expected {
 				return parties, nil
+			} else {
+				t.logger.WithField("parties", len(parties)).Debug("Waiting for more parties...")
 			}
 
-			t.logger.WithField("parties", len(parties)).Debug("Waiting for more parties...")
-			time.Sleep(time.Second)
+			err = sleepCtx(ctx, time.Second)
+			if err != nil {
+				return nil, err
+			}
 		}
 	}
 }
 
-func (t *TSSService) Reshare(ctx context.Context, vault *LocalVault, pluginID, verifierURL, authHeader, vaultPassword string) (*LocalVault, error) {
-	sessionID := uuid.New().String()
-
-	encryptionKey := make([]byte, 32)
-	_, err := rand.Read(encryptionKey)
-	if err != nil {
-		return nil, fmt.Errorf("generate encryption key: %w", err)
+// waitForCompletion polls the relay until every party other than ours has
+// marked sessionID complete, then marks ours. The error names the parties
+// that never completed, so it says whose logs to read.
+func (t *TSSService) waitForCompletion(ctx context.Context, sessionID string, parties []string) error {
+	var peers []string
+	for _, p := range parties {
+		if p != t.localPartyID {
+			peers = append(peers, p)
+		}
 	}
-	hexEncryptionKey := hex.EncodeToString(encryptionKey)
 
-	t.logger.WithFields(logrus.Fields{
-		"session_id":   sessionID,
-		"old_parties":  vault.Signers,
-		"plugin_id":    pluginID,
-		"verifier_url": verifierURL,
-	}).Info("Starting reshare session")
+	deadline := time.Now().Add(CompletionTimeout)
+	var completed []string
+	for {
+		var err error
+		completed, err = t.completedParties(ctx, sessionID)
+		if err != nil {
+			t.logger.WithError(err).Debug("Failed to get completed parties")
+		}
+		missing := missingParties(peers, completed)
+		if len(missing) == 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			if len(completed) == 0 {
+				return fmt.Errorf("no party marked session %s complete within %s (expected %s); the relay may have lost the session or every peer failed",
+					sessionID, CompletionTimeout, strings.Join(peers, ", "))
+			}
+			return fmt.Errorf("%s never marked session %s complete within %s (completed: %s); check that party's logs",
+				strings.Join(missing, ", "), sessionID, CompletionTimeout, strings.Join(completed, ", "))
+		}
+		t.logger.WithField("waiting_for", missing).Debug("Waiting for parties to complete...")
 
-	err = t.relayClient.RegisterSession(sessionID, t.localPartyID)
-	if err != nil {
-		return nil, fmt.Errorf("register session: %w", err)
+		err = sleepCtx(ctx, time.Second)
+		if err != nil {
+			return err
+		}
 	}
 
-	t.logger.Info("Requesting Fast Vault Server to join reshare...")
-	err = t.requestFastVaultReshare(ctx, vault, sessionID, hexEncryptionKey, vaultPassword)
+	err := t.relayClient.CompleteSession(sessionID, t.localPartyID)
 	if err != nil {
-		t.logger.WithError(err).Warn("Failed to request Fast Vault Server - continuing anyway")
+		return fmt.Errorf("complete session: %w", err)
 	}
+	t.logger.WithField("parties", parties).Info("All parties completed the session")
+	return nil
+}
 
-	t.logger.Info("Requesting Verifier to join reshare...")
-	err = t.requestVerifierReshare(ctx, vault, sessionID, hexEncryptionKey, pluginID, verifierURL, authHeader)
+// completedParties returns the parties that marked sessionID complete.
+func (t *TSSService) completedParties(ctx context.Context, sessionID string) ([]string, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", RelayServer+"/complete/"+sessionID, nil)
 	if err != nil {
-		return nil, fmt.Errorf("request verifier reshare: %w", err)
+		return nil, fmt.Errorf("create request: %w", err)
 	}
-
-	expectedParties := len(vault.Signers) + 2
-	t.logger.WithField("expected", expectedParties).Info("Waiting for all parties to join...")
-
-	parties, err := t.waitForParties(ctx, sessionID, expectedParties)
+	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("wait for parties: %w", err)
+		return nil, fmt.Errorf("get completed parties: %w", err)
 	}
+	defer resp.Body.Close()
 
-	t.logger.WithField("parties", parties).Info("All parties joined, starting reshare")
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("relay returned %d: %s", resp.StatusCode, errorBody(body))
+	}
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil, nil
+	}
 
-	err = t.relayClient.StartSession(sessionID, parties)
+	var completed []string
+	err = json.Unmarshal(body, &completed)
 	if err != nil {
-		return nil, fmt.Errorf("start session: %w", err)
+		return nil, fmt.Errorf("decode completed parties: %w", err)
 	}
+	return completed, nil
+}
 
-	t.logger.Info("Running DKLS QC (reshare) protocol...")
+func missingParties(expected, got []string) []string {
+	var missing []string
+	for _, p := range expected {
+		if !slices.Contains(got, p) {
+			missing = append(missing, p)
+		}
+	}
+	return missing
+}
 
-	vault.Signers = parties
+// sleepCtx waits for d, returning early with the context's error if it is
+// cancelled first.
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
 
-	return vault, nil
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
 }
 
 func (t *TSSService) requestFastVaultReshare(ctx context.Context, vault *LocalVault, sessionID, hexEncKey, password string) error {
-	serverPartyID := generateServerPartyID(sessionID)
-
-	type FastVaultReshareRequest struct {
-		Name               string   `json:"name"`
-		PublicKey          string   `json:"public_key"`
-		SessionID          string   `json:"session_id"`
-		HexEncryptionKey   string   `json:"hex_encryption_key"`
-		HexChainCode       string   `json:"hex_chain_code"`
-		LocalPartyId       string   `json:"local_party_id"`
-		OldParties         []string `json:"old_parties"`
-		OldResharePrefix   string   `json:"old_reshare_prefix"`
-		EncryptionPassword string   `json:"encryption_password"`
-		Email              string   `json:"email"`
-		ReshareType        int      `json:"reshare_type"`
-		LibType            int      `json:"lib_type"`
-	}
-
-	req := FastVaultReshareRequest{
-		Name:               vault.Name,
-		PublicKey:          vault.PublicKeyECDSA,
-		SessionID:          sessionID,
-		HexEncryptionKey:   hexEncKey,
-		HexChainCode:       vault.HexChainCode,
-		LocalPartyId:       serverPartyID,
-		OldParties:         vault.Signers,
-		OldResharePrefix:   vault.ResharePrefix,
-		EncryptionPassword: password,
-		Email:              "",
-		ReshareType:        1,
-		LibType:            vault.LibType,
+	req, err := newFastVaultReshareRequest(vault, sessionID, hexEncKey, password)
+	if err != nil {
+		return err
 	}
 
 	reqJSON, err := json.Marshal(req)
@@ -322,50 +320,13 @@ func (t *TSSService) requestFastVaultReshare(ctx context.Context, vault *LocalVa
 
 	t.logger.WithField("request", string(reqJSON)).Debug("Sending reshare request to Fast Vault Server")
 
-	url := FastVaultServer + "/vault/reshare"
-	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(reqJSON))
-	if err != nil {
-		return fmt.Errorf("create request: %w", err)
-	}
-	httpReq.Header.Set("Content-Type", "application/json")
-
-	resp, err := http.DefaultClient.Do(httpReq)
-	if err != nil {
-		return fmt.Errorf("send request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("fast vault server returned %d: %s", resp.StatusCode, string(body))
-	}
-
-	return nil
+	return postFastVault(ctx, "/vault/reshare", reqJSON)
 }
 
 func (t *TSSService) requestVerifierReshare(ctx context.Context, vault *LocalVault, sessionID, hexEncKey, pluginID, verifierURL, authHeader string) error {
-	type VerifierReshareRequest struct {
-		Name             string   `json:"name"`
-		PublicKey        string   `json:"public_key"`
-		SessionID        string   `json:"session_id"`
-		HexEncryptionKey string   `json:"hex_encryption_key"`
-		HexChainCode     string   `json:"hex_chain_code"`
-		LocalPartyId     string   `json:"local_party_id"`
-		OldParties       []string `json:"old_parties"`
-		Email            string   `json:"email"`
-		PluginID         string   `json:"plugin_id"`
-	}
-
-	req := VerifierReshareRequest{
-		Name:             vault.Name,
-		PublicKey:        vault.PublicKeyECDSA,
-		SessionID:        sessionID,
-		HexEncryptionKey: hexEncKey,
-		HexChainCode:     vault.HexChainCode,
-		LocalPartyId:     "verifier-" + sessionID[:8],
-		OldParties:       vault.Signers,
-		Email:            "",
-		PluginID:         pluginID,
+	req, err := newVerifierReshareRequest(vault, sessionID, hexEncKey, pluginID)
+	if err != nil {
+		return err
 	}
 
 	reqJSON, err := json.Marshal(req)
@@ -391,85 +352,12 @@ func (t *TSSService) requestVerifierReshare(ctx context.Context, vault *LocalVau
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("verifier returned %d: %s", resp.StatusCode, string(body))
+		return fmt.Errorf("verifier returned %d: %s", resp.StatusCode, errorBody(body))
 	}
 
 	return nil
 }
 
-func (t *TSSService) ReshareWithPlugin(ctx context.Context, vault *LocalVault, pluginID, verifierURL, authHeader, vaultPassword string) (*LocalVault, error) {
-	sessionID := uuid.New().String()
-
-	encryptionKey := make([]byte, 32)
-	_, err := rand.Read(encryptionKey)
-	if err != nil {
-		return nil, fmt.Errorf("generate encryption key: %w", err)
-	}
-	hexEncryptionKey := hex.EncodeToString(encryptionKey)
-
-	t.logger.WithFields(logrus.Fields{
-		"session_id":   sessionID,
-		"old_parties":  vault.Signers,
-		"plugin_id":    pluginID,
-		"verifier_url": verifierURL,
-	}).Info("Starting plugin reshare session")
-
-	err = t.relayClient.RegisterSession(sessionID, t.localPartyID)
-	if err != nil {
-		return nil, fmt.Errorf("register session: %w", err)
-	}
-
-	t.logger.Info("Requesting Fast Vault Server to join reshare...")
-	err = t.requestFastVaultReshare(ctx, vault, sessionID, hexEncryptionKey, vaultPassword)
-	if err != nil {
-		t.logger.WithError(err).Warn("Failed to request Fast Vault Server - continuing anyway")
-	}
-
-	t.logger.Info("Requesting Verifier to join reshare (with plugin)...")
-	err = t.requestVerifierReshare(ctx, vault, sessionID, hexEncryptionKey, pluginID, verifierURL, authHeader)
-	if err != nil {
-		return nil, fmt.Errorf("request verifier reshare: %w", err)
-	}
-
-	expectedParties := len(vault.Signers) + 2
-	t.logger.WithField("expected", expectedParties).Info("Waiting for all parties to join...")
-
-	parties, err := t.waitForParties(ctx, sessionID, expectedParties)
-	if err != nil {
-		return nil, fmt.Errorf("wait for parties: %w", err)
-	}
-
-	t.logger.WithField("parties", parties).Info("All parties joined, starting reshare")
-
-	err = t.relayClient.StartSession(sessionID, parties)
-	if err != nil {
-		return nil, fmt.Errorf("start session: %w", err)
-	}
-
-	t.logger.Info("Running DKLS QC (reshare) protocol...")
-	t.logger.Info("[NOTE: This requires go-wrappers CGO library for actual reshare]")
-
-	err = t.relayClient.CompleteSession(sessionID, t.localPartyID)
-	if err != nil {
-		t.logger.WithError(err).Warn("Failed to complete session")
-	}
-
-	newVault := &LocalVault{
-		Name:           vault.Name,
-		PublicKeyECDSA: vault.PublicKeyECDSA,
-		PublicKeyEdDSA: vault.PublicKeyEdDSA,
-		HexChainCode:   vault.HexChainCode,
-		LocalPartyID:   vault.LocalPartyID,
-		Signers:        parties,
-		KeyShares:      vault.KeyShares,
-		ResharePrefix:  sessionID[:8],
-		CreatedAt:      vault.CreatedAt,
-		LibType:        vault.LibType,
-	}
-
-	return newVault, nil
-}
-
 type KeysignResult struct {
 	R            string `json:"r"`
 	S            string `json:"s"`
@@ -477,303 +365,346 @@ type KeysignResult struct {
 	DerSignature string `json:"der_signature"`
 }
 
-func (t *TSSService) KeysignWithVerifier(ctx context.Context, vault *LocalVault, messages []string, derivePath, verifierURL, pluginID, authHeader string) ([]KeysignResult, error) {
-	sessionID := uuid.New().String()
-
-	encryptionKey := make([]byte, 32)
-	_, err := rand.Read(encryptionKey)
-	if err != nil {
-		return nil, fmt.Errorf("generate encryption key: %w", err)
+// Keysign signs messages with the Fast Vault Server: ECDSA at derivePath, or
+// EdDSA over the raw messages when isEdDSA is set. Each signature is checked
+// against the vault's key before it is returned.
+func (t *TSSService) Keysign(ctx context.Context, vault *LocalVault, messages []string, derivePath string, isEdDSA bool, vaultPassword string) ([]KeysignResult, error) {
+	if isEdDSA {
+		return t.KeysignEdDSAWithFastVault(ctx, vault, messages, vaultPassword)
 	}
-	hexEncryptionKey := hex.EncodeToString(encryptionKey)
+	return t.KeysignWithFastVault(ctx, vault, messages, derivePath, vaultPassword)
+}
 
-	t.logger.WithFields(logrus.Fields{
-		"session_id":   sessionID,
-		"public_key":   vault.PublicKeyECDSA[:16] + "...",
-		"messages":     len(messages),
-		"derive_path":  derivePath,
-		"plugin_id":    pluginID,
-		"verifier_url": verifierURL,
-	}).Info("Starting keysign with verifier")
+func VaultStoragePath() string {
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".vultisig", "vaults")
+}
 
-	err = t.relayClient.RegisterSession(sessionID, t.localPartyID)
+// SaveVault writes a vault to VaultStoragePath under its canonical name (see
+// vaultFilePath). A vault without a public key is refused if its name is
+// empty or taken by another key-less vault; once it gains a key, its
+// name-based file is removed.
+func SaveVault(vault *LocalVault) error {
+	dir := VaultStoragePath()
+	err := os.MkdirAll(dir, 0700)
 	if err != nil {
-		return nil, fmt.Errorf("register session: %w", err)
+		return fmt.Errorf("create vault dir: %w", err)
 	}
 
-	t.logger.Info("Requesting Verifier to join keysign for policy...")
-	err = t.requestVerifierKeysign(ctx, vault, sessionID, hexEncryptionKey, messages, derivePath, pluginID, verifierURL, authHeader)
-	if err != nil {
-		return nil, fmt.Errorf("request verifier keysign: %w", err)
+	if vault.PublicKeyECDSA == "" && vaultNameKey(vault.Name) == "" {
+		return fmt.Errorf("vault has neither a public key nor a name")
 	}
 
-	t.logger.Info("Waiting for Verifier to join...")
-	parties, err := t.waitForParties(ctx, sessionID, 2)
-	if err != nil {
-		return nil, fmt.Errorf("wait for parties: %w", err)
+	path := vaultFilePath(vault)
+	existing, err := readVaultFile(path)
+	if err == nil && !sameVault(existing, vault) {
+		if vault.PublicKeyECDSA == "" {
+			return fmt.Errorf("a vault named %q without a public key already exists (created %s); pick another name", existing.Name, existing.CreatedAt)
+		}
+		return fmt.Errorf("%s already holds a different vault", path)
 	}
 
-	t.logger.WithField("parties", parties).Info("All parties joined, starting keysign")
-
-	err = t.relayClient.StartSession(sessionID, parties)
+	data, err := json.MarshalIndent(vault, "", "  ")
 	if err != nil {
-		return nil, fmt.Errorf("start session: %w", err)
+		return fmt.Errorf("marshal vault: %w", err)
 	}
 
-	t.logger.Info("Running DKLS keysign protocol with Verifier...")
-	t.logger.Info("[NOTE: This requires go-wrappers CGO library for actual signing]")
-
-	err = t.relayClient.CompleteSession(sessionID, t.localPartyID)
+	// Write to a temporary file and rename it over the old one, so a failed
+	// write never leaves a truncated vault behind.
+	tmp := path + ".tmp"
+	err = os.WriteFile(tmp, data, 0600)
+	if err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("write vault: %w", err)
+	}
+	err = os.Rename(tmp, path)
 	if err != nil {
-		t.logger.WithError(err).Warn("Failed to complete session")
+		_ = os.Remove(tmp)
+		return fmt.Errorf("write vault: %w", err)
 	}
 
-	results := make([]KeysignResult, len(messages))
-	for i := range messages {
-		results[i] = KeysignResult{
-			R:            "placeholder_r",
-			S:            "placeholder_s",
-			RecoveryID:   "1b",
-			DerSignature: "placeholder_der",
+	if vault.PublicKeyECDSA != "" {
+		stub := vaultFilePath(&LocalVault{Name: vault.Name})
+		old, err := readVaultFile(stub)
+		if err == nil && old.PublicKeyECDSA == "" && old.CreatedAt == vault.CreatedAt {
+			_ = os.Remove(stub)
 		}
 	}
 
-	return results, nil
+	return nil
 }
 
-func (t *TSSService) requestVerifierKeysign(ctx context.Context, vault *LocalVault, sessionID, hexEncKey string, messages []string, derivePath, pluginID, verifierURL, authHeader string) error {
-	type VerifierKeysignRequest struct {
-		PublicKey        string   `json:"public_key"`
-		Messages         []string `json:"messages"`
-		Session          string   `json:"session"`
-		HexEncryptionKey string   `json:"hex_encryption_key"`
-		DerivePath       string   `json:"derive_path"`
-		PluginID         string   `json:"plugin_id"`
-		IsECDSA          bool     `json:"is_ecdsa"`
+// vaultFilePath returns where SaveVault stores a vault: the full ECDSA public
+// key, so the name never changes and prefix lookups keep working, or
+// "unkeyed-<name>" for a vault that has no key yet.
+func vaultFilePath(vault *LocalVault) string {
+	filename := vault.PublicKeyECDSA + ".json"
+	if vault.PublicKeyECDSA == "" {
+		filename = "unkeyed-" + vaultNameKey(vault.Name) + ".json"
 	}
+	return filepath.Join(VaultStoragePath(), filename)
+}
 
-	req := VerifierKeysignRequest{
-		PublicKey:        vault.PublicKeyECDSA,
-		Messages:         messages,
-		Session:          sessionID,
-		HexEncryptionKey: hexEncKey,
-		DerivePath:       derivePath,
-		PluginID:         pluginID,
-		IsECDSA:          true,
-	}
+// vaultNameKey makes a vault name safe for a file name.
+func vaultNameKey(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+			return r
+		default:
+			return '_'
+		}
+	}, strings.TrimSpace(name))
+}
 
-	reqJSON, err := json.Marshal(req)
-	if err != nil {
-		return fmt.Errorf("marshal request: %w", err)
+// sameVault reports whether a and b are the same vault: the same key, or for
+// key-less vaults the same name and creation time.
+func sameVault(a, b *LocalVault) bool {
+	if a.PublicKeyECDSA != "" || b.PublicKeyECDSA != "" {
+		return a.PublicKeyECDSA == b.PublicKeyECDSA
 	}
+	return a.Name == b.Name && a.CreatedAt == b.CreatedAt
+}
 
-	url := verifierURL + "/vault/keysign"
-	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(reqJSON))
+func readVaultFile(path string) (*LocalVault, error) {
+	data, err := os.ReadFile(path)
 	if err != nil {
-		return fmt.Errorf("create request: %w", err)
+		return nil, err
 	}
-	httpReq.Header.Set("Content-Type", "application/json")
-	if authHeader != "" {
-		httpReq.Header.Set("Authorization", authHeader)
-	}
-
-	resp, err := http.DefaultClient.Do(httpReq)
+	var vault LocalVault
+	err = json.Unmarshal(data, &vault)
 	if err != nil {
-		return fmt.Errorf("send request: %w", err)
+		return nil, fmt.Errorf("unmarshal vault: %w", err)
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("verifier keysign returned %d: %s", resp.StatusCode, string(body))
-	}
-
-	return nil
+	return &vault, nil
 }
 
-func (t *TSSService) Keysign(ctx context.Context, vault *LocalVault, messages []string, derivePath string, isEdDSA bool, vaultPassword string) ([]KeysignResult, error) {
-	sessionID := uuid.New().String()
-
-	encryptionKey := make([]byte, 32)
-	_, err := rand.Read(encryptionKey)
-	if err != nil {
-		return nil, fmt.Errorf("generate encryption key: %w", err)
-	}
-	hexEncryptionKey := hex.EncodeToString(encryptionKey)
+var migrateVaultsOnce sync.Once
+
+// migrateVaultFiles renames vault files saved under older schemes (the first
+// 16 characters of the key, or "<name>-<date>" for key-less vaults) to their
+// canonical name. A file whose canonical name is taken is left in place with
+// a warning.
+func migrateVaultFiles() {
+	migrateVaultsOnce.Do(func() {
+		dir := VaultStoragePath()
+		files, err := os.ReadDir(dir)
+		if err != nil {
+			return
+		}
+		for _, f := range files {
+			if f.IsDir() || !strings.HasSuffix(f.Name(), ".json") {
+				continue
+			}
+			path := filepath.Join(dir, f.Name())
+			vault, err := readVaultFile(path)
+			if err != nil {
+				continue
+			}
+			canonical := vaultFilePath(vault)
+			if path == canonical {
+				continue
+			}
+			if _, err := os.Stat(canonical); err == nil {
+				progressf("%s Vault file %s duplicates %s; remove one of them\n", warnMark(), f.Name(), filepath.Base(canonical))
+				continue
+			}
+			err = os.Rename(path, canonical)
+			if err != nil {
+				progressf("%s Could not rename vault file %s: %v\n", warnMark(), f.Name(), err)
+			}
+		}
+	})
+}
 
-	publicKey := vault.PublicKeyECDSA
-	if isEdDSA {
-		publicKey = vault.PublicKeyEdDSA
-	}
+// PartyVaultStoragePath holds shares of additional local test parties created
+// by 'vault generate --parties'. They live outside VaultStoragePath so that
+// vault listing and prefix lookup only ever see the CLI's own share.
+func PartyVaultStoragePath() string {
+	return filepath.Join(VaultStoragePath(), "parties")
+}
 
-	t.logger.WithFields(logrus.Fields{
-		"session_id":  sessionID,
-		"public_key":  publicKey[:16] + "...",
-		"messages":    len(messages),
-		"derive_path": derivePath,
-		"is_eddsa":    isEdDSA,
-	}).Info("Starting keysign session")
+func partyVaultPath(pubKeyECDSA, partyID string) string {
+	return filepath.Join(PartyVaultStoragePath(), fmt.Sprintf("%s-%s.json", vaultShortPrefix(pubKeyECDSA), partyID))
+}
 
-	err = t.relayClient.RegisterSession(sessionID, t.localPartyID)
+func SavePartyVault(vault *LocalVault) error {
+	err := os.MkdirAll(PartyVaultStoragePath(), 0700)
 	if err != nil {
-		return nil, fmt.Errorf("register session: %w", err)
+		return fmt.Errorf("create party vault dir: %w", err)
 	}
 
-	t.logger.Info("Requesting Fast Vault Server to join keysign...")
-	err = t.requestFastVaultKeysign(ctx, vault, sessionID, hexEncryptionKey, messages, derivePath, isEdDSA, vaultPassword)
+	data, err := json.MarshalIndent(vault, "", "  ")
 	if err != nil {
-		return nil, fmt.Errorf("request fast vault keysign: %w", err)
+		return fmt.Errorf("marshal vault: %w", err)
 	}
 
-	t.logger.Info("Waiting for Fast Vault Server to join...")
-	parties, err := t.waitForParties(ctx, sessionID, 2)
+	err = os.WriteFile(partyVaultPath(vault.PublicKeyECDSA, vault.LocalPartyID), data, 0600)
 	if err != nil {
-		return nil, fmt.Errorf("wait for parties: %w", err)
+		return fmt.Errorf("write vault: %w", err)
 	}
 
-	t.logger.WithField("parties", parties).Info("All parties joined, starting keysign")
+	return nil
+}
 
-	err = t.relayClient.StartSession(sessionID, parties)
+func LoadPartyVault(pubKeyECDSA, partyID string) (*LocalVault, error) {
+	data, err := os.ReadFile(partyVaultPath(pubKeyECDSA, partyID))
 	if err != nil {
-		return nil, fmt.Errorf("start session: %w", err)
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("no local share for party %s", partyID)
+		}
+		return nil, fmt.Errorf("read party vault: %w", err)
 	}
 
-	t.logger.Info("Running DKLS keysign protocol...")
-	t.logger.Info("[NOTE: This requires go-wrappers CGO library for actual signing]")
-
-	err = t.relayClient.CompleteSession(sessionID, t.localPartyID)
+	var vault LocalVault
+	err = json.Unmarshal(data, &vault)
 	if err != nil {
-		t.logger.WithError(err).Warn("Failed to complete session")
+		return nil, fmt.Errorf("unmarshal vault: %w", err)
 	}
 
-	results := make([]KeysignResult, len(messages))
-	for i := range messages {
-		results[i] = KeysignResult{
-			R:            "placeholder",
-			S:            "placeholder",
-			RecoveryID:   "00",
-			DerSignature: "placeholder",
-		}
-	}
+	return &vault, nil
+}
 
-	return results, nil
+// vaultFileMatch is a vault file that a name, prefix or fingerprint
+// selected. Err is set, and Vault empty, when the file does not parse.
+type vaultFileMatch struct {
+	Path  string
+	Vault *LocalVault
+	Err   error
 }
 
-func (t *TSSService) requestFastVaultKeysign(ctx context.Context, vault *LocalVault, sessionID, hexEncKey string, messages []string, derivePath string, isEdDSA bool, vaultPassword string) error {
-	type FastVaultSignRequest struct {
-		PublicKey        string   `json:"public_key"`
-		Messages         []string `json:"messages"`
-		Session          string   `json:"session"`
-		HexEncryptionKey string   `json:"hex_encryption_key"`
-		DerivePath       string   `json:"derive_path"`
-		IsECDSA          bool     `json:"is_ecdsa"`
-		VaultPassword    string   `json:"vault_password"`
-	}
+// matchVaultFiles returns the vault files arg selects, best match first: the
+// vaults named arg (case-insensitively); else the vaults whose ECDSA public
+// key or name starts with it; else the vault whose fingerprint it is. An
+// unreadable file is matched by its file name, which starts with the key.
+func matchVaultFiles(arg string) ([]vaultFileMatch, error) {
+	migrateVaultFiles()
+	dir := VaultStoragePath()
 
-	publicKey := vault.PublicKeyECDSA
-	if isEdDSA {
-		publicKey = vault.PublicKeyEdDSA
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("read vault dir: %w", err)
 	}
 
-	req := FastVaultSignRequest{
-		PublicKey:        publicKey,
-		Messages:         messages,
-		Session:          sessionID,
-		HexEncryptionKey: hexEncKey,
-		DerivePath:       derivePath,
-		IsECDSA:          !isEdDSA,
-		VaultPassword:    vaultPassword,
-	}
+	var byName, byPrefix, byFingerprint []vaultFileMatch
+	lowerArg := strings.ToLower(arg)
+	want := strings.ReplaceAll(lowerArg, "-", "")
+	for _, f := range files {
+		if f.IsDir() || !strings.HasSuffix(f.Name(), ".json") {
+			continue
+		}
+		path := filepath.Join(dir, f.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("read vault file: %w", err)
+		}
 
-	reqJSON, err := json.Marshal(req)
-	if err != nil {
-		return fmt.Errorf("marshal request: %w", err)
-	}
+		match := vaultFileMatch{Path: path, Vault: &LocalVault{}}
+		err = json.Unmarshal(data, match.Vault)
+		if err != nil {
+			match.Vault = &LocalVault{}
+			match.Err = fmt.Errorf("unmarshal vault %s: %w", path, err)
+		}
+		name := match.Vault.Name
+		key := match.Vault.PublicKeyECDSA
+		if match.Err != nil {
+			key = strings.TrimSuffix(f.Name(), ".json")
+		}
 
-	url := FastVaultServer + "/sign"
-	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(reqJSON))
-	if err != nil {
-		return fmt.Errorf("create request: %w", err)
+		switch {
+		case name != "" && strings.EqualFold(name, arg):
+			byName = append(byName, match)
+		case strings.HasPrefix(strings.ToLower(key), lowerArg) || (name != "" && strings.HasPrefix(strings.ToLower(name), lowerArg)):
+			byPrefix = append(byPrefix, match)
+		case isFingerprint(arg) && strings.ReplaceAll(VaultFingerprint(match.Vault.PublicKeyECDSA), "-", "") == want:
+			byFingerprint = append(byFingerprint, match)
+		}
 	}
-	httpReq.Header.Set("Content-Type", "application/json")
 
-	resp, err := http.DefaultClient.Do(httpReq)
-	if err != nil {
-		return fmt.Errorf("send request: %w", err)
+	if len(byName) > 0 {
+		return byName, nil
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("fast vault server returned %d: %s", resp.StatusCode, string(body))
+	if len(byPrefix) > 0 {
+		return byPrefix, nil
 	}
-
-	return nil
-}
-
-func VaultStoragePath() string {
-	home, _ := os.UserHomeDir()
-	return filepath.Join(home, ".vultisig", "vaults")
+	return byFingerprint, nil
 }
 
-func SaveVault(vault *LocalVault) error {
-	dir := VaultStoragePath()
-	err := os.MkdirAll(dir, 0700)
-	if err != nil {
-		return fmt.Errorf("create vault dir: %w", err)
+// ambiguousVaultError lists the vaults arg matched, so the user can pick one
+// by name, public key prefix or fingerprint.
+func ambiguousVaultError(arg string, matches []vaultFileMatch) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%q matches %d vaults:", arg, len(matches))
+	for _, m := range matches {
+		name := m.Vault.Name
+		if m.Err != nil {
+			name = "(unreadable vault file)"
+		}
+		fmt.Fprintf(&b, "\n  %-20s %-16s %s", format.Truncate(name, 20), vaultShortPrefix(m.Vault.PublicKeyECDSA), VaultFingerprint(m.Vault.PublicKeyECDSA))
 	}
+	b.WriteString("\npass the full name, a longer public key prefix or the fingerprint")
+	return fmt.Errorf("%s", b.String())
+}
 
-	var filename string
-	if vault.PublicKeyECDSA != "" && len(vault.PublicKeyECDSA) >= 16 {
-		filename = fmt.Sprintf("%s.json", vault.PublicKeyECDSA[:16])
-	} else {
-		filename = fmt.Sprintf("%s-%s.json", vault.Name, vault.CreatedAt[:10])
+// vaultShortPrefix is the public key prefix devctl names vault files and
+// party shares by, and that 'vault list' shows.
+func vaultShortPrefix(pubKeyECDSA string) string {
+	if len(pubKeyECDSA) > 16 {
+		return pubKeyECDSA[:16]
 	}
-	path := filepath.Join(dir, filename)
+	return pubKeyECDSA
+}
 
-	data, err := json.MarshalIndent(vault, "", "  ")
+// LoadVault loads the vault arg selects: by name, public key prefix or
+// fingerprint, as matchVaultFiles ranks them. More than one match is an
+// error listing the candidates.
+func LoadVault(arg string) (*LocalVault, error) {
+	matches, err := matchVaultFiles(arg)
 	if err != nil {
-		return fmt.Errorf("marshal vault: %w", err)
+		return nil, err
 	}
-
-	err = os.WriteFile(path, data, 0600)
-	if err != nil {
-		return fmt.Errorf("write vault: %w", err)
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("vault %q not found; see 'devctl vault list'", arg)
+	case 1:
+		if matches[0].Err != nil {
+			return nil, matches[0].Err
+		}
+		return matches[0].Vault, nil
+	default:
+		return nil, ambiguousVaultError(arg, matches)
 	}
-
-	return nil
 }
 
-func LoadVault(pubKeyPrefix string) (*LocalVault, error) {
-	dir := VaultStoragePath()
-
-	files, err := os.ReadDir(dir)
+// VaultFingerprint returns a short, human-comparable identifier for a vault:
+// the first 4 bytes of SHA-256 over the hex-decoded ECDSA public key, as
+// lowercase hex split into two groups (e.g. "ab12-cd34").
+func VaultFingerprint(pubKeyECDSA string) string {
+	raw, err := hex.DecodeString(pubKeyECDSA)
 	if err != nil {
-		return nil, fmt.Errorf("read vault dir: %w", err)
+		raw = []byte(pubKeyECDSA)
 	}
+	sum := sha256.Sum256(raw)
+	fp := hex.EncodeToString(sum[:4])
+	return fp[:4] + "-" + fp[4:]
+}
 
-	for _, f := range files {
-		if strings.HasPrefix(f.Name(), pubKeyPrefix) || strings.Contains(f.Name(), pubKeyPrefix) {
-			path := filepath.Join(dir, f.Name())
-			data, err := os.ReadFile(path)
-			if err != nil {
-				return nil, fmt.Errorf("read vault file: %w", err)
-			}
-
-			var vault LocalVault
-			err = json.Unmarshal(data, &vault)
-			if err != nil {
-				return nil, fmt.Errorf("unmarshal vault: %w", err)
-			}
-
-			return &vault, nil
-		}
+// isFingerprint reports whether s looks like a vault fingerprint, with or
+// without the separating dash.
+func isFingerprint(s string) bool {
+	s = strings.ToLower(s)
+	if len(s) == 9 && s[4] == '-' {
+		s = s[:4] + s[5:]
 	}
-
-	return nil, fmt.Errorf("vault not found")
+	if len(s) != 8 {
+		return false
+	}
+	_, err := hex.DecodeString(s)
+	return err == nil
 }
 
 func ListVaults() ([]*LocalVault, error) {
+	migrateVaultFiles()
 	dir := VaultStoragePath()
 
 	files, err := os.ReadDir(dir)
@@ -3,19 +3,32 @@ package cmd
 import (
 	"context"
 	"crypto/rand"
+	"encoding/base64"
 	"encoding/hex"
 	"fmt"
+	"math"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
+	vgcommon "github.com/vultisig/vultisig-go/common"
 
 	"github.com/vultisig/verifier/vault"
 	"github.com/vultisig/verifier/vault_config"
-	vgtypes "github.com/vultisig/vultisig-go/types"
 )
 
-func (t *TSSService) KeygenWithDKLS(ctx context.Context, vaultName string) (*LocalVault, error) {
+type keygenShare struct {
+	PublicKey string
+	ChainCode string
+	Keyshare  string
+}
+
+// KeygenWithDKLS runs a DKLS keygen with the Fast Vault Server, with this CLI
+// as the initiator. extraParties additional local parties join the same relay
+// session in-process; the returned slice holds the CLI's vault first, followed
+// by one vault per extra local party.
+func (t *TSSService) KeygenWithDKLS(ctx context.Context, vaultName, email, backupPassword string, extraParties int) ([]*LocalVault, error) {
 	sessionID := uuid.New().String()
 
 	encryptionKey := make([]byte, 32)
@@ -33,29 +46,50 @@ func (t *TSSService) KeygenWithDKLS(ctx context.Context, vaultName string) (*Loc
 	hexChainCode := hex.EncodeToString(chainCode)
 
 	t.logger.WithFields(logrus.Fields{
-		"session_id":  sessionID,
-		"local_party": t.localPartyID,
-		"vault_name":  vaultName,
+		"session_id":    sessionID,
+		"local_party":   t.localPartyID,
+		"vault_name":    vaultName,
+		"extra_parties": extraParties,
 	}).Info("Starting DKLS keygen session")
 
+	session := t.trackSession("keygen", "", sessionID)
+	defer session.end()
+
 	err = t.relayClient.RegisterSession(sessionID, t.localPartyID)
 	if err != nil {
 		return nil, fmt.Errorf("register session: %w", err)
 	}
 
+	locals := []*TSSService{t}
+	for i := 0; i < extraParties; i++ {
+		party := NewTSSService(fmt.Sprintf("%s-p%d", t.localPartyID, i+2))
+		t.shareRelayStats(party)
+		err = party.relayClient.RegisterSession(sessionID, party.localPartyID)
+		if err != nil {
+			return nil, fmt.Errorf("register local party %s: %w", party.localPartyID, err)
+		}
+		locals = append(locals, party)
+	}
+
 	t.logger.Info("Requesting Fast Vault Server to join keygen...")
-	err = t.requestFastVaultKeygen(ctx, vaultName, sessionID, hexEncryptionKey, hexChainCode)
+	err = t.requestFastVaultKeygen(ctx, vaultName, sessionID, hexEncryptionKey, hexChainCode, email, backupPassword, libTypeDKLS)
 	if err != nil {
 		return nil, fmt.Errorf("request fast vault keygen: %w", err)
 	}
 
-	t.logger.Info("Waiting for Fast Vault Server to join...")
-	parties, err := t.waitForParties(ctx, sessionID, 2)
+	expectedParties := len(locals) + 1
+	t.logger.WithField("expected", expectedParties).Info("Waiting for Fast Vault Server to join...")
+	parties, err := t.waitForParties(ctx, sessionID, expectedParties)
 	if err != nil {
 		return nil, fmt.Errorf("wait for parties: %w", err)
 	}
 
-	t.logger.WithField("parties", parties).Info("All parties joined")
+	t.logger.WithField("parties", parties).Info("All parties joined, starting keygen")
+
+	err = t.relayClient.StartSession(sessionID, parties)
+	if err != nil {
+		return nil, fmt.Errorf("start session: %w", err)
+	}
 
 	cfg := vault_config.Config{
 		Relay: struct {
@@ -72,19 +106,40 @@ func (t *TSSService) KeygenWithDKLS(ctx context.Context, vaultName string) (*Loc
 		return nil, fmt.Errorf("create dkls service: %w", err)
 	}
 
-	req := vgtypes.VaultCreateRequest{
-		Name:             vaultName,
-		SessionID:        sessionID,
-		HexEncryptionKey: hexEncryptionKey,
-		HexChainCode:     hexChainCode,
-		LocalPartyId:     t.localPartyID,
-		LibType:          1,
+	t.logger.Info("Running DKLS keygen protocol (ECDSA)...")
+	ecdsaShares, err := t.runKeygenAsInitiator(ctx, dklsService, locals, sessionID, hexEncryptionKey, parties, false)
+	if err != nil {
+		return nil, fmt.Errorf("keygen ECDSA failed: %w", err)
 	}
 
-	t.logger.Info("Running DKLS keygen protocol...")
-	ecdsaPubKey, eddsaPubKey, err := dklsService.ProcessDKLSKeygen(req)
+	t.logger.Info("Running DKLS keygen protocol (EdDSA)...")
+	eddsaShares, err := t.runKeygenAsInitiator(ctx, dklsService, locals, sessionID, hexEncryptionKey, parties, true)
 	if err != nil {
-		return nil, fmt.Errorf("keygen failed: %w", err)
+		return nil, fmt.Errorf("keygen EdDSA failed: %w", err)
+	}
+
+	for _, party := range locals[1:] {
+		err = party.relayClient.CompleteSession(sessionID, party.localPartyID)
+		if err != nil {
+			t.logger.WithError(err).WithField("party", party.localPartyID).Warn("Failed to complete session")
+		}
+	}
+
+	err = t.waitForCompletion(ctx, sessionID, parties)
+	if err != nil {
+		return nil, err
+	}
+	session.complete()
+
+	ecdsaPubKey := ecdsaShares[0].PublicKey
+	eddsaPubKey := eddsaShares[0].PublicKey
+	for i := range locals {
+		if ecdsaShares[i].Keyshare == "" || eddsaShares[i].Keyshare == "" {
+			return nil, fmt.Errorf("keygen returned no keyshare for party %s", locals[i].localPartyID)
+		}
+	}
+	if len(ecdsaPubKey) < 16 || len(eddsaPubKey) < 16 {
+		return nil, fmt.Errorf("keygen returned an invalid public key (ECDSA %q, EdDSA %q)", ecdsaPubKey, eddsaPubKey)
 	}
 
 	t.logger.WithFields(logrus.Fields{
@@ -92,16 +147,232 @@ func (t *TSSService) KeygenWithDKLS(ctx context.Context, vaultName string) (*Loc
 		"eddsa": eddsaPubKey[:16] + "...",
 	}).Info("Keygen completed successfully")
 
-	localVault := &LocalVault{
-		Name:           vaultName,
-		PublicKeyECDSA: ecdsaPubKey,
-		PublicKeyEdDSA: eddsaPubKey,
-		HexChainCode:   hexChainCode,
-		LocalPartyID:   t.localPartyID,
-		Signers:        parties,
-		CreatedAt:      time.Now().UTC().Format(time.RFC3339),
-		LibType:        1,
+	createdAt := time.Now().UTC().Format(time.RFC3339)
+	vaults := make([]*LocalVault, len(locals))
+	for i, party := range locals {
+		vaults[i] = &LocalVault{
+			Name:           vaultName,
+			PublicKeyECDSA: ecdsaPubKey,
+			PublicKeyEdDSA: eddsaPubKey,
+			HexChainCode:   ecdsaShares[0].ChainCode,
+			LocalPartyID:   party.localPartyID,
+			Signers:        parties,
+			KeyShares: []KeyShare{
+				{PubKey: ecdsaPubKey, Keyshare: ecdsaShares[i].Keyshare},
+				{PubKey: eddsaPubKey, Keyshare: eddsaShares[i].Keyshare},
+			},
+			CreatedAt: createdAt,
+			LibType:   libTypeDKLS,
+		}
 	}
 
-	return localVault, nil
+	return vaults, nil
+}
+
+// keygenThreshold mirrors the mobile apps: ceil(n * 2/3), so 2 parties give a
+// 2-of-2 and 3 parties a 2-of-3.
+func keygenThreshold(n int) int {
+	return int(math.Ceil(float64(n) * 2.0 / 3.0))
+}
+
+// runKeygenAsInitiator uploads the setup message for one key type and runs the
+// keygen rounds for every local party concurrently. Shares are returned in the
+// same order as locals.
+func (t *TSSService) runKeygenAsInitiator(ctx context.Context, dklsService *vault.DKLSTssService, locals []*TSSService, sessionID, hexEncryptionKey string, parties []string, isEdDSA bool) ([]keygenShare, error) {
+	mpcWrapper := dklsService.GetMPCKeygenWrapper(isEdDSA)
+	relayClient := t.newRelayClient()
+
+	threshold := keygenThreshold(len(parties))
+
+	t.logger.WithFields(logrus.Fields{
+		"parties":   parties,
+		"threshold": threshold,
+		"is_eddsa":  isEdDSA,
+	}).Debug("Creating keygen setup message")
+
+	setupMsg, err := mpcWrapper.KeygenSetupMsgNew(threshold, nil, fmtIdsSlice(parties))
+	if err != nil {
+		return nil, fmt.Errorf("create setup message: %w", err)
+	}
+
+	encodedSetupMsg := base64.StdEncoding.EncodeToString(setupMsg)
+	encryptedSetupMsg, err := vgcommon.EncryptGCM(encodedSetupMsg, hexEncryptionKey)
+	if err != nil {
+		return nil, fmt.Errorf("encrypt setup message: %w", err)
+	}
+
+	// The Fast Vault Server waits on the default message ID for both key types.
+	err = relayClient.UploadSetupMessage(sessionID, "", encryptedSetupMsg)
+	if err != nil {
+		return nil, fmt.Errorf("upload setup message: %w", err)
+	}
+
+	t.logger.Debug("Setup message uploaded, creating keygen sessions")
+
+	shares := make([]keygenShare, len(locals))
+	errs := make([]error, len(locals))
+	var wg sync.WaitGroup
+	for i, party := range locals {
+		wg.Add(1)
+		go func(i int, party *TSSService) {
+			defer wg.Done()
+
+			sessionHandle, err := mpcWrapper.KeygenSessionFromSetup(setupMsg, []byte(party.localPartyID))
+			if err != nil {
+				errs[i] = fmt.Errorf("party %s: create session from setup: %w", party.localPartyID, err)
+				return
+			}
+			defer func() {
+				_ = mpcWrapper.KeygenSessionFree(sessionHandle)
+			}()
+
+			share, err := party.processKeygenProtocol(ctx, mpcWrapper, sessionHandle, sessionID, hexEncryptionKey, parties, isEdDSA)
+			if err != nil {
+				errs[i] = fmt.Errorf("party %s: %w", party.localPartyID, err)
+				return
+			}
+			shares[i] = *share
+		}(i, party)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return shares, nil
+}
+
+func (t *TSSService) processKeygenProtocol(ctx context.Context, mpcWrapper *vault.MPCWrapperImp, sessionHandle vault.Handle, sessionID, hexEncryptionKey string, parties []string, isEdDSA bool) (*keygenShare, error) {
+	messenger := t.newMessenger(sessionID, hexEncryptionKey, "")
+	relayClient := t.newRelayClient()
+	var messageCache sync.Map
+
+	sendOutbound := func() {
+		for {
+			outbound, err := mpcWrapper.KeygenSessionOutputMessage(sessionHandle)
+			if err != nil {
+				t.logger.WithError(err).Debug("Failed to get output message")
+				return
+			}
+			if len(outbound) == 0 {
+				return
+			}
+
+			encodedOutbound := base64.StdEncoding.EncodeToString(outbound)
+			for i := 0; i < len(parties); i++ {
+				receiver, err := mpcWrapper.KeygenSessionMessageReceiver(sessionHandle, outbound, i)
+				if err != nil {
+					t.logger.WithError(err).Debug("Failed to get receiver")
+					continue
+				}
+				if len(receiver) == 0 {
+					break
+				}
+
+				t.logger.WithField("receiver", receiver).Debug("Sending message")
+				err = messenger.Send(t.localPartyID, receiver, encodedOutbound)
+				if err != nil {
+					t.logger.WithError(err).Debug("Failed to send message")
+				}
+			}
+		}
+	}
+
+	sendOutbound()
+
+	start := time.Now()
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if time.Since(start) > KeygenTimeout {
+			return nil, fmt.Errorf("keygen timeout")
+		}
+
+		messages, err := relayClient.DownloadMessages(sessionID, t.localPartyID, "")
+		if err != nil {
+			t.logger.WithError(err).Debug("Failed to download messages")
+			time.Sleep(100 * time.Millisecond)
+			continue
+		}
+
+		for _, msg := range messages {
+			if msg.From == t.localPartyID {
+				continue
+			}
+
+			cacheKey := fmt.Sprintf("%s-%s", sessionID, msg.Hash)
+			if _, found := messageCache.Load(cacheKey); found {
+				continue
+			}
+
+			decodedBody, err := base64.StdEncoding.DecodeString(msg.Body)
+			if err != nil {
+				continue
+			}
+			rawBody, err := vgcommon.DecryptGCM(decodedBody, hexEncryptionKey)
+			if err != nil {
+				continue
+			}
+			inboundBody, err := base64.StdEncoding.DecodeString(string(rawBody))
+			if err != nil {
+				continue
+			}
+
+			isFinished, err := mpcWrapper.KeygenSessionInputMessage(sessionHandle, inboundBody)
+			if err != nil {
+				t.logger.WithError(err).Debug("Failed to apply input message")
+				continue
+			}
+
+			messageCache.Store(cacheKey, true)
+			t.logger.WithFields(logrus.Fields{
+				"from": msg.From,
+				"hash": msg.Hash[:8],
+			}).Debug("Applied message")
+
+			_ = relayClient.DeleteMessageFromServer(sessionID, t.localPartyID, msg.Hash, "")
+
+			sendOutbound()
+
+			if isFinished {
+				t.logger.Info("Keygen protocol finished")
+
+				result, err := mpcWrapper.KeygenSessionFinish(sessionHandle)
+				if err != nil {
+					return nil, fmt.Errorf("finish session: %w", err)
+				}
+
+				buf, err := mpcWrapper.KeyshareToBytes(result)
+				if err != nil {
+					return nil, fmt.Errorf("keyshare to bytes: %w", err)
+				}
+
+				publicKeyBytes, err := mpcWrapper.KeysharePublicKey(result)
+				if err != nil {
+					return nil, fmt.Errorf("get public key: %w", err)
+				}
+
+				chainCode := ""
+				if !isEdDSA {
+					chainCodeBytes, err := mpcWrapper.KeyshareChainCode(result)
+					if err != nil {
+						return nil, fmt.Errorf("get chain code: %w", err)
+					}
+					chainCode = hex.EncodeToString(chainCodeBytes)
+				}
+
+				return &keygenShare{
+					PublicKey: hex.EncodeToString(publicKeyBytes),
+					ChainCode: chainCode,
+					Keyshare:  base64.StdEncoding.EncodeToString(buf),
+				}, nil
+			}
+		}
+
+		time.Sleep(100 * time.Millisecond)
+	}
 }
@@ -1,25 +1,22 @@
 package cmd
 
 import (
-	"bytes"
 	"context"
+	"crypto/ed25519"
 	"crypto/md5"
 	"crypto/rand"
 	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/btcsuite/btcd/btcec/v2"
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 	vgcommon "github.com/vultisig/vultisig-go/common"
-	vgrelay "github.com/vultisig/vultisig-go/relay"
-	"github.com/vultisig/vultiserver/relay"
 
 	"github.com/vultisig/verifier/vault"
 	"github.com/vultisig/verifier/vault_config"
@@ -29,11 +26,43 @@ func (t *TSSService) KeysignWithDKLS(ctx context.Context, v *LocalVault, message
 	return t.KeysignWithFastVault(ctx, v, messages, derivePath, "")
 }
 
+// KeysignWithFastVault signs 32-byte hashes 2-of-2 with the Fast Vault
+// Server: it posts /vault/sign, waits for the server to join the relay
+// session and runs the DKLS rounds. The results carry a verified recovery ID,
+// so auth and policy signatures recover to the vault's address.
 func (t *TSSService) KeysignWithFastVault(ctx context.Context, v *LocalVault, messages []string, derivePath, vaultPassword string) ([]KeysignResult, error) {
+	return t.keysignWithFastVault(ctx, v, messages, derivePath, vaultPassword, false)
+}
+
+// KeysignEdDSAWithFastVault signs hex-encoded raw messages (for example a
+// serialized Solana transaction message) with the vault's EdDSA key.
+func (t *TSSService) KeysignEdDSAWithFastVault(ctx context.Context, v *LocalVault, messages []string, vaultPassword string) ([]KeysignResult, error) {
+	return t.keysignWithFastVault(ctx, v, messages, "", vaultPassword, true)
+}
+
+func (t *TSSService) keysignWithFastVault(ctx context.Context, v *LocalVault, messages []string, derivePath, vaultPassword string, isEdDSA bool) ([]KeysignResult, error) {
+	err := requireKeyshare(v)
+	if err != nil {
+		return nil, err
+	}
+	err = requireDKLS(v, "Fast Vault keysign")
+	if err != nil {
+		return nil, err
+	}
+
+	if mockTSSEnabled() {
+		return mockKeysign(v, messages, derivePath, isEdDSA)
+	}
+
+	err = guardProduction(ctx, v)
+	if err != nil {
+		return nil, err
+	}
+
 	sessionID := uuid.New().String()
 
 	encryptionKey := make([]byte, 32)
-	_, err := rand.Read(encryptionKey)
+	_, err = rand.Read(encryptionKey)
 	if err != nil {
 		return nil, fmt.Errorf("generate encryption key: %w", err)
 	}
@@ -44,21 +73,29 @@ func (t *TSSService) KeysignWithFastVault(ctx context.Context, v *LocalVault, me
 		"public_key":  v.PublicKeyECDSA[:16] + "...",
 		"messages":    len(messages),
 		"derive_path": derivePath,
+		"is_eddsa":    isEdDSA,
 	}).Info("Starting DKLS keysign with Fast Vault Server")
 
+	session := t.trackSession("keysign", v.PublicKeyECDSA, sessionID)
+	defer session.end()
+
 	err = t.relayClient.RegisterSession(sessionID, t.localPartyID)
 	if err != nil {
 		return nil, fmt.Errorf("register session: %w", err)
 	}
 
 	t.logger.Info("Requesting Fast Vault Server to join keysign...")
-	err = t.requestFastVaultKeysignDKLS(ctx, v, sessionID, hexEncryptionKey, messages, derivePath, vaultPassword)
+	endPhase := t.phases.Start("Fast Vault request")
+	err = t.requestFastVaultKeysignDKLS(ctx, v, sessionID, hexEncryptionKey, messages, derivePath, vaultPassword, isEdDSA)
+	endPhase()
 	if err != nil {
 		return nil, fmt.Errorf("request fast vault keysign: %w", err)
 	}
 
 	t.logger.Info("Waiting for Fast Vault Server to join...")
+	endPhase = t.phases.Start("Party join")
 	parties, err := t.waitForParties(ctx, sessionID, 2)
+	endPhase()
 	if err != nil {
 		return nil, fmt.Errorf("wait for parties: %w", err)
 	}
@@ -85,47 +122,39 @@ func (t *TSSService) KeysignWithFastVault(ctx context.Context, v *LocalVault, me
 		return nil, fmt.Errorf("create dkls service: %w", err)
 	}
 
-	mpcWrapper := dklsService.GetMPCKeygenWrapper(false)
+	mpcWrapper := dklsService.GetMPCKeygenWrapper(isEdDSA)
 
+	endPhase = t.phases.Start("Keysign rounds")
 	results := make([]KeysignResult, len(messages))
 	for i, msg := range messages {
 		t.logger.WithField("message_index", i).Info("Running DKLS keysign protocol...")
 
-		result, err := t.runKeysignAsInitiator(mpcWrapper, v, sessionID, hexEncryptionKey, parties, msg, derivePath, i)
+		result, err := t.runKeysignAsInitiator(ctx, mpcWrapper, v, sessionID, hexEncryptionKey, parties, msg, derivePath, isEdDSA)
 		if err != nil {
 			return nil, fmt.Errorf("keysign message %d failed: %w", i, err)
 		}
+		err = verifyKeysignResult(v, msg, derivePath, isEdDSA, result)
+		if err != nil {
+			return nil, fmt.Errorf("keysign message %d: %w", i, err)
+		}
 		results[i] = *result
 	}
+	endPhase()
 
-	err = t.relayClient.CompleteSession(sessionID, t.localPartyID)
+	err = t.waitForCompletion(ctx, sessionID, parties)
 	if err != nil {
-		t.logger.WithError(err).Warn("Failed to complete session")
+		return nil, err
 	}
+	session.complete()
 
 	t.logger.WithField("signatures", len(results)).Info("Keysign completed successfully")
 	return results, nil
 }
 
-func (t *TSSService) requestFastVaultKeysignDKLS(ctx context.Context, v *LocalVault, sessionID, hexEncKey string, messages []string, derivePath, vaultPassword string) error {
-	type FastVaultSignRequest struct {
-		PublicKey        string   `json:"public_key"`
-		Messages         []string `json:"messages"`
-		Session          string   `json:"session"`
-		HexEncryptionKey string   `json:"hex_encryption_key"`
-		DerivePath       string   `json:"derive_path"`
-		IsECDSA          bool     `json:"is_ecdsa"`
-		VaultPassword    string   `json:"vault_password"`
-	}
-
-	req := FastVaultSignRequest{
-		PublicKey:        v.PublicKeyECDSA,
-		Messages:         messages,
-		Session:          sessionID,
-		HexEncryptionKey: hexEncKey,
-		DerivePath:       derivePath,
-		IsECDSA:          true,
-		VaultPassword:    vaultPassword,
+func (t *TSSService) requestFastVaultKeysignDKLS(ctx context.Context, v *LocalVault, sessionID, hexEncKey string, messages []string, derivePath, vaultPassword string, isEdDSA bool) error {
+	req, err := newFastVaultSignRequest(v.PublicKeyECDSA, sessionID, hexEncKey, messages, derivePath, isEdDSA, vaultPassword)
+	if err != nil {
+		return err
 	}
 
 	reqJSON, err := json.Marshal(req)
@@ -135,51 +164,20 @@ func (t *TSSService) requestFastVaultKeysignDKLS(ctx context.Context, v *LocalVa
 
 	t.logger.WithField("request", string(reqJSON)).Debug("Sending keysign request to Fast Vault Server")
 
-	url := FastVaultServer + "/vault/sign"
-	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(reqJSON))
-	if err != nil {
-		return fmt.Errorf("create request: %w", err)
-	}
-	httpReq.Header.Set("Content-Type", "application/json")
-
-	resp, err := http.DefaultClient.Do(httpReq)
-	if err != nil {
-		return fmt.Errorf("send request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("fast vault server returned %d: %s", resp.StatusCode, string(body))
-	}
-
-	return nil
+	return postFastVault(ctx, "/vault/sign", reqJSON)
 }
 
-func (t *TSSService) runKeysignAsInitiator(mpcWrapper *vault.MPCWrapperImp, v *LocalVault, sessionID, hexEncryptionKey string, parties []string, message, derivePath string, msgIndex int) (*KeysignResult, error) {
-	relayClient := vgrelay.NewRelayClient(RelayServer)
+func (t *TSSService) runKeysignAsInitiator(ctx context.Context, mpcWrapper *vault.MPCWrapperImp, v *LocalVault, sessionID, hexEncryptionKey string, parties []string, message, derivePath string, isEdDSA bool) (*KeysignResult, error) {
+	relayClient := t.newRelayClient()
 
 	publicKey := v.PublicKeyECDSA
-
-	var keyshare string
-	for _, ks := range v.KeyShares {
-		if ks.PubKey == publicKey {
-			keyshare = ks.Keyshare
-			break
-		}
-	}
-	if keyshare == "" {
-		return nil, fmt.Errorf("keyshare not found for public key: %s", publicKey[:16])
+	if isEdDSA {
+		publicKey = v.PublicKeyEdDSA
 	}
 
-	keyshareBytes, err := base64.StdEncoding.DecodeString(keyshare)
+	keyshareHandle, err := loadKeyshareHandle(mpcWrapper, v, publicKey)
 	if err != nil {
-		return nil, fmt.Errorf("decode keyshare: %w", err)
-	}
-
-	keyshareHandle, err := mpcWrapper.KeyshareFromBytes(keyshareBytes)
-	if err != nil {
-		return nil, fmt.Errorf("keyshare from bytes: %w", err)
+		return nil, err
 	}
 	defer func() {
 		_ = mpcWrapper.KeyshareFree(keyshareHandle)
@@ -195,9 +193,10 @@ func (t *TSSService) runKeysignAsInitiator(mpcWrapper *vault.MPCWrapperImp, v *L
 
 	messageBytes, err := hex.DecodeString(message)
 	if err != nil {
-		return nil, fmt.Errorf("message must be hex-encoded 32-byte hash: %w", err)
+		return nil, fmt.Errorf("message must be hex-encoded: %w", err)
 	}
-	if len(messageBytes) != 32 {
+	// ECDSA signs a 32-byte hash; EdDSA signs the raw message.
+	if !isEdDSA && len(messageBytes) != 32 {
 		return nil, fmt.Errorf("message must be 32 bytes, got %d", len(messageBytes))
 	}
 
@@ -234,7 +233,245 @@ func (t *TSSService) runKeysignAsInitiator(mpcWrapper *vault.MPCWrapperImp, v *L
 		return nil, fmt.Errorf("create session from setup: %w", err)
 	}
 
-	return t.processKeysignProtocol(mpcWrapper, sessionHandle, sessionID, hexEncryptionKey, parties, messageID)
+	return t.processKeysignProtocol(ctx, mpcWrapper, sessionHandle, sessionID, hexEncryptionKey, parties, messageID)
+}
+
+// verifyKeysignResult checks a signature against the vault's key, derived
+// at derivePath for ECDSA, and completes the result: the DER encoding and the
+// raw recovery ID (00/01) that recovers the key for ECDSA, or the 64-byte
+// R||S signature without a recovery ID for EdDSA.
+func verifyKeysignResult(v *LocalVault, message, derivePath string, isEdDSA bool, result *KeysignResult) error {
+	msg, err := hex.DecodeString(message)
+	if err != nil {
+		return fmt.Errorf("decode message: %w", err)
+	}
+
+	if isEdDSA {
+		pubKey, err := hex.DecodeString(v.PublicKeyEdDSA)
+		if err != nil || len(pubKey) != ed25519.PublicKeySize {
+			return fmt.Errorf("vault %s has an invalid EdDSA public key", v.Name)
+		}
+		sig, err := ed25519SignatureFromResult(*result, pubKey, msg)
+		if err != nil {
+			return err
+		}
+		result.R = hex.EncodeToString(sig[:32])
+		result.S = hex.EncodeToString(sig[32:])
+		result.RecoveryID = ""
+		result.DerSignature = hex.EncodeToString(sig)
+		return nil
+	}
+
+	pubKeyHex := v.PublicKeyECDSA
+	if derivePath != "" {
+		derived, err := deriveECDSAPubKey(v, derivePath)
+		if err != nil {
+			return err
+		}
+		pubKeyHex = derived.Compressed
+	}
+	pubKeyBytes, err := hex.DecodeString(pubKeyHex)
+	if err != nil {
+		return fmt.Errorf("decode public key: %w", err)
+	}
+	pubKey, err := btcec.ParsePubKey(pubKeyBytes)
+	if err != nil {
+		return fmt.Errorf("parse public key: %w", err)
+	}
+	sig, err := derSignatureFromResult(*result)
+	if err != nil {
+		return err
+	}
+	if !sig.Verify(msg, pubKey) {
+		return fmt.Errorf("keysign returned a signature that does not verify against the vault ECDSA key at %q", derivePath)
+	}
+	bit, err := ecdsaRecoveryBit(*result, msg, pubKey)
+	if err != nil {
+		return err
+	}
+	result.RecoveryID = fmt.Sprintf("%02x", bit)
+	result.DerSignature = hex.EncodeToString(sig.Serialize())
+	return nil
+}
+
+// KeysignWithLocalParties signs messages using only local shares created by
+// 'vault generate --parties', without the Fast Vault Server. The first vault
+// acts as initiator; the others join the same relay session in-process.
+func (t *TSSService) KeysignWithLocalParties(ctx context.Context, vaults []*LocalVault, messages []string, derivePath string) ([]KeysignResult, error) {
+	if len(vaults) < 2 {
+		return nil, fmt.Errorf("at least 2 local parties are required, got %d", len(vaults))
+	}
+	for _, v := range vaults {
+		err := requireKeyshare(v)
+		if err != nil {
+			return nil, err
+		}
+		err = requireDKLS(v, "local-party keysign")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sessionID := uuid.New().String()
+
+	encryptionKey := make([]byte, 32)
+	_, err := rand.Read(encryptionKey)
+	if err != nil {
+		return nil, fmt.Errorf("generate encryption key: %w", err)
+	}
+	hexEncryptionKey := hex.EncodeToString(encryptionKey)
+
+	parties := make([]string, len(vaults))
+	locals := make([]*TSSService, len(vaults))
+	for i, v := range vaults {
+		parties[i] = v.LocalPartyID
+		locals[i] = t
+		if v.LocalPartyID != t.localPartyID {
+			locals[i] = NewTSSService(v.LocalPartyID)
+			t.shareRelayStats(locals[i])
+		}
+	}
+
+	t.logger.WithFields(logrus.Fields{
+		"session_id":  sessionID,
+		"parties":     parties,
+		"messages":    len(messages),
+		"derive_path": derivePath,
+	}).Info("Starting DKLS keysign with local parties")
+
+	session := t.trackSession("keysign", vaults[0].PublicKeyECDSA, sessionID)
+	defer session.end()
+
+	for _, party := range locals {
+		err = party.relayClient.RegisterSession(sessionID, party.localPartyID)
+		if err != nil {
+			return nil, fmt.Errorf("register party %s: %w", party.localPartyID, err)
+		}
+	}
+
+	_, err = t.waitForParties(ctx, sessionID, len(parties))
+	if err != nil {
+		return nil, fmt.Errorf("wait for parties: %w", err)
+	}
+
+	err = t.relayClient.StartSession(sessionID, parties)
+	if err != nil {
+		return nil, fmt.Errorf("start session: %w", err)
+	}
+
+	mpcWrapper := vault.NewMPCWrapperImp(false)
+
+	results := make([]KeysignResult, len(messages))
+	for i, msg := range messages {
+		t.logger.WithField("message_index", i).Info("Running DKLS keysign protocol...")
+
+		errs := make([]error, len(locals))
+		var wg sync.WaitGroup
+		for j := 1; j < len(locals); j++ {
+			wg.Add(1)
+			go func(j int) {
+				defer wg.Done()
+				_, errs[j] = locals[j].runKeysignAsParticipant(ctx, mpcWrapper, vaults[j], sessionID, hexEncryptionKey, parties, msg)
+			}(j)
+		}
+
+		result, err := locals[0].runKeysignAsInitiator(ctx, mpcWrapper, vaults[0], sessionID, hexEncryptionKey, parties, msg, derivePath, false)
+		wg.Wait()
+		if err != nil {
+			return nil, fmt.Errorf("keysign message %d failed: %w", i, err)
+		}
+		for j, partyErr := range errs {
+			if partyErr != nil {
+				return nil, fmt.Errorf("keysign message %d failed for party %s: %w", i, parties[j], partyErr)
+			}
+		}
+		err = verifyKeysignResult(vaults[0], msg, derivePath, false, result)
+		if err != nil {
+			return nil, fmt.Errorf("keysign message %d: %w", i, err)
+		}
+		results[i] = *result
+	}
+
+	session.complete()
+
+	for _, party := range locals {
+		err = party.relayClient.CompleteSession(sessionID, party.localPartyID)
+		if err != nil {
+			t.logger.WithError(err).WithField("party", party.localPartyID).Warn("Failed to complete session")
+		}
+	}
+
+	t.logger.WithField("signatures", len(results)).Info("Keysign completed successfully")
+	return results, nil
+}
+
+// runKeysignAsParticipant joins a keysign started by another party: it waits
+// for the initiator's setup message on the relay and then runs the rounds.
+func (t *TSSService) runKeysignAsParticipant(ctx context.Context, mpcWrapper *vault.MPCWrapperImp, v *LocalVault, sessionID, hexEncryptionKey string, parties []string, message string) (*KeysignResult, error) {
+	relayClient := t.newRelayClient()
+
+	keyshareHandle, err := loadKeyshareHandle(mpcWrapper, v, v.PublicKeyECDSA)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = mpcWrapper.KeyshareFree(keyshareHandle)
+	}()
+
+	md5Hash := md5.Sum([]byte(message))
+	messageID := hex.EncodeToString(md5Hash[:])
+
+	encryptedSetupMsg, err := relayClient.WaitForSetupMessage(ctx, sessionID, messageID)
+	if err != nil {
+		return nil, fmt.Errorf("wait for setup message: %w", err)
+	}
+
+	decodedSetupMsg, err := base64.StdEncoding.DecodeString(encryptedSetupMsg)
+	if err != nil {
+		return nil, fmt.Errorf("decode setup message: %w", err)
+	}
+	rawSetupMsg, err := vgcommon.DecryptGCM(decodedSetupMsg, hexEncryptionKey)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt setup message: %w", err)
+	}
+	setupMsg, err := base64.StdEncoding.DecodeString(string(rawSetupMsg))
+	if err != nil {
+		return nil, fmt.Errorf("decode setup message: %w", err)
+	}
+
+	sessionHandle, err := mpcWrapper.SignSessionFromSetup(setupMsg, []byte(t.localPartyID), keyshareHandle)
+	if err != nil {
+		return nil, fmt.Errorf("create session from setup: %w", err)
+	}
+
+	return t.processKeysignProtocol(ctx, mpcWrapper, sessionHandle, sessionID, hexEncryptionKey, parties, messageID)
+}
+
+// loadKeyshareHandle decodes the vault's keyshare for publicKey into a handle
+// owned by the caller, who must release it with KeyshareFree.
+func loadKeyshareHandle(mpcWrapper *vault.MPCWrapperImp, v *LocalVault, publicKey string) (vault.Handle, error) {
+	var keyshare string
+	for _, ks := range v.KeyShares {
+		if ks.PubKey == publicKey {
+			keyshare = ks.Keyshare
+			break
+		}
+	}
+	if keyshare == "" {
+		return 0, fmt.Errorf("keyshare not found for public key: %s", publicKey[:16])
+	}
+
+	keyshareBytes, err := base64.StdEncoding.DecodeString(keyshare)
+	if err != nil {
+		return 0, fmt.Errorf("decode keyshare: %w", err)
+	}
+
+	keyshareHandle, err := mpcWrapper.KeyshareFromBytes(keyshareBytes)
+	if err != nil {
+		return 0, fmt.Errorf("keyshare from bytes: %w", err)
+	}
+
+	return keyshareHandle, nil
 }
 
 func fmtDerivePath(path string) []byte {
@@ -248,9 +485,9 @@ func fmtIdsSlice(ids []string) []byte {
 	return []byte(strings.Join(ids, "\x00"))
 }
 
-func (t *TSSService) processKeysignProtocol(mpcWrapper *vault.MPCWrapperImp, sessionHandle vault.Handle, sessionID, hexEncryptionKey string, parties []string, messageID string) (*KeysignResult, error) {
-	messenger := relay.NewMessenger(RelayServer, sessionID, hexEncryptionKey, true, messageID)
-	relayClient := vgrelay.NewRelayClient(RelayServer)
+func (t *TSSService) processKeysignProtocol(ctx context.Context, mpcWrapper *vault.MPCWrapperImp, sessionHandle vault.Handle, sessionID, hexEncryptionKey string, parties []string, messageID string) (*KeysignResult, error) {
+	messenger := t.newMessenger(sessionID, hexEncryptionKey, messageID)
+	relayClient := t.newRelayClient()
 	var messageCache sync.Map
 
 	go func() {
@@ -283,6 +520,9 @@ func (t *TSSService) processKeysignProtocol(mpcWrapper *vault.MPCWrapperImp, ses
 
 	start := time.Now()
 	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		if time.Since(start) > 2*time.Minute {
 			return nil, fmt.Errorf("keysign timeout")
 		}
@@ -0,0 +1,246 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/vultisig/mobile-tss-lib/tss"
+)
+
+const (
+	tssBackendDKLS = "dkls"
+	tssBackendMock = "mock"
+
+	// mockKeysharePrefix marks keyshares that hold a plain private key
+	// instead of a DKLS share.
+	mockKeysharePrefix = "mock:"
+
+	demoVaultName    = "devctl-demo"
+	demoLocalPartyID = "devctl-demo"
+)
+
+// mockTSSEnabled reports whether tss_backend is "mock": keysign and reshare
+// then run locally against mock vaults instead of over the relay.
+func mockTSSEnabled() bool {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return false
+	}
+	return cfg.TSSBackend == tssBackendMock
+}
+
+// newDemoVault builds the deterministic demo vault. Its keys are derived from
+// fixed seeds, so every 'demo up' yields the same public keys and addresses,
+// and the private keys are stored as mock keyshares for the mock backend.
+func newDemoVault() (*LocalVault, error) {
+	ecdsaKey, err := crypto.ToECDSA(crypto.Keccak256([]byte("devctl demo vault ecdsa")))
+	if err != nil {
+		return nil, fmt.Errorf("derive demo ECDSA key: %w", err)
+	}
+	chainCode := sha256.Sum256([]byte("devctl demo vault chain code"))
+	edSeed := sha256.Sum256([]byte("devctl demo vault eddsa"))
+	edKey := ed25519.NewKeyFromSeed(edSeed[:])
+
+	pubECDSA := hex.EncodeToString(crypto.CompressPubkey(&ecdsaKey.PublicKey))
+	pubEdDSA := hex.EncodeToString(edKey.Public().(ed25519.PublicKey))
+
+	return &LocalVault{
+		Name:           demoVaultName,
+		PublicKeyECDSA: pubECDSA,
+		PublicKeyEdDSA: pubEdDSA,
+		HexChainCode:   hex.EncodeToString(chainCode[:]),
+		LocalPartyID:   demoLocalPartyID,
+		Signers:        []string{demoLocalPartyID},
+		KeyShares: []KeyShare{
+			{PubKey: pubECDSA, Keyshare: mockKeysharePrefix + hex.EncodeToString(crypto.FromECDSA(ecdsaKey))},
+			{PubKey: pubEdDSA, Keyshare: mockKeysharePrefix + hex.EncodeToString(edSeed[:])},
+		},
+		CreatedAt: time.Now().UTC().Format(time.RFC3339),
+		LibType:   libTypeDKLS,
+	}, nil
+}
+
+// isMockVault reports whether v carries mock keyshares.
+func isMockVault(v *LocalVault) bool {
+	for _, ks := range v.KeyShares {
+		if strings.HasPrefix(ks.Keyshare, mockKeysharePrefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func mockKeyshare(v *LocalVault, pubKey string) ([]byte, error) {
+	for _, ks := range v.KeyShares {
+		if ks.PubKey == pubKey && strings.HasPrefix(ks.Keyshare, mockKeysharePrefix) {
+			return hex.DecodeString(strings.TrimPrefix(ks.Keyshare, mockKeysharePrefix))
+		}
+	}
+	return nil, fmt.Errorf("vault %s has no mock keyshare; the mock TSS backend only signs for the demo vault (run 'devctl demo up', or set tss_backend to %q)", v.Name, tssBackendDKLS)
+}
+
+// mockDeriveKey derives the child private key for derivePath the way
+// mobile-tss-lib derives public keys: non-hardened BIP32 steps with the
+// hardened markers dropped, so signatures verify against the addresses
+// devctl shows for the vault.
+func mockDeriveKey(key *ecdsa.PrivateKey, hexChainCode, derivePath string) (*ecdsa.PrivateKey, error) {
+	if derivePath == "" {
+		return key, nil
+	}
+	path, err := tss.GetDerivePathBytes(derivePath)
+	if err != nil {
+		return nil, fmt.Errorf("parse derive path: %w", err)
+	}
+	chainCode, err := hex.DecodeString(hexChainCode)
+	if err != nil {
+		return nil, fmt.Errorf("decode chain code: %w", err)
+	}
+
+	n := crypto.S256().Params().N
+	priv := new(big.Int).Set(key.D)
+	for _, index := range path {
+		child, err := crypto.ToECDSA(paddedKeyBytes(priv))
+		if err != nil {
+			return nil, err
+		}
+		data := make([]byte, 0, 37)
+		data = append(data, crypto.CompressPubkey(&child.PublicKey)...)
+		data = binary.BigEndian.AppendUint32(data, index)
+
+		mac := hmac.New(sha512.New, chainCode)
+		mac.Write(data)
+		sum := mac.Sum(nil)
+
+		priv.Add(priv, new(big.Int).SetBytes(sum[:32]))
+		priv.Mod(priv, n)
+		chainCode = sum[32:]
+	}
+	return crypto.ToECDSA(paddedKeyBytes(priv))
+}
+
+func paddedKeyBytes(i *big.Int) []byte {
+	buf := make([]byte, 32)
+	return i.FillBytes(buf)
+}
+
+// mockKeysign signs hex-encoded messages with the vault's mock keys: the
+// ECDSA key at derivePath for 32-byte hashes, or the EdDSA key for raw
+// messages. Results are shaped like the DKLS ones.
+func mockKeysign(v *LocalVault, messages []string, derivePath string, isEdDSA bool) ([]KeysignResult, error) {
+	progressf("  %s mock TSS backend: signing %d message(s) locally\n", warnMark(), len(messages))
+
+	pubKey := v.PublicKeyECDSA
+	if isEdDSA {
+		pubKey = v.PublicKeyEdDSA
+	}
+	secret, err := mockKeyshare(v, pubKey)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]KeysignResult, 0, len(messages))
+	for _, m := range messages {
+		msg, err := hex.DecodeString(strings.TrimPrefix(m, "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("decode message %q: %w", m, err)
+		}
+
+		var signature []byte
+		if isEdDSA {
+			signature = ed25519.Sign(ed25519.NewKeyFromSeed(secret), msg)
+		} else {
+			key, err := crypto.ToECDSA(secret)
+			if err != nil {
+				return nil, fmt.Errorf("load mock key: %w", err)
+			}
+			key, err = mockDeriveKey(key, v.HexChainCode, derivePath)
+			if err != nil {
+				return nil, err
+			}
+			signature, err = crypto.Sign(msg, key)
+			if err != nil {
+				return nil, fmt.Errorf("sign message: %w", err)
+			}
+		}
+
+		recoveryID := "1b"
+		if len(signature) > 64 {
+			recoveryID = fmt.Sprintf("%02x", signature[64])
+		}
+		results = append(results, KeysignResult{
+			R:            hex.EncodeToString(signature[:32]),
+			S:            hex.EncodeToString(signature[32:64]),
+			RecoveryID:   recoveryID,
+			DerSignature: hex.EncodeToString(signature),
+		})
+	}
+	return results, nil
+}
+
+// mockReshare stands in for the 4-party plugin reshare: it adds the verifier
+// and plugin parties to the signers, stores placeholder keyshares where the
+// workers would and records the installation in the verifier database. The
+// placeholders are not DKLS shares, so plugin workers cannot sign with them.
+func mockReshare(ctx context.Context, v *LocalVault, pluginID string) (*LocalVault, error) {
+	progressf("  %s mock TSS backend: resharing locally with placeholder keyshares\n", warnMark())
+
+	if !isMockVault(v) {
+		_, err := mockKeyshare(v, v.PublicKeyECDSA)
+		return nil, err
+	}
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		return nil, fmt.Errorf("load config: %w", err)
+	}
+	spec := pluginSpecFor(pluginID)
+
+	newVault := *v
+	newVault.Signers = append([]string{}, v.Signers...)
+	for _, party := range []string{"verifier-mock", spec.PartyPrefix + "-mock"} {
+		if !slices.Contains(newVault.Signers, party) {
+			newVault.Signers = append(newVault.Signers, party)
+		}
+	}
+
+	client, err := newMinioClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	placeholder := []byte(fmt.Sprintf("mock keyshare for %s (%s)\n", v.PublicKeyECDSA, pluginID))
+	key := keyshareObjectKey(pluginID, v.PublicKeyECDSA)
+	for _, bucket := range []string{cfg.VerifierBucket, spec.Bucket} {
+		_, err = client.PutObjectWithContext(ctx, &s3.PutObjectInput{
+			Bucket: aws.String(bucket),
+			Key:    aws.String(key),
+			Body:   bytes.NewReader(placeholder),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("store mock keyshare in %s: %w", bucket, s3ErrorMessage(err))
+		}
+	}
+
+	cmd := psqlCommand("vultisig-verifier", "-c",
+		fmt.Sprintf("INSERT INTO plugin_installations (plugin_id, public_key) VALUES ('%s', '%s') ON CONFLICT DO NOTHING", pluginID, v.PublicKeyECDSA))
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("record mock installation: %w: %s", err, strings.TrimSpace(string(output)))
+	}
+
+	return &newVault, nil
+}
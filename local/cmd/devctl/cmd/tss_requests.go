@@ -0,0 +1,233 @@
+package cmd
+
+import (
+	"encoding/hex"
+	"fmt"
+)
+
+// LibType is the TSS library a vault's keyshares were made with, as stored
+// in LocalVault.LibType and sent as the servers' lib_type.
+type LibType int
+
+const (
+	libTypeGG20 LibType = 0
+	libTypeDKLS LibType = 1
+)
+
+func (l LibType) String() string {
+	switch l {
+	case libTypeGG20:
+		return "GG20"
+	case libTypeDKLS:
+		return "DKLS"
+	}
+	return fmt.Sprintf("unknown (%d)", int(l))
+}
+
+// requireDKLS fails fast for vaults whose keyshares the DKLS protocol cannot
+// use, before any session is registered or server contacted.
+func requireDKLS(v *LocalVault, operation string) error {
+	if v.LibType == libTypeDKLS {
+		return nil
+	}
+	return fmt.Errorf("%s needs a DKLS vault, but %s is %s; migrate it to DKLS in the Vultisig app and import the new backup", operation, v.Name, v.LibType)
+}
+
+// FastVaultCreateRequest is the body of the Fast Vault Server's /vault/create.
+type FastVaultCreateRequest struct {
+	Name               string  `json:"name"`
+	SessionID          string  `json:"session_id"`
+	HexEncryptionKey   string  `json:"hex_encryption_key"`
+	HexChainCode       string  `json:"hex_chain_code"`
+	LocalPartyId       string  `json:"local_party_id"`
+	EncryptionPassword string  `json:"encryption_password"`
+	Email              string  `json:"email"`
+	LibType            LibType `json:"lib_type"`
+	// TraceID ties the server's side of the session to this invocation,
+	// as do the trace_id fields of the other session requests below. The
+	// relay's registration body is a bare party list, so this is where the
+	// session metadata goes.
+	TraceID string `json:"trace_id,omitempty"`
+}
+
+// FastVaultSignRequest is the body of the Fast Vault Server's /vault/sign
+// (and legacy /sign).
+type FastVaultSignRequest struct {
+	PublicKey        string   `json:"public_key"`
+	Messages         []string `json:"messages"`
+	Session          string   `json:"session"`
+	HexEncryptionKey string   `json:"hex_encryption_key"`
+	DerivePath       string   `json:"derive_path"`
+	IsECDSA          bool     `json:"is_ecdsa"`
+	VaultPassword    string   `json:"vault_password"`
+	TraceID          string   `json:"trace_id,omitempty"`
+}
+
+// FastVaultReshareRequest is the body of the Fast Vault Server's
+// /vault/reshare.
+type FastVaultReshareRequest struct {
+	Name               string   `json:"name"`
+	PublicKey          string   `json:"public_key"`
+	SessionID          string   `json:"session_id"`
+	HexEncryptionKey   string   `json:"hex_encryption_key"`
+	HexChainCode       string   `json:"hex_chain_code"`
+	LocalPartyId       string   `json:"local_party_id"`
+	OldParties         []string `json:"old_parties"`
+	OldResharePrefix   string   `json:"old_reshare_prefix"`
+	EncryptionPassword string   `json:"encryption_password"`
+	Email              string   `json:"email"`
+	ReshareType        int      `json:"reshare_type"`
+	LibType            LibType  `json:"lib_type"`
+	TraceID            string   `json:"trace_id,omitempty"`
+}
+
+// VerifierReshareRequest is the body of the verifier's /vault/reshare.
+type VerifierReshareRequest struct {
+	Name             string   `json:"name"`
+	PublicKey        string   `json:"public_key"`
+	SessionID        string   `json:"session_id"`
+	HexEncryptionKey string   `json:"hex_encryption_key"`
+	HexChainCode     string   `json:"hex_chain_code"`
+	LocalPartyId     string   `json:"local_party_id"`
+	OldParties       []string `json:"old_parties"`
+	Email            string   `json:"email"`
+	PluginID         string   `json:"plugin_id"`
+	LibType          LibType  `json:"lib_type"`
+	TraceID          string   `json:"trace_id,omitempty"`
+}
+
+// VerifierKeysignRequest is the body of the verifier's /vault/keysign.
+type VerifierKeysignRequest struct {
+	PublicKey        string   `json:"public_key"`
+	Messages         []string `json:"messages"`
+	Session          string   `json:"session"`
+	HexEncryptionKey string   `json:"hex_encryption_key"`
+	DerivePath       string   `json:"derive_path"`
+	PluginID         string   `json:"plugin_id"`
+	IsECDSA          bool     `json:"is_ecdsa"`
+	TraceID          string   `json:"trace_id,omitempty"`
+}
+
+// fastVaultReshareAddPlugin is reshare_type for a reshare that adds parties
+// (the verifier and plugin) to an existing Fast Vault.
+const fastVaultReshareAddPlugin = 1
+
+func newFastVaultSignRequest(publicKey, sessionID, hexEncKey string, messages []string, derivePath string, isEdDSA bool, password string) (FastVaultSignRequest, error) {
+	err := validateSessionParams(sessionID, hexEncKey)
+	if err != nil {
+		return FastVaultSignRequest{}, err
+	}
+	if publicKey == "" {
+		return FastVaultSignRequest{}, fmt.Errorf("sign request: public key is empty")
+	}
+	if len(messages) == 0 {
+		return FastVaultSignRequest{}, fmt.Errorf("sign request: no messages")
+	}
+	return FastVaultSignRequest{
+		PublicKey:        publicKey,
+		Messages:         messages,
+		Session:          sessionID,
+		HexEncryptionKey: hexEncKey,
+		DerivePath:       derivePath,
+		IsECDSA:          !isEdDSA,
+		VaultPassword:    password,
+		TraceID:          TraceID(),
+	}, nil
+}
+
+func newFastVaultReshareRequest(v *LocalVault, sessionID, hexEncKey, password string) (FastVaultReshareRequest, error) {
+	err := validateReshareVault(v, sessionID, hexEncKey)
+	if err != nil {
+		return FastVaultReshareRequest{}, err
+	}
+	return FastVaultReshareRequest{
+		Name:               v.Name,
+		PublicKey:          v.PublicKeyECDSA,
+		SessionID:          sessionID,
+		HexEncryptionKey:   hexEncKey,
+		HexChainCode:       v.HexChainCode,
+		LocalPartyId:       generateServerPartyID(sessionID),
+		OldParties:         v.Signers,
+		OldResharePrefix:   v.ResharePrefix,
+		EncryptionPassword: password,
+		ReshareType:        fastVaultReshareAddPlugin,
+		LibType:            v.LibType,
+		TraceID:            TraceID(),
+	}, nil
+}
+
+func newVerifierReshareRequest(v *LocalVault, sessionID, hexEncKey, pluginID string) (VerifierReshareRequest, error) {
+	err := validateReshareVault(v, sessionID, hexEncKey)
+	if err != nil {
+		return VerifierReshareRequest{}, err
+	}
+	if pluginID == "" {
+		return VerifierReshareRequest{}, fmt.Errorf("reshare request: plugin ID is empty")
+	}
+	return VerifierReshareRequest{
+		Name:             v.Name,
+		PublicKey:        v.PublicKeyECDSA,
+		SessionID:        sessionID,
+		HexEncryptionKey: hexEncKey,
+		HexChainCode:     v.HexChainCode,
+		LocalPartyId:     "verifier-" + sessionID[:8],
+		OldParties:       v.Signers,
+		PluginID:         pluginID,
+		LibType:          v.LibType,
+		TraceID:          TraceID(),
+	}, nil
+}
+
+func newVerifierKeysignRequest(publicKey, sessionID, hexEncKey string, messages []string, derivePath, pluginID string) (VerifierKeysignRequest, error) {
+	err := validateSessionParams(sessionID, hexEncKey)
+	if err != nil {
+		return VerifierKeysignRequest{}, err
+	}
+	if publicKey == "" {
+		return VerifierKeysignRequest{}, fmt.Errorf("keysign request: public key is empty")
+	}
+	if len(messages) == 0 {
+		return VerifierKeysignRequest{}, fmt.Errorf("keysign request: no messages")
+	}
+	return VerifierKeysignRequest{
+		PublicKey:        publicKey,
+		Messages:         messages,
+		Session:          sessionID,
+		HexEncryptionKey: hexEncKey,
+		DerivePath:       derivePath,
+		PluginID:         pluginID,
+		IsECDSA:          true,
+		TraceID:          TraceID(),
+	}, nil
+}
+
+// validateSessionParams checks what every TSS request carries: a session ID
+// of at least 8 characters (party IDs use its prefix) and a 32-byte
+// encryption key as 64 hex digits.
+func validateSessionParams(sessionID, hexEncKey string) error {
+	if len(sessionID) < 8 {
+		return fmt.Errorf("session ID %q is too short", sessionID)
+	}
+	key, err := hex.DecodeString(hexEncKey)
+	if err != nil || len(key) != 32 {
+		return fmt.Errorf("encryption key must be 64 hex digits, got %d characters", len(hexEncKey))
+	}
+	return nil
+}
+
+func validateReshareVault(v *LocalVault, sessionID, hexEncKey string) error {
+	err := validateSessionParams(sessionID, hexEncKey)
+	if err != nil {
+		return err
+	}
+	if v.PublicKeyECDSA == "" || v.HexChainCode == "" {
+		return fmt.Errorf("reshare request: vault %q has no public key or chain code", v.Name)
+	}
+	if len(v.Signers) == 0 {
+		return fmt.Errorf("reshare request: vault %q has no signers", v.Name)
+	}
+	if v.LibType != libTypeGG20 && v.LibType != libTypeDKLS {
+		return fmt.Errorf("reshare request: unknown lib type %d (want 0 = GG20 or 1 = DKLS)", int(v.LibType))
+	}
+	return nil
+}
@@ -0,0 +1,207 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// The field names below are the servers' wire format; renaming a Go field
+// must not change them.
+func TestTSSRequestJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		req  interface{}
+		want string
+	}{
+		{
+			name: "FastVaultCreateRequest",
+			req: FastVaultCreateRequest{
+				Name: "v", SessionID: "s", HexEncryptionKey: "k", HexChainCode: "c", LocalPartyId: "p",
+				EncryptionPassword: "pw", Email: "e@example.com", LibType: libTypeDKLS,
+			},
+			want: `{"name":"v","session_id":"s","hex_encryption_key":"k","hex_chain_code":"c","local_party_id":"p",
+				"encryption_password":"pw","email":"e@example.com","lib_type":1}`,
+		},
+		{
+			name: "FastVaultSignRequest",
+			req: FastVaultSignRequest{
+				PublicKey: "pk", Messages: []string{"m1", "m2"}, Session: "s", HexEncryptionKey: "k",
+				DerivePath: "m/44'/60'/0'/0/0", IsECDSA: true, VaultPassword: "pw",
+			},
+			want: `{"public_key":"pk","messages":["m1","m2"],"session":"s","hex_encryption_key":"k",
+				"derive_path":"m/44'/60'/0'/0/0","is_ecdsa":true,"vault_password":"pw"}`,
+		},
+		{
+			name: "FastVaultReshareRequest",
+			req: FastVaultReshareRequest{
+				Name: "v", PublicKey: "pk", SessionID: "s", HexEncryptionKey: "k", HexChainCode: "c", LocalPartyId: "p",
+				OldParties: []string{"a", "b"}, OldResharePrefix: "r", EncryptionPassword: "pw", Email: "e",
+				ReshareType: fastVaultReshareAddPlugin, LibType: libTypeGG20,
+			},
+			want: `{"name":"v","public_key":"pk","session_id":"s","hex_encryption_key":"k","hex_chain_code":"c",
+				"local_party_id":"p","old_parties":["a","b"],"old_reshare_prefix":"r","encryption_password":"pw",
+				"email":"e","reshare_type":1,"lib_type":0}`,
+		},
+		{
+			name: "VerifierReshareRequest",
+			req: VerifierReshareRequest{
+				Name: "v", PublicKey: "pk", SessionID: "s", HexEncryptionKey: "k", HexChainCode: "c", LocalPartyId: "p",
+				OldParties: []string{"a"}, Email: "e", PluginID: "vultisig-dca-0000", LibType: libTypeDKLS,
+			},
+			want: `{"name":"v","public_key":"pk","session_id":"s","hex_encryption_key":"k","hex_chain_code":"c",
+				"local_party_id":"p","old_parties":["a"],"email":"e","plugin_id":"vultisig-dca-0000","lib_type":1}`,
+		},
+		{
+			name: "VerifierKeysignRequest",
+			req: VerifierKeysignRequest{
+				PublicKey: "pk", Messages: []string{"m"}, Session: "s", HexEncryptionKey: "k",
+				DerivePath: "m/0", PluginID: "vultisig-dca-0000", IsECDSA: true,
+			},
+			want: `{"public_key":"pk","messages":["m"],"session":"s","hex_encryption_key":"k","derive_path":"m/0",
+				"plugin_id":"vultisig-dca-0000","is_ecdsa":true}`,
+		},
+		{
+			name: "trace ID",
+			req: VerifierKeysignRequest{
+				PublicKey: "pk", Messages: []string{"m"}, Session: "s", HexEncryptionKey: "k",
+				PluginID: "p", IsECDSA: true, TraceID: "trace",
+			},
+			want: `{"public_key":"pk","messages":["m"],"session":"s","hex_encryption_key":"k","derive_path":"",
+				"plugin_id":"p","is_ecdsa":true,"trace_id":"trace"}`,
+		},
+		{
+			// Empty values are sent, not omitted, except for the trace ID.
+			name: "zero FastVaultSignRequest",
+			req:  FastVaultSignRequest{},
+			want: `{"public_key":"","messages":null,"session":"","hex_encryption_key":"","derive_path":"",
+				"is_ecdsa":false,"vault_password":""}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(tt.req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var want bytes.Buffer
+			err = json.Compact(&want, []byte(tt.want))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != want.String() {
+				t.Errorf("json.Marshal =\n %s\nwant\n %s", got, want.String())
+			}
+		})
+	}
+}
+
+func TestTSSRequestConstructors(t *testing.T) {
+	const (
+		session = "0123456789abcdef"
+		encKey  = "00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff"
+	)
+	vault := &LocalVault{
+		Name: "test", PublicKeyECDSA: "02a1b2c3", HexChainCode: "c0de", Signers: []string{"a", "b"},
+		ResharePrefix: "r", LibType: libTypeDKLS,
+	}
+
+	sign, err := newFastVaultSignRequest("pk", session, encKey, []string{"m"}, "m/0", false, "pw")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !sign.IsECDSA || sign.Session != session || sign.VaultPassword != "pw" {
+		t.Errorf("newFastVaultSignRequest = %+v", sign)
+	}
+	sign, err = newFastVaultSignRequest("pk", session, encKey, []string{"m"}, "", true, "pw")
+	if err != nil || sign.IsECDSA {
+		t.Errorf("EdDSA sign request: %+v, %v", sign, err)
+	}
+
+	reshare, err := newFastVaultReshareRequest(vault, session, encKey, "pw")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reshare.ReshareType != fastVaultReshareAddPlugin || reshare.LibType != libTypeDKLS ||
+		reshare.OldResharePrefix != "r" || reshare.LocalPartyId != generateServerPartyID(session) {
+		t.Errorf("newFastVaultReshareRequest = %+v", reshare)
+	}
+
+	verifierReshare, err := newVerifierReshareRequest(vault, session, encKey, "vultisig-dca-0000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if verifierReshare.LocalPartyId != "verifier-01234567" || verifierReshare.PluginID != "vultisig-dca-0000" {
+		t.Errorf("newVerifierReshareRequest = %+v", verifierReshare)
+	}
+
+	keysign, err := newVerifierKeysignRequest("pk", session, encKey, []string{"m"}, "m/0", "vultisig-dca-0000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !keysign.IsECDSA || keysign.PluginID != "vultisig-dca-0000" {
+		t.Errorf("newVerifierKeysignRequest = %+v", keysign)
+	}
+
+	for _, id := range []string{sign.TraceID, reshare.TraceID, verifierReshare.TraceID, keysign.TraceID} {
+		if id != TraceID() {
+			t.Errorf("request trace ID = %q, want %q", id, TraceID())
+		}
+	}
+
+	noSigners := *vault
+	noSigners.Signers = nil
+	unknownLib := *vault
+	unknownLib.LibType = 7
+
+	errTests := []struct {
+		name    string
+		build   func() error
+		wantErr string
+	}{
+		{"short session", func() error {
+			_, err := newFastVaultSignRequest("pk", "short", encKey, []string{"m"}, "", false, "")
+			return err
+		}, `session ID "short" is too short`},
+		{"short key", func() error {
+			_, err := newVerifierKeysignRequest("pk", session, "0011", []string{"m"}, "", "p")
+			return err
+		}, "encryption key must be 64 hex digits, got 4 characters"},
+		{"key not hex", func() error {
+			_, err := newVerifierKeysignRequest("pk", session, strings.Repeat("zz", 32), []string{"m"}, "", "p")
+			return err
+		}, "encryption key must be 64 hex digits, got 64 characters"},
+		{"sign without public key", func() error {
+			_, err := newFastVaultSignRequest("", session, encKey, []string{"m"}, "", false, "")
+			return err
+		}, "sign request: public key is empty"},
+		{"sign without messages", func() error {
+			_, err := newFastVaultSignRequest("pk", session, encKey, nil, "", false, "")
+			return err
+		}, "sign request: no messages"},
+		{"keysign without messages", func() error {
+			_, err := newVerifierKeysignRequest("pk", session, encKey, nil, "", "p")
+			return err
+		}, "keysign request: no messages"},
+		{"reshare without plugin", func() error {
+			_, err := newVerifierReshareRequest(vault, session, encKey, "")
+			return err
+		}, "reshare request: plugin ID is empty"},
+		{"reshare without signers", func() error {
+			_, err := newFastVaultReshareRequest(&noSigners, session, encKey, "")
+			return err
+		}, `reshare request: vault "test" has no signers`},
+		{"reshare with unknown lib type", func() error {
+			_, err := newFastVaultReshareRequest(&unknownLib, session, encKey, "")
+			return err
+		}, "reshare request: unknown lib type 7"},
+	}
+	for _, tt := range errTests {
+		err := tt.build()
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("%s: error = %v, want %q", tt.name, err, tt.wantErr)
+		}
+	}
+}
@@ -14,18 +14,36 @@ import (
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 	vgcommon "github.com/vultisig/vultisig-go/common"
-	vgrelay "github.com/vultisig/vultisig-go/relay"
-	"github.com/vultisig/vultiserver/relay"
 
 	"github.com/vultisig/verifier/vault"
 	"github.com/vultisig/verifier/vault_config"
 )
 
 func (t *TSSService) ReshareWithDKLS(ctx context.Context, v *LocalVault, pluginID, verifierURL, authHeader, vaultPassword string) (*LocalVault, error) {
+	err := requireKeyshare(v)
+	if err != nil {
+		return nil, err
+	}
+	err = requireDKLS(v, "DKLS reshare")
+	if err != nil {
+		return nil, err
+	}
+
+	checkLocalParty(v)
+
+	if mockTSSEnabled() {
+		return mockReshare(ctx, v, pluginID)
+	}
+
+	err = guardProduction(ctx, v)
+	if err != nil {
+		return nil, err
+	}
+
 	sessionID := uuid.New().String()
 
 	encryptionKey := make([]byte, 32)
-	_, err := rand.Read(encryptionKey)
+	_, err = rand.Read(encryptionKey)
 	if err != nil {
 		return nil, fmt.Errorf("generate encryption key: %w", err)
 	}
@@ -38,6 +56,9 @@ func (t *TSSService) ReshareWithDKLS(ctx context.Context, v *LocalVault, pluginI
 		"verifier_url": verifierURL,
 	}).Info("Starting DKLS reshare session")
 
+	session := t.trackSession("reshare", v.PublicKeyECDSA, sessionID)
+	defer session.end()
+
 	err = t.relayClient.RegisterSession(sessionID, t.localPartyID)
 	if err != nil {
 		return nil, fmt.Errorf("register session: %w", err)
@@ -49,13 +70,17 @@ func (t *TSSService) ReshareWithDKLS(ctx context.Context, v *LocalVault, pluginI
 	}).Info("Registering session")
 
 	t.logger.Info("Requesting Fast Vault Server to join reshare...")
+	endPhase := t.phases.Start("Fast Vault request")
 	err = t.requestFastVaultReshare(ctx, v, sessionID, hexEncryptionKey, vaultPassword)
+	endPhase()
 	if err != nil {
 		t.logger.WithError(err).Warn("Failed to request Fast Vault Server - continuing anyway")
 	}
 
 	t.logger.Info("Requesting Verifier to join reshare (with plugin)...")
+	endPhase = t.phases.Start("Verifier request")
 	err = t.requestVerifierReshare(ctx, v, sessionID, hexEncryptionKey, pluginID, verifierURL, authHeader)
+	endPhase()
 	if err != nil {
 		return nil, fmt.Errorf("request verifier reshare: %w", err)
 	}
@@ -63,12 +88,17 @@ func (t *TSSService) ReshareWithDKLS(ctx context.Context, v *LocalVault, pluginI
 	expectedParties := len(v.Signers) + 2
 	t.logger.WithField("expected", expectedParties).Info("Waiting for all parties to join...")
 
+	endPhase = t.phases.Start("Party join")
 	parties, err := t.waitForParties(ctx, sessionID, expectedParties)
+	endPhase()
 	if err != nil {
 		return nil, fmt.Errorf("wait for parties: %w", err)
 	}
 
 	t.logger.WithField("parties", parties).Info("All parties joined, starting reshare session")
+	for _, p := range parties {
+		progressf("  Joined: %s %s\n", p, getSignerRole(p, t.localPartyID))
+	}
 
 	err = t.relayClient.StartSession(sessionID, parties)
 	if err != nil {
@@ -91,46 +121,62 @@ func (t *TSSService) ReshareWithDKLS(ctx context.Context, v *LocalVault, pluginI
 	}
 
 	t.logger.Info("Running DKLS reshare protocol (ECDSA)...")
-	ecdsaPubkey, chainCode, err := t.runReshareAsInitiator(dklsService, v, sessionID, hexEncryptionKey, parties, false)
+	endPhase = t.phases.Start("Reshare rounds (ECDSA)")
+	ecdsaShare, err := t.runReshareAsInitiator(ctx, dklsService, v, sessionID, hexEncryptionKey, parties, false)
+	endPhase()
 	if err != nil {
 		return nil, fmt.Errorf("reshare ECDSA failed: %w", err)
 	}
 
 	t.logger.Info("Running DKLS reshare protocol (EdDSA)...")
-	eddsaPubkey, _, err := t.runReshareAsInitiator(dklsService, v, sessionID, hexEncryptionKey, parties, true)
+	endPhase = t.phases.Start("Reshare rounds (EdDSA)")
+	eddsaShare, err := t.runReshareAsInitiator(ctx, dklsService, v, sessionID, hexEncryptionKey, parties, true)
+	endPhase()
 	if err != nil {
 		return nil, fmt.Errorf("reshare EdDSA failed: %w", err)
 	}
 
-	err = t.relayClient.CompleteSession(sessionID, t.localPartyID)
+	// A reshare changes the shares, never the key: a different public key
+	// means the new shares do not belong to this vault.
+	if ecdsaShare.PublicKey != v.PublicKeyECDSA || eddsaShare.PublicKey != v.PublicKeyEdDSA {
+		return nil, fmt.Errorf("reshare produced public keys %s/%s, want %s/%s", ecdsaShare.PublicKey, eddsaShare.PublicKey, v.PublicKeyECDSA, v.PublicKeyEdDSA)
+	}
+
+	err = t.waitForCompletion(ctx, sessionID, parties)
 	if err != nil {
-		t.logger.WithError(err).Warn("Failed to complete session")
+		return nil, err
 	}
+	session.complete()
 
 	t.logger.WithFields(logrus.Fields{
-		"ecdsa": ecdsaPubkey[:16] + "...",
-		"eddsa": eddsaPubkey[:16] + "...",
+		"ecdsa": ecdsaShare.PublicKey[:16] + "...",
+		"eddsa": eddsaShare.PublicKey[:16] + "...",
 	}).Info("Reshare completed successfully")
 
 	newVault := &LocalVault{
 		Name:           v.Name,
-		PublicKeyECDSA: ecdsaPubkey,
-		PublicKeyEdDSA: eddsaPubkey,
-		HexChainCode:   chainCode,
+		PublicKeyECDSA: ecdsaShare.PublicKey,
+		PublicKeyEdDSA: eddsaShare.PublicKey,
+		HexChainCode:   ecdsaShare.ChainCode,
 		LocalPartyID:   v.LocalPartyID,
 		Signers:        parties,
-		KeyShares:      v.KeyShares,
-		ResharePrefix:  sessionID[:8],
-		CreatedAt:      v.CreatedAt,
-		LibType:        v.LibType,
+		KeyShares: []KeyShare{
+			{PubKey: ecdsaShare.PublicKey, Keyshare: ecdsaShare.Keyshare},
+			{PubKey: eddsaShare.PublicKey, Keyshare: eddsaShare.Keyshare},
+		},
+		ResharePrefix: sessionID[:8],
+		CreatedAt:     v.CreatedAt,
+		LibType:       v.LibType,
+		Source:        v.Source,
+		LastUsed:      v.LastUsed,
 	}
 
 	return newVault, nil
 }
 
-func (t *TSSService) runReshareAsInitiator(dklsService *vault.DKLSTssService, v *LocalVault, sessionID, hexEncryptionKey string, parties []string, isEdDSA bool) (string, string, error) {
+func (t *TSSService) runReshareAsInitiator(ctx context.Context, dklsService *vault.DKLSTssService, v *LocalVault, sessionID, hexEncryptionKey string, parties []string, isEdDSA bool) (*keygenShare, error) {
 	mpcWrapper := dklsService.GetMPCKeygenWrapper(isEdDSA)
-	relayClient := vgrelay.NewRelayClient(RelayServer)
+	relayClient := t.newRelayClient()
 
 	publicKey := v.PublicKeyECDSA
 	if isEdDSA {
@@ -145,17 +191,17 @@ func (t *TSSService) runReshareAsInitiator(dklsService *vault.DKLSTssService, v
 		}
 	}
 	if keyshare == "" {
-		return "", "", fmt.Errorf("keyshare not found for public key: %s", publicKey[:16])
+		return nil, fmt.Errorf("keyshare not found for public key: %s", publicKey[:16])
 	}
 
 	keyshareBytes, err := base64.StdEncoding.DecodeString(keyshare)
 	if err != nil {
-		return "", "", fmt.Errorf("decode keyshare: %w", err)
+		return nil, fmt.Errorf("decode keyshare: %w", err)
 	}
 
 	keyshareHandle, err := mpcWrapper.KeyshareFromBytes(keyshareBytes)
 	if err != nil {
-		return "", "", fmt.Errorf("keyshare from bytes: %w", err)
+		return nil, fmt.Errorf("keyshare from bytes: %w", err)
 	}
 	defer func() {
 		_ = mpcWrapper.KeyshareFree(keyshareHandle)
@@ -184,13 +230,13 @@ func (t *TSSService) runReshareAsInitiator(dklsService *vault.DKLSTssService, v
 
 	setupMsg, err := mpcWrapper.QcSetupMsgNew(keyshareHandle, threshold, parties, oldPartyIndices, newPartyIndices)
 	if err != nil {
-		return "", "", fmt.Errorf("create setup message: %w", err)
+		return nil, fmt.Errorf("create setup message: %w", err)
 	}
 
 	encodedSetupMsg := base64.StdEncoding.EncodeToString(setupMsg)
 	encryptedSetupMsg, err := vgcommon.EncryptGCM(encodedSetupMsg, hexEncryptionKey)
 	if err != nil {
-		return "", "", fmt.Errorf("encrypt setup message: %w", err)
+		return nil, fmt.Errorf("encrypt setup message: %w", err)
 	}
 
 	messageID := ""
@@ -200,22 +246,24 @@ func (t *TSSService) runReshareAsInitiator(dklsService *vault.DKLSTssService, v
 
 	err = relayClient.UploadSetupMessage(sessionID, messageID, encryptedSetupMsg)
 	if err != nil {
-		return "", "", fmt.Errorf("upload setup message: %w", err)
+		return nil, fmt.Errorf("upload setup message: %w", err)
 	}
 
 	t.logger.Debug("Setup message uploaded, creating QC session")
 
 	sessionHandle, err := mpcWrapper.QcSessionFromSetup(setupMsg, t.localPartyID, keyshareHandle)
 	if err != nil {
-		return "", "", fmt.Errorf("create session from setup: %w", err)
+		return nil, fmt.Errorf("create session from setup: %w", err)
 	}
 
-	return t.processReshareProtocol(mpcWrapper, sessionHandle, sessionID, hexEncryptionKey, parties, isEdDSA)
+	return t.processReshareProtocol(ctx, mpcWrapper, sessionHandle, sessionID, hexEncryptionKey, parties, isEdDSA)
 }
 
-func (t *TSSService) processReshareProtocol(mpcWrapper *vault.MPCWrapperImp, sessionHandle vault.Handle, sessionID, hexEncryptionKey string, parties []string, isEdDSA bool) (string, string, error) {
-	messenger := relay.NewMessenger(RelayServer, sessionID, hexEncryptionKey, true, "")
-	relayClient := vgrelay.NewRelayClient(RelayServer)
+// processReshareProtocol runs the QC rounds and returns this party's new
+// share of the key.
+func (t *TSSService) processReshareProtocol(ctx context.Context, mpcWrapper *vault.MPCWrapperImp, sessionHandle vault.Handle, sessionID, hexEncryptionKey string, parties []string, isEdDSA bool) (*keygenShare, error) {
+	messenger := t.newMessenger(sessionID, hexEncryptionKey, "")
+	relayClient := t.newRelayClient()
 	var messageCache sync.Map
 
 	go func() {
@@ -251,8 +299,11 @@ func (t *TSSService) processReshareProtocol(mpcWrapper *vault.MPCWrapperImp, ses
 
 	start := time.Now()
 	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		if time.Since(start) > 2*time.Minute {
-			return "", "", fmt.Errorf("reshare timeout")
+			return nil, fmt.Errorf("reshare timeout")
 		}
 
 		messages, err := relayClient.DownloadMessages(sessionID, t.localPartyID, "")
@@ -319,17 +370,17 @@ func (t *TSSService) processReshareProtocol(mpcWrapper *vault.MPCWrapperImp, ses
 
 				result, err := mpcWrapper.QcSessionFinish(sessionHandle)
 				if err != nil {
-					return "", "", fmt.Errorf("finish session: %w", err)
+					return nil, fmt.Errorf("finish session: %w", err)
 				}
 
 				buf, err := mpcWrapper.KeyshareToBytes(result)
 				if err != nil {
-					return "", "", fmt.Errorf("keyshare to bytes: %w", err)
+					return nil, fmt.Errorf("keyshare to bytes: %w", err)
 				}
 
 				publicKeyBytes, err := mpcWrapper.KeysharePublicKey(result)
 				if err != nil {
-					return "", "", fmt.Errorf("get public key: %w", err)
+					return nil, fmt.Errorf("get public key: %w", err)
 				}
 				encodedPublicKey := hex.EncodeToString(publicKeyBytes)
 
@@ -337,7 +388,7 @@ func (t *TSSService) processReshareProtocol(mpcWrapper *vault.MPCWrapperImp, ses
 				if !isEdDSA {
 					chainCodeBytes, err := mpcWrapper.KeyshareChainCode(result)
 					if err != nil {
-						return "", "", fmt.Errorf("get chain code: %w", err)
+						return nil, fmt.Errorf("get chain code: %w", err)
 					}
 					chainCode = hex.EncodeToString(chainCodeBytes)
 				}
@@ -348,7 +399,7 @@ func (t *TSSService) processReshareProtocol(mpcWrapper *vault.MPCWrapperImp, ses
 					"share_len":  len(encodedShare),
 				}).Debug("New keyshare generated")
 
-				return encodedPublicKey, chainCode, nil
+				return &keygenShare{PublicKey: encodedPublicKey, ChainCode: chainCode, Keyshare: encodedShare}, nil
 			}
 		}
 
@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"os"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestSaveVaultFileNames(t *testing.T) {
+	const (
+		pubKey  = "024222a3ac1f41e14f0415d2a88c536e3428000ce799aa864e32303c78a565d948"
+		created = "2026-01-02T03:04:05Z"
+	)
+	unkeyed := func(name, createdAt string) *LocalVault {
+		return &LocalVault{Name: name, CreatedAt: createdAt}
+	}
+	keyed := func(name, createdAt string) *LocalVault {
+		return &LocalVault{Name: name, CreatedAt: createdAt, PublicKeyECDSA: pubKey}
+	}
+
+	tests := []struct {
+		name      string
+		saves     []*LocalVault
+		wantErr   string // of the last save
+		wantFiles []string
+	}{
+		{
+			name:      "key-less vault",
+			saves:     []*LocalVault{unkeyed("My Vault", created)},
+			wantFiles: []string{"unkeyed-My_Vault.json"},
+		},
+		{
+			name:      "keyed vault",
+			saves:     []*LocalVault{keyed("My Vault", created)},
+			wantFiles: []string{pubKey + ".json"},
+		},
+		{
+			// Keygen saves the vault before and after it has a key; the
+			// name-based file must not outlive the rename.
+			name:      "key-less vault gains a key",
+			saves:     []*LocalVault{unkeyed("My Vault", created), keyed("My Vault", created)},
+			wantFiles: []string{pubKey + ".json"},
+		},
+		{
+			name:      "saving again overwrites",
+			saves:     []*LocalVault{unkeyed("My Vault", created), unkeyed("My Vault", created)},
+			wantFiles: []string{"unkeyed-My_Vault.json"},
+		},
+		{
+			name:      "another key-less vault with the same name",
+			saves:     []*LocalVault{unkeyed("My Vault", created), unkeyed("My Vault", "2026-02-03T04:05:06Z")},
+			wantErr:   `a vault named "My Vault" without a public key already exists (created ` + created + `); pick another name`,
+			wantFiles: []string{"unkeyed-My_Vault.json"},
+		},
+		{
+			// Names that differ only in characters a file name cannot hold
+			// collide too.
+			name:      "names that map to the same file",
+			saves:     []*LocalVault{unkeyed("My Vault", created), unkeyed("My/Vault", created)},
+			wantErr:   `a vault named "My Vault" without a public key already exists`,
+			wantFiles: []string{"unkeyed-My_Vault.json"},
+		},
+		{
+			// A different vault keeps its own name-based file when another
+			// vault with the same name gains a key.
+			name:      "keyed vault leaves another key-less vault alone",
+			saves:     []*LocalVault{unkeyed("My Vault", created), keyed("My Vault", "2026-02-03T04:05:06Z")},
+			wantFiles: []string{pubKey + ".json", "unkeyed-My_Vault.json"},
+		},
+		{
+			name:    "neither key nor name",
+			saves:   []*LocalVault{unkeyed(" ", created)},
+			wantErr: "vault has neither a public key nor a name",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("HOME", t.TempDir())
+
+			var err error
+			for i, v := range tt.saves {
+				err = SaveVault(v)
+				if err != nil && i < len(tt.saves)-1 {
+					t.Fatalf("save %d: %v", i, err)
+				}
+			}
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("SaveVault: %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Errorf("SaveVault error = %v, want %q", err, tt.wantErr)
+			}
+
+			entries, err := os.ReadDir(VaultStoragePath())
+			if err != nil {
+				t.Fatal(err)
+			}
+			var files []string
+			for _, e := range entries {
+				files = append(files, e.Name())
+			}
+			sort.Strings(files)
+			if strings.Join(files, " ") != strings.Join(tt.wantFiles, " ") {
+				t.Errorf("vault files = %v, want %v", files, tt.wantFiles)
+			}
+		})
+	}
+}
+
+func TestMatchVaultFiles(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	const (
+		mainKey  = "024222a3ac1f41e14f0415d2a88c536e3428000ce799aa864e32303c78a565d948"
+		otherKey = "03d6f1a2b3c4d5e6f708192a3b4c5d6e7f8091a2b3c4d5e6f708192a3b4c5d6e7f"
+	)
+	for _, v := range []*LocalVault{
+		{Name: "Main", PublicKeyECDSA: mainKey},
+		{Name: "Other", PublicKeyECDSA: otherKey},
+		{Name: "Mainnet Test", PublicKeyECDSA: "02ffff" + mainKey[6:]},
+	} {
+		err := SaveVault(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		arg  string
+		want []string // vault names
+	}{
+		{arg: "main", want: []string{"Main"}},
+		{arg: "Mainn", want: []string{"Mainnet Test"}},
+		{arg: "024222a3", want: []string{"Main"}},
+		{arg: "024222A3AC", want: []string{"Main"}},
+		{arg: "02", want: []string{"Mainnet Test", "Main"}},
+		{arg: VaultFingerprint(otherKey), want: []string{"Other"}},
+		// Keys that hold the arg mid-string do not match it.
+		{arg: "4222a3ac1f41", want: nil},
+		{arg: "a3ac1f", want: nil},
+	}
+	for _, tt := range tests {
+		matches, err := matchVaultFiles(tt.arg)
+		if err != nil {
+			t.Fatalf("%q: %v", tt.arg, err)
+		}
+		var got []string
+		for _, m := range matches {
+			got = append(got, m.Vault.Name)
+		}
+		sort.Strings(got)
+		want := append([]string(nil), tt.want...)
+		sort.Strings(want)
+		if strings.Join(got, ", ") != strings.Join(want, ", ") {
+			t.Errorf("matchVaultFiles(%q) = %v, want %v", tt.arg, got, want)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+//go:build !windows
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/signal"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/vultisig/vultisig-go/relay"
+)
+
+// TestWaitForPartiesInterrupt checks that Ctrl-C stops a relay wait
+// promptly: main cancels the command context on SIGINT, and waitForParties
+// must return within a second rather than poll until KeygenTimeout.
+func TestWaitForPartiesInterrupt(t *testing.T) {
+	// The relay only ever knows our own party, so the wait never ends by
+	// itself.
+	relaySrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`["devctl-test"]`))
+	}))
+	defer relaySrv.Close()
+
+	logger := logrus.New()
+	logger.SetOutput(os.Stderr)
+	svc := &TSSService{localPartyID: "devctl-test", logger: logrus.NewEntry(logger), relayStats: &RelayStats{}}
+	svc.relayClient = &timedRelayClient{Client: relay.NewRelayClient(relaySrv.URL), stats: svc.relayStats, logger: svc.logger}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := svc.waitForParties(ctx, "session", 2)
+		done <- err
+	}()
+
+	time.Sleep(300 * time.Millisecond)
+	select {
+	case err := <-done:
+		t.Fatalf("waitForParties returned before the interrupt: %v", err)
+	default:
+	}
+
+	interrupted := time.Now()
+	err := syscall.Kill(os.Getpid(), syscall.SIGINT)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("waitForParties = %v, want context.Canceled", err)
+		}
+		if elapsed := time.Since(interrupted); elapsed > time.Second {
+			t.Errorf("waitForParties returned %s after SIGINT, want under 1s", elapsed)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("waitForParties still running 1s after SIGINT")
+	}
+}
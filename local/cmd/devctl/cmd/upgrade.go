@@ -0,0 +1,458 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// Version is the devctl build version, set at build time with
+//
+//	-ldflags "-X github.com/vultisig/vultisig-cluster/local/cmd/devctl/cmd.Version=v1.2.3"
+//
+// 'make local-build' sets it from git describe.
+var Version = "dev"
+
+const (
+	releasesURL   = "https://api.github.com/repos/vultisig/vultisig-cluster/releases?per_page=30"
+	goInstallPath = "github.com/vultisig/vultisig-cluster/local/cmd/devctl"
+	checksumsName = "checksums.txt"
+)
+
+// ReleaseArtifact is the devctl binary of one release for this platform.
+type ReleaseArtifact struct {
+	Version string
+	URL     string
+	SHA256  string // hex; empty when the checksum is fetched from ChecksumsURL
+	// ChecksumsURL serves sha256sum output covering the release's binaries.
+	ChecksumsURL string
+}
+
+// upgradeManifest is latest-<channel>.json served from upgrade.url. Artifacts
+// are keyed by "<goos>-<goarch>".
+type upgradeManifest struct {
+	Version   string `json:"version"`
+	Artifacts map[string]struct {
+		URL    string `json:"url"`
+		SHA256 string `json:"sha256"`
+	} `json:"artifacts"`
+}
+
+type githubRelease struct {
+	TagName    string `json:"tag_name"`
+	Draft      bool   `json:"draft"`
+	Prerelease bool   `json:"prerelease"`
+	Assets     []struct {
+		Name string `json:"name"`
+		URL  string `json:"browser_download_url"`
+	} `json:"assets"`
+}
+
+func NewUpgradeCmd() *cobra.Command {
+	var checkOnly bool
+	var channel string
+
+	cmd := &cobra.Command{
+		Use:   "upgrade",
+		Short: "Upgrade devctl to the latest release",
+		Long: `Check for a newer devctl release and replace the running binary with it.
+
+Releases come from the vultisig-cluster GitHub releases, or from upgrade.url in
+cluster.yaml when set. The stable channel follows full releases, nightly also
+follows prereleases; the default is upgrade.channel (stable).
+
+The binary for this platform (devctl-<os>-<arch>) is downloaded next to the
+current executable, its SHA-256 is checked against the release checksums, and
+it is renamed over the executable. HTTP(S)_PROXY and NO_PROXY are honored.
+
+Binaries installed with 'go install' are not replaced; reinstall them with
+go install instead.
+
+Example:
+  devctl upgrade --check-only
+  devctl upgrade --channel nightly
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runUpgrade(cmd.Context(), channel, checkOnly)
+		},
+	}
+
+	cmd.Flags().BoolVar(&checkOnly, "check-only", false, "Only report whether a newer version is available")
+	cmd.Flags().StringVar(&channel, "channel", "", "Release channel: stable or nightly (default: upgrade.channel in cluster.yaml)")
+
+	return cmd
+}
+
+func runUpgrade(ctx context.Context, channel string, checkOnly bool) error {
+	cc := clusterConfigOrDefaults()
+	if channel == "" {
+		channel = cc.Upgrade.Channel
+	}
+	if channel != "stable" && channel != "nightly" {
+		return fmt.Errorf("unknown channel %q (use stable or nightly)", channel)
+	}
+
+	current, goInstalled := buildVersion()
+	fmt.Printf("Current version: %s\n", current)
+	fmt.Printf("Channel:         %s\n", channel)
+
+	client := &http.Client{
+		Timeout:   5 * time.Minute,
+		Transport: &http.Transport{Proxy: http.ProxyFromEnvironment},
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+	defer cancel()
+
+	progressln("Checking for releases...")
+	var artifact *ReleaseArtifact
+	var err error
+	if cc.Upgrade.URL != "" {
+		artifact, err = manifestArtifact(ctx, client, cc.Upgrade.URL, channel)
+	} else {
+		artifact, err = githubArtifact(ctx, client, channel)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Latest version:  %s\n\n", artifact.Version)
+
+	if !versionNewer(artifact.Version, current) {
+		fmt.Printf("%s devctl is up to date\n", okMark())
+		return nil
+	}
+
+	if goInstalled {
+		fmt.Printf("%s devctl %s is available, but this binary was installed with go install.\n", warnMark(), artifact.Version)
+		fmt.Printf("Upgrade with: go install %s@%s\n", goInstallPath, artifact.Version)
+		return nil
+	}
+
+	if checkOnly {
+		fmt.Printf("devctl %s is available. Upgrade with: devctl upgrade --channel %s\n", artifact.Version, channel)
+		return nil
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("locate executable: %w", err)
+	}
+	exe, err = filepath.EvalSymlinks(exe)
+	if err != nil {
+		return fmt.Errorf("resolve executable: %w", err)
+	}
+
+	if artifact.SHA256 == "" {
+		artifact.SHA256, err = releaseChecksum(ctx, client, artifact)
+		if err != nil {
+			return err
+		}
+	}
+
+	progressf("Downloading %s...\n", artifact.URL)
+	err = replaceExecutable(ctx, client, exe, artifact)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s Upgraded %s to %s\n", okMark(), exe, artifact.Version)
+	return nil
+}
+
+// buildVersion returns the version devctl was built as and whether it was
+// built by 'go install' (a module version without -X ldflags).
+func buildVersion() (string, bool) {
+	if Version != "dev" {
+		return Version, false
+	}
+	info, ok := debug.ReadBuildInfo()
+	if ok && info.Main.Version != "" && info.Main.Version != "(devel)" {
+		return info.Main.Version, true
+	}
+	return Version, false
+}
+
+// artifactName is the release asset name of the devctl binary for this
+// platform.
+func artifactName() string {
+	name := fmt.Sprintf("devctl-%s-%s", runtime.GOOS, runtime.GOARCH)
+	if runtime.GOOS == "windows" {
+		name += ".exe"
+	}
+	return name
+}
+
+func fetchUpgradeJSON(ctx context.Context, client *http.Client, url string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("GET %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("GET %s: status %d: %s", url, resp.StatusCode, errorBody(body))
+	}
+
+	err = json.NewDecoder(resp.Body).Decode(v)
+	if err != nil {
+		return fmt.Errorf("decode %s: %w", url, err)
+	}
+	return nil
+}
+
+// githubArtifact picks the newest release of the channel that has a binary
+// for this platform. Nightly includes prereleases; drafts are never used.
+func githubArtifact(ctx context.Context, client *http.Client, channel string) (*ReleaseArtifact, error) {
+	var releases []githubRelease
+	err := fetchUpgradeJSON(ctx, client, releasesURL, &releases)
+	if err != nil {
+		return nil, fmt.Errorf("list releases: %w", err)
+	}
+
+	name := artifactName()
+	for _, rel := range releases {
+		if rel.Draft || (rel.Prerelease && channel != "nightly") {
+			continue
+		}
+		artifact := &ReleaseArtifact{Version: rel.TagName}
+		for _, asset := range rel.Assets {
+			switch asset.Name {
+			case name:
+				artifact.URL = asset.URL
+			case checksumsName:
+				artifact.ChecksumsURL = asset.URL
+			}
+		}
+		if artifact.URL == "" {
+			continue
+		}
+		if artifact.ChecksumsURL == "" {
+			return nil, fmt.Errorf("release %s has no %s; refusing to install an unverified binary", rel.TagName, checksumsName)
+		}
+		return artifact, nil
+	}
+
+	return nil, fmt.Errorf("no %s release has a %s binary", channel, name)
+}
+
+// manifestArtifact reads latest-<channel>.json from a configured artifact
+// base URL.
+func manifestArtifact(ctx context.Context, client *http.Client, baseURL, channel string) (*ReleaseArtifact, error) {
+	url := fmt.Sprintf("%s/latest-%s.json", strings.TrimRight(baseURL, "/"), channel)
+
+	var manifest upgradeManifest
+	err := fetchUpgradeJSON(ctx, client, url, &manifest)
+	if err != nil {
+		return nil, fmt.Errorf("read upgrade manifest: %w", err)
+	}
+
+	key := runtime.GOOS + "-" + runtime.GOARCH
+	entry, ok := manifest.Artifacts[key]
+	if !ok || entry.URL == "" {
+		return nil, fmt.Errorf("manifest %s has no %s artifact", url, key)
+	}
+	if entry.SHA256 == "" {
+		return nil, fmt.Errorf("manifest %s has no sha256 for %s; refusing to install an unverified binary", url, key)
+	}
+	return &ReleaseArtifact{Version: manifest.Version, URL: entry.URL, SHA256: entry.SHA256}, nil
+}
+
+// releaseChecksum finds the binary's SHA-256 in the release's sha256sum-style
+// checksums file.
+func releaseChecksum(ctx context.Context, client *http.Client, artifact *ReleaseArtifact) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, artifact.ChecksumsURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("create request: %w", err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("download checksums: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("download checksums: status %d", resp.StatusCode)
+	}
+
+	name := artifactName()
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && strings.TrimPrefix(fields[1], "*") == name {
+			return fields[0], nil
+		}
+	}
+	err = scanner.Err()
+	if err != nil {
+		return "", fmt.Errorf("read checksums: %w", err)
+	}
+	return "", fmt.Errorf("%s has no checksum for %s", checksumsName, name)
+}
+
+// replaceExecutable downloads the artifact into the executable's directory,
+// verifies its SHA-256 and renames it over exe, so exe is never left
+// half-written.
+func replaceExecutable(ctx context.Context, client *http.Client, exe string, artifact *ReleaseArtifact) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, artifact.URL, nil)
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("download %s: %w", artifact.URL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("download %s: status %d", artifact.URL, resp.StatusCode)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(exe), ".devctl-upgrade-*")
+	if err != nil {
+		return fmt.Errorf("create temp file next to %s: %w", exe, err)
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath)
+
+	hash := sha256.New()
+	_, err = io.Copy(io.MultiWriter(tmp, hash), resp.Body)
+	closeErr := tmp.Close()
+	if err != nil {
+		return fmt.Errorf("download %s: %w", artifact.URL, err)
+	}
+	if closeErr != nil {
+		return fmt.Errorf("write %s: %w", tmpPath, closeErr)
+	}
+
+	sum := hex.EncodeToString(hash.Sum(nil))
+	if !strings.EqualFold(sum, artifact.SHA256) {
+		return fmt.Errorf("checksum mismatch for %s: got %s, want %s", artifact.URL, sum, artifact.SHA256)
+	}
+
+	err = os.Chmod(tmpPath, 0755)
+	if err != nil {
+		return fmt.Errorf("chmod %s: %w", tmpPath, err)
+	}
+
+	// Windows cannot rename over a running executable, but it can move it
+	// aside.
+	return swapExecutable(tmpPath, exe, runtime.GOOS == "windows")
+}
+
+// swapExecutable renames newPath over exe. With moveAside, exe is first
+// renamed to exe.old, and moved back if the new binary cannot take its
+// place, so a failed upgrade never leaves exe missing.
+func swapExecutable(newPath, exe string, moveAside bool) error {
+	old := exe + ".old"
+	if moveAside {
+		_ = os.Remove(old)
+		err := os.Rename(exe, old)
+		if err != nil {
+			return fmt.Errorf("move %s aside: %w", exe, err)
+		}
+	}
+
+	err := os.Rename(newPath, exe)
+	if err == nil {
+		return nil
+	}
+	if moveAside {
+		restoreErr := os.Rename(old, exe)
+		if restoreErr != nil {
+			return fmt.Errorf("replace %s: %w; restoring it from %s also failed: %v", exe, err, old, restoreErr)
+		}
+	}
+	return fmt.Errorf("replace %s: %w", exe, err)
+}
+
+var versionPattern = regexp.MustCompile(`^v?(\d+)\.(\d+)\.(\d+)(?:-([0-9A-Za-z.-]+))?`)
+
+// describeSuffix matches the "-<commits>-g<hash>" git describe appends to
+// builds after a tag.
+var describeSuffix = regexp.MustCompile(`^\d+-g[0-9a-f]+`)
+
+// versionNewer reports whether latest is newer than current. A current
+// version that is not semver (e.g. "dev") is always older.
+func versionNewer(latest, current string) bool {
+	l := versionPattern.FindStringSubmatch(latest)
+	c := versionPattern.FindStringSubmatch(current)
+	if l == nil {
+		return false
+	}
+	if c == nil {
+		return true
+	}
+
+	for i := 1; i <= 3; i++ {
+		ln, _ := strconv.Atoi(l[i])
+		cn, _ := strconv.Atoi(c[i])
+		if ln != cn {
+			return ln > cn
+		}
+	}
+
+	// Same core version: a build past the tag is not older than it, and a
+	// release is newer than its prereleases.
+	lPre, cPre := l[4], c[4]
+	switch {
+	case describeSuffix.MatchString(cPre):
+		return false
+	case lPre == "":
+		return cPre != ""
+	case cPre == "":
+		return false
+	default:
+		return comparePrerelease(lPre, cPre) > 0
+	}
+}
+
+// comparePrerelease orders two prerelease strings by semver precedence:
+// dot-separated identifiers compare numerically when both are numeric
+// (rc.10 > rc.9) and as strings otherwise, numeric ones sort before
+// alphanumeric ones, and a longer list wins when one is a prefix of the
+// other.
+func comparePrerelease(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		an, aErr := strconv.ParseUint(as[i], 10, 64)
+		bn, bErr := strconv.ParseUint(bs[i], 10, 64)
+		switch {
+		case aErr == nil && bErr == nil:
+			if an != bn {
+				if an > bn {
+					return 1
+				}
+				return -1
+			}
+		case aErr == nil:
+			return -1
+		case bErr == nil:
+			return 1
+		default:
+			if c := strings.Compare(as[i], bs[i]); c != 0 {
+				return c
+			}
+		}
+	}
+	return len(as) - len(bs)
+}
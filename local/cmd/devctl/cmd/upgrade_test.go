@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestVersionNewer(t *testing.T) {
+	tests := []struct {
+		latest, current string
+		want            bool
+	}{
+		{"v1.2.4", "v1.2.3", true},
+		{"v1.10.0", "v1.9.9", true},
+		{"v1.2.3", "v1.2.3", false},
+		{"v1.2.3", "v1.3.0", false},
+		{"v1.2.3", "dev", true},
+		{"nightly", "v1.2.3", false},
+		{"v1.2.3", "v1.2.3-rc.1", true},
+		{"v1.2.3-rc.1", "v1.2.3", false},
+		{"v1.2.3", "v1.2.3-4-gabc1234", false},
+		{"v1.2.4", "v1.2.3-4-gabc1234", true},
+		{"v1.2.3-rc.10", "v1.2.3-rc.9", true},
+		{"v1.2.3-rc.9", "v1.2.3-rc.10", false},
+		{"v1.2.3-rc.2", "v1.2.3-rc.2", false},
+		{"v1.2.3-rc", "v1.2.3-beta", true},
+		{"v1.2.3-rc.1", "v1.2.3-rc", true},
+		{"v1.2.3-alpha", "v1.2.3-1", true},
+		{"v1.2.3-1", "v1.2.3-alpha", false},
+	}
+	for _, tt := range tests {
+		got := versionNewer(tt.latest, tt.current)
+		if got != tt.want {
+			t.Errorf("versionNewer(%q, %q) = %v, want %v", tt.latest, tt.current, got, tt.want)
+		}
+	}
+}
+
+func TestSwapExecutable(t *testing.T) {
+	for _, moveAside := range []bool{false, true} {
+		dir := t.TempDir()
+		exe := filepath.Join(dir, "devctl")
+		newPath := filepath.Join(dir, "devctl.new")
+		writeTestFile(t, exe, "old")
+		writeTestFile(t, newPath, "new")
+
+		err := swapExecutable(newPath, exe, moveAside)
+		if err != nil {
+			t.Fatalf("moveAside=%v: %v", moveAside, err)
+		}
+		if got := readTestFile(t, exe); got != "new" {
+			t.Errorf("moveAside=%v: exe holds %q, want the new binary", moveAside, got)
+		}
+	}
+
+	// When the new binary cannot be moved into place, the old one is put
+	// back rather than left at devctl.old.
+	dir := t.TempDir()
+	exe := filepath.Join(dir, "devctl")
+	writeTestFile(t, exe, "old")
+	err := swapExecutable(filepath.Join(dir, "missing"), exe, true)
+	if err == nil {
+		t.Fatal("replacing with a missing binary succeeded")
+	}
+	if got := readTestFile(t, exe); got != "old" {
+		t.Errorf("after a failed replace exe holds %q, want the old binary", got)
+	}
+	_, err = os.Stat(exe + ".old")
+	if !os.IsNotExist(err) {
+		t.Errorf("devctl.old left behind: %v", err)
+	}
+}
+
+func writeTestFile(t *testing.T, path, data string) {
+	t.Helper()
+	err := os.WriteFile(path, []byte(data), 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readTestFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
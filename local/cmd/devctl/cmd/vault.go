@@ -1,34 +1,55 @@
 package cmd
 
 import (
-	"bytes"
 	"context"
-	"crypto/rand"
+	"crypto/sha256"
 	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
+	"maps"
 	"math/big"
 	"net/http"
 	"os"
+	"path/filepath"
+	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/crypto"
-	"github.com/google/uuid"
 	"github.com/spf13/cobra"
 	"github.com/vultisig/commondata/go/vultisig/vault/v1"
 	"github.com/vultisig/vultisig-go/address"
 	"github.com/vultisig/vultisig-go/common"
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/term"
 	"google.golang.org/protobuf/proto"
+
+	"github.com/vultisig/vultisig-cluster/local/cmd/devctl/format"
 )
 
+// vaultJSON is the vault group's --json flag, short for --output json.
+var vaultJSON bool
+
+// vaultJSONCommands are the vault subcommands whose --output takes json.
+var vaultJSONCommands = map[string]bool{
+	"list":    true,
+	"info":    true,
+	"address": true,
+	"balance": true,
+	"pubkey":  true,
+	"details": true,
+	"nonce":   true,
+}
+
 func NewVaultCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "vault",
 		Short: "Vault management commands",
 	}
+	cmd.PersistentFlags().BoolVar(&vaultJSON, "json", false, "Same as --output json, on the subcommands that have it")
 
 	cmd.AddCommand(newVaultGenerateCmd())
 	cmd.AddCommand(newVaultReshareCmd())
@@ -38,15 +59,54 @@ func NewVaultCmd() *cobra.Command {
 	cmd.AddCommand(newVaultImportCmd())
 	cmd.AddCommand(newVaultExportCmd())
 	cmd.AddCommand(newVaultUseCmd())
+	cmd.AddCommand(newVaultDeleteCmd())
+	cmd.AddCommand(newVaultSetPartyIDCmd())
 	cmd.AddCommand(newVaultBalanceCmd())
 	cmd.AddCommand(newVaultAddressCmd())
+	cmd.AddCommand(newVaultPubkeyCmd())
 	cmd.AddCommand(newVaultDetailsCmd())
+	cmd.AddCommand(newVaultNonceCmd())
+	cmd.AddCommand(newVaultSendSolCmd())
+	cmd.AddCommand(newVaultSignPSBTCmd())
+
+	for _, sub := range cmd.Commands() {
+		applyVaultJSON(sub)
+	}
 
 	return cmd
 }
 
+// applyVaultJSON makes the group's --json set sub's --output to json before
+// it runs, and fails on subcommands without JSON output rather than
+// ignoring the flag.
+func applyVaultJSON(sub *cobra.Command) {
+	run := sub.RunE
+	if run == nil {
+		return
+	}
+	sub.RunE = func(cmd *cobra.Command, args []string) error {
+		if vaultJSON {
+			if !vaultJSONCommands[cmd.Name()] {
+				return fmt.Errorf("vault %s has no JSON output; --json is for vault %s", cmd.Name(), strings.Join(slices.Sorted(maps.Keys(vaultJSONCommands)), ", "))
+			}
+			output := cmd.Flags().Lookup("output")
+			if output.Changed && output.Value.String() != "json" {
+				return fmt.Errorf("--json conflicts with --output %s", output.Value)
+			}
+			err := output.Value.Set("json")
+			if err != nil {
+				return err
+			}
+		}
+		return run(cmd, args)
+	}
+}
+
 func newVaultGenerateCmd() *cobra.Command {
 	var name string
+	var email string
+	var backupPassword string
+	var extraParties int
 	var dryRun bool
 
 	cmd := &cobra.Command{
@@ -59,18 +119,28 @@ This creates a vault where:
   - Party 2: Fast Vault Server (production Vultisig server)
 
 The vault uses DKLS threshold signatures with the production relay server.
+The Fast Vault Server emails an encrypted backup of its share to --email,
+protected by --backup-password.
+
+Use --parties N to add N extra local parties to the same keygen (e.g.
+--parties 1 gives a 2-of-3 with two local shares). Their shares are saved
+under ~/.vultisig/vaults/parties/ tagged with their party IDs and can be
+used with 'vault keysign --parties'.
 
 After generation, use 'vault reshare' to add verifier and plugins.
 `,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if dryRun {
-				return runVaultGenerateDryRun(name)
+				return runVaultGenerateDryRun(name, email, extraParties)
 			}
-			return runVaultGenerate(name)
+			return runVaultGenerate(cmd.Context(), name, email, backupPassword, extraParties)
 		},
 	}
 
 	cmd.Flags().StringVarP(&name, "name", "n", "DevVault", "Name for the vault")
+	cmd.Flags().StringVar(&email, "email", "", "Email address the Fast Vault Server sends the encrypted backup to (prompted if omitted)")
+	cmd.Flags().StringVar(&backupPassword, "backup-password", "", "Password used to encrypt the server-side backup (prompted if omitted)")
+	cmd.Flags().IntVar(&extraParties, "parties", 0, "Number of additional local parties to include in keygen")
 	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would be done without executing")
 
 	return cmd
@@ -96,7 +166,7 @@ Example:
   devctl vault reshare --plugin vultisig-fees-feee --verifier http://localhost:8080 --password "your-password"
 `,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runVaultReshare(pluginID, verifierURL, password)
+			return runVaultReshare(cmd.Context(), pluginID, verifierURL, password)
 		},
 	}
 
@@ -113,6 +183,10 @@ func newVaultKeysignCmd() *cobra.Command {
 	var derivePath string
 	var isEdDSA bool
 	var vaultPassword string
+	var parties []string
+	var file string
+	var hashAlgo string
+	var messagesFile string
 
 	cmd := &cobra.Command{
 		Use:   "keysign",
@@ -125,59 +199,177 @@ The message should be hex-encoded (the hash to sign).
 For ECDSA signing (default), provide a derive path like "m/44'/60'/0'/0/0" for Ethereum.
 For EdDSA signing, use --eddsa flag (no derive path needed).
 
+Instead of --message, --file signs the digest of a file, computed locally with
+--hash sha256 (default), keccak256, blake2b-256 or none. With none the file
+must already hold the 32-byte digest, raw or hex-encoded. The digest must be
+exactly 32 bytes; it is printed along with the signature.
+
+--messages-file signs a batch in one relay session: one hex hash per line
+(optional 0x; blank lines and lines starting with # are skipped). Every line
+is checked before the session starts: 32 bytes for ECDSA, non-empty for
+EdDSA. Results are printed per message, numbered in file order.
+
 Example:
   # Sign an Ethereum transaction hash (ECDSA)
   devctl vault keysign --message "abcd1234..." --derive "m/44'/60'/0'/0/0" --password "vault-password"
 
   # Sign a Solana message (EdDSA)
   devctl vault keysign --message "abcd1234..." --eddsa --password "vault-password"
+
+  # Sign with two local shares from 'vault generate --parties 1' (no Fast Vault Server)
+  devctl vault keysign --message "abcd1234..." --parties devctl-1a2b3c4d,devctl-1a2b3c4d-p2
+
+  # Sign the SHA-256 digest of a file
+  devctl vault keysign --file payload.bin --hash sha256 --password "vault-password"
+
+  # Sign several transaction hashes in one session
+  devctl vault keysign --messages-file hashes.txt --password "vault-password"
 `,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runVaultKeysign(message, derivePath, isEdDSA, vaultPassword)
+			sources := 0
+			for _, set := range []bool{message != "", file != "", messagesFile != ""} {
+				if set {
+					sources++
+				}
+			}
+			if sources != 1 {
+				return fmt.Errorf("exactly one of --message, --file or --messages-file is required")
+			}
+			messages := []string{message}
+			if file != "" {
+				digest, err := fileDigest(file, hashAlgo)
+				if err != nil {
+					return err
+				}
+				messages = []string{hex.EncodeToString(digest)}
+				fmt.Printf("File: %s\n", file)
+				fmt.Printf("Digest (%s): %s\n\n", hashAlgo, messages[0])
+			}
+			if messagesFile != "" {
+				var err error
+				messages, err = readMessagesFile(messagesFile, isEdDSA)
+				if err != nil {
+					return err
+				}
+			}
+			if len(parties) > 0 {
+				if isEdDSA {
+					return fmt.Errorf("--parties does not support EdDSA signing yet")
+				}
+				return runVaultKeysignLocalParties(cmd.Context(), messages, derivePath, parties)
+			}
+			if vaultPassword == "" {
+				return fmt.Errorf("--password is required when signing with the Fast Vault Server")
+			}
+			return runVaultKeysign(cmd.Context(), messages, derivePath, isEdDSA, vaultPassword)
 		},
 	}
 
-	cmd.Flags().StringVarP(&message, "message", "m", "", "Hex-encoded message hash to sign (required)")
+	cmd.Flags().StringVarP(&message, "message", "m", "", "Hex-encoded message hash to sign")
+	cmd.Flags().StringVar(&file, "file", "", "Sign the digest of this file instead of --message")
+	cmd.Flags().StringVar(&hashAlgo, "hash", "sha256", "Digest for --file: sha256, keccak256, blake2b-256 or none")
+	cmd.Flags().StringVar(&messagesFile, "messages-file", "", "Sign every hex hash in this file (one per line) in one session")
 	cmd.Flags().StringVarP(&derivePath, "derive", "d", "m/44'/60'/0'/0/0", "BIP44 derivation path (for ECDSA)")
 	cmd.Flags().BoolVar(&isEdDSA, "eddsa", false, "Use EdDSA signing (for Solana, etc.)")
-	cmd.Flags().StringVarP(&vaultPassword, "password", "p", "", "Fast Vault password (required)")
-	cmd.MarkFlagRequired("message")
-	cmd.MarkFlagRequired("password")
+	cmd.Flags().StringVarP(&vaultPassword, "password", "p", "", "Fast Vault password (required unless --parties is set)")
+	cmd.Flags().StringSliceVar(&parties, "parties", nil, "Local party IDs to sign with instead of the Fast Vault Server (comma-separated)")
 
 	return cmd
 }
 
 func newVaultInfoCmd() *cobra.Command {
-	return &cobra.Command{
+	var output string
+
+	cmd := &cobra.Command{
 		Use:   "info",
 		Short: "Show current vault information",
+		Long: `Show the active vault's keys, signers and keyshare summary.
+
+The fingerprint (e.g. ab12-cd34) is the first 4 bytes of SHA-256 over the
+hex-decoded ECDSA public key, written as hex. It is short enough to compare
+by eye across machines, and is accepted anywhere a public key prefix is
+(e.g. 'devctl vault use ab12-cd34').
+
+--output json (or --json) prints the vault as one object with the fields of
+'vault list --output json'. Without an active vault it exits non-zero.
+`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runVaultInfo()
+			switch output {
+			case "text":
+				return runVaultInfo()
+			case "json":
+				return runVaultInfoJSON()
+			default:
+				return fmt.Errorf("unknown output format %q (use text or json)", output)
+			}
 		},
 	}
+
+	cmd.Flags().StringVarP(&output, "output", "o", "text", "Output format: text or json")
+
+	return cmd
 }
 
 func newVaultListCmd() *cobra.Command {
-	return &cobra.Command{
+	var output string
+	var verbose bool
+
+	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List all local vaults",
+		Long: `List all local vaults.
+
+--output json (or --json) prints the full non-secret metadata of each vault
+(public keys, chain code, party ID, signers, lib type, file path, ...) as an
+array. --verbose adds the derived Ethereum and Solana addresses to the table.
+Keyshares are never shown.
+
+Examples:
+  devctl vault list
+  devctl vault list --verbose
+  devctl vault list --output json | jq '.[].public_key_ecdsa'
+`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runVaultList()
+			switch output {
+			case "table":
+				return runVaultList(verbose)
+			case "json":
+				return runVaultListJSON()
+			default:
+				return fmt.Errorf("unknown output format %q (use table or json)", output)
+			}
 		},
 	}
+
+	cmd.Flags().StringVarP(&output, "output", "o", "table", "Output format: table or json")
+	cmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "Include derived Ethereum and Solana addresses")
+
+	return cmd
 }
 
 func newVaultImportCmd() *cobra.Command {
 	var file string
+	var qrImages []string
 	var password string
 	var force bool
+	var yes bool
+	var skipFastVaultCheck bool
+	var watchOnly bool
+	var name, pubkeyECDSA, pubkeyEdDSA, chainCode string
 
 	cmd := &cobra.Command{
 		Use:   "import",
 		Short: "Import a vault from file",
-		Long: `Import an existing vault share from a .vult backup file.
+		Long: `Import an existing vault share from a backup file.
 
 The file should be a vault backup exported from the Vultisig mobile app or extension.
+Supported formats (detected automatically):
+  .vult            - protobuf backup from any app
+  .bak             - Android backup (JSON, optionally hex/base64 encoded and encrypted)
+  extension JSON   - browser extension export ("publicKeys"/"keyShares" fields)
+  iOS backup JSON  - {"version": ..., "vault": {...}}
+  vault JSON       - the format written by 'devctl vault export'
+
 If the vault is encrypted, you will be prompted for the password interactively,
 or you can provide it with --password (be careful with special characters in shells).
 
@@ -185,20 +377,69 @@ Environment variables (override flags):
   VAULT_PATH      - Path to vault file
   VAULT_PASSWORD  - Decryption password
 
+Instead of a file, --qr-image reads the backup QR shown by the mobile app from
+a PNG or JPEG photo. Repeat the flag for multi-part QR sequences; the payloads
+are joined in the order given and then detected like a file.
+
 Use --force to overwrite any existing vault (useful after plugin uninstall).
 
+The backup's absolute path, size and SHA-256 (over the file as read, still
+encrypted) are saved with the vault and shown by 'vault info'. Importing a
+file whose checksum matches an already-imported vault does nothing unless
+--force is given.
+
+--watch-only imports a vault whose keyshare lives elsewhere, from its public
+keys and chain code or from a 'vault export --public-only' file. Addresses,
+balances, details and policy list/info (with VCLI_AUTH_TOKEN set to a token
+for the vault) work; signing, plugin install and reshare fail with
+"watch-only vault: keyshare required". No password is asked for.
+
+After saving, the vault is looked up on the Fast Vault Server reshare uses
+(the vultiserver in cluster.yaml, production by default; with a local
+vultiserver, production is checked too) and, if found and a password is
+given, logged in with the verifier. --skip-fastvault-check skips both, for
+offline imports.
+
 Example:
   devctl vault import --file ~/Downloads/MyVault.vult
   devctl vault import --file ~/Downloads/MyVault.vult --password "your-password"
+  devctl vault import --file ~/Downloads/MyVault.bak --password "your-password"
+  devctl vault import --qr-image part1.png --qr-image part2.png
   VAULT_PATH=/path/to/vault.vult VAULT_PASSWORD=secret devctl vault import --force
+  devctl vault import --watch-only --pubkey-ecdsa 02ab... --pubkey-eddsa 5c1f... --chaincode 8e3d...
+  devctl vault import --watch-only --file my-vault.public.json
 `,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			actualFile := file
 			if envPath := os.Getenv("VAULT_PATH"); envPath != "" {
 				actualFile = envPath
 			}
-			if actualFile == "" {
-				return fmt.Errorf("vault file required: use --file or set VAULT_PATH")
+			if watchOnly {
+				var data []byte
+				if actualFile != "" {
+					var err error
+					data, err = os.ReadFile(actualFile)
+					if err != nil {
+						return fmt.Errorf("read file: %w", err)
+					}
+				}
+				return runVaultImportWatchOnly(actualFile, data, name, pubkeyECDSA, pubkeyEdDSA, chainCode, force)
+			}
+			if pubkeyECDSA != "" || pubkeyEdDSA != "" || chainCode != "" {
+				return fmt.Errorf("--pubkey-ecdsa, --pubkey-eddsa and --chaincode need --watch-only")
+			}
+			if actualFile != "" && len(qrImages) > 0 {
+				return fmt.Errorf("use either --file or --qr-image, not both")
+			}
+			if actualFile == "" && len(qrImages) == 0 {
+				return fmt.Errorf("vault file required: use --file, --qr-image or set VAULT_PATH")
+			}
+
+			if force {
+				err := confirmVaultOverwrite(yes)
+				if err != nil {
+					return err
+				}
 			}
 
 			actualPassword := password
@@ -212,72 +453,176 @@ Example:
 					return err
 				}
 			}
-			return runVaultImport(actualFile, actualPassword, force)
+			return runVaultImport(cmd.Context(), actualFile, qrImages, actualPassword, force, skipFastVaultCheck)
 		},
 	}
 
 	cmd.Flags().StringVarP(&file, "file", "f", "", "Vault file to import (or set VAULT_PATH env var)")
+	cmd.Flags().StringArrayVar(&qrImages, "qr-image", nil, "PNG/JPEG of a backup QR code (repeat in order for multi-part QRs)")
 	cmd.Flags().StringVarP(&password, "password", "p", "", "Decryption password (or set VAULT_PASSWORD env var)")
 	cmd.Flags().BoolVar(&force, "force", false, "Overwrite existing vault")
+	cmd.Flags().BoolVar(&skipFastVaultCheck, "skip-fastvault-check", false, "Skip the Fast Vault Server lookup and verifier login (offline import)")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Skip the --force confirmation prompt")
+	cmd.Flags().BoolVar(&watchOnly, "watch-only", false, "Import public keys only (no keyshare); signing commands are refused")
+	cmd.Flags().StringVar(&pubkeyECDSA, "pubkey-ecdsa", "", "Watch-only: compressed ECDSA public key (hex)")
+	cmd.Flags().StringVar(&pubkeyEdDSA, "pubkey-eddsa", "", "Watch-only: EdDSA public key (hex)")
+	cmd.Flags().StringVar(&chainCode, "chaincode", "", "Watch-only: hex chain code")
+	cmd.Flags().StringVar(&name, "name", "", "Watch-only: vault name (default: watch-<fingerprint>)")
 
 	return cmd
 }
 
 func newVaultExportCmd() *cobra.Command {
 	var output string
+	var publicOnly bool
+	var exportFormat string
+	var password string
 
 	cmd := &cobra.Command{
 		Use:   "export",
 		Short: "Export current vault to file",
+		Long: `Export the current vault, keyshares included, to a file.
+
+--format json (the default) writes devctl's own vault JSON. --format vult
+writes a .vult backup the mobile apps and the extension can import: the
+vault protobuf in a base64-encoded container, encrypted with --password if
+one is given. An --output ending in .vult selects the vult format. Either
+file round-trips through 'vault import'.
+
+--public-only writes only the non-secret fields instead: name, public keys,
+chain code, signers, lib type and the derived address on each chain, plus
+the devctl version and when the file was generated. Such a file is safe to
+attach to a bug report; 'vault import' refuses it.
+
+Example:
+  devctl vault export --output my-vault.json
+  devctl vault export --output my-vault.vult --password <password>
+  devctl vault export --public-only
+`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runVaultExport(output)
+			if publicOnly {
+				return runVaultExportPublic(output)
+			}
+			if exportFormat == "" {
+				exportFormat = "json"
+				if strings.EqualFold(filepath.Ext(output), ".vult") {
+					exportFormat = "vult"
+				}
+			}
+			return runVaultExport(output, exportFormat, password)
 		},
 	}
 
 	cmd.Flags().StringVarP(&output, "output", "o", "", "Output file path")
+	cmd.Flags().BoolVar(&publicOnly, "public-only", false, "Export only public keys, chain code, signers and addresses (no keyshares)")
+	cmd.Flags().StringVar(&exportFormat, "format", "", "Output format: json or vult (default: vult for a .vult --output, else json)")
+	cmd.Flags().StringVar(&password, "password", "", "Encrypt a vult export with this password")
 
 	return cmd
 }
 
 func newVaultUseCmd() *cobra.Command {
 	return &cobra.Command{
-		Use:   "use [public-key-prefix]",
+		Use:   "use [name|public-key-prefix|fingerprint]",
 		Short: "Set active vault",
-		Args:  cobra.ExactArgs(1),
+		Long: `Set the active vault.
+
+The argument is matched case-insensitively against vault names first, then
+as a prefix of a name or public key, then as a fingerprint; 'vault list'
+shows all three. An argument that matches more than one vault is refused
+and the candidates are listed.
+
+Example:
+  devctl vault use DevVault
+  devctl vault use 02a1b2c3
+  devctl vault use ab12-cd34
+`,
+		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runVaultUse(args[0])
 		},
 	}
 }
 
-func runVaultGenerate(name string) error {
-	fmt.Println("=== Vault Generation ===")
-	fmt.Printf("Name: %s\n", name)
-	fmt.Printf("Relay Server: %s\n", RelayServer)
-	fmt.Printf("Fast Vault Server: %s\n", FastVaultServer)
-	fmt.Println()
+func runVaultGenerate(ctx context.Context, name, email, backupPassword string, extraParties int) error {
+	if extraParties < 0 {
+		return fmt.Errorf("--parties must not be negative")
+	}
 
-	localPartyID := fmt.Sprintf("%s-%s", DefaultLocalParty, uuid.New().String()[:8])
+	email, err := promptString(email, "Backup email: ")
+	if err != nil {
+		return fmt.Errorf("email required for Fast Vault backup: %w", err)
+	}
+	if !strings.Contains(email, "@") {
+		return fmt.Errorf("invalid email address: %s", email)
+	}
 
-	fmt.Printf("Local Party ID: %s\n", localPartyID)
-	fmt.Println()
-	fmt.Println("Starting TSS keygen with Fast Vault Server...")
-	fmt.Println()
+	if backupPassword == "" {
+		if !term.IsTerminal(int(os.Stdin.Fd())) {
+			return fmt.Errorf("backup password required but stdin is not a terminal. Use --backup-password flag")
+		}
+		progressln("Choose a password for the Fast Vault backup:")
+		backupPassword, err = promptPasswordWithConfirm("")
+		if err != nil {
+			return fmt.Errorf("backup password: %w", err)
+		}
+	}
+	if backupPassword == "" {
+		return fmt.Errorf("backup password cannot be empty")
+	}
+
+	progressln("=== Vault Generation ===")
+	progressf("Name: %s\n", name)
+	progressf("Backup Email: %s\n", email)
+	progressf("Relay Server: %s\n", RelayServer)
+	progressf("Fast Vault Server: %s\n", FastVaultServer)
+	printTraceID()
+	progressln()
+
+	localPartyID, err := newLocalPartyID()
+	if err != nil {
+		return err
+	}
+
+	progressf("Local Party ID: %s\n", localPartyID)
+	if extraParties > 0 {
+		progressf("Extra Local Parties: %d\n", extraParties)
+	}
+	progressln()
+	progressln("Starting TSS keygen with Fast Vault Server...")
+	progressln()
 
-	ctx, cancel := context.WithTimeout(context.Background(), KeygenTimeout)
+	ctx, cancel := context.WithTimeout(ctx, KeygenTimeout)
 	defer cancel()
 
 	tss := NewTSSService(localPartyID)
-	vault, err := tss.KeygenWithDKLS(ctx, name)
+	vaults, err := tss.KeygenWithDKLS(ctx, name, email, backupPassword, extraParties)
 	if err != nil {
 		return fmt.Errorf("keygen failed: %w", err)
 	}
+	vault := vaults[0]
 
 	err = SaveVault(vault)
 	if err != nil {
 		return fmt.Errorf("save vault: %w", err)
 	}
 
+	for _, partyVault := range vaults[1:] {
+		err = SavePartyVault(partyVault)
+		if err != nil {
+			return fmt.Errorf("save share for party %s: %w", partyVault.LocalPartyID, err)
+		}
+	}
+
+	progressln()
+	progressln("Verifying Fast Vault Server stored the backup...")
+	backupErr := WaitForFastVaultBackup(ctx, vault.PublicKeyECDSA, backupPassword, 30*time.Second)
+	if backupErr != nil {
+		progressf("  %s Could not confirm backup: %v\n", warnMark(), backupErr)
+	} else {
+		progressf("  Backup stored, email scheduled to %s\n", email)
+	}
+
 	cfg, _ := LoadConfig()
 	cfg.VaultName = vault.Name
 	cfg.PublicKeyECDSA = vault.PublicKeyECDSA
@@ -291,6 +636,12 @@ func runVaultGenerate(name string) error {
 	fmt.Printf("Public Key (EdDSA): %s\n", vault.PublicKeyEdDSA)
 	fmt.Printf("Signers: %v\n", vault.Signers)
 	fmt.Printf("Saved to: %s\n", VaultStoragePath())
+	if len(vaults) > 1 {
+		fmt.Printf("Local party shares (%s):\n", PartyVaultStoragePath())
+		for _, v := range vaults {
+			fmt.Printf("  - %s\n", v.LocalPartyID)
+		}
+	}
 	fmt.Println()
 	fmt.Println("Next steps:")
 	fmt.Println("  1. devctl vault reshare --plugin vultisig-fees-feee  # Add fee plugin")
@@ -299,11 +650,17 @@ func runVaultGenerate(name string) error {
 	return nil
 }
 
-func runVaultGenerateDryRun(name string) error {
+func runVaultGenerateDryRun(name, email string, extraParties int) error {
+	if email == "" {
+		email = "<prompted>"
+	}
+
 	fmt.Println("=== Vault Generation (Dry Run) ===")
 	fmt.Printf("Name: %s\n", name)
+	fmt.Printf("Backup Email: %s\n", email)
 	fmt.Printf("Relay Server: %s\n", RelayServer)
 	fmt.Printf("Fast Vault Server: %s\n", FastVaultServer)
+	fmt.Printf("Parties: %d local + Fast Vault Server (threshold %d)\n", extraParties+1, keygenThreshold(extraParties+2))
 	fmt.Println()
 	fmt.Println("Would perform:")
 	fmt.Println("  1. Generate session ID and encryption keys")
@@ -312,18 +669,27 @@ func runVaultGenerateDryRun(name string) error {
 	fmt.Println("  4. Run DKLS keygen protocol for ECDSA")
 	fmt.Println("  5. Run DKLS keygen protocol for EdDSA")
 	fmt.Println("  6. Save vault to ~/.vultisig/vaults/")
+	if extraParties > 0 {
+		fmt.Printf("     and %d extra local share(s) to ~/.vultisig/vaults/parties/\n", extraParties)
+	}
+	fmt.Println("  7. Verify Fast Vault Server stored the backup and scheduled the email")
 	fmt.Println()
 	fmt.Println("Run without --dry-run to execute.")
 
 	return nil
 }
 
-func runVaultReshare(pluginID string, verifierURL string, password string) error {
+func runVaultReshare(ctx context.Context, pluginID string, verifierURL string, password string) error {
 	cfg, err := LoadConfig()
 	if err != nil {
 		return fmt.Errorf("load config: %w", err)
 	}
 
+	err = preflight(ctx, verifierURL, "")
+	if err != nil {
+		return err
+	}
+
 	if cfg.PublicKeyECDSA == "" {
 		return fmt.Errorf("no vault configured. Run 'devctl vault import' first")
 	}
@@ -333,33 +699,34 @@ func runVaultReshare(pluginID string, verifierURL string, password string) error
 		return fmt.Errorf("load vault: %w", err)
 	}
 
-	fmt.Println("=== Vault Reshare ===")
-	fmt.Printf("Vault: %s\n", vault.Name)
+	progressln("=== Vault Reshare ===")
+	progressf("Vault: %s\n", vault.Name)
 	if len(vault.PublicKeyECDSA) >= 32 {
-		fmt.Printf("Public Key: %s...\n", vault.PublicKeyECDSA[:32])
+		progressf("Public Key: %s...\n", vault.PublicKeyECDSA[:32])
 	}
-	fmt.Printf("Current Signers: %v\n", vault.Signers)
-	fmt.Printf("Plugin: %s\n", pluginID)
-	fmt.Printf("Verifier: %s\n", verifierURL)
-	fmt.Println()
+	progressf("Current Signers: %v\n", vault.Signers)
+	progressf("Plugin: %s\n", pluginID)
+	progressf("Verifier: %s\n", verifierURL)
+	printTraceID()
+	progressln()
 
-	fmt.Println("This will reshare your vault to add:")
-	fmt.Println("  - Verifier worker")
-	fmt.Printf("  - Plugin: %s\n", pluginID)
-	fmt.Println()
-	fmt.Println("Starting TSS reshare...")
+	progressln("This will reshare your vault to add:")
+	progressln("  - Verifier worker")
+	progressf("  - Plugin: %s\n", pluginID)
+	progressln()
+	progressln("Starting TSS reshare...")
 
 	authHeader, err := GetAuthHeader()
 	if err != nil {
-		fmt.Println("Warning: Not authenticated. Reshare may require authentication.")
+		progressf("%s Not authenticated. Reshare may require authentication.\n", warnMark())
 		authHeader = ""
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Minute)
 	defer cancel()
 
 	tss := NewTSSService(vault.LocalPartyID)
-	newVault, err := tss.Reshare(ctx, vault, pluginID, verifierURL, authHeader, password)
+	newVault, err := tss.ReshareWithDKLS(ctx, vault, pluginID, verifierURL, authHeader, password)
 	if err != nil {
 		return fmt.Errorf("reshare failed: %w", err)
 	}
@@ -376,7 +743,95 @@ func runVaultReshare(pluginID string, verifierURL string, password string) error
 	return nil
 }
 
-func runVaultKeysign(message, derivePath string, isEdDSA bool, vaultPassword string) error {
+// fileDigest hashes the file at path with algo. "none" takes the file as the
+// digest itself, raw or hex-encoded. Every digest must be 32 bytes, the size
+// the keysign protocol signs.
+func fileDigest(path, algo string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read file: %w", err)
+	}
+
+	var digest []byte
+	switch strings.ToLower(algo) {
+	case "sha256":
+		sum := sha256.Sum256(data)
+		digest = sum[:]
+	case "keccak256":
+		digest = crypto.Keccak256(data)
+	case "blake2b-256":
+		sum := blake2b.Sum256(data)
+		digest = sum[:]
+	case "none":
+		digest = data
+		decoded, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(string(data)), "0x"))
+		if err == nil {
+			digest = decoded
+		}
+	default:
+		return nil, fmt.Errorf("unknown --hash %q (use sha256, keccak256, blake2b-256 or none)", algo)
+	}
+
+	if len(digest) != 32 {
+		return nil, fmt.Errorf("digest must be exactly 32 bytes, got %d", len(digest))
+	}
+	return digest, nil
+}
+
+// readMessagesFile reads the hex messages of --messages-file, one per line,
+// skipping blank lines and # comments. Every message must be valid hex, and
+// 32 bytes for ECDSA; errors name the offending line.
+func readMessagesFile(path string, isEdDSA bool) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read messages file: %w", err)
+	}
+
+	var messages []string
+	for i, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		msg := strings.TrimPrefix(strings.TrimPrefix(line, "0x"), "0X")
+		b, err := hex.DecodeString(msg)
+		switch {
+		case err != nil:
+			return nil, fmt.Errorf("%s line %d: %q is not valid hex", path, i+1, line)
+		case len(b) == 0:
+			return nil, fmt.Errorf("%s line %d: empty message", path, i+1)
+		case !isEdDSA && len(b) != 32:
+			return nil, fmt.Errorf("%s line %d: ECDSA hashes must be 32 bytes, got %d", path, i+1, len(b))
+		}
+		messages = append(messages, strings.ToLower(msg))
+	}
+	if len(messages) == 0 {
+		return nil, fmt.Errorf("%s holds no messages", path)
+	}
+	return messages, nil
+}
+
+// keysignTimeout is the budget of a vault keysign session: three minutes,
+// plus one for every message after the first, since the rounds run per
+// message.
+func keysignTimeout(messages int) time.Duration {
+	return 3*time.Minute + time.Duration(messages-1)*time.Minute
+}
+
+// printMessages prints the message being signed, or the numbered batch, to
+// stderr with the rest of the keysign header.
+func printMessages(messages []string) {
+	if len(messages) == 1 {
+		progressf("Message: %s\n", messages[0])
+		return
+	}
+	progressf("Messages: %d\n", len(messages))
+	for i, m := range messages {
+		progressf("  %d. %s\n", i+1, m)
+	}
+}
+
+func runVaultKeysign(ctx context.Context, messages []string, derivePath string, isEdDSA bool, vaultPassword string) error {
 	cfg, err := LoadConfig()
 	if err != nil {
 		return fmt.Errorf("load config: %w", err)
@@ -397,25 +852,88 @@ func runVaultKeysign(message, derivePath string, isEdDSA bool, vaultPassword str
 		derivePath = ""
 	}
 
-	fmt.Println("=== Vault Keysign ===")
-	fmt.Printf("Vault: %s\n", vault.Name)
+	progressln("=== Vault Keysign ===")
+	progressf("Vault: %s\n", vault.Name)
 	if len(publicKey) >= 32 {
-		fmt.Printf("Public Key: %s...\n", publicKey[:32])
+		progressf("Public Key: %s...\n", publicKey[:32])
 	}
-	fmt.Printf("Message: %s\n", message)
+	printMessages(messages)
 	if !isEdDSA {
-		fmt.Printf("Derive Path: %s\n", derivePath)
+		progressf("Derive Path: %s\n", derivePath)
 	}
-	fmt.Printf("Signature Type: %s\n", map[bool]string{true: "EdDSA", false: "ECDSA"}[isEdDSA])
-	fmt.Println()
+	progressf("Signature Type: %s\n", map[bool]string{true: "EdDSA", false: "ECDSA"}[isEdDSA])
+	printTraceID()
+	progressln()
 
-	fmt.Println("Starting TSS keysign with Fast Vault Server...")
+	progressln("Starting TSS keysign with Fast Vault Server...")
 
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Minute)
+	ctx, cancel := context.WithTimeout(ctx, keysignTimeout(len(messages)))
 	defer cancel()
 
 	tss := NewTSSService(vault.LocalPartyID)
-	results, err := tss.Keysign(ctx, vault, []string{message}, derivePath, isEdDSA, vaultPassword)
+	results, err := tss.Keysign(ctx, vault, messages, derivePath, isEdDSA, vaultPassword)
+	if err != nil {
+		return fmt.Errorf("keysign failed: %w", err)
+	}
+
+	fmt.Println()
+	fmt.Println("=== Keysign Result ===")
+	for i, result := range results {
+		fmt.Printf("Message %d: %s\n", i+1, messages[i])
+		fmt.Printf("  R: %s\n", result.R)
+		fmt.Printf("  S: %s\n", result.S)
+		if isEdDSA {
+			fmt.Printf("  Signature: %s\n", result.DerSignature)
+			continue
+		}
+		fmt.Printf("  Recovery ID: %s\n", result.RecoveryID)
+		fmt.Printf("  DER Signature: %s\n", result.DerSignature)
+	}
+
+	return nil
+}
+
+func runVaultKeysignLocalParties(ctx context.Context, messages []string, derivePath string, parties []string) error {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	if cfg.PublicKeyECDSA == "" {
+		return fmt.Errorf("no vault configured. Run 'devctl vault import' first")
+	}
+
+	primary, err := LoadVault(cfg.PublicKeyECDSA[:16])
+	if err != nil {
+		return fmt.Errorf("load vault: %w", err)
+	}
+
+	vaults := make([]*LocalVault, 0, len(parties))
+	for _, partyID := range parties {
+		if partyID == primary.LocalPartyID {
+			vaults = append(vaults, primary)
+			continue
+		}
+		v, err := LoadPartyVault(primary.PublicKeyECDSA, partyID)
+		if err != nil {
+			return fmt.Errorf("load share for party %s: %w", partyID, err)
+		}
+		vaults = append(vaults, v)
+	}
+
+	progressln("=== Vault Keysign (Local Parties) ===")
+	progressf("Vault: %s\n", primary.Name)
+	progressf("Parties: %v\n", parties)
+	printMessages(messages)
+	progressf("Derive Path: %s\n", derivePath)
+	printTraceID()
+	progressln()
+
+	ctx, cancel := context.WithTimeout(ctx, keysignTimeout(len(messages)))
+	defer cancel()
+
+	tss := NewTSSService(vaults[0].LocalPartyID)
+	results, err := tss.KeysignWithLocalParties(ctx, vaults, messages, derivePath)
 	if err != nil {
 		return fmt.Errorf("keysign failed: %w", err)
 	}
@@ -423,7 +941,7 @@ func runVaultKeysign(message, derivePath string, isEdDSA bool, vaultPassword str
 	fmt.Println()
 	fmt.Println("=== Keysign Result ===")
 	for i, result := range results {
-		fmt.Printf("Message %d:\n", i+1)
+		fmt.Printf("Message %d: %s\n", i+1, messages[i])
 		fmt.Printf("  R: %s\n", result.R)
 		fmt.Printf("  S: %s\n", result.S)
 		fmt.Printf("  Recovery ID: %s\n", result.RecoveryID)
@@ -455,6 +973,7 @@ func runVaultInfo() error {
 	vault, err := LoadVault(cfg.PublicKeyECDSA[:16])
 	if err != nil {
 		fmt.Printf("Name: %s\n", cfg.VaultName)
+		fmt.Printf("Fingerprint: %s\n", VaultFingerprint(cfg.PublicKeyECDSA))
 		fmt.Printf("Public Key (ECDSA): %s\n", cfg.PublicKeyECDSA)
 		fmt.Printf("Public Key (EdDSA): %s\n", cfg.PublicKeyEdDSA)
 		fmt.Println()
@@ -463,26 +982,38 @@ func runVaultInfo() error {
 	}
 
 	fmt.Printf("Name: %s\n", vault.Name)
+	if vault.WatchOnly {
+		fmt.Printf("Mode: watch-only %s no keyshare; signing, plugin install and reshare are refused\n", warnMark())
+	} else if slices.Contains(vault.Signers, vault.LocalPartyID) {
+		fmt.Printf("Local Party ID: %s %s\n", vault.LocalPartyID, okMark())
+	} else {
+		fmt.Printf("Local Party ID: %s %s not among the signers; see 'devctl vault set-party-id'\n", vault.LocalPartyID, failMark())
+	}
+	fmt.Printf("Fingerprint: %s\n", VaultFingerprint(vault.PublicKeyECDSA))
 	fmt.Printf("Public Key (ECDSA): %s\n", vault.PublicKeyECDSA)
 	fmt.Printf("Public Key (EdDSA): %s\n", vault.PublicKeyEdDSA)
-	fmt.Printf("Local Party ID: %s\n", vault.LocalPartyID)
-	fmt.Printf("Signers: %v\n", vault.Signers)
+	fmt.Printf("Signers: %d\n", len(vault.Signers))
+	for i, signer := range vault.Signers {
+		fmt.Printf("  %d. %s %s\n", i+1, signer, getSignerRole(signer, vault.LocalPartyID))
+	}
 	fmt.Printf("Created: %s\n", vault.CreatedAt)
 	fmt.Printf("Keyshares: %d\n", len(vault.KeyShares))
 	for _, ks := range vault.KeyShares {
 		fmt.Printf("  - %s: %d bytes\n", ks.PubKey[:16]+"...", len(ks.Keyshare))
 	}
-	fmt.Printf("LibType: %d (0=GG20, 1=DKLS)\n", vault.LibType)
+	fmt.Printf("LibType: %s\n", vault.LibType)
 	if vault.ResharePrefix != "" {
 		fmt.Printf("Reshare Prefix: %s\n", vault.ResharePrefix)
 	}
+	printVaultSource(vault)
+	printVaultUsage(vault)
 	fmt.Println()
 	fmt.Println("Storage:", VaultStoragePath())
 
 	return nil
 }
 
-func runVaultList() error {
+func runVaultList(verbose bool) error {
 	vaults, err := ListVaults()
 	if err != nil {
 		return fmt.Errorf("list vaults: %w", err)
@@ -501,14 +1032,31 @@ func runVaultList() error {
 	fmt.Printf("=== Local Vaults (%d) ===\n\n", len(vaults))
 
 	for _, v := range vaults {
-		active := ""
+		label := v.Name
+		if v.PublicKeyECDSA != "" {
+			label += " (" + vaultShortPrefix(v.PublicKeyECDSA) + ")"
+		}
 		if cfg.PublicKeyECDSA == v.PublicKeyECDSA {
-			active = " [ACTIVE]"
+			label += " [ACTIVE]"
+		}
+		if v.WatchOnly {
+			label += " [WATCH-ONLY]"
+		}
+		fmt.Printf("  %s\n", label)
+		fmt.Printf("    Fingerprint: %s\n", VaultFingerprint(v.PublicKeyECDSA))
+		if v.PublicKeyECDSA == "" {
+			fmt.Println("    ECDSA: (not generated yet)")
+		} else {
+			fmt.Printf("    ECDSA: %s\n", format.Truncate(v.PublicKeyECDSA, 35))
 		}
-		fmt.Printf("  %s%s\n", v.Name, active)
-		fmt.Printf("    ECDSA: %s...\n", v.PublicKeyECDSA[:32])
 		fmt.Printf("    Signers: %d parties\n", len(v.Signers))
+		fmt.Printf("    LibType: %s\n", v.LibType)
 		fmt.Printf("    Created: %s\n", v.CreatedAt)
+		if verbose {
+			ethAddr, solAddr := vaultListAddresses(v)
+			fmt.Printf("    Ethereum: %s\n", ethAddr)
+			fmt.Printf("    Solana: %s\n", solAddr)
+		}
 		fmt.Println()
 	}
 
@@ -517,87 +1065,205 @@ func runVaultList() error {
 	return nil
 }
 
-func runVaultImport(file, password string, force bool) error {
-	startTime := time.Now()
+// VaultListEntry is the JSON form of a vault in 'vault list --output json'.
+// It deliberately has no keyshare field.
+type VaultListEntry struct {
+	Name           string   `json:"name"`
+	Fingerprint    string   `json:"fingerprint"`
+	PublicKeyECDSA string   `json:"public_key_ecdsa"`
+	PublicKeyEdDSA string   `json:"public_key_eddsa"`
+	HexChainCode   string   `json:"hex_chain_code"`
+	LocalPartyID   string   `json:"local_party_id"`
+	Signers        []string `json:"signers"`
+	LibType        LibType  `json:"lib_type"`
+	ResharePrefix  string   `json:"reshare_prefix,omitempty"`
+	KeyshareCount  int      `json:"keyshare_count"`
+	WatchOnly      bool     `json:"watch_only"`
+	CreatedAt      string   `json:"created_at"`
+	FilePath       string   `json:"file_path"`
+	Active         bool     `json:"active"`
+}
 
-	// Check for existing vault
-	existingVaults, _ := ListVaults()
-	if len(existingVaults) > 0 && !force {
-		existing := existingVaults[0]
-		if len(existing.Signers) > 2 {
-			fmt.Printf("Warning: Existing vault has %d signers (from plugin install).\n", len(existing.Signers))
-			fmt.Println("Use --force to overwrite with fresh 2-party vault.")
-			return fmt.Errorf("existing vault found with %d signers. Use --force to overwrite", len(existing.Signers))
-		}
+func runVaultListJSON() error {
+	vaults, err := ListVaults()
+	if err != nil {
+		return fmt.Errorf("list vaults: %w", err)
 	}
 
-	if force && len(existingVaults) > 0 {
-		fmt.Println("Force mode: removing existing vault...")
-		vaultPath := VaultStoragePath()
-		os.RemoveAll(vaultPath)
-		os.MkdirAll(vaultPath, 0700)
+	cfg, _ := LoadConfig()
+
+	entries := make([]VaultListEntry, 0, len(vaults))
+	for _, v := range vaults {
+		entries = append(entries, vaultListEntry(v, cfg))
 	}
 
-	data, err := os.ReadFile(file)
+	data, err := json.MarshalIndent(entries, "", "  ")
 	if err != nil {
-		return fmt.Errorf("read file: %w", err)
+		return fmt.Errorf("marshal vaults: %w", err)
 	}
+	fmt.Println(string(data))
 
-	fileInfo, _ := os.Stat(file)
-	fileSize := fileInfo.Size()
-
-	var localVault LocalVault
-	var format string
+	return nil
+}
 
-	// Try to parse as .vult format (base64-encoded protobuf)
-	pbVault, err := common.DecryptVaultFromBackup(password, data)
-	if err == nil {
-		localVault = convertProtoVaultToLocal(pbVault)
-		format = ".vult (protobuf)"
-		fmt.Println("Detected .vult protobuf format")
-	} else {
-		// Fall back to JSON format
-		var backup BackupVault
-		jsonErr := json.Unmarshal(data, &backup)
-		if jsonErr == nil && backup.Version != "" {
-			localVault = backup.Vault
-			format = fmt.Sprintf("iOS backup (v%s)", backup.Version)
-			fmt.Printf("Detected iOS backup format (version: %s)\n", backup.Version)
-		} else {
-			jsonErr = json.Unmarshal(data, &localVault)
-			if jsonErr != nil {
-				return fmt.Errorf("parse vault file: protobuf error: %v, json error: %v", err, jsonErr)
-			}
-			format = "JSON"
-			fmt.Println("Detected JSON format")
-		}
+func vaultListEntry(v *LocalVault, cfg *DevConfig) VaultListEntry {
+	return VaultListEntry{
+		Name:           v.Name,
+		Fingerprint:    VaultFingerprint(v.PublicKeyECDSA),
+		PublicKeyECDSA: v.PublicKeyECDSA,
+		PublicKeyEdDSA: v.PublicKeyEdDSA,
+		HexChainCode:   v.HexChainCode,
+		LocalPartyID:   v.LocalPartyID,
+		Signers:        v.Signers,
+		LibType:        v.LibType,
+		ResharePrefix:  v.ResharePrefix,
+		KeyshareCount:  len(v.KeyShares),
+		WatchOnly:      v.WatchOnly,
+		CreatedAt:      v.CreatedAt,
+		FilePath:       vaultFilePath(v),
+		Active:         cfg != nil && cfg.PublicKeyECDSA == v.PublicKeyECDSA,
 	}
+}
 
-	if localVault.PublicKeyECDSA == "" {
-		return fmt.Errorf("invalid vault file: missing public key")
+// runVaultInfoJSON prints the active vault as a VaultListEntry.
+func runVaultInfoJSON() error {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
 	}
-
-	if localVault.CreatedAt == "" {
-		localVault.CreatedAt = time.Now().UTC().Format(time.RFC3339)
+	if cfg.PublicKeyECDSA == "" {
+		return fmt.Errorf("no vault configured")
+	}
+	vault, err := LoadVault(cfg.PublicKeyECDSA[:16])
+	if err != nil {
+		return fmt.Errorf("load vault: %w", err)
 	}
 
-	err = SaveVault(&localVault)
+	data, err := json.MarshalIndent(vaultListEntry(vault, cfg), "", "  ")
 	if err != nil {
-		return fmt.Errorf("save vault: %w", err)
+		return fmt.Errorf("marshal vault: %w", err)
 	}
+	fmt.Println(string(data))
+	return nil
+}
 
-	cfg, _ := LoadConfig()
-	cfg.VaultName = localVault.Name
-	cfg.PublicKeyECDSA = localVault.PublicKeyECDSA
-	cfg.PublicKeyEdDSA = localVault.PublicKeyEdDSA
-	err = SaveConfig(cfg)
+func vaultListAddresses(v *LocalVault) (string, string) {
+	ethAddr, _, _, err := address.GetAddress(v.PublicKeyECDSA, v.HexChainCode, common.Ethereum)
 	if err != nil {
-		return fmt.Errorf("save config: %w", err)
+		ethAddr = "error: " + err.Error()
 	}
 
-	fmt.Println()
-	fmt.Println("=== Vault Imported ===")
+	solAddr := "-"
+	if v.PublicKeyEdDSA != "" {
+		solAddr, _, _, err = address.GetAddress(v.PublicKeyEdDSA, v.HexChainCode, common.Solana)
+		if err != nil {
+			solAddr = "error: " + err.Error()
+		}
+	}
+
+	return ethAddr, solAddr
+}
+
+// confirmVaultOverwrite asks before --force removes the local vaults. The
+// user has to type the name of the vault being replaced.
+func confirmVaultOverwrite(yes bool) error {
+	existing, _ := ListVaults()
+	if len(existing) == 0 {
+		return nil
+	}
+	v := existing[0]
+	summary := fmt.Sprintf("Overwrite vault %s (%s..., %d signers)? All keyshares in %s are removed.",
+		v.Name, v.PublicKeyECDSA[:16], len(v.Signers), VaultStoragePath())
+	return confirmDestructive(summary, v.Name, yes)
+}
+
+func runVaultImport(ctx context.Context, file string, qrImages []string, password string, force, skipFastVaultCheck bool) error {
+	startTime := time.Now()
+
+	var data []byte
+	var err error
+	if len(qrImages) > 0 {
+		data, err = decodeQRImages(qrImages)
+		if err != nil {
+			return err
+		}
+		file = strings.Join(qrImages, ", ")
+	} else {
+		data, err = os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("read file: %w", err)
+		}
+	}
+	fileSize := int64(len(data))
+	source := newVaultSource(file, qrImages, data)
+
+	if isPublicVaultExport(data) {
+		return fmt.Errorf("%s is a public-only export ('vault export --public-only'): it has no keyshares; import the .vult backup instead, or add --watch-only", file)
+	}
+
+	// Check for existing vault
+	existingVaults, _ := ListVaults()
+	if identical := findImportedFrom(existingVaults, source.SHA256); identical != nil && !force {
+		fmt.Printf("%s is identical to already-imported vault %s (imported %s); nothing to do.\n", file, identical.Name, identical.Source.ImportedAt)
+		fmt.Println("Use --force to import it again.")
+		return nil
+	}
+	if len(existingVaults) > 0 && !force {
+		existing := existingVaults[0]
+		if len(existing.Signers) > 2 {
+			fmt.Printf("Warning: Existing vault has %d signers (from plugin install).\n", len(existing.Signers))
+			fmt.Println("Use --force to overwrite with fresh 2-party vault.")
+			return fmt.Errorf("existing vault found with %d signers. Use --force to overwrite", len(existing.Signers))
+		}
+	}
+
+	if force && len(existingVaults) > 0 {
+		fmt.Println("Force mode: removing existing vault...")
+		vaultPath := VaultStoragePath()
+		os.RemoveAll(vaultPath)
+		os.MkdirAll(vaultPath, 0700)
+	}
+
+	localVault, backupFormat, err := parseVaultBackup(file, data, password)
+	if err != nil {
+		if len(qrImages) > 0 {
+			return fmt.Errorf("parse QR payload: %w", err)
+		}
+		return err
+	}
+	if len(qrImages) > 0 {
+		backupFormat = fmt.Sprintf("%s via QR (%d images)", backupFormat, len(qrImages))
+	}
+	fmt.Printf("Detected %s format\n", backupFormat)
+
+	if localVault.PublicKeyECDSA == "" {
+		return fmt.Errorf("invalid vault file: missing public key")
+	}
+
+	if localVault.CreatedAt == "" {
+		localVault.CreatedAt = time.Now().UTC().Format(time.RFC3339)
+	}
+	localVault.Source = source
+
+	err = SaveVault(&localVault)
+	if err != nil {
+		return fmt.Errorf("save vault: %w", err)
+	}
+
+	cfg, _ := LoadConfig()
+	cfg.VaultName = localVault.Name
+	cfg.PublicKeyECDSA = localVault.PublicKeyECDSA
+	cfg.PublicKeyEdDSA = localVault.PublicKeyEdDSA
+	err = SaveConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("save config: %w", err)
+	}
+
+	fmt.Println()
+	fmt.Println("=== Vault Imported ===")
 	fmt.Printf("Name: %s\n", localVault.Name)
+	fmt.Printf("Format: %s\n", backupFormat)
+	fmt.Printf("Fingerprint: %s\n", VaultFingerprint(localVault.PublicKeyECDSA))
 	if len(localVault.PublicKeyECDSA) >= 32 {
 		fmt.Printf("Public Key (ECDSA): %s...\n", localVault.PublicKeyECDSA[:32])
 	} else {
@@ -605,37 +1271,59 @@ func runVaultImport(file, password string, force bool) error {
 	}
 	fmt.Printf("Public Key (EdDSA): %s\n", localVault.PublicKeyEdDSA)
 	fmt.Printf("Local Party ID: %s\n", localVault.LocalPartyID)
+	checkLocalParty(&localVault)
 	fmt.Printf("Signers: %v\n", localVault.Signers)
 	fmt.Printf("KeyShares: %d\n", len(localVault.KeyShares))
-	fmt.Printf("LibType: %d (0=GG20, 1=DKLS)\n", localVault.LibType)
+	fmt.Printf("LibType: %s\n", localVault.LibType)
 	fmt.Printf("Saved to: %s\n", VaultStoragePath())
 
-	// Check Fast Vault and authenticate
-	isFastVault, err := CheckFastVaultExists(localVault.PublicKeyECDSA)
-	if err != nil {
-		fmt.Printf("\nWarning: Could not check Fast Vault Server: %v\n", err)
+	if skipFastVaultCheck {
+		fmt.Println("\nFast Vault: not checked (--skip-fastvault-check)")
 		return nil
 	}
 
-	if !isFastVault {
+	// Check Fast Vault and authenticate. Reshare and keysign use the first
+	// server; the others are only reported.
+	presence := findFastVault(localVault.PublicKeyECDSA)
+	fmt.Println()
+	for _, p := range presence {
+		switch {
+		case p.Err != nil:
+			fmt.Printf("Fast Vault (%s): could not check: %v\n", p.Server, p.Err)
+		case p.Found:
+			fmt.Printf("Fast Vault (%s): Yes\n", p.Server)
+		default:
+			fmt.Printf("Fast Vault (%s): No\n", p.Server)
+		}
+	}
+
+	used := presence[0]
+	if used.Err != nil {
+		fmt.Printf("\nWarning: Could not check Fast Vault Server %s: %v\n", used.Server, used.Err)
+		return nil
+	}
+
+	if !used.Found {
 		fmt.Println("\nWarning: NOT a Fast Vault!")
-		fmt.Println("  This vault was not found on the Fast Vault Server.")
+		fmt.Printf("  This vault was not found on %s, the Fast Vault Server reshare uses.\n", used.Server)
 		fmt.Println("  Plugin reshare operations will NOT work without Fast Vault.")
-		fmt.Println("  Please use a vault created with the Vultisig app's Fast Vault feature.")
+		if len(presence) > 1 && presence[1].Found {
+			fmt.Printf("  It exists on %s; point services.vultiserver in cluster.yaml there to use it.\n", presence[1].Server)
+		} else {
+			fmt.Println("  Please use a vault created with the Vultisig app's Fast Vault feature.")
+		}
 		return nil
 	}
 
-	fmt.Println("\nFast Vault: Yes (vault exists on Fast Vault Server)")
-
 	// Auto-authenticate with verifier
 	if password == "" {
 		fmt.Println("\nTo authenticate, re-run with --password to provide Fast Vault password")
 		return nil
 	}
 
-	fmt.Println("\nAuthenticating with verifier...")
+	progressln("\nAuthenticating with verifier...")
 	authStart := time.Now()
-	err = authenticateVault(&localVault, password)
+	err = authenticateVault(ctx, &localVault, password)
 	authDuration := time.Since(authStart)
 
 	if err != nil {
@@ -656,23 +1344,24 @@ func runVaultImport(file, password string, force bool) error {
 	fmt.Println("├─────────────────────────────────────────────────────────────────┤")
 	fmt.Println("│                                                                 │")
 	fmt.Println("│  Source File:                                                   │")
-	fmt.Printf("│    Path:    %-52s │\n", truncateStr(file, 52))
-	fmt.Printf("│    Format:  %-52s │\n", format)
-	fmt.Printf("│    Size:    %-52s │\n", formatFileSize(fileSize))
+	fmt.Printf("│    Path:    %-52s │\n", format.Truncate(file, 52))
+	fmt.Printf("│    Format:  %-52s │\n", backupFormat)
+	fmt.Printf("│    Size:    %-52s │\n", format.Bytes(fileSize))
+	fmt.Printf("│    SHA-256: %-52s │\n", source.SHA256[:16]+"...")
 	fmt.Println("│                                                                 │")
 	fmt.Println("│  Vault Saved:                                                   │")
-	fmt.Printf("│    Location: %-51s │\n", truncateStr(VaultStoragePath(), 51))
-	fmt.Printf("│    Name:     %-51s │\n", truncateStr(localVault.Name, 51))
+	fmt.Printf("│    Location: %-51s │\n", format.Truncate(VaultStoragePath(), 51))
+	fmt.Printf("│    Name:     %-51s │\n", format.Truncate(localVault.Name, 51))
 	fmt.Printf("│    Parties:  %-51s │\n", fmt.Sprintf("%d signers", len(localVault.Signers)))
 	fmt.Println("│                                                                 │")
 	fmt.Println("│  Authentication:                                                │")
-	fmt.Printf("│    Status:   %-51s │\n", "✓ Authenticated")
+	fmt.Printf("│    Status:   %-51s │\n", symOK+" Authenticated")
 	if authToken != nil {
 		fmt.Printf("│    Expires:  %-51s │\n", authToken.ExpiresAt.Format("2006-01-02 15:04:05"))
 	}
-	fmt.Printf("│    Duration: %-51s │\n", authDuration.Round(time.Millisecond).String())
+	fmt.Printf("│    Duration: %-51s │\n", format.Duration(authDuration))
 	fmt.Println("│                                                                 │")
-	fmt.Printf("│  Total Time: %-51s │\n", totalDuration.Round(time.Millisecond).String())
+	fmt.Printf("│  Total Time: %-51s │\n", format.Duration(totalDuration))
 	fmt.Println("│                                                                 │")
 	fmt.Println("└─────────────────────────────────────────────────────────────────┘")
 	fmt.Println()
@@ -681,138 +1370,57 @@ func runVaultImport(file, password string, force bool) error {
 	return nil
 }
 
-func truncateStr(s string, maxLen int) string {
-	if len(s) <= maxLen {
-		return s
-	}
-	return s[:maxLen-3] + "..."
-}
-
-func formatFileSize(bytes int64) string {
-	if bytes < 1024 {
-		return fmt.Sprintf("%d bytes", bytes)
-	}
-	if bytes < 1024*1024 {
-		return fmt.Sprintf("%.1f KB", float64(bytes)/1024)
-	}
-	return fmt.Sprintf("%.1f MB", float64(bytes)/(1024*1024))
-}
-
-func authenticateVault(vault *LocalVault, password string) error {
+// authenticateVault logs the vault in with the verifier using the default
+// 'auth login' scheme: an EIP-191 personal_sign hash signed as R+S+V.
+func authenticateVault(ctx context.Context, vault *LocalVault, password string) error {
 	cfg, err := LoadConfig()
 	if err != nil {
 		cfg = DefaultConfig()
 	}
 
-	// Generate nonce for auth message
-	nonceBytes := make([]byte, 16)
-	_, err = rand.Read(nonceBytes)
-	if err != nil {
-		return fmt.Errorf("generate nonce: %w", err)
-	}
-	nonce := hex.EncodeToString(nonceBytes)
-	expiryTime := time.Now().Add(5 * time.Minute)
-
-	// Message must be JSON format for verifier
-	messageObj := map[string]string{
-		"nonce":     nonce,
-		"expiresAt": expiryTime.Format(time.RFC3339),
-	}
-	messageJSON, err := json.Marshal(messageObj)
-	if err != nil {
-		return fmt.Errorf("marshal message: %w", err)
-	}
-	message := string(messageJSON)
-
-	fmt.Printf("  Vault: %s\n", vault.Name)
-	fmt.Printf("  Verifier: %s\n", cfg.Verifier)
-
-	// Create Ethereum-prefixed message hash for signing
-	ethPrefixedMessage := fmt.Sprintf("\x19Ethereum Signed Message:\n%d%s", len(message), message)
-	messageHash := crypto.Keccak256([]byte(ethPrefixedMessage))
-	hexMessage := hex.EncodeToString(messageHash)
-
-	// Perform TSS keysign
-	tss := NewTSSService(vault.LocalPartyID)
-	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
-	defer cancel()
-
-	fmt.Println("  Performing TSS keysign...")
-
-	derivePath := "m/44'/60'/0'/0/0"
-	results, err := tss.KeysignWithFastVault(ctx, vault, []string{hexMessage}, derivePath, password)
+	authToken, err := loginVault(ctx, cfg, vault, password, AuthScheme{SigFormat: authSigRSV, MessageFormat: authMessagePersonalSign})
 	if err != nil {
-		return fmt.Errorf("TSS keysign failed: %w", err)
-	}
-
-	if len(results) == 0 {
-		return fmt.Errorf("no signature result")
+		return err
 	}
 
-	// Build signature in Ethereum format (R + S + V)
-	signature := "0x" + results[0].R + results[0].S + results[0].RecoveryID
-
-	// Send auth request to verifier
-	authReq := map[string]string{
-		"message":        message,
-		"signature":      signature,
-		"chain_code_hex": vault.HexChainCode,
-		"public_key":     vault.PublicKeyECDSA,
-	}
-
-	reqJSON, err := json.Marshal(authReq)
-	if err != nil {
-		return fmt.Errorf("marshal auth request: %w", err)
-	}
-
-	url := cfg.Verifier + "/auth"
-	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(reqJSON))
-	if err != nil {
-		return fmt.Errorf("create request: %w", err)
-	}
-	httpReq.Header.Set("Content-Type", "application/json")
-
-	resp, err := http.DefaultClient.Do(httpReq)
-	if err != nil {
-		return fmt.Errorf("auth request failed: %w", err)
-	}
-	defer resp.Body.Close()
+	fmt.Printf("  Token expires: %s\n", authToken.ExpiresAt.Format(time.RFC3339))
 
-	body, _ := io.ReadAll(resp.Body)
+	return nil
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("authentication failed (%d): %s", resp.StatusCode, string(body))
-	}
+// CheckFastVaultExists reports whether the vault exists on the Fast Vault
+// Server that reshare and keysign use (see fastVaultURL).
+func CheckFastVaultExists(publicKey string) (bool, error) {
+	return checkFastVaultOn(fastVaultURL(), publicKey)
+}
 
-	var authResp struct {
-		Data struct {
-			Token string `json:"token"`
-		} `json:"data"`
-	}
-	err = json.Unmarshal(body, &authResp)
-	if err != nil {
-		return fmt.Errorf("parse auth response: %w", err)
-	}
+// FastVaultPresence is the result of looking a vault up on one Fast Vault
+// Server.
+type FastVaultPresence struct {
+	Server string
+	Found  bool
+	Err    error
+}
 
-	// Save token
-	authToken := AuthToken{
-		Token:     authResp.Data.Token,
-		PublicKey: vault.PublicKeyECDSA,
-		ExpiresAt: time.Now().Add(7 * 24 * time.Hour),
+// findFastVault looks the vault up on the Fast Vault Server in use and, when
+// that is not the production server, on production too, so import can say
+// where the vault lives.
+func findFastVault(publicKey string) []FastVaultPresence {
+	servers := []string{fastVaultURL()}
+	if servers[0] != FastVaultServer {
+		servers = append(servers, FastVaultServer)
 	}
 
-	err = SaveAuthToken(&authToken)
-	if err != nil {
-		return fmt.Errorf("save auth token: %w", err)
+	var found []FastVaultPresence
+	for _, server := range servers {
+		ok, err := checkFastVaultOn(server, publicKey)
+		found = append(found, FastVaultPresence{Server: server, Found: ok, Err: err})
 	}
-
-	fmt.Printf("  Token expires: %s\n", authToken.ExpiresAt.Format(time.RFC3339))
-
-	return nil
+	return found
 }
 
-func CheckFastVaultExists(publicKey string) (bool, error) {
-	url := fmt.Sprintf("%s/vault/exist/%s", FastVaultServer, publicKey)
+func checkFastVaultOn(server, publicKey string) (bool, error) {
+	url := fmt.Sprintf("%s/vault/exist/%s", server, publicKey)
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -831,6 +1439,42 @@ func CheckFastVaultExists(publicKey string) (bool, error) {
 	return resp.StatusCode == http.StatusOK, nil
 }
 
+// WaitForFastVaultBackup polls the Fast Vault Server until it can decrypt the
+// stored backup with the given password. The server persists the backup (and
+// queues the email) after keygen finishes, so it may lag the local result.
+func WaitForFastVaultBackup(ctx context.Context, publicKey, backupPassword string, timeout time.Duration) error {
+	url := fmt.Sprintf("%s/vault/get/%s", FastVaultServer, publicKey)
+	deadline := time.After(timeout)
+
+	var lastErr error
+	for {
+		req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("x-password", base64.StdEncoding.EncodeToString([]byte(backupPassword)))
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			lastErr = err
+		} else {
+			resp.Body.Close()
+			if resp.StatusCode == http.StatusOK {
+				return nil
+			}
+			lastErr = fmt.Errorf("fast vault server returned %d", resp.StatusCode)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-deadline:
+			return fmt.Errorf("backup not available after %s: %w", timeout, lastErr)
+		case <-time.After(2 * time.Second):
+		}
+	}
+}
+
 func parseVultFile(data []byte, password string) (*v1.Vault, error) {
 	// Base64 decode the file content
 	decoded, err := base64.StdEncoding.DecodeString(string(data))
@@ -896,11 +1540,18 @@ func convertProtoVaultToLocal(pbVault *v1.Vault) LocalVault {
 		KeyShares:      keyShares,
 		ResharePrefix:  pbVault.ResharePrefix,
 		CreatedAt:      createdAt,
-		LibType:        int(pbVault.LibType),
+		LibType:        LibType(pbVault.LibType),
 	}
 }
 
-func runVaultExport(output string) error {
+func runVaultExport(output, exportFormat, password string) error {
+	if exportFormat != "json" && exportFormat != "vult" {
+		return fmt.Errorf("unknown --format %q (want json or vult)", exportFormat)
+	}
+	if password != "" && exportFormat != "vult" {
+		return fmt.Errorf("--password only applies to --format vult")
+	}
+
 	cfg, err := LoadConfig()
 	if err != nil {
 		return fmt.Errorf("load config: %w", err)
@@ -914,14 +1565,24 @@ func runVaultExport(output string) error {
 	if err != nil {
 		return fmt.Errorf("load vault: %w", err)
 	}
+	vault.LastUsed = nil
+	vault.Source = nil
 
-	data, err := json.MarshalIndent(vault, "", "  ")
+	var data []byte
+	if exportFormat == "vult" {
+		if vault.WatchOnly {
+			return fmt.Errorf("vault %s is watch-only; a .vult backup needs keyshares (use --public-only)", vault.Name)
+		}
+		data, err = encodeVultFile(vault, password)
+	} else {
+		data, err = json.MarshalIndent(vault, "", "  ")
+	}
 	if err != nil {
 		return fmt.Errorf("marshal vault: %w", err)
 	}
 
 	if output == "" {
-		output = fmt.Sprintf("%s-vault.json", vault.Name)
+		output = fmt.Sprintf("%s-vault.%s", vault.Name, exportFormat)
 	}
 
 	err = os.WriteFile(output, data, 0600)
@@ -930,14 +1591,66 @@ func runVaultExport(output string) error {
 	}
 
 	fmt.Printf("Vault exported to: %s\n", output)
+	if exportFormat == "vult" && password == "" {
+		fmt.Printf("%s The backup is not encrypted; pass --password to encrypt it\n", warnMark())
+	}
 
 	return nil
 }
 
-func runVaultUse(pubKeyPrefix string) error {
-	vault, err := LoadVault(pubKeyPrefix)
+func runVaultExportPublic(output string) error {
+	cfg, err := LoadConfig()
 	if err != nil {
-		return fmt.Errorf("vault not found: %w", err)
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	if cfg.PublicKeyECDSA == "" {
+		return fmt.Errorf("no vault configured")
+	}
+
+	vault, err := LoadVault(cfg.PublicKeyECDSA[:16])
+	if err != nil {
+		return fmt.Errorf("load vault: %w", err)
+	}
+
+	version, _ := buildVersion()
+	addrs, _ := vaultAddresses(vault, "")
+	export := PublicVaultExport{
+		Kind:           publicVaultKind,
+		DevctlVersion:  version,
+		GeneratedAt:    time.Now().UTC().Format(time.RFC3339),
+		Name:           vault.Name,
+		PublicKeyECDSA: vault.PublicKeyECDSA,
+		PublicKeyEdDSA: vault.PublicKeyEdDSA,
+		HexChainCode:   vault.HexChainCode,
+		Signers:        vault.Signers,
+		LibType:        vault.LibType,
+		Addresses:      addrs,
+	}
+
+	data, err := json.MarshalIndent(export, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal vault: %w", err)
+	}
+
+	if output == "" {
+		output = fmt.Sprintf("%s-public.json", vault.Name)
+	}
+
+	err = os.WriteFile(output, data, 0644)
+	if err != nil {
+		return fmt.Errorf("write file: %w", err)
+	}
+
+	fmt.Printf("Public vault data exported to: %s (no keyshares)\n", output)
+
+	return nil
+}
+
+func runVaultUse(arg string) error {
+	vault, err := LoadVault(arg)
+	if err != nil {
+		return err
 	}
 
 	cfg, _ := LoadConfig()
@@ -957,6 +1670,8 @@ func runVaultUse(pubKeyPrefix string) error {
 
 func newVaultBalanceCmd() *cobra.Command {
 	var chain string
+	var alertBelow string
+	var output string
 
 	cmd := &cobra.Command{
 		Use:   "balance",
@@ -966,22 +1681,54 @@ func newVaultBalanceCmd() *cobra.Command {
 By default shows balances on all supported EVM chains.
 Use --chain to filter to a specific chain.
 
+--alert-below exits non-zero when a chain's native balance is below the
+given amount, so long-running tests can stop before the vault runs out of
+gas. With a symbol (0.02ETH) it applies to the chains whose native token
+has that symbol; without one, to every chain in its own unit. Per-chain
+thresholds can also be set as chains.<name>.alert_below in cluster.yaml;
+they are checked on every run, and --alert-below overrides them on the
+chains it applies to. 'report' shows the cluster.yaml alerts as well.
+
+--output json (or --json) prints one object with the vault's name, ECDSA
+key, the balances (chain, symbol, address, amount in whole tokens, raw in
+base units, or error) and any alerts. Alerts still exit non-zero.
+
+Chains are queried in parallel within 15s, or the global --timeout; a chain
+whose RPC has not answered by then shows "timeout" instead of a balance.
+
 Example:
   devctl vault balance
   devctl vault balance --chain ethereum
+  devctl vault balance --alert-below 0.02ETH
+  devctl vault balance --json | jq '.balances[] | select(.chain=="Base") | .raw'
 `,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runVaultBalance(chain)
+			if output != "text" && output != "json" {
+				return fmt.Errorf("unknown output format %q (use text or json)", output)
+			}
+			var threshold *balanceThreshold
+			if alertBelow != "" {
+				t, err := parseBalanceThreshold(alertBelow)
+				if err != nil {
+					return fmt.Errorf("--alert-below: %w", err)
+				}
+				threshold = &t
+			}
+			return runVaultBalance(cmd.Context(), chain, threshold, output == "json")
 		},
 	}
 
 	cmd.Flags().StringVarP(&chain, "chain", "c", "", "Specific chain to check (ethereum, arbitrum, base, etc.)")
+	cmd.Flags().StringVar(&alertBelow, "alert-below", "", "Exit non-zero when a native balance is below this amount, e.g. 0.02ETH")
+	cmd.Flags().StringVarP(&output, "output", "o", "text", "Output format: text or json")
 
 	return cmd
 }
 
 func newVaultAddressCmd() *cobra.Command {
 	var chain string
+	var output string
+	var verbose bool
 
 	cmd := &cobra.Command{
 		Use:   "address",
@@ -991,16 +1738,26 @@ func newVaultAddressCmd() *cobra.Command {
 By default shows addresses for all supported chains.
 Use --chain to filter to a specific chain.
 
+The chain code is checked before deriving. Chains whose derivation fails are
+listed, with the underlying error under --verbose; if every chain fails the
+vault data is corrupt and the command exits non-zero.
+
 Example:
   devctl vault address
   devctl vault address --chain ethereum
+  devctl vault address --output json | jq -r '.[] | select(.chain=="Ethereum") | .address'
 `,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runVaultAddress(chain)
+			if output != "text" && output != "json" {
+				return fmt.Errorf("unknown output format %q (use text or json)", output)
+			}
+			return runVaultAddress(chain, output == "json", verbose)
 		},
 	}
 
 	cmd.Flags().StringVarP(&chain, "chain", "c", "", "Specific chain to show address for")
+	cmd.Flags().StringVarP(&output, "output", "o", "text", "Output format: text or json")
+	cmd.Flags().BoolVar(&verbose, "verbose", false, "Show why derivation failed on a chain")
 
 	return cmd
 }
@@ -1011,92 +1768,267 @@ type ChainInfo struct {
 	RPCURL   string
 	Symbol   string
 	Decimals int
+	PriceID  string
+
+	// Testnet presets and chains added with 'devctl chain add' reuse an
+	// existing Chain for address derivation.
+	Testnet  bool
+	Custom   bool
+	ChainID  int64
+	Explorer string
+	Faucet   string
 }
 
 var supportedChains = []ChainInfo{
-	{Name: "Ethereum", Chain: common.Ethereum, RPCURL: "https://ethereum-rpc.publicnode.com", Symbol: "ETH", Decimals: 18},
-	{Name: "Arbitrum", Chain: common.Arbitrum, RPCURL: "https://arbitrum-one-rpc.publicnode.com", Symbol: "ETH", Decimals: 18},
-	{Name: "Base", Chain: common.Base, RPCURL: "https://base-rpc.publicnode.com", Symbol: "ETH", Decimals: 18},
-	{Name: "Polygon", Chain: common.Polygon, RPCURL: "https://polygon-bor-rpc.publicnode.com", Symbol: "MATIC", Decimals: 18},
-	{Name: "BSC", Chain: common.BscChain, RPCURL: "https://bsc-rpc.publicnode.com", Symbol: "BNB", Decimals: 18},
-	{Name: "Avalanche", Chain: common.Avalanche, RPCURL: "https://avalanche-c-chain-rpc.publicnode.com", Symbol: "AVAX", Decimals: 18},
-	{Name: "Optimism", Chain: common.Optimism, RPCURL: "https://optimism-rpc.publicnode.com", Symbol: "ETH", Decimals: 18},
+	{Name: "Ethereum", Chain: common.Ethereum, RPCURL: "https://ethereum-rpc.publicnode.com", Symbol: "ETH", Decimals: 18, PriceID: "ethereum", ChainID: 1, Explorer: "https://etherscan.io"},
+	{Name: "Arbitrum", Chain: common.Arbitrum, RPCURL: "https://arbitrum-one-rpc.publicnode.com", Symbol: "ETH", Decimals: 18, PriceID: "ethereum", ChainID: 42161, Explorer: "https://arbiscan.io"},
+	{Name: "Base", Chain: common.Base, RPCURL: "https://base-rpc.publicnode.com", Symbol: "ETH", Decimals: 18, PriceID: "ethereum", ChainID: 8453, Explorer: "https://basescan.org"},
+	{Name: "Polygon", Chain: common.Polygon, RPCURL: "https://polygon-bor-rpc.publicnode.com", Symbol: "MATIC", Decimals: 18, PriceID: "matic-network", ChainID: 137, Explorer: "https://polygonscan.com"},
+	{Name: "BSC", Chain: common.BscChain, RPCURL: "https://bsc-rpc.publicnode.com", Symbol: "BNB", Decimals: 18, PriceID: "binancecoin", ChainID: 56, Explorer: "https://bscscan.com"},
+	{Name: "Avalanche", Chain: common.Avalanche, RPCURL: "https://avalanche-c-chain-rpc.publicnode.com", Symbol: "AVAX", Decimals: 18, PriceID: "avalanche-2", ChainID: 43114, Explorer: "https://snowtrace.io"},
+	{Name: "Optimism", Chain: common.Optimism, RPCURL: "https://optimism-rpc.publicnode.com", Symbol: "ETH", Decimals: 18, PriceID: "ethereum", ChainID: 10, Explorer: "https://optimistic.etherscan.io"},
 }
 
-func runVaultAddress(chainFilter string) error {
+// VaultAddress is one entry of 'vault address --output json'.
+type VaultAddress struct {
+	Chain   string `json:"chain"`
+	Address string `json:"address"`
+}
+
+// ChainDerivationError is a chain on which address derivation failed.
+type ChainDerivationError struct {
+	Chain string
+	Err   error
+}
+
+func runVaultAddress(chainFilter string, asJSON, verbose bool) error {
 	vaults, err := ListVaults()
 	if err != nil || len(vaults) == 0 {
 		return fmt.Errorf("no vaults found. Import a vault first: devctl vault import")
 	}
 	vault := vaults[0]
 
-	fmt.Printf("=== Vault Addresses ===\n")
-	fmt.Printf("Vault: %s\n\n", vault.Name)
+	err = validateChainCode(vault.HexChainCode)
+	if err != nil {
+		return fmt.Errorf("vault %s: %w", vault.Name, err)
+	}
 
-	for _, c := range supportedChains {
-		if chainFilter != "" && !strings.EqualFold(c.Name, chainFilter) && !strings.EqualFold(string(c.Chain), chainFilter) {
-			continue
+	if !asJSON {
+		progressf("=== Vault Addresses ===\n")
+		progressf("Vault: %s\n\n", vault.Name)
+	}
+
+	addrs, failed := vaultAddresses(vault, chainFilter)
+	for _, f := range failed {
+		if verbose {
+			progressf("  %s: error deriving address: %v\n", f.Chain, f.Err)
+		} else {
+			progressf("  %s: error deriving address (--verbose for details)\n", f.Chain)
 		}
+	}
+	if len(addrs) == 0 && len(failed) > 0 {
+		return fmt.Errorf("address derivation failed on all %d chains, so the vault data is likely corrupt (%s: %v); re-import the vault backup", len(failed), failed[0].Chain, failed[0].Err)
+	}
+	if !asJSON {
+		for _, a := range addrs {
+			if a.Chain == "Solana" {
+				fmt.Println("\nEdDSA Chains:")
+			}
+			fmt.Printf("  %s: %s\n", a.Chain, a.Address)
+		}
+	}
 
-		addr, _, _, err := address.GetAddress(vault.PublicKeyECDSA, vault.HexChainCode, c.Chain)
+	if asJSON {
+		data, err := json.MarshalIndent(addrs, "", "  ")
 		if err != nil {
-			fmt.Printf("  %s: error deriving address\n", c.Name)
+			return fmt.Errorf("marshal addresses: %w", err)
+		}
+		fmt.Println(string(data))
+	}
+
+	return nil
+}
+
+// vaultAddresses derives the vault's address on each registry chain matching
+// chainFilter (all when empty), and on Solana when the vault has an EdDSA
+// key. Chains are derived in parallel; results keep the registry order, and
+// the chains whose derivation failed are returned with their errors.
+func vaultAddresses(vault *LocalVault, chainFilter string) ([]VaultAddress, []ChainDerivationError) {
+	type target struct {
+		name   string
+		chain  common.Chain
+		pubKey string
+	}
+	var targets []target
+	for _, c := range chainRegistry() {
+		if chainFilter != "" && !c.Matches(chainFilter) {
 			continue
 		}
+		targets = append(targets, target{c.Name, c.Chain, vault.PublicKeyECDSA})
+	}
+	if vault.PublicKeyEdDSA != "" && (chainFilter == "" || chainKey(chainFilter) == "solana") {
+		targets = append(targets, target{"Solana", common.Solana, vault.PublicKeyEdDSA})
+	}
 
-		fmt.Printf("  %s: %s\n", c.Name, addr)
+	results := make([]string, len(targets))
+	errs := make([]error, len(targets))
+	var wg sync.WaitGroup
+	for i, t := range targets {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], _, _, errs[i] = address.GetAddress(t.pubKey, vault.HexChainCode, t.chain)
+		}()
 	}
+	wg.Wait()
 
-	if vault.PublicKeyEdDSA != "" {
-		fmt.Println("\nEdDSA Chains:")
-		solAddr, _, _, err := address.GetAddress(vault.PublicKeyEdDSA, vault.HexChainCode, common.Solana)
-		if err == nil {
-			fmt.Printf("  Solana: %s\n", solAddr)
+	var addrs []VaultAddress
+	var failed []ChainDerivationError
+	for i, t := range targets {
+		if errs[i] != nil {
+			failed = append(failed, ChainDerivationError{Chain: t.name, Err: errs[i]})
+			continue
 		}
+		addrs = append(addrs, VaultAddress{Chain: t.name, Address: results[i]})
 	}
+	return addrs, failed
+}
 
+// validateChainCode checks a vault's hex chain code, which every derivation
+// needs. A short one usually comes from a partial import.
+func validateChainCode(hexChainCode string) error {
+	if hexChainCode == "" {
+		return fmt.Errorf("vault has no chain code; re-import the vault backup")
+	}
+	chainCode, err := hex.DecodeString(strings.TrimPrefix(hexChainCode, "0x"))
+	if err != nil {
+		return fmt.Errorf("chain code %q is not valid hex (%v); re-import the vault backup", hexChainCode, err)
+	}
+	if len(chainCode) != 32 {
+		return fmt.Errorf("chain code is %d bytes, want 32 (truncated?); re-import the vault backup", len(chainCode))
+	}
 	return nil
 }
 
-func runVaultBalance(chainFilter string) error {
+func runVaultBalance(ctx context.Context, chainFilter string, alertBelow *balanceThreshold, asJSON bool) error {
 	vaults, err := ListVaults()
 	if err != nil || len(vaults) == 0 {
 		return fmt.Errorf("no vaults found. Import a vault first: devctl vault import")
 	}
 	vault := vaults[0]
 
-	fmt.Printf("=== Vault Balances ===\n")
-	fmt.Printf("Vault: %s\n\n", vault.Name)
+	if !asJSON {
+		progressf("=== Vault Balances ===\n")
+		progressf("Vault: %s\n\n", vault.Name)
+	}
 
-	for _, c := range supportedChains {
-		if chainFilter != "" && !strings.EqualFold(c.Name, chainFilter) && !strings.EqualFold(string(c.Chain), chainFilter) {
+	var chains []ChainInfo
+	for _, c := range chainRegistry() {
+		if chainFilter != "" && !c.Matches(chainFilter) {
 			continue
 		}
+		chains = append(chains, c)
+	}
+
+	ctx, cancel := balanceContext(ctx)
+	defer cancel()
+	balances := fetchNativeBalances(ctx, vault, chains)
+	alerts := checkBalanceAlerts(balances, balanceThresholds(chains, alertBelow))
 
-		addr, _, _, err := address.GetAddress(vault.PublicKeyECDSA, vault.HexChainCode, c.Chain)
+	if asJSON {
+		err = printVaultBalancesJSON(vault, balances, alerts)
 		if err != nil {
+			return err
+		}
+	} else {
+		printVaultBalances(balances, alerts)
+	}
+	if len(alerts) > 0 {
+		return fmt.Errorf("%d chain(s) below the balance alert threshold", len(alerts))
+	}
+	return nil
+}
+
+func printVaultBalances(balances []NativeBalance, alerts []BalanceAlert) {
+	for _, b := range balances {
+		c := b.Chain
+		if b.Address == "" {
 			fmt.Printf("  %s: error deriving address\n", c.Name)
 			continue
 		}
-
-		balance, err := getEVMBalance(c.RPCURL, addr)
-		if err != nil {
-			fmt.Printf("  %s: error fetching balance\n", c.Name)
+		if b.Err != nil {
+			fmt.Printf("  %s: %s\n", c.Name, balanceErrorText(b.Err))
 			continue
 		}
 
-		balanceFloat := new(big.Float).Quo(
-			new(big.Float).SetInt(balance),
-			new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(c.Decimals)), nil)),
-		)
+		fmt.Printf("  %s: %s %s (%s)\n", c.Name, formatBalance(b.Wei, c.Decimals), c.Symbol, b.Address[:10]+"...")
+		if c.Testnet && b.Wei.Sign() == 0 && c.Faucet != "" {
+			fmt.Printf("    faucet: %s\n", c.Faucet)
+		}
+	}
 
-		fmt.Printf("  %s: %s %s (%s)\n", c.Name, balanceFloat.Text('f', 6), c.Symbol, addr[:10]+"...")
+	if len(alerts) == 0 {
+		return
 	}
+	fmt.Println()
+	for _, a := range alerts {
+		fmt.Printf("%s %s\n", failMark(), a)
+	}
+}
+
+// VaultBalances is the JSON form of 'vault balance --json'.
+type VaultBalances struct {
+	Name           string              `json:"name"`
+	PublicKeyECDSA string              `json:"public_key_ecdsa"`
+	Balances       []VaultBalanceEntry `json:"balances"`
+	Alerts         []BalanceAlert      `json:"alerts"`
+}
 
+// VaultBalanceEntry is one chain's native balance. Amount is in whole
+// tokens and Raw in the chain's base unit (wei); both are empty when Error
+// is set.
+type VaultBalanceEntry struct {
+	Chain   string `json:"chain"`
+	Symbol  string `json:"symbol"`
+	Address string `json:"address,omitempty"`
+	Amount  string `json:"amount,omitempty"`
+	Raw     string `json:"raw,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+func printVaultBalancesJSON(vault *LocalVault, balances []NativeBalance, alerts []BalanceAlert) error {
+	out := VaultBalances{
+		Name:           vault.Name,
+		PublicKeyECDSA: vault.PublicKeyECDSA,
+		Balances:       make([]VaultBalanceEntry, 0, len(balances)),
+		Alerts:         alerts,
+	}
+	if out.Alerts == nil {
+		out.Alerts = []BalanceAlert{}
+	}
+	for _, b := range balances {
+		entry := VaultBalanceEntry{Chain: b.Chain.Name, Symbol: b.Chain.Symbol, Address: b.Address}
+		switch {
+		case b.Address == "":
+			entry.Error = "error deriving address"
+		case b.Err != nil:
+			entry.Error = balanceErrorText(b.Err)
+		default:
+			entry.Amount = formatBalance(b.Wei, b.Chain.Decimals)
+			entry.Raw = b.Wei.String()
+		}
+		out.Balances = append(out.Balances, entry)
+	}
+
+	data, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal balances: %w", err)
+	}
+	fmt.Println(string(data))
 	return nil
 }
 
-func getEVMBalance(rpcURL, address string) (*big.Int, error) {
+func getEVMBalance(ctx context.Context, rpcURL, address string) (*big.Int, error) {
 	payload := map[string]interface{}{
 		"jsonrpc": "2.0",
 		"method":  "eth_getBalance",
@@ -1109,7 +2041,7 @@ func getEVMBalance(rpcURL, address string) (*big.Int, error) {
 		return nil, err
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
 	req, err := http.NewRequestWithContext(ctx, "POST", rpcURL, strings.NewReader(string(payloadBytes)))
@@ -1165,44 +2097,133 @@ type TokenInfo struct {
 var ethereumTokens = []TokenInfo{
 	{Symbol: "USDT", Address: "0xdAC17F958D2ee523a2206206994597C13D831ec7", Decimals: 6},
 	{Symbol: "USDC", Address: "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48", Decimals: 6},
-	{Symbol: "DAI", Address: "0x6B175474E89094C44Da98b954EesD5C4BB76F7Ed", Decimals: 18},
+	{Symbol: "DAI", Address: "0x6B175474E89094C44Da98b954EedeAC495271d0F", Decimals: 18},
 	{Symbol: "WETH", Address: "0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2", Decimals: 18},
 }
 
+// tokenRegistry returns the tokens known on chain c: ethereumTokens on
+// Ethereum mainnet, merged with the cluster.yaml tokens for c, which replace
+// a built-in token of the same symbol.
+func tokenRegistry(c ChainInfo) []TokenInfo {
+	var tokens []TokenInfo
+	if c.Chain == common.Ethereum && !c.Testnet && !c.Custom {
+		tokens = append(tokens, ethereumTokens...)
+	}
+	cc, err := LoadClusterConfig()
+	if err != nil {
+		return tokens
+	}
+	for _, t := range cc.Tokens {
+		if !c.Matches(t.Chain) {
+			continue
+		}
+		info := TokenInfo{Symbol: t.Symbol, Address: t.Address, Decimals: t.Decimals}
+		i := slices.IndexFunc(tokens, func(known TokenInfo) bool { return strings.EqualFold(known.Symbol, t.Symbol) })
+		if i >= 0 {
+			tokens[i] = info
+		} else {
+			tokens = append(tokens, info)
+		}
+	}
+	return tokens
+}
+
+// knownTokens returns the tokens of every registry chain, for lookups by
+// symbol or contract address.
+func knownTokens() []TokenInfo {
+	var tokens []TokenInfo
+	for _, c := range chainRegistry() {
+		tokens = append(tokens, tokenRegistry(c)...)
+	}
+	return tokens
+}
+
 func newVaultDetailsCmd() *cobra.Command {
 	var chain string
+	var output string
 
 	cmd := &cobra.Command{
 		Use:   "details",
 		Short: "Show detailed vault info with addresses and token balances",
 		Long: `Show comprehensive vault details including:
 - All chain addresses
-- Native token balances
-- Common ERC20 token balances (USDT, USDC, etc.)
+- Native token balances on the EVM chains, Bitcoin (Esplora), Solana,
+  THORChain, Maya and the Cosmos chains (Sui, Polkadot and TON show the
+  address only)
+- ERC20 token balances: USDT, USDC, DAI and WETH on Ethereum, plus the
+  tokens listed under tokens in cluster.yaml
 
-This is useful for preparing DCA policies.
+- Latest and pending nonce per EVM chain (a gap flags a likely stuck tx)
+
+This is useful for preparing DCA policies and debugging stuck executions.
+--output json prints the keys, the EVM section and the other chains.
+chains.<name>.rpc in cluster.yaml replaces the Bitcoin Esplora, Solana RPC
+or Cosmos REST endpoint (names: bitcoin, solana, thorchain, mayachain,
+cosmos_hub, osmosis, dydx, kujira).
+
+Balances are fetched in parallel within 15s, or the global --timeout; a
+chain whose RPC has not answered by then shows "timeout".
 
 Example:
   devctl vault details
   devctl vault details --chain ethereum
+  devctl vault details --output json
 `,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runVaultDetails(chain)
+			if output != "text" && output != "json" {
+				return fmt.Errorf("unknown output format %q (use text or json)", output)
+			}
+			return runVaultDetails(cmd.Context(), chain, output == "json")
 		},
 	}
 
 	cmd.Flags().StringVarP(&chain, "chain", "c", "", "Specific chain to check (ethereum, arbitrum, base, etc.)")
+	cmd.Flags().StringVarP(&output, "output", "o", "text", "Output format: text or json")
 
 	return cmd
 }
 
-func runVaultDetails(chainFilter string) error {
+func runVaultDetails(ctx context.Context, chainFilter string, asJSON bool) error {
 	vaults, err := ListVaults()
 	if err != nil || len(vaults) == 0 {
 		return fmt.Errorf("no vaults found. Import a vault first: devctl vault import")
 	}
 	vault := vaults[0]
 
+	// Get EVM address (same for all EVM chains)
+	evmAddr, _, _, err := address.GetAddress(vault.PublicKeyECDSA, vault.HexChainCode, common.Ethereum)
+	if err != nil {
+		return fmt.Errorf("derive EVM address: %w", err)
+	}
+
+	var evmChains []ChainInfo
+	if chainFilter == "" || isEVMChain(chainFilter) {
+		for _, c := range chainRegistry() {
+			if chainFilter == "" || c.Matches(chainFilter) {
+				evmChains = append(evmChains, c)
+			}
+		}
+	}
+
+	// Nonces are fetched in the background while balances are queried.
+	noncesCh := make(chan []ChainNonce, 1)
+	go func() {
+		noncesCh <- fetchNonces(evmChains, evmAddr)
+	}()
+
+	ctx, cancel := balanceContext(ctx)
+	defer cancel()
+	nativeCh := make(chan []nativeChainBalance, 1)
+	go func() {
+		nativeCh <- fetchNativeChainBalances(ctx, vault, chainFilter)
+	}()
+	balances := fetchEVMChainBalances(ctx, evmAddr, evmChains)
+	natives := <-nativeCh
+
+	if asJSON {
+		return printVaultDetailsJSON(vault, evmAddr, evmChains, balances, natives, noncesCh)
+	}
+
 	fmt.Println("╔══════════════════════════════════════════════════════════════════╗")
 	fmt.Println("║                      VAULT DETAILS                               ║")
 	fmt.Println("╚══════════════════════════════════════════════════════════════════╝")
@@ -1213,180 +2234,170 @@ func runVaultDetails(chainFilter string) error {
 	}
 	fmt.Println()
 
-	// Get EVM address (same for all EVM chains)
-	evmAddr, _, _, err := address.GetAddress(vault.PublicKeyECDSA, vault.HexChainCode, common.Ethereum)
-	if err != nil {
-		return fmt.Errorf("derive EVM address: %w", err)
-	}
-
 	// EVM Chains section - consolidated
-	if chainFilter == "" || isEVMChain(chainFilter) {
+	if len(evmChains) > 0 {
 		fmt.Printf("┌─────────────────────────────────────────────────────────────────┐\n")
 		fmt.Printf("│ EVM CHAINS                                                      │\n")
 		fmt.Printf("├─────────────────────────────────────────────────────────────────┤\n")
 		fmt.Printf("│ Address: %s\n", evmAddr)
 		fmt.Printf("│\n")
 
-		for _, c := range supportedChains {
-			if chainFilter != "" && !strings.EqualFold(c.Name, chainFilter) && !strings.EqualFold(string(c.Chain), chainFilter) {
-				continue
-			}
-
-			balance, err := getEVMBalance(c.RPCURL, evmAddr)
-			if err != nil {
-				fmt.Printf("│ %-12s %s: error\n", c.Name+":", c.Symbol)
+		for i, c := range evmChains {
+			b := balances[i]
+			if b.Err != nil {
+				fmt.Printf("│ %-12s %s: %s\n", c.Name+":", c.Symbol, balanceErrorText(b.Err))
 			} else {
-				balanceFloat := formatBalance(balance, c.Decimals)
+				balanceFloat := formatBalance(b.Native, c.Decimals)
 				fmt.Printf("│ %-12s %s: %s\n", c.Name+":", c.Symbol, balanceFloat)
 			}
 
-			// Token balances for Ethereum mainnet
-			if c.Chain == common.Ethereum {
-				for _, token := range ethereumTokens {
-					tokenBalance, err := getERC20Balance(c.RPCURL, token.Address, evmAddr)
-					if err != nil {
-						continue
-					}
-					if tokenBalance.Cmp(big.NewInt(0)) > 0 {
-						balanceFloat := formatBalance(tokenBalance, token.Decimals)
-						fmt.Printf("│ %-12s %s: %s\n", "", token.Symbol, balanceFloat)
-					}
+			for _, t := range b.Tokens {
+				if t.Balance != nil && t.Balance.Sign() > 0 {
+					fmt.Printf("│ %-12s %s: %s\n", "", t.Token.Symbol, formatBalance(t.Balance, t.Token.Decimals))
 				}
 			}
 		}
 
+		fmt.Printf("│\n")
+		fmt.Printf("│ Nonces (latest / pending):\n")
+		for _, n := range <-noncesCh {
+			fmt.Printf("│ %-12s %s / %s  %s\n", n.Chain+":", nonceValue(n, n.Latest), nonceValue(n, n.Pending), nonceStatus(n))
+		}
+
 		fmt.Printf("└─────────────────────────────────────────────────────────────────┘\n")
 		fmt.Println()
 	}
 
-	// Bitcoin
-	if chainFilter == "" || strings.EqualFold(chainFilter, "bitcoin") || strings.EqualFold(chainFilter, "btc") {
-		btcAddr, _, _, err := address.GetAddress(vault.PublicKeyECDSA, vault.HexChainCode, common.Bitcoin)
-		if err == nil {
-			fmt.Printf("┌─────────────────────────────────────────────────────────────────┐\n")
-			fmt.Printf("│ Bitcoin                                                         │\n")
-			fmt.Printf("├─────────────────────────────────────────────────────────────────┤\n")
-			fmt.Printf("│ Address: %s\n", btcAddr)
-			fmt.Printf("│ BTC: (use explorer to check balance)\n")
-			fmt.Printf("└─────────────────────────────────────────────────────────────────┘\n")
-			fmt.Println()
+	for _, n := range natives {
+		fmt.Printf("┌─────────────────────────────────────────────────────────────────┐\n")
+		fmt.Printf("│ %-64s│\n", n.Chain.Title)
+		fmt.Printf("├─────────────────────────────────────────────────────────────────┤\n")
+		fmt.Printf("│ Address: %s\n", n.Address)
+		switch {
+		case n.Chain.Provider == nil:
+			fmt.Printf("│ %s: (use explorer to check balance)\n", n.Chain.Symbol)
+		case n.Err != nil:
+			fmt.Printf("│ %s: %s\n", n.Chain.Symbol, balanceErrorText(n.Err))
+		default:
+			fmt.Printf("│ %s: %s\n", n.Chain.Symbol, formatBalance(n.Balance, n.Chain.Decimals))
 		}
+		fmt.Printf("└─────────────────────────────────────────────────────────────────┘\n")
+		fmt.Println()
 	}
 
-	// THORChain
-	if chainFilter == "" || strings.EqualFold(chainFilter, "thorchain") || strings.EqualFold(chainFilter, "rune") {
-		thorAddr, _, _, err := address.GetAddress(vault.PublicKeyECDSA, vault.HexChainCode, common.THORChain)
-		if err == nil {
-			fmt.Printf("┌─────────────────────────────────────────────────────────────────┐\n")
-			fmt.Printf("│ THORChain                                                       │\n")
-			fmt.Printf("├─────────────────────────────────────────────────────────────────┤\n")
-			fmt.Printf("│ Address: %s\n", thorAddr)
-			fmt.Printf("│ RUNE: (use explorer to check balance)\n")
-			fmt.Printf("└─────────────────────────────────────────────────────────────────┘\n")
-			fmt.Println()
-		}
+	return nil
+}
+
+// VaultDetails is the JSON form of 'vault details'.
+type VaultDetails struct {
+	Name           string            `json:"name"`
+	PublicKeyECDSA string            `json:"public_key_ecdsa"`
+	PublicKeyEdDSA string            `json:"public_key_eddsa,omitempty"`
+	EVMAddress     string            `json:"evm_address"`
+	EVMChains      []EVMChainDetails `json:"evm_chains"`
+	Chains         []ChainDetails    `json:"chains"`
+}
+
+// ChainDetails is a non-EVM chain in 'vault details --output json'. Balance
+// is empty for chains devctl cannot fetch a balance for.
+type ChainDetails struct {
+	Chain   string `json:"chain"`
+	Symbol  string `json:"symbol"`
+	Address string `json:"address"`
+	Balance string `json:"balance,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+type EVMChainDetails struct {
+	Chain   string     `json:"chain"`
+	Symbol  string     `json:"symbol"`
+	Balance string     `json:"balance,omitempty"`
+	Error   string     `json:"error,omitempty"`
+	Nonce   ChainNonce `json:"nonce"`
+}
+
+func printVaultDetailsJSON(vault *LocalVault, evmAddr string, evmChains []ChainInfo, balances []evmChainBalance, natives []nativeChainBalance, noncesCh <-chan []ChainNonce) error {
+	details := VaultDetails{
+		Name:           vault.Name,
+		PublicKeyECDSA: vault.PublicKeyECDSA,
+		PublicKeyEdDSA: vault.PublicKeyEdDSA,
+		EVMAddress:     evmAddr,
+		EVMChains:      make([]EVMChainDetails, len(evmChains)),
+		Chains:         make([]ChainDetails, len(natives)),
 	}
 
-	// Maya
-	if chainFilter == "" || strings.EqualFold(chainFilter, "maya") || strings.EqualFold(chainFilter, "cacao") {
-		mayaAddr, _, _, err := address.GetAddress(vault.PublicKeyECDSA, vault.HexChainCode, common.MayaChain)
-		if err == nil {
-			fmt.Printf("┌─────────────────────────────────────────────────────────────────┐\n")
-			fmt.Printf("│ MayaChain                                                       │\n")
-			fmt.Printf("├─────────────────────────────────────────────────────────────────┤\n")
-			fmt.Printf("│ Address: %s\n", mayaAddr)
-			fmt.Printf("│ CACAO: (use explorer to check balance)\n")
-			fmt.Printf("└─────────────────────────────────────────────────────────────────┘\n")
-			fmt.Println()
+	for i, c := range evmChains {
+		details.EVMChains[i] = EVMChainDetails{Chain: c.Name, Symbol: c.Symbol}
+		if balances[i].Err != nil {
+			details.EVMChains[i].Error = balanceErrorText(balances[i].Err)
+			continue
+		}
+		details.EVMChains[i].Balance = formatBalance(balances[i].Native, c.Decimals)
+	}
+	for i, n := range <-noncesCh {
+		details.EVMChains[i].Nonce = n
+	}
+	for i, n := range natives {
+		details.Chains[i] = ChainDetails{Chain: n.Chain.Title, Symbol: n.Chain.Symbol, Address: n.Address}
+		switch {
+		case n.Err != nil:
+			details.Chains[i].Error = balanceErrorText(n.Err)
+		case n.Balance != nil:
+			details.Chains[i].Balance = formatBalance(n.Balance, n.Chain.Decimals)
 		}
 	}
 
-	// Cosmos chains
-	cosmosChains := []struct {
-		name   string
-		chain  common.Chain
-		symbol string
-	}{
-		{"Cosmos Hub", common.GaiaChain, "ATOM"},
-		{"Osmosis", common.Osmosis, "OSMO"},
-		{"Dydx", common.Dydx, "DYDX"},
-		{"Kujira", common.Kujira, "KUJI"},
-	}
-
-	for _, cc := range cosmosChains {
-		if chainFilter == "" || strings.EqualFold(chainFilter, cc.name) || strings.EqualFold(chainFilter, cc.symbol) {
-			addr, _, _, err := address.GetAddress(vault.PublicKeyECDSA, vault.HexChainCode, cc.chain)
-			if err == nil {
-				fmt.Printf("┌─────────────────────────────────────────────────────────────────┐\n")
-				fmt.Printf("│ %s\n", cc.name)
-				fmt.Printf("├─────────────────────────────────────────────────────────────────┤\n")
-				fmt.Printf("│ Address: %s\n", addr)
-				fmt.Printf("│ %s: (use explorer to check balance)\n", cc.symbol)
-				fmt.Printf("└─────────────────────────────────────────────────────────────────┘\n")
-				fmt.Println()
-			}
-		}
+	data, err := json.MarshalIndent(details, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal vault details: %w", err)
 	}
+	fmt.Println(string(data))
+	return nil
+}
 
-	// Solana (EdDSA)
-	if vault.PublicKeyEdDSA != "" {
-		if chainFilter == "" || strings.EqualFold(chainFilter, "solana") || strings.EqualFold(chainFilter, "sol") {
-			solAddr, _, _, err := address.GetAddress(vault.PublicKeyEdDSA, vault.HexChainCode, common.Solana)
-			if err == nil {
-				fmt.Printf("┌─────────────────────────────────────────────────────────────────┐\n")
-				fmt.Printf("│ Solana (EdDSA)                                                  │\n")
-				fmt.Printf("├─────────────────────────────────────────────────────────────────┤\n")
-				fmt.Printf("│ Address: %s\n", solAddr)
-				fmt.Printf("│ SOL: (use explorer to check balance)\n")
-				fmt.Printf("└─────────────────────────────────────────────────────────────────┘\n")
-				fmt.Println()
-			}
-		}
+// evmChainBalance is one chain's balances for 'vault details'. Tokens holds
+// the chain's tokenRegistry entries; a token whose balance could not be
+// fetched has a nil Balance.
+type evmChainBalance struct {
+	Native *big.Int
+	Err    error
+	Tokens []tokenBalance
+}
 
-		// Sui
-		if chainFilter == "" || strings.EqualFold(chainFilter, "sui") {
-			suiAddr, _, _, err := address.GetAddress(vault.PublicKeyEdDSA, vault.HexChainCode, common.Sui)
-			if err == nil {
-				fmt.Printf("┌─────────────────────────────────────────────────────────────────┐\n")
-				fmt.Printf("│ Sui (EdDSA)                                                     │\n")
-				fmt.Printf("├─────────────────────────────────────────────────────────────────┤\n")
-				fmt.Printf("│ Address: %s\n", suiAddr)
-				fmt.Printf("│ SUI: (use explorer to check balance)\n")
-				fmt.Printf("└─────────────────────────────────────────────────────────────────┘\n")
-				fmt.Println()
-			}
-		}
+type tokenBalance struct {
+	Token   TokenInfo
+	Balance *big.Int
+}
 
-		// Polkadot
-		if chainFilter == "" || strings.EqualFold(chainFilter, "polkadot") || strings.EqualFold(chainFilter, "dot") {
-			dotAddr, _, _, err := address.GetAddress(vault.PublicKeyEdDSA, vault.HexChainCode, common.Polkadot)
-			if err == nil {
-				fmt.Printf("┌─────────────────────────────────────────────────────────────────┐\n")
-				fmt.Printf("│ Polkadot (EdDSA)                                                │\n")
-				fmt.Printf("├─────────────────────────────────────────────────────────────────┤\n")
-				fmt.Printf("│ Address: %s\n", dotAddr)
-				fmt.Printf("│ DOT: (use explorer to check balance)\n")
-				fmt.Printf("└─────────────────────────────────────────────────────────────────┘\n")
-				fmt.Println()
-			}
+// fetchEVMChainBalances fetches the native and tokenRegistry balances on
+// each chain in parallel under ctx's deadline. Results keep the order of
+// chains.
+func fetchEVMChainBalances(ctx context.Context, evmAddr string, chains []ChainInfo) []evmChainBalance {
+	results := make([]evmChainBalance, len(chains))
+	var wg sync.WaitGroup
+	for i, c := range chains {
+		tokens := tokenRegistry(c)
+		results[i].Tokens = make([]tokenBalance, len(tokens))
+		for j, token := range tokens {
+			results[i].Tokens[j].Token = token
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				balance, err := getERC20Balance(ctx, c.RPCURL, token.Address, evmAddr)
+				if err == nil {
+					results[i].Tokens[j].Balance = balance
+				}
+			}()
 		}
 
-		// Ton
-		if chainFilter == "" || strings.EqualFold(chainFilter, "ton") {
-			tonAddr, _, _, err := address.GetAddress(vault.PublicKeyEdDSA, vault.HexChainCode, common.Ton)
-			if err == nil {
-				fmt.Printf("┌─────────────────────────────────────────────────────────────────┐\n")
-				fmt.Printf("│ TON (EdDSA)                                                     │\n")
-				fmt.Printf("├─────────────────────────────────────────────────────────────────┤\n")
-				fmt.Printf("│ Address: %s\n", tonAddr)
-				fmt.Printf("│ TON: (use explorer to check balance)\n")
-				fmt.Printf("└─────────────────────────────────────────────────────────────────┘\n")
-				fmt.Println()
-			}
-		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i].Native, results[i].Err = getEVMBalance(ctx, c.RPCURL, evmAddr)
+		}()
 	}
-
-	return nil
+	wg.Wait()
+	return results
 }
 
 func isEVMChain(chainFilter string) bool {
@@ -1397,7 +2408,8 @@ func isEVMChain(chainFilter string) bool {
 			return true
 		}
 	}
-	return false
+	_, ok := findSupportedChain(chainFilter)
+	return ok
 }
 
 func formatBalance(balance *big.Int, decimals int) string {
@@ -1409,7 +2421,7 @@ func formatBalance(balance *big.Int, decimals int) string {
 	return balanceFloat.Text('f', 6)
 }
 
-func getERC20Balance(rpcURL, tokenAddress, walletAddress string) (*big.Int, error) {
+func getERC20Balance(ctx context.Context, rpcURL, tokenAddress, walletAddress string) (*big.Int, error) {
 	// balanceOf(address) selector = 0x70a08231
 	// Pad address to 32 bytes
 	paddedAddress := fmt.Sprintf("000000000000000000000000%s", strings.TrimPrefix(walletAddress, "0x"))
@@ -1433,7 +2445,7 @@ func getERC20Balance(rpcURL, tokenAddress, walletAddress string) (*big.Int, erro
 		return nil, err
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
 	req, err := http.NewRequestWithContext(ctx, "POST", rpcURL, strings.NewReader(string(payloadBytes)))
@@ -0,0 +1,224 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/spf13/cobra"
+)
+
+// PluginConsistency is one plugin's installation state for a vault, as the
+// local vault file, the verifier database and MinIO each see it.
+type PluginConsistency struct {
+	PluginID         string `json:"plugin_id"`
+	LocalSigner      bool   `json:"local_signer"`
+	Installed        bool   `json:"installed"`
+	VerifierKeyshare bool   `json:"verifier_keyshare"`
+	PluginKeyshare   bool   `json:"plugin_keyshare"`
+	Warning          string `json:"warning,omitempty"`
+}
+
+// VaultConsistency is the result of checkVaultConsistency. Plugins lists
+// only plugins that at least one side knows about.
+type VaultConsistency struct {
+	Vault     string              `json:"vault"`
+	PublicKey string              `json:"public_key"`
+	Plugins   []PluginConsistency `json:"plugins"`
+}
+
+// OK reports whether every side agrees on every plugin.
+func (c *VaultConsistency) OK() bool {
+	return len(c.Warnings()) == 0
+}
+
+func (c *VaultConsistency) Warnings() []string {
+	var warnings []string
+	for _, p := range c.Plugins {
+		if p.Warning != "" {
+			warnings = append(warnings, p.Warning)
+		}
+	}
+	return warnings
+}
+
+// printWarnings prints each divergence to stderr.
+func (c *VaultConsistency) printWarnings() {
+	for _, w := range c.Warnings() {
+		progressf("%s %s\n", warnMark(), w)
+	}
+}
+
+// checkVaultConsistency compares the plugin parties among v's signers with
+// plugin_installations and the keyshares in the verifier and plugin
+// buckets. A local vault saved by a reshare that never completed lists a
+// plugin party the verifier knows nothing about, and every keysign with it
+// fails. When pluginID is set only that plugin is checked.
+func checkVaultConsistency(ctx context.Context, cfg *DevConfig, v *LocalVault, pluginID string) (*VaultConsistency, error) {
+	client, err := newMinioClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &VaultConsistency{Vault: v.Name, PublicKey: v.PublicKeyECDSA}
+	for _, spec := range pluginRegistry() {
+		if pluginID != "" && spec.ID != pluginID {
+			continue
+		}
+
+		p := PluginConsistency{PluginID: spec.ID}
+		for _, signer := range v.Signers {
+			if spec.PartyPrefix != "" && strings.HasPrefix(signer, spec.PartyPrefix+"-") {
+				p.LocalSigner = true
+			}
+		}
+		p.Installed, err = pluginInstalled(spec.ID, v.PublicKeyECDSA)
+		if err != nil {
+			return nil, err
+		}
+		key := keyshareObjectKey(spec.ID, v.PublicKeyECDSA)
+		p.VerifierKeyshare, err = keyshareExists(ctx, client, cfg.VerifierBucket, key)
+		if err != nil {
+			return nil, err
+		}
+		if spec.Bucket != "" {
+			p.PluginKeyshare, err = keyshareExists(ctx, client, spec.Bucket, key)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if !p.LocalSigner && !p.Installed && !p.VerifierKeyshare && !p.PluginKeyshare {
+			continue
+		}
+		p.Warning = consistencyWarning(spec, p)
+		result.Plugins = append(result.Plugins, p)
+	}
+	return result, nil
+}
+
+func consistencyWarning(spec PluginSpec, p PluginConsistency) string {
+	switch {
+	case p.LocalSigner && !p.Installed:
+		return fmt.Sprintf("local vault lists a %s party but no installation exists - run 'devctl plugin uninstall %s' and reinstall, or 'devctl vault import --force'", spec.Name, spec.ID)
+	case p.Installed && !p.LocalSigner:
+		return fmt.Sprintf("%s is installed but the local vault does not list its party - re-import the reshared vault with 'devctl vault import --force', or uninstall and reinstall", spec.Name)
+	case p.Installed && !p.VerifierKeyshare:
+		return fmt.Sprintf("%s is installed but the verifier has no keyshare for this vault - run 'devctl plugin uninstall %s' and reinstall", spec.Name, spec.ID)
+	case p.Installed && spec.Bucket != "" && !p.PluginKeyshare:
+		return fmt.Sprintf("%s is installed but %s has no keyshare for this vault - run 'devctl plugin uninstall %s' and reinstall", spec.Name, spec.Bucket, spec.ID)
+	case !p.Installed:
+		return fmt.Sprintf("%s keyshares remain in MinIO without an installation - run 'devctl plugin uninstall %s'", spec.Name, spec.ID)
+	}
+	return ""
+}
+
+// pluginInstalled reports whether plugin_installations has a row for the
+// plugin and vault. Unlike checkPluginInstallation, a database that cannot
+// be queried is an error rather than "not installed".
+func pluginInstalled(pluginID, publicKey string) (bool, error) {
+	cmd := psqlCommand("vultisig-verifier", "-t", "-c",
+		fmt.Sprintf("SELECT COUNT(*) FROM plugin_installations WHERE plugin_id='%s' AND public_key='%s'", pluginID, publicKey))
+	output, err := cmd.Output()
+	if err != nil {
+		return false, fmt.Errorf("query plugin_installations: %w", err)
+	}
+	return strings.TrimSpace(string(output)) != "0", nil
+}
+
+func keyshareExists(ctx context.Context, client *s3.S3, bucket, key string) (bool, error) {
+	_, err := client.HeadObjectWithContext(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		if isS3NotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("check %s/%s: %w", bucket, key, s3ErrorMessage(err))
+	}
+	return true, nil
+}
+
+// activeVaultConsistency runs checkVaultConsistency for the active vault.
+func activeVaultConsistency(ctx context.Context, cfg *DevConfig, pluginID string) (*VaultConsistency, error) {
+	if cfg.PublicKeyECDSA == "" {
+		return nil, fmt.Errorf("no vault configured. Run 'devctl vault import' first")
+	}
+	vault, err := LoadVault(cfg.PublicKeyECDSA[:16])
+	if err != nil {
+		return nil, fmt.Errorf("load vault: %w", err)
+	}
+	return checkVaultConsistency(ctx, cfg, vault, pluginID)
+}
+
+func newPluginInstalledCmd() *cobra.Command {
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "installed",
+		Short: "Show the active vault's plugin installations and check they agree",
+		Long: `Show, for each plugin the active vault is involved with, whether the local
+vault lists the plugin's party, whether plugin_installations has a row, and
+whether the verifier and plugin buckets hold its keyshare. Divergences, e.g.
+a local vault that lists a plugin party the verifier never installed, are
+printed with the command that repairs them, and make the command exit
+non-zero.
+`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if output != "table" && output != "json" {
+				return fmt.Errorf("unknown output format %q (use table or json)", output)
+			}
+			return runPluginInstalled(cmd.Context(), output)
+		},
+	}
+
+	cmd.Flags().StringVarP(&output, "output", "o", "table", "Output format: table or json")
+	return cmd
+}
+
+func runPluginInstalled(ctx context.Context, output string) error {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	result, err := activeVaultConsistency(ctx, cfg, "")
+	if err != nil {
+		return err
+	}
+
+	if output == "json" {
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal result: %w", err)
+		}
+		fmt.Println(string(data))
+	} else {
+		yesNo := func(b bool) string {
+			if b {
+				return okMark()
+			}
+			return "-"
+		}
+		rows := make([][]string, 0, len(result.Plugins))
+		for _, p := range result.Plugins {
+			rows = append(rows, []string{p.PluginID, yesNo(p.LocalSigner), yesNo(p.Installed), yesNo(p.VerifierKeyshare), yesNo(p.PluginKeyshare)})
+		}
+		if len(rows) == 0 {
+			fmt.Printf("No plugins installed for %s.\n", result.Vault)
+		} else {
+			printTable([]string{"PLUGIN", "LOCAL SIGNER", "INSTALLED", "VERIFIER KEYSHARE", "PLUGIN KEYSHARE"}, rows)
+		}
+	}
+
+	if !result.OK() {
+		result.printWarnings()
+		return fmt.Errorf("local vault and verifier disagree on %d plugin(s)", len(result.Warnings()))
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+)
+
+func newVaultDeleteCmd() *cobra.Command {
+	var yes bool
+
+	cmd := &cobra.Command{
+		Use:   "delete [name|public-key-prefix|fingerprint]",
+		Short: "Delete a vault from the local vault store",
+		Long: `Delete a vault file from ~/.vultisig/vaults.
+
+The vault is found the same way 'vault use' finds it: by name, public key
+prefix or fingerprint. An argument that matches more than one vault is
+refused and the candidates are listed. Without an argument the active
+vault is deleted.
+
+The vault's name has to be typed to confirm, unless --yes is given. If it
+was the active vault, it and its auth token are cleared from devctl.json.
+The keyshare is gone for good unless you have a backup ('vault export').
+
+Example:
+  devctl vault delete ab12-cd34
+  devctl vault delete DevVault
+  devctl vault delete 02a1b2c3 --yes
+`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			prefix := ""
+			if len(args) == 1 {
+				prefix = args[0]
+			}
+			return runVaultDelete(prefix, yes)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Skip the confirmation prompt")
+
+	return cmd
+}
+
+func runVaultDelete(prefix string, yes bool) error {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	if prefix == "" {
+		if cfg.PublicKeyECDSA == "" {
+			return fmt.Errorf("no active vault; pass the public key prefix or fingerprint of the vault to delete")
+		}
+		prefix = cfg.PublicKeyECDSA
+	}
+
+	matches, err := matchVaultFiles(prefix)
+	if err != nil {
+		return err
+	}
+	switch len(matches) {
+	case 0:
+		return fmt.Errorf("no vault matches %q; see 'devctl vault list'", prefix)
+	case 1:
+	default:
+		return ambiguousVaultError(prefix, matches)
+	}
+
+	// An unreadable file can still be deleted by its name.
+	m := matches[0]
+	vault := m.Vault
+	if m.Err != nil {
+		vault.Name = filepath.Base(m.Path)
+		progressf("%s %v\n", warnMark(), m.Err)
+	}
+	active := vault.PublicKeyECDSA != "" && vault.PublicKeyECDSA == cfg.PublicKeyECDSA
+
+	fmt.Printf("Vault:       %s\n", vault.Name)
+	fmt.Printf("Fingerprint: %s\n", VaultFingerprint(vault.PublicKeyECDSA))
+	fmt.Printf("File:        %s\n", m.Path)
+	if vault.WatchOnly {
+		fmt.Println("Mode:        watch-only (no keyshare)")
+	} else {
+		fmt.Printf("Keyshares:   %d %s deleted with the file; keep a backup ('devctl vault export') if you need them\n", len(vault.KeyShares), warnMark())
+	}
+	if active {
+		fmt.Println("Active:      yes; devctl.json will have no active vault afterwards")
+	}
+	fmt.Println()
+
+	err = confirmDestructive(fmt.Sprintf("Delete vault %s?", vault.Name), vault.Name, yes)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(m.Path)
+	if err != nil {
+		return fmt.Errorf("delete vault: %w", err)
+	}
+	fmt.Printf("%s Deleted %s\n", okMark(), m.Path)
+
+	if active {
+		cfg.VaultName = ""
+		cfg.PublicKeyECDSA = ""
+		cfg.PublicKeyEdDSA = ""
+		delete(cfg.AuthTokens, vault.PublicKeyECDSA)
+		err = SaveConfig(cfg)
+		if err != nil {
+			return fmt.Errorf("save config: %w", err)
+		}
+		fmt.Printf("%s Cleared the active vault from devctl.json; pick another with 'devctl vault use'\n", okMark())
+	}
+
+	if vault.PublicKeyECDSA != "" {
+		parties, _ := filepath.Glob(partyVaultPath(vault.PublicKeyECDSA, "*"))
+		if len(parties) > 0 {
+			fmt.Printf("  %d local party share(s) of this vault remain in %s\n", len(parties), PartyVaultStoragePath())
+		}
+	}
+	return nil
+}
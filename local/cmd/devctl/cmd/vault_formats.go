@@ -0,0 +1,306 @@
+package cmd
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	keygen "github.com/vultisig/commondata/go/vultisig/keygen/v1"
+	"github.com/vultisig/commondata/go/vultisig/vault/v1"
+	"github.com/vultisig/vultisig-go/common"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// AndroidBackupVault is the vault JSON written by the Android app into .bak
+// backups. The file holds this JSON either as-is or hex/base64 encoded, and
+// optionally AES-GCM encrypted with the backup password.
+type AndroidBackupVault struct {
+	Name           string     `json:"name"`
+	PublicKeyECDSA string     `json:"pubKeyECDSA"`
+	PublicKeyEdDSA string     `json:"pubKeyEDDSA"`
+	HexChainCode   string     `json:"hexChainCode"`
+	LocalPartyID   string     `json:"localPartyID"`
+	Signers        []string   `json:"signers"`
+	KeyShares      []KeyShare `json:"keyshares"`
+	ResharePrefix  string     `json:"resharePrefix"`
+	LibType        string     `json:"libType"`
+}
+
+// ExtensionVault is the vault JSON exported by the browser extension.
+// Keyshares are keyed by signature algorithm instead of by public key, and
+// createdAt is milliseconds since the epoch.
+type ExtensionVault struct {
+	Name       string `json:"name"`
+	PublicKeys struct {
+		ECDSA string `json:"ecdsa"`
+		EdDSA string `json:"eddsa"`
+	} `json:"publicKeys"`
+	HexChainCode  string            `json:"hexChainCode"`
+	LocalPartyID  string            `json:"localPartyId"`
+	Signers       []string          `json:"signers"`
+	KeyShares     map[string]string `json:"keyShares"`
+	ResharePrefix string            `json:"resharePrefix"`
+	CreatedAt     int64             `json:"createdAt"`
+	LibType       string            `json:"libType"`
+}
+
+// publicVaultKind marks the files written by 'vault export --public-only'.
+const publicVaultKind = "devctl-public-vault"
+
+// PublicVaultExport is the non-secret part of a vault, for attaching to bug
+// reports. It has no keyshares and cannot be imported.
+type PublicVaultExport struct {
+	Kind           string         `json:"kind"`
+	DevctlVersion  string         `json:"devctl_version"`
+	GeneratedAt    string         `json:"generated_at"`
+	Name           string         `json:"name"`
+	PublicKeyECDSA string         `json:"public_key_ecdsa"`
+	PublicKeyEdDSA string         `json:"public_key_eddsa"`
+	HexChainCode   string         `json:"hex_chain_code"`
+	Signers        []string       `json:"signers"`
+	LibType        LibType        `json:"lib_type"`
+	Addresses      []VaultAddress `json:"addresses"`
+}
+
+// isPublicVaultExport reports whether data is a 'vault export --public-only'
+// file.
+func isPublicVaultExport(data []byte) bool {
+	var probe struct {
+		Kind string `json:"kind"`
+	}
+	err := json.Unmarshal(data, &probe)
+	return err == nil && probe.Kind == publicVaultKind
+}
+
+// parseVaultBackup detects the format of a vault backup and converts it to a
+// LocalVault. The returned format names the detected source for the import
+// summary. Formats are tried from most to least specific:
+//
+//   - .vult (base64-encoded VaultContainer protobuf)
+//   - Android .bak
+//   - browser extension JSON export
+//   - iOS backup JSON wrapper
+//   - bare LocalVault JSON
+func parseVaultBackup(file string, data []byte, password string) (LocalVault, string, error) {
+	pbVault, vultErr := common.DecryptVaultFromBackup(password, data)
+	if vultErr == nil {
+		return convertProtoVaultToLocal(pbVault), ".vult (protobuf)", nil
+	}
+
+	if strings.EqualFold(filepath.Ext(file), ".bak") {
+		vault, err := parseAndroidBackup(data, password)
+		if err != nil {
+			return LocalVault{}, "", fmt.Errorf("parse Android backup: %w", err)
+		}
+		return vault, "Android .bak", nil
+	}
+
+	var probe map[string]json.RawMessage
+	jsonErr := json.Unmarshal(data, &probe)
+	if jsonErr != nil {
+		return LocalVault{}, "", fmt.Errorf("parse vault file: protobuf error: %v, json error: %v", vultErr, jsonErr)
+	}
+
+	if _, ok := probe["publicKeys"]; ok {
+		var ext ExtensionVault
+		err := json.Unmarshal(data, &ext)
+		if err != nil {
+			return LocalVault{}, "", fmt.Errorf("parse extension export: %w", err)
+		}
+		return convertExtensionVaultToLocal(ext), "Browser extension JSON", nil
+	}
+
+	var backup BackupVault
+	err := json.Unmarshal(data, &backup)
+	if err == nil && backup.Version != "" {
+		return backup.Vault, fmt.Sprintf("iOS backup (v%s)", backup.Version), nil
+	}
+
+	var localVault LocalVault
+	err = json.Unmarshal(data, &localVault)
+	if err != nil {
+		return LocalVault{}, "", fmt.Errorf("parse vault file: protobuf error: %v, json error: %v", vultErr, err)
+	}
+	return localVault, "JSON", nil
+}
+
+// parseAndroidBackup unwraps the encoding and optional encryption of an
+// Android .bak file and converts the vault JSON inside.
+func parseAndroidBackup(data []byte, password string) (LocalVault, error) {
+	raw := bytes.TrimSpace(data)
+
+	if !json.Valid(raw) {
+		decoded, err := hex.DecodeString(string(raw))
+		if err != nil {
+			decoded, err = base64.StdEncoding.DecodeString(string(raw))
+			if err != nil {
+				return LocalVault{}, fmt.Errorf("not JSON, hex or base64")
+			}
+		}
+		raw = decoded
+	}
+
+	if !json.Valid(raw) {
+		if password == "" {
+			return LocalVault{}, fmt.Errorf("backup is encrypted - password required (use --password)")
+		}
+		decrypted, err := common.DecryptVault(password, raw)
+		if err != nil {
+			return LocalVault{}, fmt.Errorf("decrypt backup: %w", err)
+		}
+		raw = decrypted
+	}
+
+	var android AndroidBackupVault
+	err := json.Unmarshal(raw, &android)
+	if err != nil {
+		return LocalVault{}, fmt.Errorf("unmarshal vault: %w", err)
+	}
+
+	return LocalVault{
+		Name:           android.Name,
+		PublicKeyECDSA: android.PublicKeyECDSA,
+		PublicKeyEdDSA: android.PublicKeyEdDSA,
+		HexChainCode:   android.HexChainCode,
+		LocalPartyID:   android.LocalPartyID,
+		Signers:        android.Signers,
+		KeyShares:      android.KeyShares,
+		ResharePrefix:  android.ResharePrefix,
+		LibType:        parseLibTypeName(android.LibType),
+	}, nil
+}
+
+func convertExtensionVaultToLocal(ext ExtensionVault) LocalVault {
+	keyShares := make([]KeyShare, 0, len(ext.KeyShares))
+	if share, ok := ext.KeyShares["ecdsa"]; ok {
+		keyShares = append(keyShares, KeyShare{PubKey: ext.PublicKeys.ECDSA, Keyshare: share})
+	}
+	if share, ok := ext.KeyShares["eddsa"]; ok {
+		keyShares = append(keyShares, KeyShare{PubKey: ext.PublicKeys.EdDSA, Keyshare: share})
+	}
+
+	createdAt := ""
+	if ext.CreatedAt > 0 {
+		createdAt = time.UnixMilli(ext.CreatedAt).UTC().Format(time.RFC3339)
+	}
+
+	return LocalVault{
+		Name:           ext.Name,
+		PublicKeyECDSA: ext.PublicKeys.ECDSA,
+		PublicKeyEdDSA: ext.PublicKeys.EdDSA,
+		HexChainCode:   ext.HexChainCode,
+		LocalPartyID:   ext.LocalPartyID,
+		Signers:        ext.Signers,
+		KeyShares:      keyShares,
+		ResharePrefix:  ext.ResharePrefix,
+		CreatedAt:      createdAt,
+		LibType:        parseLibTypeName(ext.LibType),
+	}
+}
+
+// parseLibTypeName maps the "GG20"/"DKLS" names used by the apps to the
+// LibType stored in LocalVault. Unknown or empty names mean GG20.
+func parseLibTypeName(name string) LibType {
+	if strings.EqualFold(name, "DKLS") {
+		return libTypeDKLS
+	}
+	return libTypeGG20
+}
+
+// convertLocalVaultToProto is the inverse of convertProtoVaultToLocal, for
+// writing .vult backups the apps can import.
+func convertLocalVaultToProto(vault *LocalVault) (*v1.Vault, error) {
+	keyShares := make([]*v1.Vault_KeyShare, 0, len(vault.KeyShares))
+	for _, ks := range vault.KeyShares {
+		keyShares = append(keyShares, &v1.Vault_KeyShare{
+			PublicKey: ks.PubKey,
+			Keyshare:  ks.Keyshare,
+		})
+	}
+
+	var createdAt *timestamppb.Timestamp
+	if vault.CreatedAt != "" {
+		t, err := time.Parse(time.RFC3339, vault.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("parse createdAt %q: %w", vault.CreatedAt, err)
+		}
+		createdAt = timestamppb.New(t)
+	}
+
+	return &v1.Vault{
+		Name:           vault.Name,
+		PublicKeyEcdsa: vault.PublicKeyECDSA,
+		PublicKeyEddsa: vault.PublicKeyEdDSA,
+		HexChainCode:   vault.HexChainCode,
+		LocalPartyId:   vault.LocalPartyID,
+		Signers:        vault.Signers,
+		KeyShares:      keyShares,
+		ResharePrefix:  vault.ResharePrefix,
+		CreatedAt:      createdAt,
+		LibType:        keygen.LibType(vault.LibType),
+	}, nil
+}
+
+// encodeVultFile serializes vault as a .vult backup: the Vault protobuf,
+// encrypted with password if one is given, inside a base64-encoded
+// VaultContainer. parseVultFile and common.DecryptVaultFromBackup read it
+// back.
+func encodeVultFile(vault *LocalVault, password string) ([]byte, error) {
+	pbVault, err := convertLocalVaultToProto(vault)
+	if err != nil {
+		return nil, err
+	}
+	vaultBytes, err := proto.Marshal(pbVault)
+	if err != nil {
+		return nil, fmt.Errorf("marshal vault: %w", err)
+	}
+
+	if password != "" {
+		vaultBytes, err = encryptVault(password, vaultBytes)
+		if err != nil {
+			return nil, fmt.Errorf("encrypt vault: %w", err)
+		}
+	}
+
+	container := &v1.VaultContainer{
+		Version:     1,
+		Vault:       base64.StdEncoding.EncodeToString(vaultBytes),
+		IsEncrypted: password != "",
+	}
+	containerBytes, err := proto.Marshal(container)
+	if err != nil {
+		return nil, fmt.Errorf("marshal vault container: %w", err)
+	}
+	return []byte(base64.StdEncoding.EncodeToString(containerBytes)), nil
+}
+
+// encryptVault encrypts data the way common.DecryptVault expects: AES-256-GCM
+// keyed with the SHA-256 of the password, with the nonce prepended to the
+// ciphertext.
+func encryptVault(password string, data []byte) ([]byte, error) {
+	key := sha256.Sum256([]byte(password))
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	_, err = rand.Read(nonce)
+	if err != nil {
+		return nil, err
+	}
+	return gcm.Seal(nonce, nonce, data, nil), nil
+}
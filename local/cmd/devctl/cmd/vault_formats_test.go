@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestVultFileRoundTrip(t *testing.T) {
+	want := LocalVault{
+		Name:           "Round Trip",
+		PublicKeyECDSA: "024222a3ac1f41e14f0415d2a88c536e3428000ce799aa864e32303c78a565d948",
+		PublicKeyEdDSA: "8c2a1e7f5b0d4f6a9e3c7b1d2f4a6c8e0b2d4f6a8c0e2a4c6e8a0c2e4a6c8e0a",
+		HexChainCode:   "c39c57cd4127a5c5d6c8583f3f12d7be26e7eed8c398e7ee9926cd33845cae1b",
+		LocalPartyID:   "devctl-1a2b",
+		Signers:        []string{"devctl-1a2b", "Server-12345"},
+		KeyShares: []KeyShare{
+			{PubKey: "024222a3ac1f41e14f0415d2a88c536e3428000ce799aa864e32303c78a565d948", Keyshare: "ecdsa-share"},
+			{PubKey: "8c2a1e7f5b0d4f6a9e3c7b1d2f4a6c8e0b2d4f6a8c0e2a4c6e8a0c2e4a6c8e0a", Keyshare: "eddsa-share"},
+		},
+		ResharePrefix: "5f3c",
+		CreatedAt:     "2026-01-02T03:04:05Z",
+		LibType:       libTypeDKLS,
+	}
+
+	for _, password := range []string{"", "hunter2"} {
+		data, err := encodeVultFile(&want, password)
+		if err != nil {
+			t.Fatalf("password %q: export: %v", password, err)
+		}
+		got, backupFormat, err := parseVaultBackup("backup.vult", data, password)
+		if err != nil {
+			t.Fatalf("password %q: import: %v", password, err)
+		}
+		if backupFormat != ".vult (protobuf)" {
+			t.Errorf("password %q: imported as %s", password, backupFormat)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("password %q: imported\n%+v\nwant\n%+v", password, got, want)
+		}
+	}
+
+	data, err := encodeVultFile(&want, "hunter2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _, err = parseVaultBackup("backup.vult", data, "wrong")
+	if err == nil {
+		t.Error("import with the wrong password succeeded")
+	}
+}
@@ -0,0 +1,185 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/spf13/cobra"
+	"github.com/vultisig/vultisig-go/address"
+	"github.com/vultisig/vultisig-go/common"
+
+	"github.com/vultisig/vultisig-cluster/local/cmd/devctl/format"
+)
+
+// ChainNonce is an address's transaction count on one EVM chain. Pending
+// above Latest means transactions sit in the mempool; a lasting gap usually
+// is a stuck transaction blocking every later nonce.
+type ChainNonce struct {
+	Chain   string `json:"chain"`
+	Latest  uint64 `json:"latest"`
+	Pending uint64 `json:"pending"`
+	Gap     uint64 `json:"gap"`
+	Error   string `json:"error,omitempty"`
+}
+
+func (n ChainNonce) Stuck() bool {
+	return n.Error == "" && n.Gap > 0
+}
+
+func newVaultNonceCmd() *cobra.Command {
+	var chain string
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "nonce",
+		Short: "Show the vault's latest and pending nonce on EVM chains",
+		Long: `Show the active vault's transaction count on each EVM chain, for the
+"latest" and "pending" blocks. A pending nonce above the latest one means
+transactions are waiting in the mempool; if the gap persists, an underpriced
+or invalid transaction is likely blocking the policy's later transactions.
+
+Example:
+  devctl vault nonce
+  devctl vault nonce --chain base --output json
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if output != "table" && output != "json" {
+				return fmt.Errorf("unknown output format %q (use table or json)", output)
+			}
+			return runVaultNonce(chain, output == "json")
+		},
+	}
+
+	cmd.Flags().StringVarP(&chain, "chain", "c", "", "Specific chain to check (ethereum, arbitrum, base, etc.)")
+	cmd.Flags().StringVarP(&output, "output", "o", "table", "Output format: table or json")
+
+	return cmd
+}
+
+func runVaultNonce(chainFilter string, asJSON bool) error {
+	vaults, err := ListVaults()
+	if err != nil || len(vaults) == 0 {
+		return fmt.Errorf("no vaults found. Import a vault first: devctl vault import")
+	}
+	vault := vaults[0]
+
+	evmAddr, _, _, err := address.GetAddress(vault.PublicKeyECDSA, vault.HexChainCode, common.Ethereum)
+	if err != nil {
+		return fmt.Errorf("derive EVM address: %w", err)
+	}
+
+	var chains []ChainInfo
+	for _, c := range chainRegistry() {
+		if chainFilter == "" || c.Matches(chainFilter) {
+			chains = append(chains, c)
+		}
+	}
+	if len(chains) == 0 {
+		return fmt.Errorf("unknown EVM chain: %s", chainFilter)
+	}
+
+	nonces := fetchNonces(chains, evmAddr)
+
+	if asJSON {
+		data, err := json.MarshalIndent(nonces, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal nonces: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	progressf("Address: %s\n\n", evmAddr)
+	rows := make([][]string, 0, len(nonces))
+	for _, n := range nonces {
+		rows = append(rows, []string{n.Chain, nonceValue(n, n.Latest), nonceValue(n, n.Pending), nonceStatus(n)})
+	}
+	printTable([]string{"CHAIN", "LATEST", "PENDING", "STATUS"}, rows)
+	return nil
+}
+
+// fetchNonces queries every chain concurrently, so the total latency is that
+// of the slowest RPC. Results keep the order of chains.
+func fetchNonces(chains []ChainInfo, addr string) []ChainNonce {
+	nonces := make([]ChainNonce, len(chains))
+
+	var wg sync.WaitGroup
+	for i, c := range chains {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			nonces[i] = fetchNonce(c, addr)
+		}()
+	}
+	wg.Wait()
+
+	return nonces
+}
+
+func fetchNonce(c ChainInfo, addr string) ChainNonce {
+	n := ChainNonce{Chain: c.Name}
+
+	var latestErr, pendingErr error
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		n.Latest, latestErr = getEVMNonce(c.RPCURL, addr, "latest")
+	}()
+	go func() {
+		defer wg.Done()
+		n.Pending, pendingErr = getEVMNonce(c.RPCURL, addr, "pending")
+	}()
+	wg.Wait()
+
+	switch {
+	case latestErr != nil:
+		n.Error = latestErr.Error()
+	case pendingErr != nil:
+		n.Error = pendingErr.Error()
+	case n.Pending > n.Latest:
+		n.Gap = n.Pending - n.Latest
+	}
+	return n
+}
+
+func getEVMNonce(rpcURL, addr, block string) (uint64, error) {
+	raw, err := jsonRPC(rpcURL, "eth_getTransactionCount", []interface{}{addr, block})
+	if err != nil {
+		return 0, err
+	}
+
+	var count string
+	err = json.Unmarshal(raw, &count)
+	if err != nil {
+		return 0, fmt.Errorf("parse transaction count: %w", err)
+	}
+	v, err := parseHexBig(count)
+	if err != nil {
+		return 0, err
+	}
+	if !v.IsUint64() {
+		return 0, fmt.Errorf("transaction count out of range: %s", count)
+	}
+	return v.Uint64(), nil
+}
+
+func nonceValue(n ChainNonce, v uint64) string {
+	if n.Error != "" {
+		return "-"
+	}
+	return strconv.FormatUint(v, 10)
+}
+
+func nonceStatus(n ChainNonce) string {
+	switch {
+	case n.Error != "":
+		return fmt.Sprintf("%s error: %s", failMark(), format.Truncate(n.Error, 40))
+	case n.Stuck():
+		return fmt.Sprintf("%s %d pending (likely stuck tx)", warnMark(), n.Gap)
+	default:
+		return okMark()
+	}
+}
@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+)
+
+// partyIDRe limits party IDs to what the relay and the verifier's party
+// naming handle: letters, digits, '-' and '_'.
+var partyIDRe = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// newLocalPartyID returns the party ID for a new keygen: local_party_prefix
+// from cluster.yaml (default "devctl") and a random suffix.
+func newLocalPartyID() (string, error) {
+	prefix := clusterConfigOrDefaults().LocalPartyPrefix
+	if prefix == "" {
+		prefix = DefaultLocalParty
+	}
+	if !partyIDRe.MatchString(prefix) {
+		return "", fmt.Errorf("local_party_prefix %q in cluster.yaml may only contain letters, digits, '-' and '_'", prefix)
+	}
+	return fmt.Sprintf("%s-%s", prefix, uuid.New().String()[:8]), nil
+}
+
+// checkLocalParty warns when the vault's local party ID is not among its
+// signers. That happens when a share exported from another device is
+// imported with the wrong party ID; the relay session then waits for a
+// party that never joins.
+func checkLocalParty(v *LocalVault) {
+	if len(v.Signers) == 0 || slices.Contains(v.Signers, v.LocalPartyID) {
+		return
+	}
+	progressf("\n%s WARNING: local party ID %q is not one of the vault's signers %v.\n", warnMark(), v.LocalPartyID, v.Signers)
+	progressln("  The keyshare was probably imported from a different device; the reshare is")
+	progressln("  likely to time out waiting for parties. If the share belongs to one of the")
+	progressln("  signers, repair it with: devctl vault set-party-id <signer>")
+	progressln("")
+}
+
+func newVaultSetPartyIDCmd() *cobra.Command {
+	var force, yes bool
+
+	cmd := &cobra.Command{
+		Use:   "set-party-id <party-id>",
+		Short: "Change the current vault's local party ID (repairs mismatched imports)",
+		Long: `Change the local party ID stored with the current vault.
+
+This is an escape hatch for imported shares whose party ID does not match
+the signer the share was created for, which 'vault info' and reshares flag.
+The party ID has to be the one the keyshare was generated under: a wrong ID
+makes every keysign and reshare fail until it is set back. The keyshare
+itself is not changed.
+
+The new ID must be one of the vault's signers unless --force is given.
+
+Example:
+  devctl vault info                       # shows the signers
+  devctl vault set-party-id iPhone-5C9
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runVaultSetPartyID(args[0], force, yes)
+		},
+	}
+
+	cmd.Flags().BoolVar(&force, "force", false, "Allow a party ID that is not among the vault's signers")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Skip the confirmation prompt")
+
+	return cmd
+}
+
+func runVaultSetPartyID(partyID string, force, yes bool) error {
+	if !partyIDRe.MatchString(partyID) {
+		return fmt.Errorf("party ID %q may only contain letters, digits, '-' and '_'", partyID)
+	}
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	if cfg.PublicKeyECDSA == "" {
+		return fmt.Errorf("no vault configured")
+	}
+	vault, err := LoadVault(cfg.PublicKeyECDSA[:16])
+	if err != nil {
+		return fmt.Errorf("load vault: %w", err)
+	}
+
+	if vault.LocalPartyID == partyID {
+		fmt.Printf("Vault %s already uses party ID %s.\n", vault.Name, partyID)
+		return nil
+	}
+	if !slices.Contains(vault.Signers, partyID) && !force {
+		return fmt.Errorf("%q is not one of the vault's signers (%s); pass --force to set it anyway", partyID, strings.Join(vault.Signers, ", "))
+	}
+
+	fmt.Printf("%s Changing the local party ID of vault %s (%s)\n", warnMark(), vault.Name, VaultFingerprint(vault.PublicKeyECDSA))
+	fmt.Printf("  from: %s\n", vault.LocalPartyID)
+	fmt.Printf("  to:   %s\n", partyID)
+	fmt.Println("  Only do this if the keyshare was generated for the new party ID; otherwise")
+	fmt.Println("  keysign and reshare fail until the old ID is restored.")
+	err = confirmDestructive("Change the local party ID?", vault.Name, yes)
+	if err != nil {
+		return err
+	}
+
+	old := vault.LocalPartyID
+	vault.LocalPartyID = partyID
+	err = SaveVault(vault)
+	if err != nil {
+		return fmt.Errorf("save vault: %w", err)
+	}
+
+	fmt.Printf("%s Local party ID set to %s (was %s)\n", okMark(), partyID, old)
+	return nil
+}
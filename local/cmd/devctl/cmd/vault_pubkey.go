@@ -0,0 +1,190 @@
+package cmd
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/spf13/cobra"
+	"github.com/vultisig/mobile-tss-lib/tss"
+	"github.com/vultisig/vultisig-go/address"
+	"github.com/vultisig/vultisig-go/common"
+)
+
+// pubkeyAddressChains are the ECDSA chains address.GetAddress supports. A
+// chain is shown for a path when it is the chain's standard derive path.
+var pubkeyAddressChains = []common.Chain{
+	common.Ethereum, common.Arbitrum, common.Base, common.Polygon, common.BscChain,
+	common.Avalanche, common.Optimism, common.Blast, common.CronosChain, common.Zksync,
+	common.Mantle, common.Bitcoin, common.BitcoinCash, common.Litecoin, common.Zcash,
+	common.GaiaChain, common.THORChain, common.MayaChain, common.Kujira, common.Dydx,
+	common.Terra, common.Osmosis, common.Noble,
+}
+
+// DerivedPubKey is the output of 'vault pubkey'.
+type DerivedPubKey struct {
+	DerivePath   string         `json:"derive_path,omitempty"`
+	Algorithm    string         `json:"algorithm"`
+	Compressed   string         `json:"compressed"`
+	Uncompressed string         `json:"uncompressed,omitempty"`
+	Addresses    []VaultAddress `json:"addresses"`
+}
+
+func newVaultPubkeyCmd() *cobra.Command {
+	var derivePath string
+	var isEdDSA bool
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "pubkey",
+		Short: "Show the vault's child public key at a derivation path",
+		Long: `Show the child public key of the active vault at a derivation path, as
+plugin servers need it to verify signatures.
+
+ECDSA keys are derived with the same non-hardened BIP32 derivation the address
+library uses (apostrophes in the path are accepted and ignored). The output has
+the compressed and uncompressed key and the addresses of every chain whose
+standard path is --derive; for other paths the EVM and Bitcoin address formats
+of the key are shown. Each chain address is cross-checked against
+address.GetAddress, and a mismatch is an error.
+
+EdDSA keys are not derived: --eddsa prints the vault's root EdDSA key and its
+Solana and Sui addresses.
+
+Example:
+  devctl vault pubkey --derive "m/44'/60'/0'/0/0"
+  devctl vault pubkey --eddsa
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if output != "text" && output != "json" {
+				return fmt.Errorf("unknown output format %q (use text or json)", output)
+			}
+			return runVaultPubkey(derivePath, isEdDSA, output == "json")
+		},
+	}
+
+	cmd.Flags().StringVarP(&derivePath, "derive", "d", "m/44'/60'/0'/0/0", "BIP32 derivation path (ECDSA)")
+	cmd.Flags().BoolVar(&isEdDSA, "eddsa", false, "Show the EdDSA public key (not derived)")
+	cmd.Flags().StringVarP(&output, "output", "o", "text", "Output format: text or json")
+
+	return cmd
+}
+
+func runVaultPubkey(derivePath string, isEdDSA, asJSON bool) error {
+	vaults, err := ListVaults()
+	if err != nil || len(vaults) == 0 {
+		return fmt.Errorf("no vaults found. Import a vault first: devctl vault import")
+	}
+	vault := vaults[0]
+
+	var result *DerivedPubKey
+	if isEdDSA {
+		result, err = eddsaPubKey(vault)
+	} else {
+		result, err = deriveECDSAPubKey(vault, derivePath)
+	}
+	if err != nil {
+		return err
+	}
+
+	if asJSON {
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal public key: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	fmt.Println("=== Vault Public Key ===")
+	fmt.Printf("Vault: %s\n", vault.Name)
+	if result.DerivePath != "" {
+		fmt.Printf("Derive Path: %s\n", result.DerivePath)
+	}
+	fmt.Printf("Algorithm: %s\n", result.Algorithm)
+	fmt.Printf("Compressed: %s\n", result.Compressed)
+	if result.Uncompressed != "" {
+		fmt.Printf("Uncompressed: %s\n", result.Uncompressed)
+	}
+	fmt.Println()
+	fmt.Println("Addresses:")
+	for _, a := range result.Addresses {
+		fmt.Printf("  %s: %s\n", a.Chain, a.Address)
+	}
+
+	return nil
+}
+
+func deriveECDSAPubKey(vault *LocalVault, derivePath string) (*DerivedPubKey, error) {
+	child, err := tss.GetDerivedPubKey(vault.PublicKeyECDSA, vault.HexChainCode, derivePath, false)
+	if err != nil {
+		return nil, fmt.Errorf("derive public key at %s: %w", derivePath, err)
+	}
+
+	childBytes, err := hex.DecodeString(child)
+	if err != nil {
+		return nil, fmt.Errorf("decode derived key: %w", err)
+	}
+	pubKey, err := btcec.ParsePubKey(childBytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse derived key: %w", err)
+	}
+
+	result := &DerivedPubKey{
+		DerivePath:   derivePath,
+		Algorithm:    "ECDSA (secp256k1)",
+		Compressed:   hex.EncodeToString(pubKey.SerializeCompressed()),
+		Uncompressed: hex.EncodeToString(pubKey.SerializeUncompressed()),
+	}
+
+	for _, chain := range pubkeyAddressChains {
+		if chain.GetDerivePath() != derivePath {
+			continue
+		}
+		addr, chainPubKey, _, err := address.GetAddress(vault.PublicKeyECDSA, vault.HexChainCode, chain)
+		if err != nil {
+			return nil, fmt.Errorf("derive %s address: %w", chain, err)
+		}
+		if chainPubKey != result.Compressed {
+			return nil, fmt.Errorf("%s: derived key %s does not match address library key %s", chain, result.Compressed, chainPubKey)
+		}
+		result.Addresses = append(result.Addresses, VaultAddress{Chain: chain.String(), Address: addr})
+	}
+
+	if len(result.Addresses) == 0 {
+		evmAddr, err := address.GetEVMAddress(result.Compressed)
+		if err != nil {
+			return nil, fmt.Errorf("EVM address: %w", err)
+		}
+		btcAddr, err := address.GetBitcoinAddress(result.Compressed)
+		if err != nil {
+			return nil, fmt.Errorf("bitcoin address: %w", err)
+		}
+		result.Addresses = append(result.Addresses,
+			VaultAddress{Chain: "EVM format", Address: evmAddr},
+			VaultAddress{Chain: "Bitcoin format", Address: btcAddr},
+		)
+	}
+
+	return result, nil
+}
+
+func eddsaPubKey(vault *LocalVault) (*DerivedPubKey, error) {
+	if vault.PublicKeyEdDSA == "" {
+		return nil, fmt.Errorf("vault %s has no EdDSA key", vault.Name)
+	}
+
+	result := &DerivedPubKey{
+		Algorithm:  "EdDSA (ed25519)",
+		Compressed: vault.PublicKeyEdDSA,
+	}
+	for _, chain := range []common.Chain{common.Solana, common.Sui} {
+		addr, _, _, err := address.GetAddress(vault.PublicKeyEdDSA, vault.HexChainCode, chain)
+		if err != nil {
+			return nil, fmt.Errorf("derive %s address: %w", chain, err)
+		}
+		result.Addresses = append(result.Addresses, VaultAddress{Chain: chain.String(), Address: addr})
+	}
+	return result, nil
+}
@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+// The keys below are BIP32 test vectors 1 and 2: each case derives a
+// published child xpub from its published parent along non-hardened steps.
+func TestDeriveECDSAPubKey(t *testing.T) {
+	tests := []struct {
+		name         string
+		pubKey       string
+		chainCode    string
+		path         string
+		compressed   string
+		uncompressed string
+	}{
+		{
+			name:         "vector 1 m/0H to m/0H/1",
+			pubKey:       "035a784662a4a20a65bf6aab9ae98a6c068a81c52e4b032c0fb5400c706cfccc56",
+			chainCode:    "47fdacbd0f1097043b78c63c20c34ef4ed9a111d980047ad16282c7ae6236141",
+			path:         "m/1",
+			compressed:   "03501e454bf00751f24b1b489aa925215d66af2234e3891c3b21a52bedb3cd711c",
+			uncompressed: "04501e454bf00751f24b1b489aa925215d66af2234e3891c3b21a52bedb3cd711c008794c1df8131b9ad1e1359965b3f3ee2feef0866be693729772be14be881ab",
+		},
+		{
+			name:         "vector 1 m/0H/1/2H to m/0H/1/2H/2/1000000000",
+			pubKey:       "0357bfe1e341d01c69fe5654309956cbea516822fba8a601743a012a7896ee8dc2",
+			chainCode:    "04466b9cc8e161e966409ca52986c584f07e9dc81f735db683c3ff6ec7b1503f",
+			path:         "m/2/1000000000",
+			compressed:   "022a471424da5e657499d1ff51cb43c47481a03b1e77f951fe64cec9f5a48f7011",
+			uncompressed: "042a471424da5e657499d1ff51cb43c47481a03b1e77f951fe64cec9f5a48f7011cf31cb47de7ccf6196d3a580d055837de7aa374e28c6c8a263e7b4512ceee362",
+		},
+		{
+			// Apostrophes are ignored, so this is the same derivation.
+			name:         "apostrophes ignored",
+			pubKey:       "0357bfe1e341d01c69fe5654309956cbea516822fba8a601743a012a7896ee8dc2",
+			chainCode:    "04466b9cc8e161e966409ca52986c584f07e9dc81f735db683c3ff6ec7b1503f",
+			path:         "m/2'/1000000000'",
+			compressed:   "022a471424da5e657499d1ff51cb43c47481a03b1e77f951fe64cec9f5a48f7011",
+			uncompressed: "042a471424da5e657499d1ff51cb43c47481a03b1e77f951fe64cec9f5a48f7011cf31cb47de7ccf6196d3a580d055837de7aa374e28c6c8a263e7b4512ceee362",
+		},
+		{
+			name:         "vector 2 m to m/0",
+			pubKey:       "03cbcaa9c98c877a26977d00825c956a238e8dddfbd322cce4f74b0b5bd6ace4a7",
+			chainCode:    "60499f801b896d83179a4374aeb7822aaeaceaa0db1f85ee3e904c4defbd9689",
+			path:         "m/0",
+			compressed:   "02fc9e5af0ac8d9b3cecfe2a888e2117ba3d089d8585886c9c826b6b22a98d12ea",
+			uncompressed: "04fc9e5af0ac8d9b3cecfe2a888e2117ba3d089d8585886c9c826b6b22a98d12ea67a50538b6f7d8b5f7a1cc657efd267cde8cc1d8c0451d1340a0fb3642777544",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vault := &LocalVault{Name: "test", PublicKeyECDSA: tt.pubKey, HexChainCode: tt.chainCode}
+			got, err := deriveECDSAPubKey(vault, tt.path)
+			if err != nil {
+				t.Fatalf("deriveECDSAPubKey: %v", err)
+			}
+			if got.DerivePath != tt.path || got.Algorithm != "ECDSA (secp256k1)" {
+				t.Errorf("path, algorithm = %q, %q", got.DerivePath, got.Algorithm)
+			}
+			if got.Compressed != tt.compressed {
+				t.Errorf("compressed = %s, want %s", got.Compressed, tt.compressed)
+			}
+			if got.Uncompressed != tt.uncompressed {
+				t.Errorf("uncompressed = %s, want %s", got.Uncompressed, tt.uncompressed)
+			}
+
+			// No chain uses these paths, so only the address formats are shown.
+			if len(got.Addresses) != 2 || got.Addresses[0].Chain != "EVM format" || got.Addresses[1].Chain != "Bitcoin format" {
+				t.Errorf("addresses = %+v, want the EVM and Bitcoin formats", got.Addresses)
+			}
+		})
+	}
+}
+
+func TestDeriveECDSAPubKeyInvalid(t *testing.T) {
+	const (
+		pubKey    = "03cbcaa9c98c877a26977d00825c956a238e8dddfbd322cce4f74b0b5bd6ace4a7"
+		chainCode = "60499f801b896d83179a4374aeb7822aaeaceaa0db1f85ee3e904c4defbd9689"
+	)
+	tests := []struct {
+		name      string
+		pubKey    string
+		chainCode string
+		path      string
+	}{
+		{name: "pubkey not hex", pubKey: "zz", chainCode: chainCode, path: "m/0"},
+		{name: "chain code not hex", pubKey: pubKey, chainCode: "zz", path: "m/0"},
+		{name: "path not numeric", pubKey: pubKey, chainCode: chainCode, path: "m/44'/sixty'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vault := &LocalVault{Name: "test", PublicKeyECDSA: tt.pubKey, HexChainCode: tt.chainCode}
+			_, err := deriveECDSAPubKey(vault, tt.path)
+			if err == nil || !strings.HasPrefix(err.Error(), "derive public key at "+tt.path+": ") {
+				t.Errorf("deriveECDSAPubKey error = %v, want a derive public key error", err)
+			}
+		})
+	}
+}
+
+func TestEdDSAPubKey(t *testing.T) {
+	_, err := eddsaPubKey(&LocalVault{Name: "old"})
+	if err == nil || err.Error() != "vault old has no EdDSA key" {
+		t.Errorf("vault without EdDSA key: got %v", err)
+	}
+
+	const key = "3d4017c3e843895a92b70aa74d1b7ebc9c982ccf2ec4968cc0cd55f12af4660c"
+	got, err := eddsaPubKey(&LocalVault{Name: "test", PublicKeyEdDSA: key})
+	if err != nil {
+		t.Fatalf("eddsaPubKey: %v", err)
+	}
+	if got.Compressed != key || got.Uncompressed != "" || got.DerivePath != "" {
+		t.Errorf("eddsaPubKey = %+v, want the root key as is", got)
+	}
+	if len(got.Addresses) != 2 || got.Addresses[0].Chain != "Solana" || got.Addresses[1].Chain != "Sui" {
+		t.Errorf("addresses = %+v, want Solana and Sui", got.Addresses)
+	}
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/vultisig/vultisig-cluster/local/cmd/devctl/format"
+)
+
+// VaultSource records the backup a vault was imported from. Several backups
+// of one vault exist at different reshare states, and weeks later nobody
+// remembers which one was used. SHA256 is over the file as read, i.e. the
+// still-encrypted backup, never the decrypted vault.
+type VaultSource struct {
+	Path       string `json:"path"`
+	Size       int64  `json:"size"`
+	SHA256     string `json:"sha256"`
+	ImportedAt string `json:"importedAt"`
+}
+
+// newVaultSource describes data read from file, or from the QR images when
+// qrImages is set.
+func newVaultSource(file string, qrImages []string, data []byte) *VaultSource {
+	sum := sha256.Sum256(data)
+	path := file
+	if len(qrImages) == 0 {
+		abs, err := filepath.Abs(file)
+		if err == nil {
+			path = abs
+		}
+	}
+	return &VaultSource{
+		Path:       path,
+		Size:       int64(len(data)),
+		SHA256:     hex.EncodeToString(sum[:]),
+		ImportedAt: time.Now().UTC().Format(time.RFC3339),
+	}
+}
+
+// findImportedFrom returns the vault that was imported from a backup with
+// the given checksum, or nil.
+func findImportedFrom(vaults []*LocalVault, sha string) *LocalVault {
+	for _, v := range vaults {
+		if v.Source != nil && v.Source.SHA256 == sha {
+			return v
+		}
+	}
+	return nil
+}
+
+// printVaultSource prints the import provenance for 'vault info'.
+func printVaultSource(vault *LocalVault) {
+	if vault.Source == nil {
+		fmt.Println("Imported From: unknown (generated locally, or imported before provenance was recorded)")
+		return
+	}
+	fmt.Println("Imported From:")
+	fmt.Printf("  Path:     %s\n", vault.Source.Path)
+	fmt.Printf("  Size:     %s\n", format.Bytes(vault.Source.Size))
+	fmt.Printf("  SHA-256:  %s\n", vault.Source.SHA256)
+	fmt.Printf("  Imported: %s\n", vault.Source.ImportedAt)
+}
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestVaultJSONFlag(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Cleanup(func() { vaultJSON = false })
+
+	// Every subcommand --json applies to takes --output json.
+	for _, sub := range NewVaultCmd().Commands() {
+		if !vaultJSONCommands[sub.Name()] {
+			continue
+		}
+		output := sub.Flags().Lookup("output")
+		if output == nil || !strings.Contains(output.Usage, "json") {
+			t.Errorf("vault %s has no --output json", sub.Name())
+		}
+	}
+
+	tests := []struct {
+		args    []string
+		wantErr string
+	}{
+		{args: []string{"list", "--json"}},
+		{args: []string{"list", "--json", "--output", "json"}},
+		{args: []string{"list", "--json", "--output", "table"}, wantErr: "--json conflicts with --output table"},
+		{args: []string{"use", "main", "--json"}, wantErr: "vault use has no JSON output"},
+		{args: []string{"export", "--json"}, wantErr: "vault export has no JSON output"},
+	}
+	for _, tt := range tests {
+		cmd := NewVaultCmd()
+		cmd.SetArgs(tt.args)
+		cmd.SetOut(io.Discard)
+		cmd.SetErr(io.Discard)
+		err := cmd.Execute()
+		if tt.wantErr == "" && err != nil {
+			t.Errorf("%v: %v", tt.args, err)
+		}
+		if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+			t.Errorf("%v: error = %v, want %q", tt.args, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Operations recorded in LocalVault.LastUsed.
+const (
+	vaultUsageAuth    = "auth"
+	vaultUsageInstall = "install"
+	vaultUsagePolicy  = "policy"
+)
+
+// VaultUsage records the endpoints a vault was used with for one kind of
+// operation. Auth tokens, plugin installations and policies exist only on
+// the verifier they were made on, so switching cluster.yaml between all-local
+// and hybrid service modes leaves them behind.
+type VaultUsage struct {
+	Verifier    string `json:"verifier"`
+	Vultiserver string `json:"vultiserver"`
+	Profile     string `json:"profile"`
+	At          string `json:"at"`
+}
+
+// clusterProfile describes the active service modes: "all local", or
+// "hybrid (relay, vultiserver: production)" naming the remote services.
+func clusterProfile() string {
+	cc := clusterConfigOrDefaults()
+	var remote []string
+	for _, service := range []string{"relay", "vultiserver", "verifier"} {
+		if !cc.IsLocal(service) {
+			remote = append(remote, service)
+		}
+	}
+	if len(remote) == 0 {
+		return "all local"
+	}
+	return fmt.Sprintf("hybrid (%s: production)", strings.Join(remote, ", "))
+}
+
+// recordVaultUsage stores the current endpoints under operation in the
+// vault's metadata. It re-reads the vault so a copy held since before a
+// reshare does not overwrite the new signers; failures only warn.
+func recordVaultUsage(vault *LocalVault, cfg *DevConfig, operation string) {
+	stored, err := LoadVault(vault.PublicKeyECDSA)
+	if err != nil {
+		progressf("  %s Could not record vault usage: %v\n", warnMark(), err)
+		return
+	}
+	if stored.LastUsed == nil {
+		stored.LastUsed = map[string]VaultUsage{}
+	}
+	stored.LastUsed[operation] = VaultUsage{
+		Verifier:    cfg.Verifier,
+		Vultiserver: clusterConfigOrDefaults().GetVultiserverURL(),
+		Profile:     clusterProfile(),
+		At:          time.Now().UTC().Format(time.RFC3339),
+	}
+	err = SaveVault(stored)
+	if err != nil {
+		progressf("  %s Could not record vault usage: %v\n", warnMark(), err)
+	}
+}
+
+// lastVaultUsage returns the most recent recorded usage of the vault.
+func lastVaultUsage(vault *LocalVault) (VaultUsage, bool) {
+	var last VaultUsage
+	for _, u := range vault.LastUsed {
+		if u.At > last.At {
+			last = u
+		}
+	}
+	return last, last.At != ""
+}
+
+// warnVaultEndpoint warns when the vault was last used with another
+// verifier than verifierURL: its token, installations and policies are
+// likely on the other one.
+func warnVaultEndpoint(vault *LocalVault, verifierURL string) {
+	last, ok := lastVaultUsage(vault)
+	if !ok || strings.TrimRight(last.Verifier, "/") == strings.TrimRight(verifierURL, "/") {
+		return
+	}
+	progressf("%s Vault %s was last used with %s (%s); you are now targeting %s (%s).\n",
+		warnMark(), vault.Name, last.Verifier, last.Profile, verifierURL, clusterProfile())
+	progressln("  Its auth token, plugin installations and policies may exist only on the other verifier.")
+}
+
+// printVaultUsage lists the recorded usage for 'vault info'.
+func printVaultUsage(vault *LocalVault) {
+	if len(vault.LastUsed) == 0 {
+		fmt.Println("Last Used: never (no auth, install or policy operation recorded)")
+		return
+	}
+	operations := make([]string, 0, len(vault.LastUsed))
+	for op := range vault.LastUsed {
+		operations = append(operations, op)
+	}
+	sort.Strings(operations)
+
+	fmt.Println("Last Used:")
+	for _, op := range operations {
+		u := vault.LastUsed[op]
+		fmt.Printf("  %-8s %s  verifier %s, vultiserver %s (%s)\n", op+":", u.At, u.Verifier, valueOr(u.Vultiserver, "-"), u.Profile)
+	}
+}
@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// errWatchOnly is returned by every keysign and reshare entry point for a
+// vault imported with 'vault import --watch-only'.
+var errWatchOnly = errors.New("watch-only vault: keyshare required")
+
+// requireKeyshare fails fast for watch-only vaults, before any session is
+// registered or server contacted.
+func requireKeyshare(v *LocalVault) error {
+	if !v.WatchOnly {
+		return nil
+	}
+	return fmt.Errorf("%w (%s was imported with --watch-only; import its backup with 'devctl vault import --file ... --force' to sign)", errWatchOnly, v.Name)
+}
+
+// watchOnlyVault builds a keyshare-less vault from its public keys and chain
+// code, enough to derive addresses, show balances and inspect policies.
+func watchOnlyVault(name, pubkeyECDSA, pubkeyEdDSA, chainCode string) (LocalVault, error) {
+	ecdsa, err := hexField("--pubkey-ecdsa", pubkeyECDSA, 33)
+	if err != nil {
+		return LocalVault{}, err
+	}
+	eddsa, err := hexField("--pubkey-eddsa", pubkeyEdDSA, 32)
+	if err != nil {
+		return LocalVault{}, err
+	}
+	code, err := hexField("--chaincode", chainCode, 32)
+	if err != nil {
+		return LocalVault{}, err
+	}
+	if name == "" {
+		name = "watch-" + VaultFingerprint(ecdsa)
+	}
+	return LocalVault{
+		Name:           name,
+		PublicKeyECDSA: ecdsa,
+		PublicKeyEdDSA: eddsa,
+		HexChainCode:   code,
+		CreatedAt:      time.Now().UTC().Format(time.RFC3339),
+		LibType:        libTypeDKLS,
+		WatchOnly:      true,
+	}, nil
+}
+
+// watchOnlyFromPublicExport turns a 'vault export --public-only' file into a
+// watch-only vault.
+func watchOnlyFromPublicExport(data []byte) (LocalVault, error) {
+	var export PublicVaultExport
+	err := json.Unmarshal(data, &export)
+	if err != nil {
+		return LocalVault{}, fmt.Errorf("parse public export: %w", err)
+	}
+	v, err := watchOnlyVault(export.Name, export.PublicKeyECDSA, export.PublicKeyEdDSA, export.HexChainCode)
+	if err != nil {
+		return LocalVault{}, fmt.Errorf("public export: %w", err)
+	}
+	v.Signers = export.Signers
+	v.LibType = export.LibType
+	return v, nil
+}
+
+// hexField normalizes a hex flag value (optional 0x prefix, any case) and
+// checks its length in bytes.
+func hexField(flag, value string, size int) (string, error) {
+	s := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(value), "0x"))
+	if s == "" {
+		return "", fmt.Errorf("%s is required for a watch-only import", flag)
+	}
+	b, err := hex.DecodeString(s)
+	if err != nil || len(b) != size {
+		return "", fmt.Errorf("%s must be %d bytes of hex, got %q", flag, size, value)
+	}
+	return s, nil
+}
+
+func runVaultImportWatchOnly(file string, data []byte, name, pubkeyECDSA, pubkeyEdDSA, chainCode string, force bool) error {
+	var v LocalVault
+	var err error
+	source := "public keys"
+	if data != nil {
+		if !isPublicVaultExport(data) {
+			return fmt.Errorf("%s is not a public-only export; --watch-only takes a 'vault export --public-only' file or --pubkey-ecdsa, --pubkey-eddsa and --chaincode", file)
+		}
+		v, err = watchOnlyFromPublicExport(data)
+		source = "public-only export"
+		if name != "" {
+			v.Name = name
+		}
+	} else {
+		v, err = watchOnlyVault(name, pubkeyECDSA, pubkeyEdDSA, chainCode)
+	}
+	if err != nil {
+		return err
+	}
+
+	existing, _ := LoadVault(v.PublicKeyECDSA[:16])
+	if existing != nil && !existing.WatchOnly && !force {
+		return fmt.Errorf("vault %s is already imported with its keyshare; pass --force to replace it with a watch-only copy", existing.Name)
+	}
+
+	err = SaveVault(&v)
+	if err != nil {
+		return fmt.Errorf("save vault: %w", err)
+	}
+
+	cfg, _ := LoadConfig()
+	cfg.VaultName = v.Name
+	cfg.PublicKeyECDSA = v.PublicKeyECDSA
+	cfg.PublicKeyEdDSA = v.PublicKeyEdDSA
+	err = SaveConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("save config: %w", err)
+	}
+
+	fmt.Println("=== Watch-Only Vault Imported ===")
+	fmt.Printf("Name: %s\n", v.Name)
+	fmt.Printf("Source: %s\n", source)
+	fmt.Printf("Fingerprint: %s\n", VaultFingerprint(v.PublicKeyECDSA))
+	fmt.Printf("Public Key (ECDSA): %s\n", v.PublicKeyECDSA)
+	fmt.Printf("Public Key (EdDSA): %s\n", v.PublicKeyEdDSA)
+	fmt.Printf("Saved to: %s\n", VaultStoragePath())
+	fmt.Println()
+	fmt.Println("Addresses, balances and details work as usual. Signing, plugin install")
+	fmt.Println("and reshare need the keyshare and fail with \"watch-only vault: keyshare required\".")
+	fmt.Println("For policy list/info, set VCLI_AUTH_TOKEN to a verifier token issued for this vault.")
+	return nil
+}
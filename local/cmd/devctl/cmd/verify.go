@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/vultisig/vultisig-cluster/local/cmd/devctl/client"
 )
 
 func NewVerifyCmd() *cobra.Command {
@@ -19,7 +20,9 @@ func NewVerifyCmd() *cobra.Command {
 
 	cmd.AddCommand(newVerifyTransactionsCmd())
 	cmd.AddCommand(newVerifyPolicyCmd())
+	cmd.AddCommand(newVerifySwapCmd())
 	cmd.AddCommand(newVerifyHealthCmd())
+	cmd.AddCommand(newVerifyAllCmd())
 
 	return cmd
 }
@@ -27,17 +30,21 @@ func NewVerifyCmd() *cobra.Command {
 func newVerifyTransactionsCmd() *cobra.Command {
 	var policyID string
 	var pluginID string
-	var limit int
+	var filter HistoryFilter
+	var verbose bool
 
 	cmd := &cobra.Command{
 		Use:   "transactions",
 		Short: "Check transaction history for a policy",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if policyID != "" {
-				return runVerifyPolicyTransactions(policyID, limit)
+				return runVerifyPolicyTransactions(cmd.Context(), policyID, filter, verbose)
 			}
 			if pluginID != "" {
-				return runVerifyPluginTransactions(pluginID, limit)
+				if !filter.Since.IsZero() {
+					return fmt.Errorf("--since is only supported with --policy")
+				}
+				return runVerifyPluginTransactions(cmd.Context(), pluginID, filter.Limit)
 			}
 			return fmt.Errorf("specify --policy or --plugin")
 		},
@@ -45,7 +52,8 @@ func newVerifyTransactionsCmd() *cobra.Command {
 
 	cmd.Flags().StringVarP(&policyID, "policy", "p", "", "Policy ID to check")
 	cmd.Flags().StringVarP(&pluginID, "plugin", "P", "", "Plugin ID to check all transactions")
-	cmd.Flags().IntVarP(&limit, "limit", "l", 10, "Number of transactions to show")
+	addHistoryFlags(cmd, &filter, 10, "transactions")
+	cmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "Show absolute timestamps")
 
 	return cmd
 }
@@ -56,7 +64,7 @@ func newVerifyPolicyCmd() *cobra.Command {
 		Short: "Verify policy status and execution state",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runVerifyPolicy(args[0])
+			return runVerifyPolicy(cmd.Context(), args[0])
 		},
 	}
 }
@@ -66,12 +74,12 @@ func newVerifyHealthCmd() *cobra.Command {
 		Use:   "health",
 		Short: "Check health of all services",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runVerifyHealth()
+			return runVerifyHealth(cmd.Context())
 		},
 	}
 }
 
-func runVerifyPolicyTransactions(policyID string, limit int) error {
+func runVerifyPolicyTransactions(ctx context.Context, policyID string, filter HistoryFilter, verbose bool) error {
 	cfg, err := LoadConfig()
 	if err != nil {
 		return fmt.Errorf("load config: %w", err)
@@ -82,10 +90,13 @@ func runVerifyPolicyTransactions(policyID string, limit int) error {
 		return fmt.Errorf("authentication required: %w", err)
 	}
 
-	fmt.Printf("Fetching transactions for policy %s...\n\n", policyID)
+	progressf("Fetching transactions for policy %s...\n\n", policyID)
 
-	url := fmt.Sprintf("%s/plugin/policies/%s/history?take=%d", cfg.Verifier, policyID, limit)
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	url := fmt.Sprintf("%s/plugin/policies/%s/history", cfg.Verifier, policyID)
+	if filter.Limit > 0 {
+		url += fmt.Sprintf("?take=%d", filter.Limit)
+	}
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
 	req, _ := http.NewRequestWithContext(ctx, "GET", url, nil)
@@ -99,36 +110,45 @@ func runVerifyPolicyTransactions(policyID string, limit int) error {
 
 	body, _ := io.ReadAll(resp.Body)
 
-	var result map[string]interface{}
-	json.Unmarshal(body, &result)
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("request failed (%d): %s", resp.StatusCode, errorBody(body))
+	}
 
-	if data, ok := result["data"].([]interface{}); ok {
-		if len(data) == 0 {
-			fmt.Println("No transactions found for this policy.")
-			fmt.Println("\nThe plugin may not have executed any transactions yet.")
-			return nil
+	history, err := client.Decode[client.PolicyHistory](body)
+	if err != nil {
+		return fmt.Errorf("parse transactions: %w", err)
+	}
+
+	var txs []client.PolicyHistoryEntry
+	for _, tx := range history.History {
+		if filter.Includes(tx.CreatedAt) {
+			txs = append(txs, tx)
 		}
+	}
 
-		fmt.Printf("Found %d transactions:\n\n", len(data))
-		for i, tx := range data {
-			txMap := tx.(map[string]interface{})
-			fmt.Printf("%d. Transaction:\n", i+1)
-			fmt.Printf("   ID: %v\n", txMap["id"])
-			fmt.Printf("   Status: %v\n", txMap["status"])
-			fmt.Printf("   Chain: %v\n", txMap["chain"])
-			fmt.Printf("   TxHash: %v\n", txMap["tx_hash"])
-			fmt.Printf("   Created: %v\n", txMap["created_at"])
-			fmt.Println()
+	if len(txs) == 0 {
+		fmt.Println("No transactions found for this policy.")
+		fmt.Println("\nThe plugin may not have executed any transactions yet.")
+		return nil
+	}
+
+	fmt.Printf("Found %d transactions:\n\n", len(txs))
+	for i, tx := range txs {
+		fmt.Printf("%d. Transaction:\n", i+1)
+		fmt.Printf("   ID: %s\n", tx.ID)
+		fmt.Printf("   Status: %s\n", tx.Status)
+		fmt.Printf("   Chain: %s\n", tx.Chain)
+		if tx.TxHash != nil {
+			fmt.Printf("   TxHash: %s\n", *tx.TxHash)
 		}
-	} else {
-		prettyJSON, _ := json.MarshalIndent(result, "", "  ")
-		fmt.Println(string(prettyJSON))
+		fmt.Printf("   Created: %s\n", formatTimestamp(tx.CreatedAt, verbose))
+		fmt.Println()
 	}
 
 	return nil
 }
 
-func runVerifyPluginTransactions(pluginID string, limit int) error {
+func runVerifyPluginTransactions(ctx context.Context, pluginID string, limit int) error {
 	cfg, err := LoadConfig()
 	if err != nil {
 		return fmt.Errorf("load config: %w", err)
@@ -139,10 +159,13 @@ func runVerifyPluginTransactions(pluginID string, limit int) error {
 		return fmt.Errorf("authentication required: %w", err)
 	}
 
-	fmt.Printf("Fetching transactions for plugin %s...\n\n", pluginID)
+	progressf("Fetching transactions for plugin %s...\n\n", pluginID)
 
-	url := fmt.Sprintf("%s/plugin/transactions?plugin_id=%s&take=%d", cfg.Verifier, pluginID, limit)
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	url := fmt.Sprintf("%s/plugin/transactions?plugin_id=%s", cfg.Verifier, pluginID)
+	if limit > 0 {
+		url += fmt.Sprintf("&take=%d", limit)
+	}
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
 	req, _ := http.NewRequestWithContext(ctx, "GET", url, nil)
@@ -165,7 +188,7 @@ func runVerifyPluginTransactions(pluginID string, limit int) error {
 	return nil
 }
 
-func runVerifyPolicy(policyID string) error {
+func runVerifyPolicy(ctx context.Context, policyID string) error {
 	cfg, err := LoadConfig()
 	if err != nil {
 		return fmt.Errorf("load config: %w", err)
@@ -176,10 +199,10 @@ func runVerifyPolicy(policyID string) error {
 		return fmt.Errorf("authentication required: %w", err)
 	}
 
-	fmt.Printf("Verifying policy %s...\n\n", policyID)
+	progressf("Verifying policy %s...\n\n", policyID)
 
 	url := fmt.Sprintf("%s/plugin/policy/%s", cfg.Verifier, policyID)
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
 	req, _ := http.NewRequestWithContext(ctx, "GET", url, nil)
@@ -194,43 +217,41 @@ func runVerifyPolicy(policyID string) error {
 	body, _ := io.ReadAll(resp.Body)
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("policy not found: %s", string(body))
+		return fmt.Errorf("policy not found: %s", errorBody(body))
 	}
 
-	var result map[string]interface{}
-	json.Unmarshal(body, &result)
+	policy, err := client.Decode[client.Policy](body)
+	if err != nil {
+		return fmt.Errorf("parse policy: %w", err)
+	}
 
-	if data, ok := result["data"].(map[string]interface{}); ok {
-		fmt.Println("Policy Status:")
-		fmt.Printf("  ID: %v\n", data["id"])
-		fmt.Printf("  Plugin: %v\n", data["plugin_id"])
-		fmt.Printf("  Active: %v\n", data["active"])
-		fmt.Printf("  Version: %v\n", data["policy_version"])
-		fmt.Printf("  Public Key: %v\n", data["public_key"])
-		fmt.Printf("  Created: %v\n", data["created_at"])
-
-		if billing, ok := data["billing"].([]interface{}); ok && len(billing) > 0 {
-			fmt.Println("\nBilling:")
-			for _, b := range billing {
-				bMap := b.(map[string]interface{})
-				fmt.Printf("  - Type: %v, Amount: %v\n", bMap["type"], bMap["amount"])
-			}
+	fmt.Println("Policy Status:")
+	fmt.Printf("  ID: %s\n", policy.ID)
+	fmt.Printf("  Plugin: %s\n", policy.PluginID)
+	fmt.Printf("  Active: %v\n", policy.Active)
+	fmt.Printf("  Version: %d\n", policy.PolicyVersion)
+	fmt.Printf("  Public Key: %s\n", policy.PublicKey)
+	if policy.CreatedAt != nil {
+		fmt.Printf("  Created: %s\n", policy.CreatedAt.Format(time.RFC3339))
+	}
+
+	if len(policy.Billing) > 0 {
+		fmt.Println("\nBilling:")
+		for _, b := range policy.Billing {
+			fmt.Printf("  - Type: %s, Amount: %d\n", b.Type, b.Amount)
 		}
-	} else {
-		prettyJSON, _ := json.MarshalIndent(result, "", "  ")
-		fmt.Println(string(prettyJSON))
 	}
 
 	return nil
 }
 
-func runVerifyHealth() error {
+func runVerifyHealth(ctx context.Context) error {
 	cfg, err := LoadConfig()
 	if err != nil {
 		return fmt.Errorf("load config: %w", err)
 	}
 
-	fmt.Println("Checking service health...\n")
+	progressf("Checking service health...\n\n")
 
 	services := []struct {
 		name string
@@ -243,7 +264,7 @@ func runVerifyHealth() error {
 		{"Relay Server", RelayServer},
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
 	for _, svc := range services {
@@ -259,9 +280,9 @@ func runVerifyHealth() error {
 		}
 
 		if status == "OK" {
-			fmt.Printf("  ✓ %s: %s\n", svc.name, status)
+			fmt.Printf("  %s %s: %s\n", symOK, svc.name, status)
 		} else {
-			fmt.Printf("  ✗ %s: %s\n", svc.name, status)
+			fmt.Printf("  %s %s: %s\n", symFail, svc.name, status)
 		}
 	}
 
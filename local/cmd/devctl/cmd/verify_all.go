@@ -0,0 +1,380 @@
+package cmd
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/spf13/cobra"
+
+	"github.com/vultisig/vultisig-cluster/local/cmd/devctl/format"
+)
+
+// GateCheck is one line of 'verify all'. Status is pass, fail or skip.
+type GateCheck struct {
+	Name       string `json:"name"`
+	Status     string `json:"status"`
+	Detail     string `json:"detail,omitempty"`
+	DurationMs int64  `json:"duration_ms"`
+}
+
+// GateReport is the JSON form of 'verify all'.
+type GateReport struct {
+	Passed bool        `json:"passed"`
+	Checks []GateCheck `json:"checks"`
+}
+
+// gateCheck is a named check of the gate. run returns a detail line on
+// success and an error on failure.
+type gateCheck struct {
+	name string
+	skip bool
+	run  func(ctx context.Context) (string, error)
+}
+
+type verifyAllOptions struct {
+	skipDoctor    bool
+	skipServices  bool
+	skipDB        bool
+	skipMinio     bool
+	skipRelay     bool
+	skipFastVault bool
+	skipAuth      bool
+	skipInstalls  bool
+	withKeysign   bool
+	password      string
+	output        string
+}
+
+func newVerifyAllCmd() *cobra.Command {
+	var opts verifyAllOptions
+
+	cmd := &cobra.Command{
+		Use:   "all",
+		Short: "Run every environment check and fail if any fails (CI gate)",
+		Long: `Assert that the local environment is fully functional, for CI pipelines
+to run before plugin tests. Checks run in order and each prints a pass, fail
+or skip line:
+
+  doctor      cluster.yaml loads, repos exist, docker is on PATH, the
+              go-wrappers signing library in library.dyld_path matches this
+              OS and architecture, and the local clock is within 1m of the
+              verifier's
+  services    every local service answers its health check
+  database    the verifier database accepts queries (database_dsn)
+  minio       the verifier and plugin keyshare buckets exist
+  relay       the relay server is reachable
+  fast-vault  the Fast Vault Server is reachable
+  auth        the auth token is valid for the active vault
+  installs    the active vault's signers agree with plugin_installations
+              and the MinIO keyshares (see 'devctl plugin installed')
+  keysign     a Fast Vault keysign of a random digest (only with --with-keysign)
+
+The command exits non-zero if any check fails. Skip checks that do not apply
+to a pipeline with the --skip-* flags.
+
+Example:
+  devctl verify all
+  devctl verify all --skip-auth --output json
+  VAULT_PASSWORD=... devctl verify all --with-keysign
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.output != "table" && opts.output != "json" {
+				return fmt.Errorf("unknown output format %q (use table or json)", opts.output)
+			}
+			if envPass := os.Getenv("VAULT_PASSWORD"); envPass != "" && opts.password == "" {
+				opts.password = envPass
+			}
+			return runVerifyAll(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.skipDoctor, "skip-doctor", false, "Skip the configuration checks")
+	cmd.Flags().BoolVar(&opts.skipServices, "skip-services", false, "Skip the service health checks")
+	cmd.Flags().BoolVar(&opts.skipDB, "skip-db", false, "Skip the database check")
+	cmd.Flags().BoolVar(&opts.skipMinio, "skip-minio", false, "Skip the MinIO bucket check")
+	cmd.Flags().BoolVar(&opts.skipRelay, "skip-relay", false, "Skip the relay check")
+	cmd.Flags().BoolVar(&opts.skipFastVault, "skip-fast-vault", false, "Skip the Fast Vault Server check")
+	cmd.Flags().BoolVar(&opts.skipAuth, "skip-auth", false, "Skip the auth token check")
+	cmd.Flags().BoolVar(&opts.skipInstalls, "skip-installs", false, "Skip the plugin installation consistency check")
+	cmd.Flags().BoolVar(&opts.withKeysign, "with-keysign", false, "Also run a keysign round-trip with the Fast Vault Server")
+	cmd.Flags().StringVarP(&opts.password, "password", "p", "", "Fast Vault password for --with-keysign (or set VAULT_PASSWORD env var)")
+	cmd.Flags().StringVarP(&opts.output, "output", "o", "table", "Output format: table or json")
+
+	return cmd
+}
+
+func runVerifyAll(ctx context.Context, opts verifyAllOptions) error {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	cc := clusterConfigOrDefaults()
+
+	checks := []gateCheck{
+		{"doctor", opts.skipDoctor, func(ctx context.Context) (string, error) { return checkDoctor(ctx, cfg) }},
+		{"services", opts.skipServices, func(ctx context.Context) (string, error) { return checkServicesHealth(cc) }},
+		{"database", opts.skipDB, func(ctx context.Context) (string, error) { return checkDatabase(ctx, cfg.DatabaseDSN) }},
+		{"minio", opts.skipMinio, func(ctx context.Context) (string, error) { return checkMinioBuckets(ctx, cfg, cc) }},
+		{"relay", opts.skipRelay, func(ctx context.Context) (string, error) { return checkReachable(ctx, cc.GetRelayURL()) }},
+		{"fast-vault", opts.skipFastVault, func(ctx context.Context) (string, error) { return checkReachable(ctx, cc.GetVultiserverURL()) }},
+		{"auth", opts.skipAuth, func(ctx context.Context) (string, error) { return checkAuthToken(ctx, cfg) }},
+		{"installs", opts.skipInstalls, func(ctx context.Context) (string, error) { return checkInstallConsistency(ctx, cfg) }},
+		{"keysign", !opts.withKeysign, func(ctx context.Context) (string, error) { return checkKeysign(ctx, cfg, opts.password) }},
+	}
+
+	report := GateReport{Passed: true}
+	failed := 0
+	for _, c := range checks {
+		result := GateCheck{Name: c.name, Status: "skip"}
+		if !c.skip {
+			started := time.Now()
+			checkCtx, cancel := context.WithTimeout(ctx, gateCheckTimeout(c.name))
+			detail, err := c.run(checkCtx)
+			cancel()
+			result.DurationMs = time.Since(started).Milliseconds()
+			result.Status = "pass"
+			result.Detail = detail
+			if err != nil {
+				result.Status = "fail"
+				result.Detail = err.Error()
+				report.Passed = false
+				failed++
+			}
+		}
+		report.Checks = append(report.Checks, result)
+		if opts.output != "json" {
+			printGateCheck(result)
+		}
+	}
+
+	if opts.output == "json" {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal report: %w", err)
+		}
+		fmt.Println(string(data))
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d checks failed", failed, len(checks))
+	}
+	if opts.output != "json" {
+		fmt.Printf("\n%s Environment ready\n", okMark())
+	}
+	return nil
+}
+
+func gateCheckTimeout(name string) time.Duration {
+	if name == "keysign" {
+		return 3 * time.Minute
+	}
+	return 15 * time.Second
+}
+
+func printGateCheck(c GateCheck) {
+	mark := okMark()
+	switch c.Status {
+	case "fail":
+		mark = failMark()
+	case "skip":
+		mark = warnMark()
+	}
+	fmt.Printf("%s %-11s %-4s  %s\n", mark, c.Name, c.Status, c.Detail)
+}
+
+func checkDoctor(ctx context.Context, cfg *DevConfig) (string, error) {
+	cc, err := LoadClusterConfig()
+	if err != nil {
+		return "", err
+	}
+	err = cc.ValidateRepos()
+	if err != nil {
+		return "", err
+	}
+	_, err = exec.LookPath("docker")
+	if err != nil {
+		return "", fmt.Errorf("docker not found on PATH")
+	}
+	lib, err := probeSigningLibrary(cc)
+	if err != nil {
+		return "", err
+	}
+
+	skew, err := measureClockSkew(ctx, cfg.Verifier)
+	if err != nil {
+		return fmt.Sprintf("config %s, repos, docker and library %s OK; clock skew not measured (verifier unreachable)", ClusterConfigSource(), lib), nil
+	}
+	if skew.Abs() >= clockSkewAbort {
+		return "", fmt.Errorf("%s; auth login will fail (sync the clock or use --server-time)", describeClockSkew(skew))
+	}
+	return fmt.Sprintf("config %s, repos, docker and library %s OK; %s", ClusterConfigSource(), lib, describeClockSkew(skew)), nil
+}
+
+func checkServicesHealth(cc *ClusterConfig) (string, error) {
+	var down []string
+	checked := 0
+	for _, svc := range cc.LocalServices() {
+		url := svc.HealthURL()
+		if url == "" {
+			continue
+		}
+		checked++
+		state, reason := checkHealthState(url, svc.Health)
+		switch state {
+		case healthDown:
+			down = append(down, svc.Name)
+		case healthUnhealthy:
+			down = append(down, fmt.Sprintf("%s (unhealthy payload: %s)", svc.Name, reason))
+		}
+	}
+	if len(down) > 0 {
+		return "", fmt.Errorf("not healthy: %s", strings.Join(down, ", "))
+	}
+	return fmt.Sprintf("%d services healthy", checked), nil
+}
+
+func checkDatabase(ctx context.Context, dsn string) (string, error) {
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return "", fmt.Errorf("open database: %w", err)
+	}
+	defer db.Close()
+
+	var one int
+	err = db.QueryRowContext(ctx, "SELECT 1").Scan(&one)
+	if err != nil {
+		return "", fmt.Errorf("query database: %w", err)
+	}
+	return "verifier database reachable", nil
+}
+
+func checkMinioBuckets(ctx context.Context, cfg *DevConfig, cc *ClusterConfig) (string, error) {
+	client, err := newMinioClient(cfg)
+	if err != nil {
+		return "", err
+	}
+
+	buckets := []string{cfg.VerifierBucket}
+	if cc.IsLocal("dca") {
+		buckets = append(buckets, pluginSpecFor("vultisig-dca-0000").Bucket)
+	}
+	if cc.IsLocal("sends") {
+		buckets = append(buckets, pluginSpecFor("vultisig-recurring-sends-0000").Bucket)
+	}
+
+	var missing []string
+	for _, b := range buckets {
+		_, err := client.HeadBucketWithContext(ctx, &s3.HeadBucketInput{Bucket: aws.String(b)})
+		if err != nil {
+			missing = append(missing, b)
+		}
+	}
+	if len(missing) > 0 {
+		return "", fmt.Errorf("buckets missing or unreachable at %s: %s", cfg.MinioHost, strings.Join(missing, ", "))
+	}
+	return strings.Join(buckets, ", "), nil
+}
+
+// checkReachable passes when url answers at all below 500; relay and Fast
+// Vault Server have no common health path.
+func checkReachable(ctx context.Context, url string) (string, error) {
+	if url == "" {
+		return "", fmt.Errorf("no endpoint configured")
+	}
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("%s unreachable: %w", url, err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 500 {
+		return "", fmt.Errorf("%s returned %d", url, resp.StatusCode)
+	}
+	return url, nil
+}
+
+func checkInstallConsistency(ctx context.Context, cfg *DevConfig) (string, error) {
+	result, err := activeVaultConsistency(ctx, cfg, "")
+	if err != nil {
+		return "", err
+	}
+	if !result.OK() {
+		return "", fmt.Errorf("%s", strings.Join(result.Warnings(), "; "))
+	}
+	return fmt.Sprintf("%d plugin(s) consistent", len(result.Plugins)), nil
+}
+
+func checkAuthToken(ctx context.Context, cfg *DevConfig) (string, error) {
+	if cfg.PublicKeyECDSA == "" {
+		return "", fmt.Errorf("no active vault. Run 'devctl vault import' first")
+	}
+	token, err := LoadAuthToken()
+	if err != nil {
+		return "", fmt.Errorf("not authenticated. Run 'devctl auth login' first")
+	}
+	if token.PublicKey != "" && token.PublicKey != cfg.PublicKeyECDSA {
+		return "", fmt.Errorf("auth token belongs to vault %s, not the active vault %s", VaultFingerprint(token.PublicKey), VaultFingerprint(cfg.PublicKeyECDSA))
+	}
+	if time.Now().After(token.ExpiresAt) {
+		return "", fmt.Errorf("auth token expired at %s. Run 'devctl auth login'", token.ExpiresAt.Format(time.RFC3339))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", cfg.Verifier+"/auth/me", nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+token.Token)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("verifier unreachable: %w", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("verifier rejected the auth token (%d). Run 'devctl auth login'", resp.StatusCode)
+	}
+	return fmt.Sprintf("valid until %s", token.ExpiresAt.Local().Format("2006-01-02 15:04")), nil
+}
+
+func checkKeysign(ctx context.Context, cfg *DevConfig, password string) (string, error) {
+	if password == "" {
+		return "", fmt.Errorf("--with-keysign needs --password or VAULT_PASSWORD")
+	}
+	if cfg.PublicKeyECDSA == "" {
+		return "", fmt.Errorf("no active vault. Run 'devctl vault import' first")
+	}
+	vault, err := LoadVault(cfg.PublicKeyECDSA)
+	if err != nil {
+		return "", fmt.Errorf("load vault: %w", err)
+	}
+
+	digest := make([]byte, 32)
+	_, err = rand.Read(digest)
+	if err != nil {
+		return "", fmt.Errorf("generate digest: %w", err)
+	}
+
+	started := time.Now()
+	tss := NewTSSService(vault.LocalPartyID)
+	results, err := tss.Keysign(ctx, vault, []string{hex.EncodeToString(digest)}, "m/44'/60'/0'/0/0", false, password)
+	if err != nil {
+		return "", fmt.Errorf("keysign: %w", err)
+	}
+	if len(results) != 1 || results[0].R == "" || results[0].S == "" {
+		return "", fmt.Errorf("keysign returned no signature")
+	}
+	return fmt.Sprintf("signed a random digest in %s", format.Duration(time.Since(started))), nil
+}
@@ -0,0 +1,409 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vultisig/vultisig-cluster/local/cmd/devctl/client"
+	"github.com/vultisig/vultisig-cluster/local/cmd/devctl/format"
+)
+
+// swapLeg is one side of a DCA swap: the token the vault holds on a chain.
+type swapLeg struct {
+	Side     string `json:"side"`
+	Chain    string `json:"chain"`
+	RPCURL   string `json:"-"`
+	Token    string `json:"token"` // empty for the native coin
+	Holder   string `json:"holder"`
+	Symbol   string `json:"symbol"`
+	Decimals int    `json:"decimals"`
+}
+
+func (l swapLeg) native() bool {
+	return l.Token == ""
+}
+
+// SwapSnapshot is what 'policy trigger --snapshot' records before a run.
+type SwapSnapshot struct {
+	PolicyID string            `json:"policy_id"`
+	TakenAt  time.Time         `json:"taken_at"`
+	Balances map[string]string `json:"balances"` // side -> base units
+}
+
+func newVerifySwapCmd() *cobra.Command {
+	var txHash string
+	var tolerance float64
+	var expectOut string
+
+	cmd := &cobra.Command{
+		Use:   "swap <policy-id>",
+		Short: "Check that a DCA swap moved the vault's token balances",
+		Long: `Check a swap by its effect on the vault's balances rather than by its
+transaction status.
+
+The policy's from/to assets come from its decoded recipe. Balances are read
+at the block before and the block of --tx, which needs an RPC that serves
+historical state (an archive node, or the local anvil fork). When it does
+not, the current balances are compared with the snapshot 'policy trigger
+--snapshot' took before the run.
+
+The from balance must drop by the recipe's fromAmount, plus the transaction
+fee when the from asset is the native coin, within --tolerance percent. The
+to balance must rise; with --expect-out it must rise by that amount (base
+units) within --tolerance. A mismatch exits non-zero with the expected and
+observed values.
+
+Example:
+  devctl policy trigger <policy-id> --snapshot
+  devctl verify swap <policy-id> --tx 0xabc...
+  devctl verify swap <policy-id> --tx 0xabc... --expect-out 2500000 --tolerance 1
+
+Note: Requires authentication. Run 'devctl auth login' first.
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runVerifySwap(cmd.Context(), args[0], txHash, tolerance, expectOut)
+		},
+	}
+
+	cmd.Flags().StringVar(&txHash, "tx", "", "Swap transaction hash (without it, only the snapshot is compared)")
+	cmd.Flags().Float64Var(&tolerance, "tolerance", 2, "Allowed deviation from the expected amounts, in percent (fees, slippage)")
+	cmd.Flags().StringVar(&expectOut, "expect-out", "", "Expected increase of the to balance, in base units")
+
+	return cmd
+}
+
+func runVerifySwap(ctx context.Context, policyID, txHash string, tolerance float64, expectOut string) error {
+	from, to, fromAmount, err := policySwapLegs(ctx, policyID)
+	if err != nil {
+		return err
+	}
+
+	var wantOut *big.Int
+	if expectOut != "" {
+		var ok bool
+		wantOut, ok = new(big.Int).SetString(expectOut, 10)
+		if !ok {
+			return fmt.Errorf("--expect-out must be an integer amount in base units, got %q", expectOut)
+		}
+	}
+
+	fmt.Printf("Swap check for policy %s\n", policyID)
+	fmt.Printf("  From: %s on %s (%s)\n", from.Symbol, from.Chain, from.Holder)
+	fmt.Printf("  To:   %s on %s (%s)\n", to.Symbol, to.Chain, to.Holder)
+
+	var fromDelta, toDelta, fee *big.Int
+	fee = new(big.Int)
+	source := ""
+	if txHash != "" {
+		fromDelta, toDelta, fee, err = swapDeltasAtTx(from, to, txHash)
+		if err == nil {
+			source = "block before vs. block of " + format.Truncate(txHash, 14)
+		} else {
+			progressf("  %s Historical balances unavailable (%v); using the trigger snapshot\n", warnMark(), err)
+		}
+	}
+	if source == "" {
+		snap, err := loadSwapSnapshot(policyID)
+		if err != nil {
+			return err
+		}
+		fromDelta, toDelta, err = swapDeltasSinceSnapshot(snap, from, to)
+		if err != nil {
+			return err
+		}
+		source = fmt.Sprintf("snapshot of %s vs. now (includes any other activity since)", snap.TakenAt.Local().Format("2006-01-02 15:04:05"))
+	}
+	fmt.Printf("  Balances: %s\n\n", source)
+
+	var mismatches []string
+
+	wantFrom := new(big.Int).Set(fromAmount)
+	if from.native() {
+		wantFrom.Add(wantFrom, fee)
+	}
+	spent := new(big.Int).Neg(fromDelta)
+	fromOK := withinTolerance(spent, wantFrom, tolerance)
+	printSwapDelta(from, "spent", spent, wantFrom, fromOK)
+	if !fromOK {
+		mismatches = append(mismatches, fmt.Sprintf("%s spent: expected %s, observed %s",
+			from.Symbol, formatBalance(wantFrom, from.Decimals), formatBalance(spent, from.Decimals)))
+	}
+
+	toOK := toDelta.Sign() > 0
+	if wantOut != nil {
+		toOK = withinTolerance(toDelta, wantOut, tolerance)
+	}
+	printSwapDelta(to, "received", toDelta, wantOut, toOK)
+	if !toOK {
+		want := "> 0"
+		if wantOut != nil {
+			want = formatBalance(wantOut, to.Decimals)
+		}
+		mismatches = append(mismatches, fmt.Sprintf("%s received: expected %s, observed %s",
+			to.Symbol, want, formatBalance(toDelta, to.Decimals)))
+	}
+
+	fmt.Println()
+	if len(mismatches) > 0 {
+		return fmt.Errorf("swap did not move the balances as configured (tolerance %.2f%%):\n  %s", tolerance, strings.Join(mismatches, "\n  "))
+	}
+	fmt.Printf("%s Swap verified\n", okMark())
+	return nil
+}
+
+func printSwapDelta(leg swapLeg, verb string, got, want *big.Int, ok bool) {
+	mark := okMark()
+	if !ok {
+		mark = failMark()
+	}
+	expected := "any increase"
+	if want != nil {
+		expected = formatBalance(want, leg.Decimals) + " " + leg.Symbol
+	}
+	fmt.Printf("  %s %-8s %s %s (expected %s)\n", mark, verb, formatBalance(got, leg.Decimals), leg.Symbol, expected)
+}
+
+// withinTolerance reports whether got is within pct percent of want.
+func withinTolerance(got, want *big.Int, pct float64) bool {
+	if want.Sign() == 0 {
+		return got.Sign() == 0
+	}
+	diff := new(big.Float).SetInt(new(big.Int).Abs(new(big.Int).Sub(got, want)))
+	limit := new(big.Float).Mul(new(big.Float).SetInt(new(big.Int).Abs(want)), big.NewFloat(pct/100))
+	return diff.Cmp(limit) <= 0
+}
+
+// policySwapLegs reads the from/to assets and fromAmount of a DCA policy.
+func policySwapLegs(ctx context.Context, policyID string) (swapLeg, swapLeg, *big.Int, error) {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return swapLeg{}, swapLeg{}, nil, fmt.Errorf("load config: %w", err)
+	}
+	authHeader, err := requireAuth(ctx, cfg.Verifier, "")
+	if err != nil {
+		return swapLeg{}, swapLeg{}, nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	policy, err := getAPI[client.Policy](ctx, fmt.Sprintf("%s/plugin/policy/%s", cfg.Verifier, policyID), authHeader)
+	if err != nil {
+		return swapLeg{}, swapLeg{}, nil, fmt.Errorf("get policy: %w", err)
+	}
+	recipe, err := decodePolicyRecipe(policy.Recipe)
+	if err != nil {
+		return swapLeg{}, swapLeg{}, nil, err
+	}
+	config := recipe.GetConfiguration().AsMap()
+
+	amount, _ := config["fromAmount"].(string)
+	fromAmount, ok := new(big.Int).SetString(amount, 10)
+	if !ok {
+		return swapLeg{}, swapLeg{}, nil, fmt.Errorf("policy %s has no integer fromAmount; verify swap only checks DCA swaps", policyID)
+	}
+
+	from, err := recipeSwapLeg(config, "from")
+	if err != nil {
+		return swapLeg{}, swapLeg{}, nil, err
+	}
+	to, err := recipeSwapLeg(config, "to")
+	if err != nil {
+		return swapLeg{}, swapLeg{}, nil, err
+	}
+	return from, to, fromAmount, nil
+}
+
+func recipeSwapLeg(config map[string]interface{}, side string) (swapLeg, error) {
+	asset, ok := config[side].(map[string]interface{})
+	if !ok {
+		return swapLeg{}, fmt.Errorf("recipe has no %s asset", side)
+	}
+	chainName, _ := asset["chain"].(string)
+	token, _ := asset["token"].(string)
+	holder, _ := asset["address"].(string)
+	if holder == "" {
+		return swapLeg{}, fmt.Errorf("recipe %s.address is empty", side)
+	}
+
+	chain, ok := findSupportedChain(chainName)
+	if !ok {
+		return swapLeg{}, fmt.Errorf("%s chain %q is not in the EVM chain registry", side, chainName)
+	}
+
+	leg := swapLeg{Side: side, Chain: chain.Name, RPCURL: chain.RPCURL, Token: token, Holder: holder, Symbol: chain.Symbol, Decimals: chain.Decimals}
+	if token != "" {
+		leg.Symbol, leg.Decimals = erc20Metadata(chain.RPCURL, token)
+	}
+	return leg, nil
+}
+
+// erc20Metadata returns a token's symbol and decimals, from the known token
+// list or the contract, falling back to a short address and 18.
+func erc20Metadata(rpcURL, token string) (string, int) {
+	for _, t := range knownTokens() {
+		if strings.EqualFold(t.Address, token) {
+			return t.Symbol, t.Decimals
+		}
+	}
+	decimals := 18
+	raw, err := jsonRPC(rpcURL, "eth_call", []interface{}{map[string]string{"to": token, "data": "0x313ce567"}, "latest"})
+	if err == nil {
+		var hexValue string
+		if json.Unmarshal(raw, &hexValue) == nil {
+			if d, err := parseHexBig(hexValue); err == nil && d.IsInt64() {
+				decimals = int(d.Int64())
+			}
+		}
+	}
+	return format.Truncate(token, 10), decimals
+}
+
+// balanceAt reads the leg's balance at block ("latest" or a hex number).
+func balanceAt(leg swapLeg, block string) (*big.Int, error) {
+	var raw json.RawMessage
+	var err error
+	if leg.native() {
+		raw, err = jsonRPC(leg.RPCURL, "eth_getBalance", []interface{}{leg.Holder, block})
+	} else {
+		data := "0x70a08231" + fmt.Sprintf("%064s", strings.TrimPrefix(strings.ToLower(leg.Holder), "0x"))
+		raw, err = jsonRPC(leg.RPCURL, "eth_call", []interface{}{map[string]string{"to": leg.Token, "data": data}, block})
+	}
+	if err != nil {
+		return nil, err
+	}
+	var hexValue string
+	err = json.Unmarshal(raw, &hexValue)
+	if err != nil {
+		return nil, fmt.Errorf("decode balance: %w", err)
+	}
+	if hexValue == "0x" {
+		return new(big.Int), nil
+	}
+	return parseHexBig(hexValue)
+}
+
+// swapDeltasAtTx diffs both legs between the block before the transaction
+// and its block, and returns the fee the transaction paid.
+func swapDeltasAtTx(from, to swapLeg, txHash string) (*big.Int, *big.Int, *big.Int, error) {
+	raw, err := jsonRPC(from.RPCURL, "eth_getTransactionReceipt", []interface{}{txHash})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	var receipt struct {
+		BlockNumber       string `json:"blockNumber"`
+		GasUsed           string `json:"gasUsed"`
+		EffectiveGasPrice string `json:"effectiveGasPrice"`
+		From              string `json:"from"`
+	}
+	if string(raw) == "null" || json.Unmarshal(raw, &receipt) != nil || receipt.BlockNumber == "" {
+		return nil, nil, nil, fmt.Errorf("no receipt for %s on %s", txHash, from.Chain)
+	}
+	block, err := parseHexBig(receipt.BlockNumber)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	before := "0x" + new(big.Int).Sub(block, big.NewInt(1)).Text(16)
+	after := receipt.BlockNumber
+
+	fee := new(big.Int)
+	if strings.EqualFold(receipt.From, from.Holder) {
+		gasUsed, err1 := parseHexBig(receipt.GasUsed)
+		price, err2 := parseHexBig(receipt.EffectiveGasPrice)
+		if err1 == nil && err2 == nil {
+			fee.Mul(gasUsed, price)
+		}
+	}
+
+	deltas := make([]*big.Int, 2)
+	for i, leg := range []swapLeg{from, to} {
+		b0, err := balanceAt(leg, before)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("%s balance at block %s: %w", leg.Side, before, err)
+		}
+		b1, err := balanceAt(leg, after)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("%s balance at block %s: %w", leg.Side, after, err)
+		}
+		deltas[i] = new(big.Int).Sub(b1, b0)
+	}
+	return deltas[0], deltas[1], fee, nil
+}
+
+func swapDeltasSinceSnapshot(snap *SwapSnapshot, from, to swapLeg) (*big.Int, *big.Int, error) {
+	deltas := make([]*big.Int, 2)
+	for i, leg := range []swapLeg{from, to} {
+		before, ok := new(big.Int).SetString(snap.Balances[leg.Side], 10)
+		if !ok {
+			return nil, nil, fmt.Errorf("snapshot has no %s balance; re-run 'devctl policy trigger %s --snapshot'", leg.Side, snap.PolicyID)
+		}
+		now, err := balanceAt(leg, "latest")
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s balance: %w", leg.Side, err)
+		}
+		deltas[i] = new(big.Int).Sub(now, before)
+	}
+	return deltas[0], deltas[1], nil
+}
+
+func swapSnapshotPath(policyID string) string {
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".vultisig", "snapshots", policyID+".json")
+}
+
+// takeSwapSnapshot records the current from/to balances of a DCA policy for
+// 'verify swap'.
+func takeSwapSnapshot(ctx context.Context, policyID string) error {
+	from, to, _, err := policySwapLegs(ctx, policyID)
+	if err != nil {
+		return err
+	}
+	snap := SwapSnapshot{PolicyID: policyID, TakenAt: time.Now(), Balances: map[string]string{}}
+	for _, leg := range []swapLeg{from, to} {
+		b, err := balanceAt(leg, "latest")
+		if err != nil {
+			return fmt.Errorf("%s balance: %w", leg.Side, err)
+		}
+		snap.Balances[leg.Side] = b.String()
+		fmt.Printf("  Snapshot %-4s %s %s\n", leg.Side+":", formatBalance(b, leg.Decimals), leg.Symbol)
+	}
+
+	path := swapSnapshotPath(policyID)
+	err = os.MkdirAll(filepath.Dir(path), 0700)
+	if err != nil {
+		return fmt.Errorf("create snapshot dir: %w", err)
+	}
+	data, err := json.MarshalIndent(snap, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal snapshot: %w", err)
+	}
+	err = os.WriteFile(path, data, 0600)
+	if err != nil {
+		return fmt.Errorf("write snapshot: %w", err)
+	}
+	return nil
+}
+
+func loadSwapSnapshot(policyID string) (*SwapSnapshot, error) {
+	data, err := os.ReadFile(swapSnapshotPath(policyID))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("no balance snapshot for policy %s; pass --tx with an archive-capable RPC, or run 'devctl policy trigger %s --snapshot' before the next execution", policyID, policyID)
+		}
+		return nil, fmt.Errorf("read snapshot: %w", err)
+	}
+	var snap SwapSnapshot
+	err = json.Unmarshal(data, &snap)
+	if err != nil {
+		return nil, fmt.Errorf("parse snapshot: %w", err)
+	}
+	return &snap, nil
+}
@@ -0,0 +1,58 @@
+// Package format renders byte sizes, durations and truncated strings the
+// same way in every devctl report, table and JSON document.
+//
+// Sizes use binary multiples (1KB = 1024 bytes) with one decimal and no
+// space ("512B", "1.2KB", "3.4MB", "1.0GB"). Durations are rounded by
+// magnitude: milliseconds below a second ("420ms"), tenths of a second below
+// a minute ("12.3s"), whole seconds above ("2m5s"). Truncation counts runes,
+// not bytes, so multi-byte characters neither split nor skew column widths.
+package format
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	kb = 1024
+	mb = 1024 * kb
+	gb = 1024 * mb
+)
+
+// Bytes renders a size, e.g. "1.2KB".
+func Bytes(n int64) string {
+	switch {
+	case n < kb:
+		return fmt.Sprintf("%dB", n)
+	case n < mb:
+		return fmt.Sprintf("%.1fKB", float64(n)/kb)
+	case n < gb:
+		return fmt.Sprintf("%.1fMB", float64(n)/mb)
+	default:
+		return fmt.Sprintf("%.1fGB", float64(n)/gb)
+	}
+}
+
+// Duration renders an elapsed time, e.g. "420ms", "12.3s" or "2m5s".
+func Duration(d time.Duration) string {
+	switch {
+	case d < time.Second:
+		return d.Round(time.Millisecond).String()
+	case d < time.Minute:
+		return d.Round(100 * time.Millisecond).String()
+	default:
+		return d.Round(time.Second).String()
+	}
+}
+
+// Truncate shortens s to at most max runes, ending in "..." when cut.
+func Truncate(s string, max int) string {
+	r := []rune(s)
+	if len(r) <= max {
+		return s
+	}
+	if max <= 3 {
+		return string(r[:max])
+	}
+	return string(r[:max-3]) + "..."
+}
@@ -0,0 +1,75 @@
+package format
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBytes(t *testing.T) {
+	tests := []struct {
+		n    int64
+		want string
+	}{
+		{0, "0B"},
+		{512, "512B"},
+		{1023, "1023B"},
+		{1024, "1.0KB"},
+		{1229, "1.2KB"},
+		{1024*1024 - 1, "1024.0KB"},
+		{1024 * 1024, "1.0MB"},
+		{3565158, "3.4MB"},
+		{1024 * 1024 * 1024, "1.0GB"},
+		{5 << 40, "5120.0GB"},
+	}
+	for _, tt := range tests {
+		if got := Bytes(tt.n); got != tt.want {
+			t.Errorf("Bytes(%d) = %q, want %q", tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestDuration(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{0, "0s"},
+		{420 * time.Millisecond, "420ms"},
+		{420*time.Millisecond + 400*time.Microsecond, "420ms"},
+		{999*time.Millisecond + 600*time.Microsecond, "1s"},
+		{time.Second, "1s"},
+		{12345 * time.Millisecond, "12.3s"},
+		{59*time.Second + 960*time.Millisecond, "1m0s"},
+		{2*time.Minute + 5*time.Second + 499*time.Millisecond, "2m5s"},
+		{90 * time.Minute, "1h30m0s"},
+	}
+	for _, tt := range tests {
+		if got := Duration(tt.d); got != tt.want {
+			t.Errorf("Duration(%s) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		s    string
+		max  int
+		want string
+	}{
+		{"hello", 10, "hello"},
+		{"hello", 5, "hello"},
+		{"hello world", 8, "hello..."},
+		{"hello", 3, "hel"},
+		{"hello", 0, ""},
+		{"", 5, ""},
+		// Runes, not bytes.
+		{"héllo wörld", 8, "héllo..."},
+		{"日本語のテキスト", 5, "日本..."},
+		{"日本語", 3, "日本語"},
+	}
+	for _, tt := range tests {
+		if got := Truncate(tt.s, tt.max); got != tt.want {
+			t.Errorf("Truncate(%q, %d) = %q, want %q", tt.s, tt.max, got, tt.want)
+		}
+	}
+}
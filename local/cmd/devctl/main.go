@@ -1,11 +1,16 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/vultisig/vultisig-cluster/local/cmd/devctl/client"
 	"github.com/vultisig/vultisig-cluster/local/cmd/devctl/cmd"
 )
 
@@ -45,11 +50,45 @@ Commands:
   policy   - Create and manage policies
   auth     - Authenticate with verifier using TSS keysign
   verify   - Check transaction history and service health
+  chain    - Chain utilities (registry, gas estimation, fork funding)
+  notify   - Webhook/desktop notifications for policy and health events
   report   - Show comprehensive validation report
+  config   - Show devctl configuration and its schema version
+  env      - Export the cluster endpoints for other tools (JSON or dotenv)
+  demo     - Bring up or tear down a mock-TSS demo on the local stack
+  audit    - Show the log of state-changing commands
+  history  - Show recent devctl invocations
+  gc       - Remove stale caches, snapshots, profiles and history lines
   status   - Show quick service status
+  logs     - Show a service's log or its recent errors
+  upgrade  - Upgrade devctl to the latest release
 `,
 	}
 
+	var timeout time.Duration
+	var noColor bool
+	cancelTimeout := func() {}
+	rootCmd.PersistentFlags().DurationVar(&timeout, "timeout", 0, "Overall time budget for the command, e.g. 2m (0 = per-operation defaults only)")
+	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "Disable colored output and use ASCII status symbols (also honors NO_COLOR)")
+	rootCmd.PersistentFlags().StringVar(&cmd.ClusterConfigPath, "cluster-config", "", "cluster.yaml to use instead of searching ./, local/ and ~/.vultisig (also VULTISIG_CLUSTER_CONFIG)")
+	rootCmd.PersistentPreRunE = func(c *cobra.Command, args []string) error {
+		if noColor {
+			cmd.SetPlainOutput()
+		}
+		if timeout > 0 {
+			ctx, cancel := context.WithTimeout(c.Context(), timeout)
+			cancelTimeout = cancel
+			c.SetContext(ctx)
+		}
+		return cmd.CheckClusterConfigPath()
+	}
+
+	rootCmd.PersistentFlags().BoolVar(&client.StrictAPI, "strict-api", false, "Fail on verifier response fields devctl does not know about")
+	rootCmd.PersistentFlags().BoolVar(&cmd.SkipPreflight, "skip-preflight", false, "Do not check that the verifier/plugin server are reachable before TSS sessions")
+	rootCmd.PersistentFlags().BoolVar(&cmd.AckProduction, "i-know-this-is-production", false, "Allow reshare/keysign of a funded vault through the production Fast Vault Server")
+	rootCmd.PersistentFlags().BoolVar(&cmd.Verbose, "verbose", false, "Log every relay request of TSS operations with its duration")
+	rootCmd.PersistentFlags().BoolVar(&cmd.IncludeTestnets, "include-testnets", false, "Include testnet chains (Sepolia, Base Sepolia, Arbitrum Sepolia)")
+
 	rootCmd.AddCommand(cmd.NewStartCmd())
 	rootCmd.AddCommand(cmd.NewStopCmd())
 	rootCmd.AddCommand(cmd.NewVaultCmd())
@@ -59,11 +98,41 @@ Commands:
 	rootCmd.AddCommand(cmd.NewStatusCmd())
 	rootCmd.AddCommand(cmd.NewAuthCmd())
 	rootCmd.AddCommand(cmd.NewVerifyCmd())
+	rootCmd.AddCommand(cmd.NewChainCmd())
+	rootCmd.AddCommand(cmd.NewNotifyCmd())
 	rootCmd.AddCommand(cmd.NewReportCmd())
+	rootCmd.AddCommand(cmd.NewConfigCmd())
+	rootCmd.AddCommand(cmd.NewEnvCmd())
+	rootCmd.AddCommand(cmd.NewDemoCmd())
+	rootCmd.AddCommand(cmd.NewAuditCmd())
 	rootCmd.AddCommand(cmd.NewDevTokenCmd())
+	rootCmd.AddCommand(cmd.NewRelayCmd())
+	rootCmd.AddCommand(cmd.NewUpgradeCmd())
+	rootCmd.AddCommand(cmd.NewLogsCmd())
+	rootCmd.AddCommand(cmd.NewHistoryCmd())
+	rootCmd.AddCommand(cmd.NewGCCmd())
 
-	if err := rootCmd.Execute(); err != nil {
+	cmd.InitTracing()
+	started := time.Now()
+
+	// The first Ctrl-C cancels the root context so in-flight requests and
+	// relay polls unwind cleanly; a second one kills the process as usual.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+
+	executed, err := rootCmd.ExecuteContextC(ctx)
+	cancelTimeout()
+	stop()
+	if executed != nil {
+		_ = cmd.AppendHistory(executed.CommandPath(), started, err)
+		_ = cmd.AppendAudit(executed, started, err)
+	}
+	if err != nil {
 		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintf(os.Stderr, "trace id: %s\n", cmd.TraceID())
 		os.Exit(1)
 	}
 }
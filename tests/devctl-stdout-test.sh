@@ -0,0 +1,60 @@
+#!/bin/bash
+set -euo pipefail
+
+# Checks that devctl keeps stdout clean for machine-readable output: every
+# command below must produce valid JSON on stdout, with progress on stderr.
+# Requires a built devctl (make local-build) and at least one imported vault;
+# the verifier checks run only when the verifier answers.
+
+RED='\033[0;31m'
+GREEN='\033[0;32m'
+NC='\033[0m'
+
+pass() { echo -e "${GREEN}PASS${NC}: $1"; }
+fail() { echo -e "${RED}FAIL${NC}: $1"; }
+
+DEVCTL=${DEVCTL:-./local/vcli}
+FAILED=0
+
+echo "=== devctl stdout/stderr separation ==="
+echo ""
+
+command -v jq &>/dev/null || { fail "jq not installed"; exit 1; }
+[ -x "$DEVCTL" ] || { fail "devctl binary not found at $DEVCTL (set DEVCTL=...)"; exit 1; }
+
+check_json() {
+    local name=$1
+    shift
+    if "$DEVCTL" "$@" 2>/dev/null | jq -e . >/dev/null; then
+        pass "$name"
+    else
+        fail "$name: stdout is not valid JSON"
+        FAILED=1
+    fi
+}
+
+check_json "vault list --output json" vault list --output json
+check_json "vault list --json" vault list --json
+check_json "vault info --output json" vault info --output json
+check_json "vault address --output json" vault address --output json
+check_json "vault address --chain ethereum --output json" vault address --chain ethereum --output json
+check_json "vault pubkey --output json" vault pubkey --output json
+check_json "vault pubkey --eddsa --output json" vault pubkey --eddsa --output json
+check_json "env export --format json" env export --format json
+
+# These read from the verifier, so they only run against a started cluster.
+VERIFIER_URL=${VCLI_VERIFIER_URL:-http://localhost:8080}
+if curl -sf "$VERIFIER_URL/healthz" >/dev/null 2>&1; then
+    check_json "plugin installed --output json" plugin installed --output json
+    check_json "verify all --output json" verify all --output json
+else
+    echo "SKIP: verifier not reachable at $VERIFIER_URL (plugin installed, verify all)"
+fi
+
+echo ""
+if [ "$FAILED" -eq 0 ]; then
+    pass "All commands produced clean JSON on stdout"
+else
+    fail "Some commands wrote non-JSON output to stdout"
+    exit 1
+fi
@@ -0,0 +1,66 @@
+#!/bin/bash
+set -euo pipefail
+
+# Checks that devctl output is valid UTF-8 and that --no-color output has no
+# ANSI escape codes or non-ASCII status symbols.
+# Requires a built devctl (make local-build).
+
+RED='\033[0;31m'
+GREEN='\033[0;32m'
+NC='\033[0m'
+
+pass() { echo -e "${GREEN}PASS${NC}: $1"; }
+fail() { echo -e "${RED}FAIL${NC}: $1"; }
+
+DEVCTL=${DEVCTL:-./local/vcli}
+FAILED=0
+
+echo "=== devctl UTF-8 output ==="
+echo ""
+
+command -v iconv &>/dev/null || { fail "iconv not installed"; exit 1; }
+[ -x "$DEVCTL" ] || { fail "devctl binary not found at $DEVCTL (set DEVCTL=...)"; exit 1; }
+
+check_utf8() {
+    local name=$1
+    shift
+    local out
+    out=$("$DEVCTL" "$@" 2>&1 || true)
+    if printf '%s' "$out" | iconv -f UTF-8 -t UTF-8 >/dev/null 2>&1; then
+        pass "$name"
+    else
+        fail "$name: output is not valid UTF-8"
+        FAILED=1
+    fi
+}
+
+check_plain() {
+    local name=$1
+    shift
+    local out
+    out=$("$DEVCTL" --no-color "$@" 2>&1 || true)
+    if printf '%s' "$out" | grep -q $'\033\['; then
+        fail "$name --no-color: output contains ANSI escape codes"
+        FAILED=1
+    elif printf '%s' "$out" | grep -qF -e '✓' -e '✗' -e '⚠'; then
+        fail "$name --no-color: output contains non-ASCII status symbols"
+        FAILED=1
+    else
+        pass "$name --no-color"
+    fi
+}
+
+for args in "--help" "vault list" "auth status" "status" "audit"; do
+    # shellcheck disable=SC2086
+    check_utf8 "$args" $args
+    # shellcheck disable=SC2086
+    check_plain "$args" $args
+done
+
+echo ""
+if [ "$FAILED" -eq 0 ]; then
+    pass "All commands produced valid UTF-8"
+else
+    fail "Some commands produced invalid or non-plain output"
+    exit 1
+fi
@@ -0,0 +1,87 @@
+#!/bin/bash
+set -euo pipefail
+
+# Round trip of 'policy export' / 'policy import': create a DCA policy, export
+# it, check the file holds nothing of the vault, then import it for a second
+# vault with --dry-run and check it resolves to as many rules, with addresses
+# filled in from the second vault.
+# Requires 'devctl start', an imported and authenticated Fast Vault with the
+# DCA plugin installed, VAULT_PASSWORD, and SECOND_HOME: a HOME whose devctl
+# has a different vault imported and authenticated.
+
+RED='\033[0;31m'
+GREEN='\033[0;32m'
+NC='\033[0m'
+
+pass() { echo -e "${GREEN}PASS${NC}: $1"; }
+fail() { echo -e "${RED}FAIL${NC}: $1"; }
+
+DEVCTL=${DEVCTL:-./local/vcli}
+PLUGIN=vultisig-dca-0000
+POLICY_CONFIG=${POLICY_CONFIG:-./local/configs/test-one-time-policy.json}
+FAILED=0
+
+echo "=== policy export / import round trip ==="
+echo ""
+
+command -v jq &>/dev/null || { fail "jq not installed"; exit 1; }
+[ -x "$DEVCTL" ] || { fail "devctl binary not found at $DEVCTL (set DEVCTL=...)"; exit 1; }
+[ -n "${VAULT_PASSWORD:-}" ] || { fail "VAULT_PASSWORD not set"; exit 1; }
+[ -d "${SECOND_HOME:-}" ] || { fail "SECOND_HOME not set to a HOME with a second vault"; exit 1; }
+
+work=$(mktemp -d)
+POLICY_ID=""
+cleanup() {
+    if [ -n "$POLICY_ID" ]; then
+        "$DEVCTL" policy delete "$POLICY_ID" --yes >/dev/null 2>&1 || true
+    fi
+    rm -rf "$work"
+}
+trap cleanup EXIT
+
+# Blank addresses so the policy is built for the first vault.
+jq '.recipe.from.address = "" | .recipe.to.address = ""' "$POLICY_CONFIG" > "$work/config.json"
+create=$("$DEVCTL" policy create --plugin "$PLUGIN" --config "$work/config.json" --output json 2>/dev/null)
+POLICY_ID=$(echo "$create" | jq -r '.policy_id // empty')
+RULES=$(echo "$create" | jq -r '.rules')
+if [ -z "$POLICY_ID" ]; then
+    fail "policy create: no policy ID returned"
+    exit 1
+fi
+pass "policy create ($POLICY_ID, $RULES rules)"
+
+"$DEVCTL" policy export "$POLICY_ID" -o "$work/exported.json" >/dev/null 2>&1
+first_addr=$("$DEVCTL" vault address --chain ethereum --output json 2>/dev/null | jq -r '.[0].address')
+first_key=$("$DEVCTL" vault info 2>/dev/null | awk '/ECDSA/ {print $NF; exit}' | tr -d '.')
+if grep -qi "$first_addr" "$work/exported.json" || { [ -n "$first_key" ] && grep -q "$first_key" "$work/exported.json"; } \
+    || jq -e 'has("signature") or has("public_key")' "$work/exported.json" >/dev/null; then
+    fail "export still holds vault-specific data"
+    FAILED=1
+else
+    pass "export holds no address, key or signature of the vault"
+fi
+if [ "$(jq -r .plugin_id "$work/exported.json")" != "$PLUGIN" ]; then
+    fail "export does not record plugin_id"
+    exit 1
+fi
+
+second_addr=$(HOME="$SECOND_HOME" "$DEVCTL" vault address --chain ethereum --output json 2>/dev/null | jq -r '.[0].address')
+out=$(HOME="$SECOND_HOME" "$DEVCTL" policy import --config "$work/exported.json" --dry-run 2>&1)
+rules=$(echo "$out" | awk '/^  Rules: / {print $2; exit}')
+if [ "$rules" != "$RULES" ]; then
+    fail "import for the second vault resolved $rules rules, want $RULES"
+    echo "$out" | sed 's/^/    /'
+    exit 1
+fi
+if ! echo "$out" | grep -q "Auto-filled from.address: $second_addr"; then
+    fail "import did not fill addresses from the second vault ($second_addr)"
+    echo "$out" | sed 's/^/    /'
+    exit 1
+fi
+pass "import for the second vault: $rules rules, addresses of $second_addr"
+
+if [ "$FAILED" -ne 0 ]; then
+    exit 1
+fi
+echo ""
+pass "Policy round trip produces equivalent rules for another vault"
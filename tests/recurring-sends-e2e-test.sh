@@ -0,0 +1,74 @@
+#!/bin/bash
+set -euo pipefail
+
+# End-to-end test for the recurring-sends plugin against the local stack:
+# install the plugin, create a recurring send to the vault's own address,
+# trigger it and wait for the transaction, then clean up.
+# Requires 'devctl start' (with sends_server: local), an imported Fast Vault
+# with some ETH for gas, and VAULT_PASSWORD.
+
+RED='\033[0;31m'
+GREEN='\033[0;32m'
+NC='\033[0m'
+
+pass() { echo -e "${GREEN}PASS${NC}: $1"; }
+fail() { echo -e "${RED}FAIL${NC}: $1"; }
+
+DEVCTL=${DEVCTL:-./local/vcli}
+PLUGIN=vultisig-recurring-sends-0000
+POLICY_CONFIG=${POLICY_CONFIG:-./local/configs/recurring-send-policy.json}
+TX_TIMEOUT=${TX_TIMEOUT:-180}
+
+echo "=== recurring-sends end to end ==="
+echo ""
+
+command -v jq &>/dev/null || { fail "jq not installed"; exit 1; }
+[ -x "$DEVCTL" ] || { fail "devctl binary not found at $DEVCTL (set DEVCTL=...)"; exit 1; }
+[ -n "${VAULT_PASSWORD:-}" ] || { fail "VAULT_PASSWORD not set"; exit 1; }
+
+curl -sf http://localhost:8083/healthz >/dev/null || { fail "sends plugin API not running on :8083 (devctl start)"; exit 1; }
+pass "sends plugin API healthy"
+
+POLICY_ID=""
+cleanup() {
+    if [ -n "$POLICY_ID" ]; then
+        "$DEVCTL" policy delete "$POLICY_ID" --yes >/dev/null 2>&1 || true
+    fi
+    "$DEVCTL" plugin uninstall "$PLUGIN" --yes >/dev/null 2>&1 || true
+}
+trap cleanup EXIT
+
+install=$("$DEVCTL" plugin install "$PLUGIN" --output json 2>/dev/null)
+if [ -z "$(echo "$install" | jq -r '.plugin_keyshare // empty')" ]; then
+    fail "plugin install: no keyshare in vultisig-sends bucket"
+    exit 1
+fi
+pass "plugin install (keyshare stored in vultisig-sends)"
+
+create=$("$DEVCTL" policy create --plugin "$PLUGIN" --config "$POLICY_CONFIG" --output json 2>/dev/null)
+POLICY_ID=$(echo "$create" | jq -r '.policy_id // empty')
+if [ -z "$POLICY_ID" ]; then
+    fail "policy create: no policy ID returned"
+    exit 1
+fi
+pass "policy create ($POLICY_ID)"
+
+if ! "$DEVCTL" policy status "$POLICY_ID" | grep -q "Next Execution"; then
+    fail "policy not scheduled in vultisig-sends"
+    exit 1
+fi
+pass "policy scheduled"
+
+"$DEVCTL" policy trigger "$POLICY_ID" >/dev/null
+
+deadline=$((SECONDS + TX_TIMEOUT))
+while [ $SECONDS -lt $deadline ]; do
+    if "$DEVCTL" policy transactions "$POLICY_ID" | grep -q "0x"; then
+        pass "recurring send executed"
+        exit 0
+    fi
+    sleep 10
+done
+
+fail "no transaction within ${TX_TIMEOUT}s (see devctl policy status $POLICY_ID and /tmp/sends-worker.log)"
+exit 1
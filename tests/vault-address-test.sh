@@ -0,0 +1,63 @@
+#!/bin/bash
+set -euo pipefail
+
+# Checks 'vault address' on a good vault and on one whose chain code was
+# truncated by a partial import: the bad chain code is reported up front and
+# the command exits non-zero instead of printing bare per-chain errors.
+# Requires a built devctl (make local-build) and jq.
+
+RED='\033[0;31m'
+GREEN='\033[0;32m'
+NC='\033[0m'
+
+pass() { echo -e "${GREEN}PASS${NC}: $1"; }
+fail() { echo -e "${RED}FAIL${NC}: $1"; }
+
+DEVCTL=${DEVCTL:-./local/vcli}
+FIXTURES=${FIXTURES:-./tests/fixtures/vaults}
+FAILED=0
+
+echo "=== devctl vault address ==="
+echo ""
+
+command -v jq &>/dev/null || { fail "jq not installed"; exit 1; }
+[ -x "$DEVCTL" ] || { fail "devctl binary not found at $DEVCTL (set DEVCTL=...)"; exit 1; }
+
+# Imports a fixture into a throwaway HOME and runs 'vault address'; sets
+# $status and $out (stdout and stderr).
+run_address() {
+    local fixture=$1
+    shift
+    local home
+    home=$(mktemp -d)
+    HOME="$home" "$DEVCTL" vault import --file "$FIXTURES/$fixture" --skip-fastvault-check >/dev/null 2>&1
+    status=0
+    out=$(HOME="$home" "$DEVCTL" vault address "$@" 2>&1) || status=$?
+    rm -rf "$home"
+}
+
+run_address vault.json --output json
+if [ "$status" -eq 0 ] && [ "$(echo "$out" | sed -n '/^\[/,$p' | jq 'map(select(.chain == "Ethereum")) | length')" = "1" ]; then
+    pass "valid vault derives addresses"
+else
+    fail "valid vault: exit $status"
+    echo "$out" | sed 's/^/    /'
+    FAILED=1
+fi
+
+run_address vault-truncated-chaincode.json
+if [ "$status" -ne 0 ] && echo "$out" | grep -q "chain code is 20 bytes, want 32"; then
+    pass "truncated chain code rejected up front"
+else
+    fail "truncated chain code: exit $status"
+    echo "$out" | sed 's/^/    /'
+    FAILED=1
+fi
+
+echo ""
+if [ "$FAILED" -eq 0 ]; then
+    pass "Vault addresses derive or fail clearly"
+else
+    fail "Some vault address checks failed"
+    exit 1
+fi
@@ -0,0 +1,58 @@
+#!/bin/bash
+set -euo pipefail
+
+# Imports each fixture in tests/fixtures/vaults into a throwaway HOME and
+# checks that the detected format is reported in the import summary.
+# Requires a built devctl (make local-build).
+
+RED='\033[0;31m'
+GREEN='\033[0;32m'
+NC='\033[0m'
+
+pass() { echo -e "${GREEN}PASS${NC}: $1"; }
+fail() { echo -e "${RED}FAIL${NC}: $1"; }
+
+DEVCTL=${DEVCTL:-./local/vcli}
+FIXTURES=${FIXTURES:-./tests/fixtures/vaults}
+FAILED=0
+
+echo "=== devctl vault import formats ==="
+echo ""
+
+[ -x "$DEVCTL" ] || { fail "devctl binary not found at $DEVCTL (set DEVCTL=...)"; exit 1; }
+
+check_import() {
+    local fixture=$1
+    local want=$2
+    local home
+    home=$(mktemp -d)
+
+    local out
+    if ! out=$(HOME="$home" "$DEVCTL" vault import --file "$FIXTURES/$fixture" 2>&1); then
+        fail "$fixture: import failed"
+        echo "$out" | sed 's/^/    /'
+        FAILED=1
+    elif ! echo "$out" | grep -q "^Format: $want\$"; then
+        fail "$fixture: expected format \"$want\""
+        echo "$out" | grep "^Format:" | sed 's/^/    /' || true
+        FAILED=1
+    else
+        pass "$fixture ($want)"
+    fi
+
+    rm -rf "$home"
+}
+
+check_import android.bak "Android .bak"
+check_import android-base64.bak "Android .bak"
+check_import extension.json "Browser extension JSON"
+check_import ios-backup.json "iOS backup (v2)"
+check_import vault.json "JSON"
+
+echo ""
+if [ "$FAILED" -eq 0 ]; then
+    pass "All fixtures imported with the expected format"
+else
+    fail "Some fixtures did not import as expected"
+    exit 1
+fi
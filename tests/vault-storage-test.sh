@@ -0,0 +1,86 @@
+#!/bin/bash
+set -euo pipefail
+
+# Checks vault file naming in a throwaway HOME: legacy file names are
+# migrated to the canonical one, a key-less vault's file is replaced once the
+# vault has a key, and key-less vaults sharing a name are not merged.
+# Requires a built devctl (make local-build) and jq.
+
+RED='\033[0;31m'
+GREEN='\033[0;32m'
+NC='\033[0m'
+
+pass() { echo -e "${GREEN}PASS${NC}: $1"; }
+fail() { echo -e "${RED}FAIL${NC}: $1"; }
+
+DEVCTL=${DEVCTL:-./local/vcli}
+FIXTURE=${FIXTURE:-./tests/fixtures/vaults/vault.json}
+FAILED=0
+
+echo "=== devctl vault storage ==="
+echo ""
+
+command -v jq &>/dev/null || { fail "jq not installed"; exit 1; }
+[ -x "$DEVCTL" ] || { fail "devctl binary not found at $DEVCTL (set DEVCTL=...)"; exit 1; }
+
+PUBKEY=$(jq -r .pubKeyECDSA "$FIXTURE")
+NAME=$(jq -r .name "$FIXTURE")
+CREATED=$(jq -r .createdAt "$FIXTURE")
+
+expect_files() {
+    local name=$1 dir=$2
+    shift 2
+    local want got
+    want=$(printf '%s\n' "$@" | sort)
+    got=$(ls "$dir" | grep '\.json$' | sort || true)
+    if [ "$want" = "$got" ]; then
+        pass "$name"
+    else
+        fail "$name"
+        echo "    want: $(echo $want)"
+        echo "    got:  $(echo $got)"
+        FAILED=1
+    fi
+}
+
+# Legacy key-prefix file name is renamed to the full key.
+home=$(mktemp -d)
+vaults="$home/.vultisig/vaults"
+mkdir -p "$vaults"
+cp "$FIXTURE" "$vaults/${PUBKEY:0:16}.json"
+HOME="$home" "$DEVCTL" vault list --output json >/dev/null 2>&1
+expect_files "legacy key-prefix file migrated" "$vaults" "$PUBKEY.json"
+rm -rf "$home"
+
+# A key-less vault's file goes away when the same vault is saved with a key.
+home=$(mktemp -d)
+vaults="$home/.vultisig/vaults"
+mkdir -p "$vaults"
+jq '.pubKeyECDSA = "" | .pubKeyEdDSA = ""' "$FIXTURE" > "$vaults/$NAME-${CREATED:0:10}.json"
+HOME="$home" "$DEVCTL" vault import --file "$FIXTURE" >/dev/null 2>&1
+expect_files "key-less file replaced on first key" "$vaults" "$PUBKEY.json"
+rm -rf "$home"
+
+# Two key-less vaults with the same name keep separate files.
+home=$(mktemp -d)
+vaults="$home/.vultisig/vaults"
+mkdir -p "$vaults"
+jq '.pubKeyECDSA = "" | .name = "Dup" | .createdAt = "2025-01-01T00:00:00Z"' "$FIXTURE" > "$vaults/Dup-2025-01-01.json"
+jq '.pubKeyECDSA = "" | .name = "Dup" | .createdAt = "2025-01-02T00:00:00Z"' "$FIXTURE" > "$vaults/Dup-2025-01-02.json"
+out=$(HOME="$home" "$DEVCTL" vault list --output json 2>&1 >/dev/null || true)
+expect_files "same-name key-less vaults not merged" "$vaults" "unkeyed-Dup.json" "Dup-2025-01-02.json"
+if echo "$out" | grep -q "duplicates unkeyed-Dup.json"; then
+    pass "same-name collision reported"
+else
+    fail "same-name collision not reported"
+    FAILED=1
+fi
+rm -rf "$home"
+
+echo ""
+if [ "$FAILED" -eq 0 ]; then
+    pass "Vault files are named canonically"
+else
+    fail "Some vault storage checks failed"
+    exit 1
+fi